@@ -48,6 +48,11 @@ type DisposableRequestParameters struct {
 	// The expression should return a boolean; if true, the response is considered expected.
 	// Example: '.Body.job_status == "success"'
 	ExpectedResponse string `json:"expectedResponse,omitempty"`
+
+	// RedactHeaders are the names of request and response headers whose values
+	// are replaced with `***` before being stored in the status, e.g.
+	// Authorization.
+	RedactHeaders []string `json:"redactHeaders,omitempty"`
 }
 
 // A DisposableRequestSpec defines the desired state of a DisposableRequest.
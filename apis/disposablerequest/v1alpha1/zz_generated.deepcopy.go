@@ -113,6 +113,11 @@ func (in *DisposableRequestParameters) DeepCopyInto(out *DisposableRequestParame
 		*out = new(int32)
 		**out = **in
 	}
+	if in.RedactHeaders != nil {
+		in, out := &in.RedactHeaders, &out.RedactHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisposableRequestParameters.
@@ -0,0 +1,51 @@
+package v1alpha1
+
+import "net/http"
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-http-crossplane-io-v1alpha1-request,mutating=false,failurePolicy=fail,groups=http.crossplane.io,resources=requests,versions=v1alpha1,name=requests.http.crossplane.io,sideEffects=None,admissionReviewVersions=v1
+
+// Actions of a mapping.
+const (
+	ActionCreate  = "CREATE"
+	ActionObserve = "OBSERVE"
+	ActionUpdate  = "UPDATE"
+	ActionRemove  = "REMOVE"
+)
+
+// actionMethods are the standard methods whose role each action gives a mapping.
+var actionMethods = map[string]string{
+	ActionCreate:  http.MethodPost,
+	ActionObserve: http.MethodGet,
+	ActionUpdate:  http.MethodPut,
+	ActionRemove:  http.MethodDelete,
+}
+
+// RoleMethod returns the standard method whose role the mapping has: the method of
+// its action, or else its own method.
+func (m *Mapping) RoleMethod() string {
+	if method, ok := actionMethods[m.Action]; ok {
+		return method
+	}
+	return m.Method
+}
+
+// MappingByMethod returns the mapping with the role of the method, if there's one.
+func (p *RequestParameters) MappingByMethod(method string) (*Mapping, bool) {
+	for _, mapping := range p.Mappings {
+		if mapping.RoleMethod() == method {
+			return &mapping, true
+		}
+	}
+	return nil, false
+}
+
+// RoleOf returns the role of the mapping sending requests with the method, e.g. of
+// the last request recorded in the status. Without such a mapping, it's the method.
+func (p *RequestParameters) RoleOf(method string) string {
+	for _, mapping := range p.Mappings {
+		if mapping.Method == method {
+			return mapping.RoleMethod()
+		}
+	}
+	return method
+}
@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"reflect"
 
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -33,18 +34,665 @@ type RequestParameters struct {
 
 	WaitTimeout *metav1.Duration `json:"waitTimeout,omitempty"`
 
+	// PollInterval is how often the resource is observed, instead of the
+	// ProviderConfig's pollInterval or the provider's --poll interval.
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+
+	// HeadersFromSecret maps header names to secret keys holding their values,
+	// e.g. API keys. They take precedence over headers with the same name, and
+	// are never written to the status.
+	HeadersFromSecret map[string]xpv1.SecretKeySelector `json:"headersFromSecret,omitempty"`
+
 	// InsecureSkipTLSVerify, when set to true, skips TLS certificate checks for the HTTP request
 	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+
+	// TLSCACertSecretRef references a PEM encoded CA bundle used to verify the
+	// server certificates instead of the system roots. It's ignored when
+	// InsecureSkipTLSVerify is set.
+	TLSCACertSecretRef *xpv1.SecretKeySelector `json:"tlsCACertSecretRef,omitempty"`
+
+	// TLSClientCertSecretRef references a secret holding the PEM encoded client
+	// certificate and private key under the tls.crt and tls.key keys, presented
+	// to servers requiring mutual TLS.
+	TLSClientCertSecretRef *xpv1.SecretReference `json:"tlsClientCertSecretRef,omitempty"`
+
+	// BasicAuth, when set, authorizes every request with HTTP basic
+	// authentication. The credentials are never written to the status.
+	BasicAuth *BasicAuth `json:"basicAuth,omitempty"`
+
+	// HMAC, when set, signs the body of every request with an HMAC sent in a
+	// header. The signature is never written to the status.
+	HMAC *HMAC `json:"hmac,omitempty"`
+
+	// OAuth2, when set, authorizes every request with an access token obtained
+	// through the OAuth2 client credentials grant.
+	OAuth2 *OAuth2 `json:"oauth2,omitempty"`
+
+	// Kerberos, when set, authorizes every request with SPNEGO, negotiating a
+	// Kerberos ticket for the API with a keytab. The token is never written to
+	// the status.
+	Kerberos *Kerberos `json:"kerberos,omitempty"`
+
+	// Session, when set, keeps the cookies set by the API across the requests
+	// of a reconcile, e.g. the session cookie set by a login request.
+	Session *Session `json:"session,omitempty"`
+
+	// Async, when set, waits for operations that the API accepts with
+	// 202 Accepted on create to complete before the resource becomes available.
+	Async *AsyncOperation `json:"async,omitempty"`
+
+	// WaitForDeletion, when set, waits after the DELETE request until the
+	// response to the GET mapping means that the object doesn't exist, for APIs
+	// that delete objects asynchronously.
+	WaitForDeletion *WaitForDeletion `json:"waitForDeletion,omitempty"`
+
+	// Retry, when set, retries requests that get a transient failure response.
+	Retry *RetryPolicy `json:"retry,omitempty"`
+
+	// Idempotency, when set, sends create requests with an idempotency key, so
+	// that the API recognizes a retried create as the same operation.
+	Idempotency *Idempotency `json:"idempotency,omitempty"`
+
+	// ObserveAfterFailedCreate, when set to true, observes the object with the
+	// GET mapping even though the last POST request failed, e.g. so that an
+	// object fixed upstream after a failed create is adopted instead of created
+	// again. By default, an object whose create failed isn't observed.
+	ObserveAfterFailedCreate bool `json:"observeAfterFailedCreate,omitempty"`
+
+	// ConditionalUpdate, when set, guards updates against lost writes with the
+	// entity tag captured by the last observation.
+	ConditionalUpdate *ConditionalUpdate `json:"conditionalUpdate,omitempty"`
+
+	// ConditionalObserve, when set to true, sends the GET request with
+	// If-None-Match and the entity tag captured by the last observation. A 304
+	// Not Modified response is answered with the response stored in the status
+	// instead of downloading the object again. It can't be combined with
+	// secretFields, as the stored response is redacted.
+	ConditionalObserve bool `json:"conditionalObserve,omitempty"`
+
+	// GRPCStatus, when set to true, interprets the grpc-status header or trailer
+	// of responses from gRPC services transcoded to HTTP/JSON. A successful
+	// response with a non-zero gRPC status is handled as the HTTP status code
+	// that the gRPC status maps to, e.g. 404 for NOT_FOUND.
+	GRPCStatus bool `json:"grpcStatus,omitempty"`
+
+	// RedactHeaders are the names of request and response headers whose values
+	// are replaced with `***` before being stored in the status, e.g.
+	// Authorization.
+	RedactHeaders []string `json:"redactHeaders,omitempty"`
+
+	// SecretFields are JSONPath expressions, e.g. `$.password`, selecting the
+	// request and response body fields whose values are replaced with `***`
+	// before being stored in the status.
+	SecretFields []string `json:"secretFields,omitempty"`
+
+	// SecretOutputs maps connection secret keys to JSONPath expressions, e.g.
+	// `$.token`, selecting values from successful create and update responses.
+	// The values are published to the writeConnectionSecretToRef secret and
+	// redacted from the response stored in the status.
+	SecretOutputs map[string]string `json:"secretOutputs,omitempty"`
+
+	// StatusOutputs maps the keys of the outputs in the status to JSONPath
+	// expressions, e.g. `$.id`, selecting non-sensitive values from successful
+	// responses, such as a created ID or URL, so that other resources can
+	// reference them. A number or boolean keeps its type, and an expression
+	// selecting several values outputs them as an array.
+	StatusOutputs map[string]string `json:"statusOutputs,omitempty"`
+
+	// ImmutableFields are JSONPath expressions, e.g. `$.region`, selecting the
+	// fields of the desired state that the API can't update. When the object
+	// is out of date and one of them differs from the response, it's deleted
+	// and created again rather than updated. Fields missing in the response
+	// aren't compared.
+	ImmutableFields []string `json:"immutableFields,omitempty"`
+}
+
+// RetryPolicy configures how requests are retried after a transient failure
+// response. A Retry-After header sent by the server takes precedence over the
+// exponential backoff.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	// +kubebuilder:validation:Minimum=1
+	MaxAttempts int `json:"maxAttempts"`
+
+	// InitialBackoff is the wait time before the first retry. Defaults to 1s.
+	InitialBackoff *metav1.Duration `json:"initialBackoff,omitempty"`
+
+	// Multiplier is applied to the wait time after each retry. Defaults to 2.
+	// +kubebuilder:validation:Minimum=1
+	Multiplier int `json:"multiplier,omitempty"`
+
+	// RetryableStatusCodes are the response status codes that trigger a retry.
+	// Defaults to 502, 503 and 504.
+	RetryableStatusCodes []int `json:"retryableStatusCodes,omitempty"`
+
+	// RetryNonIdempotent, when set to true, also retries non-idempotent
+	// requests such as POST.
+	RetryNonIdempotent bool `json:"retryNonIdempotent,omitempty"`
+}
+
+// Idempotency configures the idempotency key sent with POST requests. The key
+// is derived from the resource's UID and generation, so it's the same for
+// every attempt to create the same revision of the resource, across
+// controller restarts.
+type Idempotency struct {
+	// HeaderName is the request header holding the key. Defaults to
+	// Idempotency-Key.
+	HeaderName string `json:"headerName,omitempty"`
+}
+
+// ConditionalUpdate configures optimistic concurrency for updates. The entity tag
+// of the GET response is stored in the status and sent as If-Match with PUT and
+// PATCH requests. When the API answers with 412 Precondition Failed, the object
+// is observed again and the update is sent once more.
+type ConditionalUpdate struct {
+	// ETagHeader is the GET response header holding the entity tag. Defaults to ETag.
+	ETagHeader string `json:"etagHeader,omitempty"`
+}
+
+// BasicAuth configures HTTP basic authentication.
+type BasicAuth struct {
+	// UsernameSecretRef references the secret key holding the username.
+	UsernameSecretRef xpv1.SecretKeySelector `json:"usernameSecretRef"`
+
+	// PasswordSecretRef references the secret key holding the password.
+	PasswordSecretRef xpv1.SecretKeySelector `json:"passwordSecretRef"`
+}
+
+// HMAC configures the signature of the request bodies.
+type HMAC struct {
+	// SecretRef references the secret key holding the signing key.
+	SecretRef xpv1.SecretKeySelector `json:"secretRef"`
+
+	// Header is the name of the request header holding the signature.
+	Header string `json:"header"`
+
+	// Algorithm is the digest algorithm of the HMAC, SHA256 by default.
+	// +kubebuilder:validation:Enum=SHA1;SHA256;SHA512
+	Algorithm string `json:"algorithm,omitempty"`
+
+	// Encoding is how the signature is written in the header, hex by default.
+	// +kubebuilder:validation:Enum=hex;base64
+	Encoding string `json:"encoding,omitempty"`
+
+	// Prefix is written before the signature, e.g. sha256=.
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// Session configures a cookie session with the API.
+type Session struct {
+	// Login, when set, is sent before the first request of every reconcile to
+	// obtain the session cookies. Its url, body and headers are templated like
+	// those of the other mappings.
+	Login *Mapping `json:"login,omitempty"`
+}
+
+// OAuth2 configures the OAuth2 client credentials grant.
+type OAuth2 struct {
+	// TokenURL is the endpoint access tokens are requested from.
+	TokenURL string `json:"tokenUrl"`
+
+	// ClientIDSecretRef references the secret key holding the client ID.
+	ClientIDSecretRef xpv1.SecretKeySelector `json:"clientIdSecretRef"`
+
+	// ClientSecretSecretRef references the secret key holding the client secret.
+	ClientSecretSecretRef xpv1.SecretKeySelector `json:"clientSecretSecretRef"`
+
+	// Scopes requested for the access token.
+	Scopes []string `json:"scopes,omitempty"`
+
+	// DefaultTokenTTL is the token lifetime assumed when the token endpoint
+	// doesn't return expires_in. Defaults to 5m.
+	DefaultTokenTTL *metav1.Duration `json:"defaultTokenTTL,omitempty"`
+}
+
+// Kerberos configures SPNEGO authentication with a Kerberos keytab.
+type Kerberos struct {
+	// Username is the principal the provider authenticates as, without its
+	// realm, e.g. `provider-http`.
+	Username string `json:"username"`
+
+	// Realm of the principal, e.g. `EXAMPLE.COM`.
+	Realm string `json:"realm"`
+
+	// KeytabSecretRef references the secret key holding the keytab of the
+	// principal, as written by ktutil.
+	KeytabSecretRef xpv1.SecretKeySelector `json:"keytabSecretRef"`
+
+	// ConfigSecretRef references the secret key holding the krb5.conf that
+	// locates the KDCs of the realm.
+	ConfigSecretRef xpv1.SecretKeySelector `json:"configSecretRef"`
+
+	// SPN is the service principal name of the API, e.g.
+	// `HTTP/api.example.com`. Defaults to HTTP/ and the canonical name of the
+	// host of the request URL.
+	SPN string `json:"spn,omitempty"`
+}
+
+// AsyncOperation polls the status of an operation accepted with 202 Accepted.
+type AsyncOperation struct {
+	// StatusURLPath is the JSONPath to the operation status URL in the body of
+	// the 202 Accepted response, e.g. `$.links.status`. Relative URLs are
+	// resolved against the request URL. Defaults to the Location header.
+	StatusURLPath string `json:"statusURLPath,omitempty"`
+
+	// PollInterval is the time between status requests. Defaults to 5s.
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+
+	// SuccessStatusCodes are the status codes of a status response for a
+	// completed operation. Defaults to any 2xx status code other than 202.
+	// Error status codes fail the operation.
+	SuccessStatusCodes []int `json:"successStatusCodes,omitempty"`
+
+	// SuccessCondition is a jq expression the status response must also satisfy
+	// for the operation to be completed, e.g. `.body.status == "succeeded"`.
+	SuccessCondition string `json:"successCondition,omitempty"`
+
+	// MaxWait is how long to wait for the operation to complete after the create
+	// request. The observation fails when it's exceeded, or when the operation
+	// fails, rather than sending the create request again. Defaults to 5m.
+	MaxWait *metav1.Duration `json:"maxWait,omitempty"`
+}
+
+// WaitForDeletion polls the GET mapping after a DELETE request until the object
+// is reported as not found, according to its notFoundCheck.
+type WaitForDeletion struct {
+	// PollInterval is the time between GET requests. Defaults to 5s.
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+
+	// MaxWait is how long to wait for the object to be deleted after the DELETE
+	// request. The DELETE request is sent again when it's exceeded. Defaults to
+	// 5m.
+	MaxWait *metav1.Duration `json:"maxWait,omitempty"`
+}
+
+// NotFoundCheck detects responses meaning that the object doesn't exist. A
+// response matching either the status codes or the condition does.
+type NotFoundCheck struct {
+	// StatusCodes meaning that the object doesn't exist. Defaults to 404.
+	StatusCodes []int `json:"statusCodes,omitempty"`
+
+	// Condition is a jq expression evaluated against the response, e.g.
+	// `.body.error == "not_found"` or `.body.items == []`, returning true when
+	// the object doesn't exist.
+	Condition string `json:"condition,omitempty"`
+}
+
+// AlreadyExistsCheck detects responses to a create request meaning that the object
+// already exists. A response matching either the status codes or the condition
+// does.
+type AlreadyExistsCheck struct {
+	// StatusCodes meaning that the object already exists. Defaults to 409.
+	StatusCodes []int `json:"statusCodes,omitempty"`
+
+	// Condition is a jq expression evaluated against the response, e.g.
+	// `.body.error.code == "DUPLICATE_NAME"`, returning true when the object
+	// already exists.
+	Condition string `json:"condition,omitempty"`
+
+	// Identity is a jq expression evaluated against the response meaning that
+	// the object already exists, e.g. `{id: .body.existingId}`, returning an
+	// object merged into the response body kept in the status, so that the
+	// mappings referencing `.response.body` locate the adopted object. Without
+	// one, the other mappings can't reference the response.
+	Identity string `json:"identity,omitempty"`
+}
+
+// ReadinessCheck detects responses meaning that the object is ready. A response
+// passes it when the values selected by the path equal the value, and the
+// condition returns true.
+type ReadinessCheck struct {
+	// Path is a JSONPath expression, e.g. `$.status` or `$.nodes[*].state`,
+	// selecting the values of the response body that must all equal Value.
+	Path string `json:"path,omitempty"`
+
+	// Value the values selected by the path must equal, e.g. `active`. Values
+	// that aren't strings are compared to their JSON encoding, e.g. `true`.
+	Value string `json:"value,omitempty"`
+
+	// Condition is a jq expression evaluated against the response, e.g.
+	// `.body.status == "active"`, returning true when the object is ready.
+	Condition string `json:"condition,omitempty"`
+}
+
+// CompareOptions relax how the JSON response is compared to the desired state.
+type CompareOptions struct {
+	// IgnoreArrayOrder compares arrays regardless of the order of their items.
+	IgnoreArrayOrder bool `json:"ignoreArrayOrder,omitempty"`
+
+	// TreatNullAsAbsent considers fields whose value is null to be missing, so a
+	// null field of the desired state matches a missing field of the response.
+	TreatNullAsAbsent bool `json:"treatNullAsAbsent,omitempty"`
+
+	// NumericTolerance is the largest difference between numbers that are
+	// considered equal, e.g. `0.01`.
+	NumericTolerance string `json:"numericTolerance,omitempty"`
+
+	// NormalizeWhitespace compares bodies that aren't JSON or XML, such as plain
+	// text, with their line endings and runs of whitespace normalized, so that a
+	// reformatted response still contains the desired state.
+	NormalizeWhitespace bool `json:"normalizeWhitespace,omitempty"`
+
+	// IgnoreCase compares bodies that aren't JSON or XML regardless of case.
+	IgnoreCase bool `json:"ignoreCase,omitempty"`
+}
+
+// CompareList is a list of objects compared by matching their items by a key, so
+// that reordered items or fields added by the server aren't reported as drift.
+type CompareList struct {
+	// Path is the JSONPath expression selecting the list in both the response and
+	// the desired state, e.g. `$.rules`. It can't contain wildcards.
+	Path string `json:"path"`
+
+	// Key is the field identifying an item of the list, e.g. `id`.
+	Key string `json:"key"`
+}
+
+// MultipartPart is a form field or a file of a multipart/form-data body.
+type MultipartPart struct {
+	// Name of the form field.
+	Name string `json:"name"`
+
+	// Value is a jq expression for the value of the part, templated like the
+	// URL, e.g. `.payload.body.description`.
+	Value string `json:"value,omitempty"`
+
+	// ValueFrom references the Secret or ConfigMap key holding the value of the
+	// part, e.g. a credential or the content of a file. The value is only
+	// resolved to send the request, and is masked in the status.
+	ValueFrom *PartValueSource `json:"valueFrom,omitempty"`
+
+	// FileName, when set, sends the part as a file with this name.
+	FileName string `json:"fileName,omitempty"`
+
+	// ContentType of the part. Defaults to application/octet-stream for files.
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// PartValueSource references the key holding the value of a multipart part.
+// Exactly one of the references must be set.
+type PartValueSource struct {
+	// SecretKeyRef references a key of a Secret.
+	SecretKeyRef *xpv1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+
+	// ConfigMapKeyRef references a key of a ConfigMap, in its data or binary data.
+	ConfigMapKeyRef *ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+}
+
+// ChunkedUpload uploads content in byte ranges to an upload session. Every chunk
+// is sent with a Content-Range header, e.g. `bytes 0-8388607/20971520`, and
+// accepted with a 2xx or 308 status code. The response to the last chunk is
+// the response to the mapping.
+type ChunkedUpload struct {
+	// UploadURL is a jq expression returning the URL of the upload session from
+	// the response to the mapping's request, which it receives with its
+	// `.statusCode`, `.headers` and `.body`, e.g. `.headers.Location[0]`.
+	// Relative URLs are resolved against the request URL.
+	UploadURL string `json:"uploadURL"`
+
+	// ContentFrom references the key holding the content to upload.
+	ContentFrom PartValueSource `json:"contentFrom"`
+
+	// Method is the method of the chunk requests. Defaults to PUT.
+	Method string `json:"method,omitempty"`
+
+	// ChunkSize is the size of a chunk in bytes. Defaults to 8388608 (8 MiB).
+	// +kubebuilder:validation:Minimum=1
+	ChunkSize int64 `json:"chunkSize,omitempty"`
+
+	// MaxAttempts is the maximum number of attempts to upload a chunk,
+	// including the first one. Failed requests and 5xx responses are retried.
+	// Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	MaxAttempts int `json:"maxAttempts,omitempty"`
+}
+
+// BodySource references the key holding the body of a mapping. Exactly one of
+// the references must be set.
+type BodySource struct {
+	// SecretKeyRef references a key of a Secret.
+	SecretKeyRef *xpv1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+
+	// ConfigMapKeyRef references a key of a ConfigMap, in its data or binary data.
+	ConfigMapKeyRef *ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// Template renders the content as a jq template, like body, instead of
+	// sending it as is.
+	Template bool `json:"template,omitempty"`
+}
+
+// Pagination describes how to get the next page of a paginated response.
+type Pagination struct {
+	// NextURL is a jq expression returning the URL of the next page from the
+	// response, e.g. `.response.body.next`. A null or empty result means it's
+	// the last page. Defaults to the `next` link of the Link response header.
+	NextURL string `json:"nextUrl,omitempty"`
+
+	// MaxPages bounds the number of pages requested, including the first one.
+	// Defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	MaxPages *int `json:"maxPages,omitempty"`
+}
+
+// ConfigMapKeySelector references a key of a ConfigMap.
+type ConfigMapKeySelector struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+
+	// Key within the ConfigMap.
+	Key string `json:"key"`
 }
 
 type Mapping struct {
-	// +kubebuilder:validation:Enum=POST;GET;PUT;DELETE
-	Method  string              `json:"method"`
-	Body    string              `json:"body,omitempty"`
-	URL     string              `json:"url"`
+	// Method of the requests, a standard one such as GET, or any other, such
+	// as PURGE, given an action.
+	// +kubebuilder:validation:Pattern=`^[!#$%&'*+.^_|~0-9A-Za-z-]+$`
+	Method string `json:"method"`
+
+	// Action is the role of the mapping when its method isn't the standard one
+	// for it: CREATE as POST does, OBSERVE as GET, UPDATE as PUT and REMOVE as
+	// DELETE. Defaults to the role of the method.
+	// +kubebuilder:validation:Enum=CREATE;OBSERVE;UPDATE;REMOVE
+	Action string `json:"action,omitempty"`
+
+	Body string `json:"body,omitempty"`
+	URL  string `json:"url"`
+
+	// Headers of the requests. Each value is a jq expression, used as is when
+	// it isn't a valid one. An expression returning an array of strings adds a
+	// value per item, so a header may have several values.
 	Headers map[string][]string `json:"headers,omitempty"`
-	// +kubebuilder:validation:Enum=gitlab-file;harbor-robot
+
+	// QueryParams are added to the query of the URL, encoded. Like headers,
+	// each value is a jq expression, and a parameter is repeated for each of
+	// its values, e.g. `tag: ['"a"', '"b"']` adds `tag=a&tag=b`.
+	QueryParams map[string][]string `json:"queryParams,omitempty"`
+
+	// BodyFrom references the Secret or ConfigMap key holding the body, e.g. a
+	// certificate or a policy document too large to inline, instead of body.
+	// A body from a Secret is masked in the status.
+	BodyFrom *BodySource `json:"bodyFrom,omitempty"`
+
+	// BodyType is how the body is encoded. A json body is sent as is, a form
+	// body must be an object and is sent as application/x-www-form-urlencoded,
+	// an xml body must be an XML document and is compared as one, and a raw body
+	// is sent as is but never compared as JSON. A multipart body is built from
+	// the multipart parts instead of the body, and sent as multipart/form-data.
+	// Defaults to json.
+	// +kubebuilder:validation:Enum=json;form;raw;xml;multipart
+	BodyType string `json:"bodyType,omitempty"`
+
+	// ContentType is the Content-Type of the request unless its headers set one,
+	// e.g. application/vnd.api+json. Without a bodyType, the body type follows
+	// from it: JSON media types are json, XML media types are xml, form media
+	// types are form, multipart/form-data is multipart, and any other is raw.
+	ContentType string `json:"contentType,omitempty"`
+
+	// Multipart are the parts of the body when bodyType is multipart.
+	Multipart []MultipartPart `json:"multipart,omitempty"`
+
+	// Upload, when set, uploads content in chunks to the upload session that
+	// the request of this mapping creates, e.g. for a resumable upload of a
+	// large artifact.
+	Upload *ChunkedUpload `json:"upload,omitempty"`
+
+	// CompareType selects how the response is compared to the desired state.
+	// With jsonpatch, the body of the mapping is a JSON Patch, and the response
+	// is synced if applying it would leave the response as it is. With
+	// keyedlist, the items of the list selected by compareList are matched by
+	// their key rather than by their position.
+	// +kubebuilder:validation:Enum=gitlab-file;harbor-robot;jsonpath;jq;cel;jsonpatch;keyedlist
 	CompareType string `json:"comparetype,omitempty"`
+
+	// CompareExpression is the expression deciding whether the response is
+	// synced with the desired state when comparetype is jq or cel. It receives
+	// the parsed bodies, as `.response` and `.desired` in jq, or as the
+	// `response` and `desired` variables in CEL, and must return a boolean,
+	// e.g. `(.response | del(.updated_at)) == .desired` or
+	// `omit(response, ["updated_at"]) == desired`.
+	CompareExpression string `json:"compareExpression,omitempty"`
+
+	// ComparePaths are the JSONPath expressions, e.g. `$.spec.replicas` or
+	// `$.items[*].name`, whose values are compared between the response and the
+	// desired state when comparetype is jsonpath.
+	ComparePaths []string `json:"comparePaths,omitempty"`
+
+	// CompareList selects the list compared item by item when comparetype is
+	// keyedlist.
+	CompareList *CompareList `json:"compareList,omitempty"`
+
+	// CompareOptions relax how JSON values are compared by the comparison of this
+	// mapping, or by the default comparison when set on the GET mapping.
+	CompareOptions *CompareOptions `json:"compareOptions,omitempty"`
+
+	// IgnoreFields are JSONPath expressions, e.g. `$.update_time` or
+	// `$.items[*].secret`, selecting fields removed from both the response and
+	// the desired state before the comparison of this mapping, or the default
+	// comparison when set on the GET mapping. They're meant for fields managed by
+	// the server, such as timestamps.
+	IgnoreFields []string `json:"ignoreFields,omitempty"`
+
+	// ResponseRoot is a JSONPath expression, e.g. `$.data.attributes`, selecting
+	// the object of a JSON response that is compared to the desired state by the
+	// comparison of this mapping, or the default comparison when set on the GET
+	// mapping, for responses nesting the object in an envelope. The first value
+	// selected is compared, and without one, the object is out of date. The
+	// whole response is still stored in the status.
+	ResponseRoot string `json:"responseRoot,omitempty"`
+
+	// ExpectedResponse is a JSON document that the response to this GET mapping
+	// is compared to as the desired state, instead of the body of the PUT or
+	// PATCH mapping, e.g. `{"status": "active"}`.
+	ExpectedResponse string `json:"expectedResponse,omitempty"`
+
+	// CompareStatusOnly, set on the GET mapping, reports the object as synced as
+	// soon as the response to it is successful, without comparing its body to
+	// the desired state, e.g. for objects whose existence is all that matters.
+	CompareStatusOnly bool `json:"compareStatusOnly,omitempty"`
+
+	// CacheResponse, set on the GET mapping, caches its 200 OK responses in
+	// memory for as long as their Cache-Control max-age or Expires headers
+	// allow, observing the object from the cache instead of sending the request.
+	// The cached responses are invalidated by any create, update or delete
+	// request of the Request.
+	CacheResponse bool `json:"cacheResponse,omitempty"`
+
+	// MinimalUpdate sends only the fields of the JSON body that differ from the
+	// observed state when this PATCH mapping updates the object, instead of the
+	// whole body. No request is sent when none of the fields differ.
+	MinimalUpdate bool `json:"minimalUpdate,omitempty"`
+
+	// ExpectedStatusCodes are the status codes of a successful response to this
+	// mapping, any other status code fails the request. Defaults to any 2xx
+	// status code.
+	ExpectedStatusCodes []int `json:"expectedStatusCodes,omitempty"`
+
+	// SuccessExpression is a CEL expression deciding whether a response to this
+	// mapping is successful in place of its status code, e.g.
+	// `response.statusCode == 200 && response.body.ok`. Its `response` variable
+	// holds the `statusCode`, `headers` and `body`, parsed if it's JSON, and it
+	// must return a bool.
+	SuccessExpression string `json:"successExpression,omitempty"`
+
+	// When is a CEL condition on the observed state deciding whether this
+	// mapping is the one sending the requests of its role, e.g.
+	// `response.body.enabled == false` for a mapping enabling the object. It
+	// receives the last response to the GET mapping as `response`, with its
+	// `statusCode`, `headers` and `body`, parsed if it's JSON. Several mappings may then share a role: the first one whose
+	// condition matches is used, and a mapping without a condition always
+	// matches. It can't be set on the GET mapping.
+	When string `json:"when,omitempty"`
+
+	// ResponseSelector is a jq expression selecting the object from the response to
+	// the GET mapping, for APIs that are observed through a different endpoint, such
+	// as a collection, than they're written to, e.g.
+	// `.payload.body.name as $name | .response.body.items[] | select(.name == $name)`.
+	// Its first result is used as the response body. Without a result, the object
+	// doesn't exist. A response body that is a JSON array is available as an array,
+	// e.g. `.response.body[]`.
+	ResponseSelector string `json:"responseSelector,omitempty"`
+
+	// UniqueResponse requires the responseSelector to have at most one result, so
+	// that an ambiguous selection fails the observation instead of the first result
+	// being used.
+	UniqueResponse bool `json:"uniqueResponse,omitempty"`
+
+	// Pagination follows the pages of a paginated response to this GET mapping
+	// until its responseSelector finds the object.
+	Pagination *Pagination `json:"pagination,omitempty"`
+
+	// ResponseSchema is a JSON Schema the response to this GET mapping must be
+	// valid against before it's compared to the desired state, e.g. so that an
+	// HTML error page answered with 200 OK fails the observation instead of
+	// being mistaken for an out of date object. It's checked after the object
+	// is selected from the response.
+	ResponseSchema string `json:"responseSchema,omitempty"`
+
+	// ResponseSchemaFrom references the ConfigMap key holding the response
+	// schema, instead of responseSchema.
+	ResponseSchemaFrom *ConfigMapKeySelector `json:"responseSchemaFrom,omitempty"`
+
+	// ResponseFormat is the format of the response body to the GET mapping. An
+	// ndjson body holds a JSON document per line, which are aggregated into the
+	// single document used as the response body by responseAggregation.
+	// Defaults to json.
+	// +kubebuilder:validation:Enum=json;ndjson
+	ResponseFormat string `json:"responseFormat,omitempty"`
+
+	// ResponseAggregation is the jq expression aggregating the documents of an
+	// ndjson response, which it receives as an array, e.g. `add` to merge them,
+	// or `map(select(.type == "status")) | last`. Defaults to `last`, keeping
+	// the last document. Without a result, the object doesn't exist.
+	ResponseAggregation string `json:"responseAggregation,omitempty"`
+
+	// NotFoundCheck decides when the response to the GET mapping means that the
+	// object doesn't exist, so that it's created again. Defaults to a 404 status code.
+	NotFoundCheck *NotFoundCheck `json:"notFoundCheck,omitempty"`
+
+	// AlreadyExistsCheck, set on the POST mapping, decides when the response to
+	// a create request means that the object already exists, e.g. a 409
+	// Conflict, so that the object is adopted rather than failing to be
+	// created: it's observed with the GET mapping, and updated if it isn't
+	// synced.
+	AlreadyExistsCheck *AlreadyExistsCheck `json:"alreadyExistsCheck,omitempty"`
+
+	// ReadinessCheck decides when the response to the GET mapping means that the
+	// object is ready. Until it passes, the Request is unavailable. Without one, the
+	// object is ready as soon as it exists.
+	ReadinessCheck *ReadinessCheck `json:"readinessCheck,omitempty"`
+
+	// WaitTimeout limits how long requests sent for this mapping may take,
+	// within the resource-level waitTimeout.
+	WaitTimeout *metav1.Duration `json:"waitTimeout,omitempty"`
+
+	// InsecureSkipTLSVerify overrides the resource-level insecureSkipTLSVerify
+	// for the requests sent for this mapping, e.g. to verify the certificate of
+	// the host observed with the GET mapping but not of the host the actions are
+	// sent to.
+	InsecureSkipTLSVerify *bool `json:"insecureSkipTLSVerify,omitempty"`
 }
 
 type Payload struct {
@@ -73,8 +721,133 @@ type RequestStatus struct {
 	Failed              int32    `json:"failed,omitempty"`
 	Error               string   `json:"error,omitempty"`
 	RequestDetails      Mapping  `json:"requestDetails,omitempty"`
+
+	// ETag is the entity tag captured by the last observation, when
+	// conditionalUpdate or conditionalObserve is set.
+	ETag string `json:"etag,omitempty"`
+
+	// Diff lists the fields of the desired state that differ from the last
+	// observed state by JSONPath, with their desired and observed values, when
+	// the resource isn't synced. Secret fields are redacted.
+	Diff string `json:"diff,omitempty"`
+
+	// DesiredState is the desired state the last observation was compared to,
+	// rendered from the PUT or PATCH mapping, or the expectedResponse of the GET
+	// mapping. Secret fields are redacted, and a body from a Secret is masked.
+	DesiredState string `json:"desiredState,omitempty"`
+
+	// Location is the URL of the object that the response to its create request
+	// pointed at with a Location or Content-Location header, resolved against the
+	// URL of the request. It's available to the templates as `.location`.
+	Location string `json:"location,omitempty"`
+
+	// ForceSync is the value of the http.crossplane.io/force-sync annotation
+	// when the object was last created or updated, so that changing it forces a
+	// single update.
+	ForceSync string `json:"forceSync,omitempty"`
+
+	// Outputs are the values selected by the statusOutputs from the last
+	// successful responses. An output the responses don't hold anymore keeps
+	// its last value.
+	Outputs map[string]extv1.JSON `json:"outputs,omitempty"`
+
+	// LastRequestTime is when the last request recorded in the status was sent.
+	LastRequestTime *metav1.Time `json:"lastRequestTime,omitempty"`
+
+	// LastResponseTime is when the response to the last request recorded in
+	// the status was received.
+	LastResponseTime *metav1.Time `json:"lastResponseTime,omitempty"`
+
+	// LatencyMillis is how long the last request recorded in the status took
+	// to get its response, retries included, in milliseconds.
+	LatencyMillis int64 `json:"latencyMillis,omitempty"`
+
+	// RateLimitedUntil is when the API allows the next request, after it
+	// answered the last one with 429 Too Many Requests and a Retry-After
+	// header.
+	RateLimitedUntil *metav1.Time `json:"rateLimitedUntil,omitempty"`
+
+	// RetryBackoff is how long the provider waits before retrying the create
+	// or update that failed last, doubling with every consecutive failure. It's
+	// cleared once a request succeeds.
+	RetryBackoff *metav1.Duration `json:"retryBackoff,omitempty"`
+
+	// PlannedAction is the request the provider would send to reconcile the
+	// object, Create, Update or Recreate, when its management policy is
+	// ObserveOnly.
+	// It's empty when the object is synced.
+	PlannedAction string `json:"plannedAction,omitempty"`
+
+	// Upload is the progress of the chunked upload of the last create or
+	// update, so that an interrupted upload resumes from the last byte the
+	// server confirmed. It's cleared once the upload completes.
+	Upload *UploadProgress `json:"upload,omitempty"`
 }
 
+// UploadProgress is the progress of a chunked upload to an upload session.
+type UploadProgress struct {
+	// Method is the method of the mapping whose request created the session.
+	Method string `json:"method"`
+
+	// URL is the URL of the upload session.
+	URL string `json:"url"`
+
+	// Digest is the SHA-256 digest of the uploaded content, so that changed
+	// content is uploaded to a new session instead.
+	Digest string `json:"digest"`
+
+	// Offset is the number of bytes of the content the server confirmed.
+	Offset int64 `json:"offset"`
+}
+
+// AnnotationForceSync is the annotation whose new values, e.g. a nonce, each force
+// an update of the object, even if it's observed to be synced.
+const AnnotationForceSync = "http.crossplane.io/force-sync"
+
+// AnnotationPreviewRequest is the annotation listing the roles, e.g. `PUT,POST`,
+// whose requests are recorded in an event as they would be sent, for debugging,
+// whenever they change.
+const AnnotationPreviewRequest = "http.crossplane.io/preview-request"
+
+// Actions planned for an object observed with the ObserveOnly management policy.
+const (
+	PlannedActionCreate   = "Create"
+	PlannedActionUpdate   = "Update"
+	PlannedActionRecreate = "Recreate"
+)
+
+// TypeObserved is the condition telling the outcome of the last observation of the
+// object, with a stable reason that automation can react to.
+const TypeObserved xpv1.ConditionType = "Observed"
+
+// Reasons of the Observed condition.
+const (
+	// ReasonUpToDate means that the object is synced with the desired state.
+	ReasonUpToDate xpv1.ConditionReason = "UpToDate"
+	// ReasonDriftDetected means that the object differs from the desired state.
+	ReasonDriftDetected xpv1.ConditionReason = "DriftDetected"
+	// ReasonNotFound means that the object doesn't exist.
+	ReasonNotFound xpv1.ConditionReason = "NotFound"
+	// ReasonAuthFailed means that the API answered the GET request with 401
+	// Unauthorized or 403 Forbidden.
+	ReasonAuthFailed xpv1.ConditionReason = "AuthFailed"
+	// ReasonClientError means that the API answered the GET request with
+	// another client error, such as 400 Bad Request or 410 Gone.
+	ReasonClientError xpv1.ConditionReason = "ClientError"
+	// ReasonRateLimited means that the API answered the GET request with 429
+	// Too Many Requests.
+	ReasonRateLimited xpv1.ConditionReason = "RateLimited"
+	// ReasonUnavailable means that the API couldn't be reached, or answered
+	// with a server error.
+	ReasonUnavailable xpv1.ConditionReason = "Unavailable"
+	// ReasonObserveFailed means that the observation failed for another
+	// reason, such as an invalid template or response.
+	ReasonObserveFailed xpv1.ConditionReason = "ObserveFailed"
+	// ReasonPending means that the object waits for an asynchronous creation
+	// or a deletion to complete, and isn't compared to the desired state yet.
+	ReasonPending xpv1.ConditionReason = "Pending"
+)
+
 type Cache struct {
 	LastUpdated string   `json:"lastUpdated,omitempty"`
 	Response    Response `json:"response,omitempty"`
@@ -1,6 +1,10 @@
 package v1alpha1
 
-import "time"
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 func (d *Request) SetStatusCode(statusCode int) {
 	d.Status.Response.StatusCode = statusCode
@@ -24,6 +28,13 @@ func (d *Request) SetError(err error) {
 func (d *Request) ResetFailures() {
 	d.Status.Failed = 0
 	d.Status.Error = ""
+	d.Status.RateLimitedUntil = nil
+	d.Status.RetryBackoff = nil
+}
+
+func (d *Request) SetRateLimited(message string, until *metav1.Time) {
+	d.Status.Error = message
+	d.Status.RateLimitedUntil = until
 }
 
 func (d *Request) SetRequestDetails(url, method, body string, headers map[string][]string) {
@@ -39,3 +50,21 @@ func (d *Request) SetCache(statusCode int, headers map[string][]string, body str
 	d.Status.Cache.Response.Body = body
 	d.Status.Cache.LastUpdated = time.Now().UTC().Format(time.RFC3339)
 }
+
+func (d *Request) SetETag(etag string) {
+	d.Status.ETag = etag
+}
+
+func (d *Request) SetDiff(diff string) {
+	d.Status.Diff = diff
+}
+
+func (d *Request) SetDesiredState(desiredState string) {
+	d.Status.DesiredState = desiredState
+}
+
+func (d *Request) SetTiming(requestTime, responseTime time.Time) {
+	d.Status.LastRequestTime = &metav1.Time{Time: requestTime}
+	d.Status.LastResponseTime = &metav1.Time{Time: responseTime}
+	d.Status.LatencyMillis = responseTime.Sub(requestTime).Milliseconds()
+}
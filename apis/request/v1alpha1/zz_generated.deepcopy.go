@@ -22,10 +22,104 @@ limitations under the License.
 package v1alpha1
 
 import (
+	commonv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlreadyExistsCheck) DeepCopyInto(out *AlreadyExistsCheck) {
+	*out = *in
+	if in.StatusCodes != nil {
+		in, out := &in.StatusCodes, &out.StatusCodes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlreadyExistsCheck.
+func (in *AlreadyExistsCheck) DeepCopy() *AlreadyExistsCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(AlreadyExistsCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AsyncOperation) DeepCopyInto(out *AsyncOperation) {
+	*out = *in
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.SuccessStatusCodes != nil {
+		in, out := &in.SuccessStatusCodes, &out.SuccessStatusCodes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxWait != nil {
+		in, out := &in.MaxWait, &out.MaxWait
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AsyncOperation.
+func (in *AsyncOperation) DeepCopy() *AsyncOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(AsyncOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
+	*out = *in
+	out.UsernameSecretRef = in.UsernameSecretRef
+	out.PasswordSecretRef = in.PasswordSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BasicAuth.
+func (in *BasicAuth) DeepCopy() *BasicAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(BasicAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BodySource) DeepCopyInto(out *BodySource) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(commonv1.SecretKeySelector)
+		**out = **in
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(ConfigMapKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BodySource.
+func (in *BodySource) DeepCopy() *BodySource {
+	if in == nil {
+		return nil
+	}
+	out := new(BodySource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Cache) DeepCopyInto(out *Cache) {
 	*out = *in
@@ -42,6 +136,130 @@ func (in *Cache) DeepCopy() *Cache {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChunkedUpload) DeepCopyInto(out *ChunkedUpload) {
+	*out = *in
+	in.ContentFrom.DeepCopyInto(&out.ContentFrom)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChunkedUpload.
+func (in *ChunkedUpload) DeepCopy() *ChunkedUpload {
+	if in == nil {
+		return nil
+	}
+	out := new(ChunkedUpload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompareList) DeepCopyInto(out *CompareList) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompareList.
+func (in *CompareList) DeepCopy() *CompareList {
+	if in == nil {
+		return nil
+	}
+	out := new(CompareList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompareOptions) DeepCopyInto(out *CompareOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompareOptions.
+func (in *CompareOptions) DeepCopy() *CompareOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(CompareOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionalUpdate) DeepCopyInto(out *ConditionalUpdate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionalUpdate.
+func (in *ConditionalUpdate) DeepCopy() *ConditionalUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionalUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapKeySelector) DeepCopyInto(out *ConfigMapKeySelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapKeySelector.
+func (in *ConfigMapKeySelector) DeepCopy() *ConfigMapKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HMAC) DeepCopyInto(out *HMAC) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HMAC.
+func (in *HMAC) DeepCopy() *HMAC {
+	if in == nil {
+		return nil
+	}
+	out := new(HMAC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Idempotency) DeepCopyInto(out *Idempotency) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Idempotency.
+func (in *Idempotency) DeepCopy() *Idempotency {
+	if in == nil {
+		return nil
+	}
+	out := new(Idempotency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Kerberos) DeepCopyInto(out *Kerberos) {
+	*out = *in
+	out.KeytabSecretRef = in.KeytabSecretRef
+	out.ConfigSecretRef = in.ConfigSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Kerberos.
+func (in *Kerberos) DeepCopy() *Kerberos {
+	if in == nil {
+		return nil
+	}
+	out := new(Kerberos)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Mapping) DeepCopyInto(out *Mapping) {
 	*out = *in
@@ -60,6 +278,98 @@ func (in *Mapping) DeepCopyInto(out *Mapping) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.QueryParams != nil {
+		in, out := &in.QueryParams, &out.QueryParams
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.BodyFrom != nil {
+		in, out := &in.BodyFrom, &out.BodyFrom
+		*out = new(BodySource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Multipart != nil {
+		in, out := &in.Multipart, &out.Multipart
+		*out = make([]MultipartPart, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Upload != nil {
+		in, out := &in.Upload, &out.Upload
+		*out = new(ChunkedUpload)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ComparePaths != nil {
+		in, out := &in.ComparePaths, &out.ComparePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompareList != nil {
+		in, out := &in.CompareList, &out.CompareList
+		*out = new(CompareList)
+		**out = **in
+	}
+	if in.CompareOptions != nil {
+		in, out := &in.CompareOptions, &out.CompareOptions
+		*out = new(CompareOptions)
+		**out = **in
+	}
+	if in.IgnoreFields != nil {
+		in, out := &in.IgnoreFields, &out.IgnoreFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExpectedStatusCodes != nil {
+		in, out := &in.ExpectedStatusCodes, &out.ExpectedStatusCodes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.Pagination != nil {
+		in, out := &in.Pagination, &out.Pagination
+		*out = new(Pagination)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResponseSchemaFrom != nil {
+		in, out := &in.ResponseSchemaFrom, &out.ResponseSchemaFrom
+		*out = new(ConfigMapKeySelector)
+		**out = **in
+	}
+	if in.NotFoundCheck != nil {
+		in, out := &in.NotFoundCheck, &out.NotFoundCheck
+		*out = new(NotFoundCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AlreadyExistsCheck != nil {
+		in, out := &in.AlreadyExistsCheck, &out.AlreadyExistsCheck
+		*out = new(AlreadyExistsCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessCheck != nil {
+		in, out := &in.ReadinessCheck, &out.ReadinessCheck
+		*out = new(ReadinessCheck)
+		**out = **in
+	}
+	if in.WaitTimeout != nil {
+		in, out := &in.WaitTimeout, &out.WaitTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.InsecureSkipTLSVerify != nil {
+		in, out := &in.InsecureSkipTLSVerify, &out.InsecureSkipTLSVerify
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Mapping.
@@ -72,6 +382,118 @@ func (in *Mapping) DeepCopy() *Mapping {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultipartPart) DeepCopyInto(out *MultipartPart) {
+	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(PartValueSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultipartPart.
+func (in *MultipartPart) DeepCopy() *MultipartPart {
+	if in == nil {
+		return nil
+	}
+	out := new(MultipartPart)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotFoundCheck) DeepCopyInto(out *NotFoundCheck) {
+	*out = *in
+	if in.StatusCodes != nil {
+		in, out := &in.StatusCodes, &out.StatusCodes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotFoundCheck.
+func (in *NotFoundCheck) DeepCopy() *NotFoundCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(NotFoundCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuth2) DeepCopyInto(out *OAuth2) {
+	*out = *in
+	out.ClientIDSecretRef = in.ClientIDSecretRef
+	out.ClientSecretSecretRef = in.ClientSecretSecretRef
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultTokenTTL != nil {
+		in, out := &in.DefaultTokenTTL, &out.DefaultTokenTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuth2.
+func (in *OAuth2) DeepCopy() *OAuth2 {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuth2)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Pagination) DeepCopyInto(out *Pagination) {
+	*out = *in
+	if in.MaxPages != nil {
+		in, out := &in.MaxPages, &out.MaxPages
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pagination.
+func (in *Pagination) DeepCopy() *Pagination {
+	if in == nil {
+		return nil
+	}
+	out := new(Pagination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartValueSource) DeepCopyInto(out *PartValueSource) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(commonv1.SecretKeySelector)
+		**out = **in
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(ConfigMapKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartValueSource.
+func (in *PartValueSource) DeepCopy() *PartValueSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PartValueSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Payload) DeepCopyInto(out *Payload) {
 	*out = *in
@@ -87,6 +509,21 @@ func (in *Payload) DeepCopy() *Payload {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessCheck) DeepCopyInto(out *ReadinessCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessCheck.
+func (in *ReadinessCheck) DeepCopy() *ReadinessCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Request) DeepCopyInto(out *Request) {
 	*out = *in
@@ -177,6 +614,107 @@ func (in *RequestParameters) DeepCopyInto(out *RequestParameters) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.HeadersFromSecret != nil {
+		in, out := &in.HeadersFromSecret, &out.HeadersFromSecret
+		*out = make(map[string]commonv1.SecretKeySelector, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TLSCACertSecretRef != nil {
+		in, out := &in.TLSCACertSecretRef, &out.TLSCACertSecretRef
+		*out = new(commonv1.SecretKeySelector)
+		**out = **in
+	}
+	if in.TLSClientCertSecretRef != nil {
+		in, out := &in.TLSClientCertSecretRef, &out.TLSClientCertSecretRef
+		*out = new(commonv1.SecretReference)
+		**out = **in
+	}
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(BasicAuth)
+		**out = **in
+	}
+	if in.HMAC != nil {
+		in, out := &in.HMAC, &out.HMAC
+		*out = new(HMAC)
+		**out = **in
+	}
+	if in.OAuth2 != nil {
+		in, out := &in.OAuth2, &out.OAuth2
+		*out = new(OAuth2)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kerberos != nil {
+		in, out := &in.Kerberos, &out.Kerberos
+		*out = new(Kerberos)
+		**out = **in
+	}
+	if in.Session != nil {
+		in, out := &in.Session, &out.Session
+		*out = new(Session)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Async != nil {
+		in, out := &in.Async, &out.Async
+		*out = new(AsyncOperation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WaitForDeletion != nil {
+		in, out := &in.WaitForDeletion, &out.WaitForDeletion
+		*out = new(WaitForDeletion)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Idempotency != nil {
+		in, out := &in.Idempotency, &out.Idempotency
+		*out = new(Idempotency)
+		**out = **in
+	}
+	if in.ConditionalUpdate != nil {
+		in, out := &in.ConditionalUpdate, &out.ConditionalUpdate
+		*out = new(ConditionalUpdate)
+		**out = **in
+	}
+	if in.RedactHeaders != nil {
+		in, out := &in.RedactHeaders, &out.RedactHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecretFields != nil {
+		in, out := &in.SecretFields, &out.SecretFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecretOutputs != nil {
+		in, out := &in.SecretOutputs, &out.SecretOutputs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.StatusOutputs != nil {
+		in, out := &in.StatusOutputs, &out.StatusOutputs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ImmutableFields != nil {
+		in, out := &in.ImmutableFields, &out.ImmutableFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestParameters.
@@ -213,6 +751,35 @@ func (in *RequestStatus) DeepCopyInto(out *RequestStatus) {
 	in.Response.DeepCopyInto(&out.Response)
 	in.Cache.DeepCopyInto(&out.Cache)
 	in.RequestDetails.DeepCopyInto(&out.RequestDetails)
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make(map[string]apiextensionsv1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.LastRequestTime != nil {
+		in, out := &in.LastRequestTime, &out.LastRequestTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastResponseTime != nil {
+		in, out := &in.LastResponseTime, &out.LastResponseTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RateLimitedUntil != nil {
+		in, out := &in.RateLimitedUntil, &out.RateLimitedUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.RetryBackoff != nil {
+		in, out := &in.RetryBackoff, &out.RetryBackoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Upload != nil {
+		in, out := &in.Upload, &out.Upload
+		*out = new(UploadProgress)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestStatus.
@@ -254,3 +821,88 @@ func (in *Response) DeepCopy() *Response {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
+	if in.InitialBackoff != nil {
+		in, out := &in.InitialBackoff, &out.InitialBackoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RetryableStatusCodes != nil {
+		in, out := &in.RetryableStatusCodes, &out.RetryableStatusCodes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Session) DeepCopyInto(out *Session) {
+	*out = *in
+	if in.Login != nil {
+		in, out := &in.Login, &out.Login
+		*out = new(Mapping)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Session.
+func (in *Session) DeepCopy() *Session {
+	if in == nil {
+		return nil
+	}
+	out := new(Session)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UploadProgress) DeepCopyInto(out *UploadProgress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UploadProgress.
+func (in *UploadProgress) DeepCopy() *UploadProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(UploadProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WaitForDeletion) DeepCopyInto(out *WaitForDeletion) {
+	*out = *in
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxWait != nil {
+		in, out := &in.MaxWait, &out.MaxWait
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WaitForDeletion.
+func (in *WaitForDeletion) DeepCopy() *WaitForDeletion {
+	if in == nil {
+		return nil
+	}
+	out := new(WaitForDeletion)
+	in.DeepCopyInto(out)
+	return out
+}
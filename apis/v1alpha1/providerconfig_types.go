@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"reflect"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -29,6 +30,293 @@ import (
 type ProviderConfigSpec struct {
 	// Credentials required to authenticate to this provider.
 	Credentials ProviderCredentials `json:"credentials"`
+
+	// RateLimit, when set, throttles the requests sent to each host by the
+	// resources using this ProviderConfig.
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+
+	// CircuitBreaker, when set, stops sending requests to a host that keeps
+	// failing, so that the resources using this ProviderConfig fail fast
+	// instead of waiting for their timeouts.
+	CircuitBreaker *CircuitBreaker `json:"circuitBreaker,omitempty"`
+
+	// Proxy, when set, sends the requests of the resources using this
+	// ProviderConfig through the proxy, instead of the one set by the
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+	Proxy *Proxy `json:"proxy,omitempty"`
+
+	// MaxResponseSize limits the size of the response bodies read for the
+	// resources using this ProviderConfig, e.g. `10Mi`. Requests getting a
+	// larger response fail. Defaults to 4Mi.
+	MaxResponseSize *resource.Quantity `json:"maxResponseSize,omitempty"`
+
+	// PollInterval is how often the Requests using this ProviderConfig are
+	// observed, unless they set their own, instead of the provider's
+	// --poll interval.
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+
+	// ServiceAccountToken, when set, authorizes the requests of the resources
+	// using this ProviderConfig with the provider's projected ServiceAccount
+	// token as a bearer token.
+	ServiceAccountToken *ServiceAccountToken `json:"serviceAccountToken,omitempty"`
+
+	// UserAgent is the User-Agent header of the requests of the resources using
+	// this ProviderConfig, unless their headers set one. Defaults to
+	// provider-http/<version>.
+	UserAgent string `json:"userAgent,omitempty"`
+
+	// Redirects configures how the requests of the resources using this
+	// ProviderConfig follow redirects. By default, up to 10 redirects are
+	// followed, and credentials are only sent to the host of the original
+	// request.
+	Redirects *Redirects `json:"redirects,omitempty"`
+
+	// HostAliases pin hostnames to IP addresses for the requests of the
+	// resources using this ProviderConfig, instead of resolving them through
+	// DNS. The Host header and TLS server name remain the hostname.
+	HostAliases []HostAlias `json:"hostAliases,omitempty"`
+
+	// ConnectionPool configures how many connections to the APIs are kept
+	// open for reuse by the resources using this ProviderConfig, and for how
+	// long.
+	ConnectionPool *ConnectionPool `json:"connectionPool,omitempty"`
+
+	// Protocol configures the HTTP version of the requests of the resources
+	// using this ProviderConfig. By default, HTTP/2 is negotiated with the APIs
+	// supporting it over TLS, and HTTP/1.1 is used otherwise.
+	Protocol *Protocol `json:"protocol,omitempty"`
+
+	// AllowedEnvVars are the names of the provider's environment variables
+	// available to the templates of the Requests using this ProviderConfig, as
+	// `.env.<NAME>`. Other environment variables aren't available.
+	AllowedEnvVars []string `json:"allowedEnvVars,omitempty"`
+
+	// Notify configures a webhook notified when an observation changes whether
+	// a Request using this ProviderConfig is synced.
+	Notify *Notify `json:"notify,omitempty"`
+
+	// BasicAuth, when set, authorizes the requests of the resources using this
+	// ProviderConfig with HTTP basic authentication, unless a Request sets its
+	// own basicAuth or oauth2.
+	BasicAuth *BasicAuth `json:"basicAuth,omitempty"`
+
+	// OAuth2, when set, authorizes the requests of the resources using this
+	// ProviderConfig with an access token obtained through the OAuth2 client
+	// credentials grant, unless a Request sets its own basicAuth or oauth2.
+	OAuth2 *OAuth2 `json:"oauth2,omitempty"`
+
+	// Kerberos, when set, authorizes the requests of the resources using this
+	// ProviderConfig with SPNEGO, negotiating a Kerberos ticket for the API
+	// with a keytab, unless a Request sets its own basicAuth or oauth2.
+	Kerberos *Kerberos `json:"kerberos,omitempty"`
+
+	// TLS configures the certificates the resources using this ProviderConfig
+	// trust and present, unless a Request references its own.
+	TLS *TLS `json:"tls,omitempty"`
+
+	// CertificateExpiryWarning is how long before the certificate an API
+	// presents expires that the Requests using this ProviderConfig warn about
+	// it with an event on every observation, e.g. `336h`. Defaults to 720h,
+	// i.e. 30 days. Zero disables the warning.
+	CertificateExpiryWarning *metav1.Duration `json:"certificateExpiryWarning,omitempty"`
+
+	// FailureBackoff configures how long the Requests using this ProviderConfig
+	// wait before retrying a create or update that failed.
+	FailureBackoff *FailureBackoff `json:"failureBackoff,omitempty"`
+}
+
+// FailureBackoff configures the exponential backoff between the retries of
+// failed creates and updates. The wait doubles with every consecutive failure,
+// and is reset once a request succeeds.
+type FailureBackoff struct {
+	// Initial is the wait after the first failure. Defaults to 10s.
+	Initial *metav1.Duration `json:"initial,omitempty"`
+
+	// Max caps the wait. Defaults to 10m.
+	Max *metav1.Duration `json:"max,omitempty"`
+}
+
+// BasicAuth configures HTTP basic authentication.
+type BasicAuth struct {
+	// UsernameSecretRef references the secret key holding the username.
+	UsernameSecretRef xpv1.SecretKeySelector `json:"usernameSecretRef"`
+
+	// PasswordSecretRef references the secret key holding the password.
+	PasswordSecretRef xpv1.SecretKeySelector `json:"passwordSecretRef"`
+}
+
+// OAuth2 configures the OAuth2 client credentials grant.
+type OAuth2 struct {
+	// TokenURL is the endpoint access tokens are requested from.
+	TokenURL string `json:"tokenUrl"`
+
+	// ClientIDSecretRef references the secret key holding the client ID.
+	ClientIDSecretRef xpv1.SecretKeySelector `json:"clientIdSecretRef"`
+
+	// ClientSecretSecretRef references the secret key holding the client secret.
+	ClientSecretSecretRef xpv1.SecretKeySelector `json:"clientSecretSecretRef"`
+
+	// Scopes requested for the access token.
+	Scopes []string `json:"scopes,omitempty"`
+
+	// DefaultTokenTTL is the token lifetime assumed when the token endpoint
+	// doesn't return expires_in. Defaults to 5m.
+	DefaultTokenTTL *metav1.Duration `json:"defaultTokenTTL,omitempty"`
+}
+
+// Kerberos configures SPNEGO authentication with a Kerberos keytab.
+type Kerberos struct {
+	// Username is the principal the provider authenticates as, without its
+	// realm, e.g. `provider-http`.
+	Username string `json:"username"`
+
+	// Realm of the principal, e.g. `EXAMPLE.COM`.
+	Realm string `json:"realm"`
+
+	// KeytabSecretRef references the secret key holding the keytab of the
+	// principal, as written by ktutil.
+	KeytabSecretRef xpv1.SecretKeySelector `json:"keytabSecretRef"`
+
+	// ConfigSecretRef references the secret key holding the krb5.conf that
+	// locates the KDCs of the realm.
+	ConfigSecretRef xpv1.SecretKeySelector `json:"configSecretRef"`
+
+	// SPN is the service principal name of the APIs, e.g.
+	// `HTTP/api.example.com`. Defaults to HTTP/ and the canonical name of the
+	// host of the request URL.
+	SPN string `json:"spn,omitempty"`
+}
+
+// TLS configures the certificates of the TLS connections to the APIs.
+type TLS struct {
+	// CACertSecretRef references a PEM encoded CA bundle used to verify the
+	// server certificates instead of the system roots.
+	CACertSecretRef *xpv1.SecretKeySelector `json:"caCertSecretRef,omitempty"`
+
+	// ClientCertSecretRef references a secret holding the PEM encoded client
+	// certificate and private key under the tls.crt and tls.key keys, presented
+	// to servers requiring mutual TLS.
+	ClientCertSecretRef *xpv1.SecretReference `json:"clientCertSecretRef,omitempty"`
+}
+
+// Notify configures the webhook notified of the sync state changes of Requests.
+type Notify struct {
+	// URL the notifications are POSTed to, as JSON.
+	URL string `json:"url"`
+}
+
+// ConnectionPool configures the idle connections kept open for reuse.
+type ConnectionPool struct {
+	// MaxIdleConns is how many idle connections are kept open across all
+	// hosts. Defaults to 100.
+	// +kubebuilder:validation:Minimum=1
+	MaxIdleConns int `json:"maxIdleConns,omitempty"`
+
+	// MaxIdleConnsPerHost is how many idle connections are kept open per
+	// host. Defaults to 2.
+	// +kubebuilder:validation:Minimum=1
+	MaxIdleConnsPerHost int `json:"maxIdleConnsPerHost,omitempty"`
+
+	// IdleConnTimeout is how long an idle connection is kept open, e.g. `30s`.
+	// Defaults to 90s.
+	IdleConnTimeout *metav1.Duration `json:"idleConnTimeout,omitempty"`
+}
+
+// Protocol configures the HTTP version of the requests.
+type Protocol struct {
+	// Version is the HTTP version of the requests to https URLs. Auto
+	// negotiates HTTP/2 with the APIs supporting it, HTTP1 always uses
+	// HTTP/1.1, and HTTP2 fails the requests to the APIs that don't negotiate
+	// HTTP/2. Defaults to Auto.
+	// +kubebuilder:validation:Enum=Auto;HTTP1;HTTP2
+	Version string `json:"version,omitempty"`
+
+	// H2C sends the requests to http URLs over HTTP/2 without TLS, known as
+	// h2c, for internal services known to support it. The proxy isn't used
+	// for these requests.
+	H2C bool `json:"h2c,omitempty"`
+}
+
+// HostAlias maps hostnames to the IP address that is connected to for them.
+type HostAlias struct {
+	// IP address of the hosts, e.g. `10.0.0.12`.
+	IP string `json:"ip"`
+
+	// Hostnames connected to at the IP address, e.g. `api.example.com`.
+	Hostnames []string `json:"hostnames"`
+}
+
+// Redirects configures how redirect responses are followed.
+type Redirects struct {
+	// Follow redirect responses. Otherwise the redirect response is the
+	// response of the request. Defaults to true.
+	Follow *bool `json:"follow,omitempty"`
+
+	// MaxRedirects is how many redirects are followed before the request
+	// fails. Defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	MaxRedirects int `json:"maxRedirects,omitempty"`
+
+	// PreserveSensitiveHeaders forwards the Authorization and Cookie headers,
+	// and the headers of the credentials, to a different host than the one of
+	// the original request when following a redirect.
+	PreserveSensitiveHeaders bool `json:"preserveSensitiveHeaders,omitempty"`
+}
+
+// ServiceAccountToken selects the projected ServiceAccount token of the provider.
+// The token is read again on every reconcile, as the kubelet rotates it.
+type ServiceAccountToken struct {
+	// Audience of the token. The token is read from the projected token volume
+	// mounted at /var/run/secrets/tokens/<audience>/token, so the audience must
+	// be a single path element. Without an audience, the token of the provider's
+	// ServiceAccount, issued for the Kubernetes API, is used.
+	Audience string `json:"audience,omitempty"`
+
+	// Path of the token file, overriding the one derived from the audience. It
+	// must be an absolute path under /var/run/secrets/tokens.
+	Path string `json:"path,omitempty"`
+}
+
+// Proxy configures an HTTP, HTTPS or SOCKS5 proxy.
+type Proxy struct {
+	// URL of the proxy, e.g. http://proxy.example.com:3128 or
+	// socks5://proxy.example.com:1080.
+	URL string `json:"url"`
+
+	// NoProxy lists the hosts requests are sent to directly, in the format of
+	// the NO_PROXY environment variable, e.g. `.svc,.cluster.local,10.0.0.0/8`.
+	NoProxy string `json:"noProxy,omitempty"`
+
+	// CredentialsSecretRef references a secret holding the username and
+	// password keys used to authenticate to the proxy.
+	CredentialsSecretRef *xpv1.SecretReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// RateLimit configures a token bucket per host. Hosts answering with
+// 429 Too Many Requests are additionally backed off from, respecting the
+// Retry-After header.
+type RateLimit struct {
+	// RequestsPerSecond is the rate at which the bucket refills.
+	// +kubebuilder:validation:Minimum=1
+	RequestsPerSecond int `json:"requestsPerSecond"`
+
+	// Burst is the size of the bucket, i.e. how many requests may be sent at
+	// once. Defaults to RequestsPerSecond.
+	// +kubebuilder:validation:Minimum=1
+	Burst int `json:"burst,omitempty"`
+}
+
+// CircuitBreaker configures a circuit breaker per host. A request fails when it
+// gets no response, e.g. because it timed out, or a 5xx response.
+type CircuitBreaker struct {
+	// FailureThreshold is the number of consecutive failed requests to a host
+	// after which the requests to it fail fast.
+	// +kubebuilder:validation:Minimum=1
+	FailureThreshold int `json:"failureThreshold"`
+
+	// Cooldown is how long the requests to a host fail fast, before a single
+	// request is sent to find out whether it recovered. Defaults to 30s.
+	Cooldown *metav1.Duration `json:"cooldown,omitempty"`
 }
 
 // ProviderCredentials required to authenticate.
@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"time"
@@ -32,18 +33,30 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 
 	"github.com/crossplane-contrib/provider-http/apis"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	template "github.com/crossplane-contrib/provider-http/internal/controller"
+	"github.com/crossplane-contrib/provider-http/internal/features"
+	"github.com/crossplane-contrib/provider-http/internal/tracing"
+	"github.com/crossplane-contrib/provider-http/internal/webhook"
 )
 
 func main() {
 	var (
-		app              = kingpin.New(filepath.Base(os.Args[0]), "Http support for Crossplane.").DefaultEnvars()
-		debug            = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
-		leaderElection   = app.Flag("leader-election", "Use leader election for the controller manager.").Short('l').Default("false").OverrideDefaultFromEnvar("LEADER_ELECTION").Bool()
-		timeout          = app.Flag("timeout", "Controls how long http requests may take before they are failed.").Default("10m").Duration()
-		syncInterval     = app.Flag("sync", "How often all resources will be double-checked for drift from the desired state.").Short('s').Default("1h").Duration()
-		pollInterval     = app.Flag("poll", "How often individual resources will be checked for drift from the desired state").Default("1m").Duration()
-		maxReconcileRate = app.Flag("max-reconcile-rate", "The global maximum rate per second at which resources may checked for drift from the desired state.").Default("10").Int()
+		app                      = kingpin.New(filepath.Base(os.Args[0]), "Http support for Crossplane.").DefaultEnvars()
+		debug                    = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
+		leaderElection           = app.Flag("leader-election", "Use leader election for the controller manager.").Short('l').Default("false").OverrideDefaultFromEnvar("LEADER_ELECTION").Bool()
+		timeout                  = app.Flag("timeout", "Controls how long http requests may take before they are failed.").Default("10m").Duration()
+		syncInterval             = app.Flag("sync", "How often all resources will be double-checked for drift from the desired state.").Short('s').Default("1h").Duration()
+		pollInterval             = app.Flag("poll", "How often individual resources will be checked for drift from the desired state").Default("1m").Duration()
+		maxReconcileRate         = app.Flag("max-reconcile-rate", "The global maximum rate per second at which resources may checked for drift from the desired state.").Default("10").Int()
+		maxConcurrentReconciles  = app.Flag("max-concurrent-reconciles", "The maximum number of resources reconciled at once, per kind. Defaults to the maximum reconcile rate.").Default("0").Int()
+		maxInFlightRequests      = app.Flag("max-in-flight-requests", "The maximum number of HTTP requests sent at once by all resources. Zero doesn't cap them.").Default("0").Int()
+		responseCacheSize        = app.Flag("response-cache-size", "The maximum size of the responses cached for the GET mappings setting cacheResponse, e.g. 64MiB. Zero disables the cache.").Default("64MiB").Bytes()
+		jitter                   = app.Flag("jitter", "The fraction of the poll intervals and retry backoffs by which they are spread at random, between 0 and 1, e.g. 0.1 for 10% shorter or longer waits.").Default("0").Float64()
+		enableTracing            = app.Flag("enable-tracing", "Create an OpenTelemetry span per outgoing HTTP request and propagate it in the W3C traceparent header.").Default("false").Bool()
+		tracingExporter          = app.Flag("tracing-exporter", "The exporter of the spans: otlp, configured by the OTEL_EXPORTER_OTLP_* environment variables, or stdout.").Default(tracing.ExporterOTLP).Enum(tracing.ExporterOTLP, tracing.ExporterStdout)
+		enableManagementPolicies = app.Flag("enable-management-policies", "Honor the managementPolicy of the Requests, observing ObserveOnly Requests without sending any other request.").Default("false").Bool()
+		webhookCertDir           = app.Flag("webhook-tls-cert-dir", "The directory of the TLS certificate and key of the validating webhook. The webhook is disabled without one.").Envar("WEBHOOK_TLS_CERT_DIR").String()
 
 		// namespace = app.Flag("namespace", "Namespace used to set as default scope in default secret store config.").Default("crossplane-system").Envar("POD_NAMESPACE").String()
 	)
@@ -76,18 +89,53 @@ func main() {
 		LeaderElectionResourceLock: resourcelock.LeasesResourceLock,
 		LeaseDuration:              func() *time.Duration { d := 60 * time.Second; return &d }(),
 		RenewDeadline:              func() *time.Duration { d := 50 * time.Second; return &d }(),
+
+		CertDir: *webhookCertDir,
+		Port:    9443,
 	})
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add Http APIs to scheme")
 
+	concurrentReconciles := *maxConcurrentReconciles
+	if concurrentReconciles <= 0 {
+		concurrentReconciles = *maxReconcileRate
+	}
+	httpClient.SetMaxInFlight(*maxInFlightRequests)
+	log.Info("Concurrency limits", "max-concurrent-reconciles", concurrentReconciles, "max-in-flight-requests", httpClient.MaxInFlight())
+	if *jitter < 0 || *jitter > 1 {
+		kingpin.Fatalf("--jitter must be between 0 and 1, got %v", *jitter)
+	}
+	httpClient.SetJitter(*jitter)
+	httpClient.SetResponseCacheBytes(int64(*responseCacheSize))
+
 	o := controller.Options{
 		Logger:                  log,
-		MaxConcurrentReconciles: *maxReconcileRate,
+		MaxConcurrentReconciles: concurrentReconciles,
 		PollInterval:            *pollInterval,
 		GlobalRateLimiter:       ratelimiter.NewGlobal(*maxReconcileRate),
 		Features:                &feature.Flags{},
 	}
 
+	shutdownTracing := func(context.Context) error { return nil }
+	if *enableTracing {
+		shutdownTracing, err = tracing.Setup(context.Background(), *tracingExporter)
+		kingpin.FatalIfError(err, "Cannot setup tracing")
+		o.Features.Enable(features.EnableTracing)
+		log.Info("Alpha feature enabled", "flag", features.EnableTracing, "exporter", *tracingExporter)
+	}
+
+	if *enableManagementPolicies {
+		o.Features.Enable(features.EnableAlphaManagementPolicies)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaManagementPolicies)
+	}
+
 	kingpin.FatalIfError(template.Setup(mgr, o, *timeout), "Cannot setup Template controllers")
-	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
+	if *webhookCertDir != "" {
+		kingpin.FatalIfError(webhook.SetupRequest(mgr), "Cannot setup the Request webhook")
+	}
+	err = mgr.Start(ctrl.SetupSignalHandler())
+	if shutdownErr := shutdownTracing(context.Background()); shutdownErr != nil {
+		log.Info("Cannot flush the tracing spans", "error", shutdownErr)
+	}
+	kingpin.FatalIfError(err, "Cannot start controller manager")
 }
@@ -0,0 +1,168 @@
+package cel
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"github.com/pkg/errors"
+)
+
+const (
+	errEnvironment = "cannot create the CEL environment"
+	errInvalidExpr = "failed to compile CEL expression %s: %s"
+	errEvaluation  = "failed to evaluate CEL expression %s: %s"
+	errNotBool     = "CEL expression %s should return a bool, returns %s"
+	errBoolResult  = "CEL expression %s returned %s instead of a bool"
+)
+
+const (
+	variableResponse = "response"
+	variableDesired  = "desired"
+
+	// maxPrograms bounds the number of compiled programs kept for reuse.
+	maxPrograms = 500
+)
+
+var (
+	envOnce sync.Once
+	env     *cel.Env
+	envErr  error
+)
+
+// environment returns the CEL environment of the compare expressions, declaring the
+// parsed response and desired state, the string extensions, and the helpers.
+func environment() (*cel.Env, error) {
+	envOnce.Do(func() {
+		options := []cel.EnvOption{
+			cel.Variable(variableResponse, cel.MapType(cel.StringType, cel.DynType)),
+			cel.Variable(variableDesired, cel.MapType(cel.StringType, cel.DynType)),
+			ext.Strings(),
+		}
+		env, envErr = cel.NewEnv(append(options, functions...)...)
+	})
+	return env, errors.Wrap(envErr, errEnvironment)
+}
+
+// compile parses and checks the expression, which must return a bool.
+func compile(e *cel.Env, expression string) (*cel.Ast, error) {
+	ast, issues := e.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, errors.Errorf(errInvalidExpr, expression, issues.Err().Error())
+	}
+
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, errors.Errorf(errNotBool, expression, ast.OutputType())
+	}
+	return ast, nil
+}
+
+// Validate checks that the expression compiles and returns a bool, without
+// evaluating it.
+func Validate(expression string) error {
+	e, err := environment()
+	if err != nil {
+		return err
+	}
+
+	_, err = compile(e, expression)
+	return err
+}
+
+// ParseBool evaluates the expression with the parsed response and desired state
+// bound to its `response` and `desired` variables.
+func ParseBool(expression string, response, desired map[string]interface{}) (bool, error) {
+	program, err := programs.get(expression)
+	if err != nil {
+		return false, err
+	}
+
+	result, _, err := program.Eval(map[string]interface{}{
+		variableResponse: normalize(orEmpty(response)),
+		variableDesired:  normalize(orEmpty(desired)),
+	})
+	if err != nil {
+		return false, errors.Errorf(errEvaluation, expression, err.Error())
+	}
+
+	boolean, ok := result.Value().(bool)
+	if !ok {
+		return false, errors.Errorf(errBoolResult, expression, fmt.Sprint(result.Value()))
+	}
+	return boolean, nil
+}
+
+// programs keeps the programs of the expressions, so that an expression evaluated
+// on every observation is only compiled once.
+var programs = &programCache{programs: map[string]cel.Program{}}
+
+type programCache struct {
+	mu       sync.Mutex
+	programs map[string]cel.Program
+}
+
+// get returns the program of the expression, compiling it when it isn't cached yet.
+// The cache is emptied once full, as expressions only change with their Requests.
+func (c *programCache) get(expression string) (cel.Program, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if program, ok := c.programs[expression]; ok {
+		return program, nil
+	}
+
+	e, err := environment()
+	if err != nil {
+		return nil, err
+	}
+	ast, err := compile(e, expression)
+	if err != nil {
+		return nil, err
+	}
+	program, err := e.Program(ast)
+	if err != nil {
+		return nil, errors.Errorf(errInvalidExpr, expression, err.Error())
+	}
+
+	if len(c.programs) >= maxPrograms {
+		c.programs = map[string]cel.Program{}
+	}
+	c.programs[expression] = program
+	return program, nil
+}
+
+// normalize converts the JSON numbers of a decoded document, which CEL doesn't
+// know, to an int when they're integers, or else to a double.
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			normalized[key] = normalize(element)
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(v))
+		for i, element := range v {
+			normalized[i] = normalize(element)
+		}
+		return normalized
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	}
+	return value
+}
+
+// orEmpty returns the map, or an empty one if it's nil.
+func orEmpty(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return map[string]interface{}{}
+	}
+	return m
+}
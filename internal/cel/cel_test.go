@@ -0,0 +1,231 @@
+package cel
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	testResponse = map[string]interface{}{
+		"id":             "123",
+		"username":       "John_Doe",
+		"content_sha256": "99682b662166cd8e4adc60d43c67b7a8227b3cdd74452dbdf71b6ca42a366363",
+		"token":          "am9obl9kb2U=",
+		"updated_at":     "2024-01-01T00:00:00Z",
+		"settings":       map[string]interface{}{"theme": "dark", "language": "en"},
+		"replicas":       float64(3),
+	}
+	testDesired = map[string]interface{}{
+		"username": "john_doe",
+		"content":  "john_doe",
+		"settings": map[string]interface{}{"theme": "dark"},
+		"replicas": float64(3),
+	}
+)
+
+func Test_ParseBool(t *testing.T) {
+	type args struct {
+		expression string
+		response   map[string]interface{}
+		desired    map[string]interface{}
+	}
+	type want struct {
+		result bool
+		err    bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Equal": {
+			args: args{
+				expression: `response.replicas == desired.replicas`,
+				response:   testResponse,
+				desired:    testDesired,
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"TolerantComparison": {
+			args: args{
+				expression: `response.username.lowerAscii() == desired.username`,
+				response:   testResponse,
+				desired:    testDesired,
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"SHA256": {
+			args: args{
+				expression: `sha256(desired.content) == response.content_sha256`,
+				response:   testResponse,
+				desired:    testDesired,
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"Base64": {
+			args: args{
+				expression: `b64dec(response.token) == desired.content && b64enc(desired.content) == response.token`,
+				response:   testResponse,
+				desired:    testDesired,
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"PickAndOmit": {
+			args: args{
+				expression: `pick(response, ["replicas"]) == omit(desired, ["username", "content", "settings"])`,
+				response:   testResponse,
+				desired:    testDesired,
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"Merge": {
+			args: args{
+				expression: `merge(response.settings, desired.settings) == response.settings`,
+				response:   testResponse,
+				desired:    testDesired,
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"NotEqual": {
+			args: args{
+				expression: `omit(response, ["updated_at"]) == desired`,
+				response:   testResponse,
+				desired:    testDesired,
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"WithoutDesiredState": {
+			args: args{
+				expression: `response.id == "123" && size(desired) == 0`,
+				response:   testResponse,
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"JSONNumbers": {
+			args: args{
+				expression: `response.id == 12345678901234567890.0 && response.replicas == 3 && response.ratio > 0.5 && desired.replicas == response.replicas`,
+				response:   map[string]interface{}{"id": json.Number("12345678901234567890"), "replicas": json.Number("3"), "ratio": json.Number("0.75")},
+				desired:    map[string]interface{}{"replicas": json.Number("3")},
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"InvalidBase64": {
+			args: args{
+				expression: `b64dec(response.id) == ""`,
+				response:   testResponse,
+				desired:    testDesired,
+			},
+			want: want{
+				err: true,
+			},
+		},
+		"MissingField": {
+			args: args{
+				expression: `response.missing == desired.username`,
+				response:   testResponse,
+				desired:    testDesired,
+			},
+			want: want{
+				err: true,
+			},
+		},
+		"NotBool": {
+			args: args{
+				expression: `response.id`,
+				response:   testResponse,
+				desired:    testDesired,
+			},
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ParseBool(tc.args.expression, tc.args.response, tc.args.desired)
+			if diff := cmp.Diff(tc.want.err, gotErr != nil); diff != "" {
+				t.Fatalf("ParseBool(...): -want error, +got error: %s: %v", diff, gotErr)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("ParseBool(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_Validate(t *testing.T) {
+	cases := map[string]struct {
+		expression string
+		want       bool
+	}{
+		"Valid": {
+			expression: `omit(response, ["updated_at"]) == desired`,
+		},
+		"Dynamic": {
+			expression: `response.enabled`,
+		},
+		"NotBool": {
+			expression: `sha256(response.id)`,
+			want:       true,
+		},
+		"UnknownFunction": {
+			expression: `sha512(response.id) == ""`,
+			want:       true,
+		},
+		"SyntaxError": {
+			expression: `response.id ==`,
+			want:       true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := Validate(tc.expression)
+			if diff := cmp.Diff(tc.want, gotErr != nil); diff != "" {
+				t.Fatalf("Validate(...): -want error, +got error: %s: %v", diff, gotErr)
+			}
+		})
+	}
+}
+
+func Test_programCache_get(t *testing.T) {
+	c := &programCache{programs: map[string]cel.Program{}}
+
+	first, err := c.get(`response.id == "123"`)
+	if err != nil {
+		t.Fatalf("get(...): unexpected error: %s", err)
+	}
+	second, err := c.get(`response.id == "123"`)
+	if err != nil {
+		t.Fatalf("get(...): unexpected error: %s", err)
+	}
+	if first != second {
+		t.Errorf("get(...): want the cached program of the expression")
+	}
+
+	if _, err := c.get(`response.id ==`); err == nil {
+		t.Errorf("get(...): want an error for an invalid expression")
+	}
+	if diff := cmp.Diff(1, len(c.programs)); diff != "" {
+		t.Errorf("get(...): -want cached programs, +got cached programs: %s", diff)
+	}
+}
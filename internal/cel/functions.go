@@ -0,0 +1,134 @@
+package cel
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"reflect"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+)
+
+const (
+	errBase64Decode = "b64dec failed to decode: %s"
+	errMapArgument  = "%s expects a map with string keys"
+	errKeysArgument = "%s expects a list of string keys"
+)
+
+var (
+	mapType  = reflect.TypeOf(map[string]interface{}{})
+	keysType = reflect.TypeOf([]string{})
+)
+
+// functions are the helpers available to every CEL expression in addition to the
+// CEL standard library, e.g. `sha256(desired.content) == response.content_sha256`.
+var functions = []cel.EnvOption{
+	cel.Function("sha256",
+		cel.Overload("sha256_string", []*cel.Type{cel.StringType}, cel.StringType,
+			cel.UnaryBinding(stringFunction(func(s string) ref.Val {
+				hash := sha256.Sum256([]byte(s))
+				return types.String(hex.EncodeToString(hash[:]))
+			})))),
+	cel.Function("b64enc",
+		cel.Overload("b64enc_string", []*cel.Type{cel.StringType}, cel.StringType,
+			cel.UnaryBinding(stringFunction(func(s string) ref.Val {
+				return types.String(base64.StdEncoding.EncodeToString([]byte(s)))
+			})))),
+	cel.Function("b64dec",
+		cel.Overload("b64dec_string", []*cel.Type{cel.StringType}, cel.StringType,
+			cel.UnaryBinding(stringFunction(func(s string) ref.Val {
+				decoded, err := base64.StdEncoding.DecodeString(s)
+				if err != nil {
+					return types.NewErr(errBase64Decode, s)
+				}
+				return types.String(decoded)
+			})))),
+
+	// Map helpers.
+	cel.Function("pick",
+		cel.Overload("pick_map_list", []*cel.Type{cel.MapType(cel.StringType, cel.DynType), cel.ListType(cel.StringType)}, cel.MapType(cel.StringType, cel.DynType),
+			cel.BinaryBinding(keysFunction("pick", func(m map[string]interface{}, keys map[string]bool) map[string]interface{} {
+				picked := map[string]interface{}{}
+				for key, value := range m {
+					if keys[key] {
+						picked[key] = value
+					}
+				}
+				return picked
+			})))),
+	cel.Function("omit",
+		cel.Overload("omit_map_list", []*cel.Type{cel.MapType(cel.StringType, cel.DynType), cel.ListType(cel.StringType)}, cel.MapType(cel.StringType, cel.DynType),
+			cel.BinaryBinding(keysFunction("omit", func(m map[string]interface{}, keys map[string]bool) map[string]interface{} {
+				kept := map[string]interface{}{}
+				for key, value := range m {
+					if !keys[key] {
+						kept[key] = value
+					}
+				}
+				return kept
+			})))),
+	cel.Function("merge",
+		cel.Overload("merge_map_map", []*cel.Type{cel.MapType(cel.StringType, cel.DynType), cel.MapType(cel.StringType, cel.DynType)}, cel.MapType(cel.StringType, cel.DynType),
+			cel.BinaryBinding(func(lhs, rhs ref.Val) ref.Val {
+				base, ok := toMap(lhs)
+				if !ok {
+					return types.NewErr(errMapArgument, "merge")
+				}
+				overrides, ok := toMap(rhs)
+				if !ok {
+					return types.NewErr(errMapArgument, "merge")
+				}
+
+				merged := map[string]interface{}{}
+				for key, value := range base {
+					merged[key] = value
+				}
+				for key, value := range overrides {
+					merged[key] = value
+				}
+				return types.DefaultTypeAdapter.NativeToValue(merged)
+			}))),
+}
+
+// stringFunction adapts f to a CEL function operating on a string argument.
+func stringFunction(f func(string) ref.Val) func(ref.Val) ref.Val {
+	return func(arg ref.Val) ref.Val {
+		s, ok := arg.(types.String)
+		if !ok {
+			return types.MaybeNoSuchOverloadErr(arg)
+		}
+		return f(string(s))
+	}
+}
+
+// keysFunction adapts f to a CEL function operating on a map, given a list of keys.
+func keysFunction(name string, f func(map[string]interface{}, map[string]bool) map[string]interface{}) func(ref.Val, ref.Val) ref.Val {
+	return func(lhs, rhs ref.Val) ref.Val {
+		m, ok := toMap(lhs)
+		if !ok {
+			return types.NewErr(errMapArgument, name)
+		}
+
+		list, err := rhs.ConvertToNative(keysType)
+		if err != nil {
+			return types.NewErr(errKeysArgument, name)
+		}
+		keys := map[string]bool{}
+		for _, key := range list.([]string) {
+			keys[key] = true
+		}
+		return types.DefaultTypeAdapter.NativeToValue(f(m, keys))
+	}
+}
+
+// toMap converts a CEL map with string keys to a Go map.
+func toMap(val ref.Val) (map[string]interface{}, bool) {
+	native, err := val.ConvertToNative(mapType)
+	if err != nil {
+		return nil, false
+	}
+	m, ok := native.(map[string]interface{})
+	return m, ok
+}
@@ -0,0 +1,211 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errCircuitOpen = "circuit breaker of host %s is open after %d consecutive failed requests, failing fast until %s"
+
+	defaultCircuitBreakerCooldown = 30 * time.Second
+
+	// breakerIdleTimeout is how long the breaker of a host is kept without any
+	// request to it, so the breakers of hosts no longer used are dropped.
+	breakerIdleTimeout = 30 * time.Minute
+)
+
+// CircuitBreaker configures the circuit breaker of each host.
+type CircuitBreaker struct {
+	// Scope isolates the breakers of different configurations, e.g. ProviderConfigs,
+	// sending requests to the same host.
+	Scope            string
+	FailureThreshold int
+	Cooldown         time.Duration
+}
+
+// WithCircuitBreaker fails the requests to a host fast once the given number of
+// consecutive requests to it failed, for the cooldown, after which a single request
+// is sent to the host. The breakers are shared by all clients with the same scope.
+func WithCircuitBreaker(breaker CircuitBreaker) Option {
+	return func(c *client) {
+		c.circuitBreaker = &breaker
+	}
+}
+
+// CircuitOpenError is returned when a request isn't sent because the circuit breaker
+// of its host is open.
+type CircuitOpenError struct {
+	Host     string
+	Failures int
+	Until    time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf(errCircuitOpen, e.Host, e.Failures, e.Until.UTC().Format(time.RFC3339))
+}
+
+// IsCircuitOpen reports whether err was caused by an open circuit breaker.
+func IsCircuitOpen(err error) bool {
+	var circuitErr *CircuitOpenError
+	return errors.As(err, &circuitErr)
+}
+
+// hostBreakers holds the circuit breakers per scope and host, so they are shared
+// between reconciles and the clients built for them.
+var hostBreakers = &breakerRegistry{breakers: map[string]*hostBreaker{}}
+
+type breakerRegistry struct {
+	mu        sync.Mutex
+	breakers  map[string]*hostBreaker
+	lastSweep time.Time
+}
+
+// hostBreaker counts the consecutive failed requests to a host. Once they reach the
+// threshold, it's open until the cooldown elapses, then lets a single request probe
+// the host: its success closes the breaker, and its failure opens it again.
+type hostBreaker struct {
+	host      string
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+	lastUsed  time.Time
+}
+
+// get returns the breaker of the host, created or updated to match the configuration,
+// and drops the breakers idle for longer than breakerIdleTimeout.
+func (r *breakerRegistry) get(breaker CircuitBreaker, host string, now time.Time) *hostBreaker {
+	key := breaker.Scope + "/" + host
+	cooldown := breaker.Cooldown
+	if cooldown <= 0 {
+		cooldown = defaultCircuitBreakerCooldown
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if now.Sub(r.lastSweep) > breakerIdleTimeout {
+		r.sweep(now)
+	}
+
+	b, ok := r.breakers[key]
+	if !ok {
+		b = &hostBreaker{host: host}
+		r.breakers[key] = b
+	}
+
+	b.mu.Lock()
+	b.threshold, b.cooldown, b.lastUsed = breaker.FailureThreshold, cooldown, now
+	b.mu.Unlock()
+	return b
+}
+
+// sweep drops the breakers without any request for longer than breakerIdleTimeout,
+// unless they are probing their host. It must be called with the lock held.
+func (r *breakerRegistry) sweep(now time.Time) {
+	r.lastSweep = now
+	for key, b := range r.breakers {
+		b.mu.Lock()
+		idle := !b.probing && now.Sub(b.lastUsed) > breakerIdleTimeout
+		b.mu.Unlock()
+		if idle {
+			delete(r.breakers, key)
+		}
+	}
+}
+
+// Allow returns a CircuitOpenError if no request may be sent to the host for now.
+func (b *hostBreaker) Allow(now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return nil
+	}
+	if now.Before(b.openUntil) || b.probing {
+		rejectedRequests.WithLabelValues(b.host).Inc()
+		return &CircuitOpenError{Host: b.host, Failures: b.failures, Until: b.openUntil}
+	}
+
+	b.probing = true
+	return nil
+}
+
+// Record counts the outcome of a request sent to the host.
+func (b *hostBreaker) Record(now time.Time, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if !failed {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = now.Add(b.cooldown)
+	}
+}
+
+// Release ends the probe of the host without counting the request, e.g. because it
+// was cancelled.
+func (b *hostBreaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// breakerFor returns the circuit breaker of the request's host, or nil when requests
+// aren't guarded by one.
+func (hc *client) breakerFor(requestURL string) *hostBreaker {
+	if hc.circuitBreaker == nil {
+		return nil
+	}
+
+	u, err := url.Parse(requestURL)
+	if err != nil {
+		return nil
+	}
+
+	return hostBreakers.get(*hc.circuitBreaker, u.Host, time.Now())
+}
+
+// hostError marks an error of the host, or of the connection to it, as opposed to
+// the errors of the provider, e.g. a token it can't fetch or a response it refuses.
+type hostError struct {
+	err error
+}
+
+func (e *hostError) Error() string {
+	return e.err.Error()
+}
+
+func (e *hostError) Unwrap() error {
+	return e.err
+}
+
+// isHostError reports whether err was caused by the host or the connection to it.
+func isHostError(err error) bool {
+	var hostErr *hostError
+	return errors.As(err, &hostErr)
+}
+
+// isFailedRequest reports whether a request counts as a failure of its host: it got
+// no response because of a transport error or a timeout, or a 5xx response.
+func isFailedRequest(response HttpResponse, err error) bool {
+	if err != nil {
+		return isHostError(err)
+	}
+	return response.StatusCode >= http.StatusInternalServerError
+}
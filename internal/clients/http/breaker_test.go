@@ -0,0 +1,186 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_hostBreaker(t *testing.T) {
+	now := time.Now()
+	type step struct {
+		// at is the time of the step, after now.
+		at time.Duration
+		// failed is the outcome of the request if it's sent.
+		failed bool
+	}
+	cases := map[string]struct {
+		steps []step
+		want  []bool
+	}{
+		"ClosedWhileSucceeding": {
+			steps: []step{{}, {failed: true}, {}, {failed: true}},
+			want:  []bool{true, true, true, true},
+		},
+		"OpenAfterThreshold": {
+			steps: []step{{failed: true}, {failed: true}, {at: time.Second}, {at: 2 * time.Second}},
+			want:  []bool{true, true, false, false},
+		},
+		"ClosedAfterSuccessfulProbe": {
+			steps: []step{{failed: true}, {failed: true}, {at: time.Minute}, {at: time.Minute}},
+			want:  []bool{true, true, true, true},
+		},
+		"OpenAgainAfterFailedProbe": {
+			steps: []step{{failed: true}, {failed: true}, {at: time.Minute, failed: true}, {at: time.Minute + time.Second}},
+			want:  []bool{true, true, true, false},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			b := hostBreakers.get(CircuitBreaker{Scope: t.Name(), FailureThreshold: 2, Cooldown: 30 * time.Second}, "api.example.com", now)
+
+			var got []bool
+			for _, s := range tc.steps {
+				err := b.Allow(now.Add(s.at))
+				got = append(got, err == nil)
+				if err == nil {
+					b.Record(now.Add(s.at), s.failed)
+				}
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("hostBreaker: -want allowed, +got allowed: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequest_CircuitBreaker(t *testing.T) {
+	var sent atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c, err := NewClient(logging.NewNopLogger(), time.Minute, WithCircuitBreaker(CircuitBreaker{Scope: t.Name(), FailureThreshold: 2, Cooldown: time.Minute}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var open []bool
+	for i := 0; i < 3; i++ {
+		_, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, "", nil, false)
+		open = append(open, IsCircuitOpen(err))
+	}
+	if diff := cmp.Diff([]bool{false, false, true}, open); diff != "" {
+		t.Errorf("SendRequest(...): -want circuit open, +got circuit open: %s", diff)
+	}
+	if diff := cmp.Diff(int32(2), sent.Load()); diff != "" {
+		t.Errorf("SendRequest(...): -want requests sent, +got requests sent: %s", diff)
+	}
+}
+
+func Test_breakerRegistry_get(t *testing.T) {
+	now := time.Now()
+	breaker := CircuitBreaker{FailureThreshold: 1}
+	cases := map[string]struct {
+		// idle is how long the breaker of the host wasn't used.
+		idle    time.Duration
+		probing bool
+		want    bool
+	}{
+		"KeptWhileUsed": {
+			idle: time.Minute,
+			want: true,
+		},
+		"DroppedWhenIdle": {
+			idle: breakerIdleTimeout + time.Minute,
+			want: false,
+		},
+		"KeptWhileProbing": {
+			idle:    breakerIdleTimeout + time.Minute,
+			probing: true,
+			want:    true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &breakerRegistry{breakers: map[string]*hostBreaker{}}
+			idle := r.get(breaker, "idle.example.com", now.Add(-tc.idle))
+			idle.probing = tc.probing
+
+			r.get(breaker, "api.example.com", now)
+
+			_, got := r.breakers["/idle.example.com"]
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("get(...): -want kept, +got kept: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequest_CircuitBreakerProviderErrors(t *testing.T) {
+	var sent atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent.Add(1)
+		_, _ = w.Write([]byte(`{"name":"too large"}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(logging.NewNopLogger(), time.Minute, WithMaxResponseSize(4), WithCircuitBreaker(CircuitBreaker{Scope: t.Name(), FailureThreshold: 1, Cooldown: time.Minute}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var open []bool
+	for i := 0; i < 2; i++ {
+		_, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, "", nil, false)
+		open = append(open, IsCircuitOpen(err))
+	}
+	if diff := cmp.Diff([]bool{false, false}, open); diff != "" {
+		t.Errorf("SendRequest(...): -want circuit open, +got circuit open: %s", diff)
+	}
+	if diff := cmp.Diff(int32(2), sent.Load()); diff != "" {
+		t.Errorf("SendRequest(...): -want requests sent, +got requests sent: %s", diff)
+	}
+}
+
+func Test_isFailedRequest(t *testing.T) {
+	cases := map[string]struct {
+		response HttpResponse
+		err      error
+		want     bool
+	}{
+		"ServerError": {
+			response: HttpResponse{StatusCode: http.StatusBadGateway},
+			want:     true,
+		},
+		"ClientError": {
+			response: HttpResponse{StatusCode: http.StatusNotFound},
+			want:     false,
+		},
+		"TransportError": {
+			err:  &hostError{err: errors.New("connection refused")},
+			want: true,
+		},
+		"ProviderError": {
+			err:  errors.New("cannot fetch the OAuth2 token"),
+			want: false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := isFailedRequest(tc.response, tc.err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("isFailedRequest(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
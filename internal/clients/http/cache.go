@@ -0,0 +1,223 @@
+package http
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultResponseCacheBytes is the default size of the cached responses, 64 MiB.
+const defaultResponseCacheBytes = 64 << 20
+
+// responses caches the responses to the GET requests opting in to it, for as long as
+// their Cache-Control or Expires headers allow, so that the observations of stable
+// objects don't reach the APIs.
+var responses = newResponseCache(defaultResponseCacheBytes)
+
+type responseCache struct {
+	mu sync.Mutex
+	// maxBytes bounds the size of the cached responses, and bytes is their size.
+	maxBytes int64
+	bytes    int64
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+type cachedResponse struct {
+	scope    string
+	key      string
+	url      string
+	response HttpResponse
+	expires  time.Time
+	size     int64
+}
+
+func newResponseCache(maxBytes int64) *responseCache {
+	return &responseCache{maxBytes: maxBytes, order: list.New(), entries: map[string]*list.Element{}}
+}
+
+// SetResponseCacheBytes sets the maximum size in bytes of the cached responses, by
+// their URL, headers and body, evicting the least recently used responses above it.
+// Zero disables the cache.
+func SetResponseCacheBytes(maxBytes int64) {
+	responses.mu.Lock()
+	defer responses.mu.Unlock()
+
+	responses.maxBytes = maxBytes
+	responses.evict()
+}
+
+// CachedResponse returns the cached response to the request observing the object,
+// sent for the scope, e.g. a resource, if it's still fresh.
+func CachedResponse(scope string, method string, url string, body string, headers map[string][]string) (HttpResponse, bool) {
+	return responses.get(cacheKey(scope, method, url, body, headers), time.Now())
+}
+
+// CacheResponse caches the response to the request observing the object, sent for
+// the scope, if its headers allow it.
+func CacheResponse(scope string, method string, url string, body string, headers map[string][]string, response HttpResponse) {
+	responses.put(scope, cacheKey(scope, method, url, body, headers), url, response, time.Now())
+}
+
+// InvalidateResponses removes the cached responses of the scope, e.g. after a
+// request changed the resource, along with the responses of any scope to the URLs,
+// e.g. the URL of the request and the Location of its response, as other resources
+// may observe the same object.
+func InvalidateResponses(scope string, urls ...string) {
+	responses.invalidate(scope, urls)
+}
+
+func (c *responseCache) get(key string, now time.Time) (HttpResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return HttpResponse{}, false
+	}
+
+	entry := element.Value.(*cachedResponse)
+	if !now.Before(entry.expires) {
+		c.remove(element)
+		return HttpResponse{}, false
+	}
+
+	c.order.MoveToFront(element)
+	return entry.response, true
+}
+
+func (c *responseCache) put(scope string, key string, url string, response HttpResponse, now time.Time) {
+	lifetime, ok := freshnessLifetime(response, now)
+	if !ok {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+
+	// A response larger than the whole cache isn't cached.
+	size := responseSize(key, url, response)
+	if size > c.maxBytes {
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&cachedResponse{scope: scope, key: key, url: url, response: response, expires: now.Add(lifetime), size: size})
+	c.bytes += size
+	c.evict()
+}
+
+func (c *responseCache) invalidate(scope string, urls []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for element := c.order.Front(); element != nil; {
+		next := element.Next()
+		if entry := element.Value.(*cachedResponse); entry.scope == scope || slices.Contains(urls, entry.url) {
+			c.remove(element)
+		}
+		element = next
+	}
+}
+
+// evict removes the least recently used responses until they fit the cache.
+func (c *responseCache) evict() {
+	for c.bytes > c.maxBytes && c.order.Len() > 0 {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *responseCache) remove(element *list.Element) {
+	entry := element.Value.(*cachedResponse)
+	c.order.Remove(element)
+	delete(c.entries, entry.key)
+	c.bytes -= entry.size
+}
+
+// responseSize returns the approximate size in memory of a cached response.
+func responseSize(key string, url string, response HttpResponse) int64 {
+	size := len(key) + len(url) + len(response.Body)
+	for name, values := range response.Headers {
+		size += len(name)
+		for _, value := range values {
+			size += len(value)
+		}
+	}
+	return int64(size)
+}
+
+// freshnessLifetime returns how long a 200 OK response stays fresh, by its max-age
+// or Expires header, less its Age. Responses that mustn't be reused without
+// revalidation aren't fresh.
+func freshnessLifetime(response HttpResponse, now time.Time) (time.Duration, bool) {
+	if response.StatusCode != http.StatusOK {
+		return 0, false
+	}
+
+	headers := http.Header(response.Headers)
+	var lifetime time.Duration
+	maxAge := false
+	for _, directive := range strings.Split(strings.Join(headers.Values("Cache-Control"), ","), ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "no-store", "no-cache":
+			return 0, false
+		case "max-age":
+			seconds, err := strconv.Atoi(strings.Trim(value, `"`))
+			if err != nil {
+				return 0, false
+			}
+			lifetime, maxAge = time.Duration(seconds)*time.Second, true
+		}
+	}
+
+	if !maxAge {
+		expires, err := http.ParseTime(headers.Get("Expires"))
+		if err != nil {
+			return 0, false
+		}
+		date, err := http.ParseTime(headers.Get("Date"))
+		if err != nil {
+			date = now
+		}
+		lifetime = expires.Sub(date)
+	}
+
+	if age, err := strconv.Atoi(headers.Get("Age")); err == nil {
+		lifetime -= time.Duration(age) * time.Second
+	}
+	return lifetime, lifetime > 0
+}
+
+// cacheKey identifies a request by its scope, method, URL, body and headers, the body
+// and headers hashed so that the credentials they hold aren't kept.
+func cacheKey(scope string, method string, url string, body string, headers map[string][]string) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	hash := sha256.New()
+	hash.Write([]byte(body))
+	hash.Write([]byte{'\n'})
+	for _, name := range names {
+		hash.Write([]byte(http.CanonicalHeaderKey(name)))
+		for _, value := range headers[name] {
+			hash.Write([]byte{0})
+			hash.Write([]byte(value))
+		}
+		hash.Write([]byte{'\n'})
+	}
+	return scope + " " + method + " " + url + " " + hex.EncodeToString(hash.Sum(nil))
+}
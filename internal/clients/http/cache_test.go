@@ -0,0 +1,184 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_freshnessLifetime(t *testing.T) {
+	now := time.Date(2023, 10, 21, 7, 28, 0, 0, time.UTC)
+	type want struct {
+		lifetime time.Duration
+		fresh    bool
+	}
+	cases := map[string]struct {
+		response HttpResponse
+		want     want
+	}{
+		"MaxAge": {
+			response: HttpResponse{StatusCode: http.StatusOK, Headers: map[string][]string{"Cache-Control": {"private, max-age=60"}}},
+			want:     want{lifetime: time.Minute, fresh: true},
+		},
+		"MaxAgeLessAge": {
+			response: HttpResponse{StatusCode: http.StatusOK, Headers: map[string][]string{"Cache-Control": {"max-age=60"}, "Age": {"20"}}},
+			want:     want{lifetime: 40 * time.Second, fresh: true},
+		},
+		"MaxAgeOverExpires": {
+			response: HttpResponse{StatusCode: http.StatusOK, Headers: map[string][]string{"Cache-Control": {"max-age=0"}, "Expires": {"Sat, 21 Oct 2023 08:28:00 GMT"}}},
+			want:     want{},
+		},
+		"Expires": {
+			response: HttpResponse{StatusCode: http.StatusOK, Headers: map[string][]string{"Date": {"Sat, 21 Oct 2023 07:00:00 GMT"}, "Expires": {"Sat, 21 Oct 2023 07:05:00 GMT"}}},
+			want:     want{lifetime: 5 * time.Minute, fresh: true},
+		},
+		"ExpiresWithoutDate": {
+			response: HttpResponse{StatusCode: http.StatusOK, Headers: map[string][]string{"Expires": {"Sat, 21 Oct 2023 07:30:00 GMT"}}},
+			want:     want{lifetime: 2 * time.Minute, fresh: true},
+		},
+		"NoStore": {
+			response: HttpResponse{StatusCode: http.StatusOK, Headers: map[string][]string{"Cache-Control": {"no-store, max-age=60"}}},
+			want:     want{},
+		},
+		"NoCacheHeaders": {
+			response: HttpResponse{StatusCode: http.StatusOK},
+			want:     want{},
+		},
+		"NotOK": {
+			response: HttpResponse{StatusCode: http.StatusNotFound, Headers: map[string][]string{"Cache-Control": {"max-age=60"}}},
+			want:     want{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			lifetime, fresh := freshnessLifetime(tc.response, now)
+			if diff := cmp.Diff(tc.want.fresh, fresh); diff != "" {
+				t.Fatalf("freshnessLifetime(...): -want fresh, +got fresh: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.lifetime, lifetime); tc.want.fresh && diff != "" {
+				t.Errorf("freshnessLifetime(...): -want lifetime, +got lifetime: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_responseCache(t *testing.T) {
+	now := time.Now()
+	fresh := HttpResponse{StatusCode: http.StatusOK, Body: `{"id":"1"}`, Headers: map[string][]string{"Cache-Control": {"max-age=60"}}}
+	cases := map[string]struct {
+		do   func(c *responseCache)
+		key  string
+		at   time.Duration
+		want bool
+	}{
+		"Hit": {
+			do: func(c *responseCache) {
+				c.put("a", "a /users/1", "/users/1", fresh, now)
+			},
+			key:  "a /users/1",
+			want: true,
+		},
+		"Expired": {
+			do: func(c *responseCache) {
+				c.put("a", "a /users/1", "/users/1", fresh, now)
+			},
+			key: "a /users/1",
+			at:  time.Minute,
+		},
+		"Evicted": {
+			do: func(c *responseCache) {
+				c.put("a", "a /users/1", "/users/1", fresh, now)
+				c.put("a", "a /users/2", "/users/2", fresh, now)
+				c.put("a", "a /users/3", "/users/3", fresh, now)
+			},
+			key: "a /users/1",
+		},
+		"RecentlyUsedKept": {
+			do: func(c *responseCache) {
+				c.put("a", "a /users/1", "/users/1", fresh, now)
+				c.put("a", "a /users/2", "/users/2", fresh, now)
+				c.get("a /users/1", now)
+				c.put("a", "a /users/3", "/users/3", fresh, now)
+			},
+			key:  "a /users/1",
+			want: true,
+		},
+		"Invalidated": {
+			do: func(c *responseCache) {
+				c.put("a", "a /users/1", "/users/1", fresh, now)
+				c.invalidate("a", nil)
+			},
+			key: "a /users/1",
+		},
+		"InvalidatedByURL": {
+			do: func(c *responseCache) {
+				c.put("a", "a /users/1", "/users/1", fresh, now)
+				c.invalidate("b", []string{"/users/1"})
+			},
+			key: "a /users/1",
+		},
+		"OtherURLKept": {
+			do: func(c *responseCache) {
+				c.put("a", "a /users/1", "/users/1", fresh, now)
+				c.invalidate("b", []string{"/users/2"})
+			},
+			key:  "a /users/1",
+			want: true,
+		},
+		"TooLarge": {
+			do: func(c *responseCache) {
+				large := fresh
+				large.Body = strings.Repeat("a", 1024)
+				c.put("a", "a /users/1", "/users/1", large, now)
+			},
+			key: "a /users/1",
+		},
+		"OtherScopeKept": {
+			do: func(c *responseCache) {
+				c.put("a", "a /users/1", "/users/1", fresh, now)
+				c.invalidate("b", nil)
+			},
+			key:  "a /users/1",
+			want: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// The cache holds two of the responses.
+			c := newResponseCache(2 * responseSize("a /users/1", "/users/1", fresh))
+			tc.do(c)
+
+			_, got := c.get(tc.key, now.Add(tc.at))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("get(...): -want cached, +got cached: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_cacheKey(t *testing.T) {
+	key := cacheKey("a", http.MethodGet, "/users", "", map[string][]string{"Authorization": {"Bearer token"}})
+	cases := map[string]struct {
+		key  string
+		want bool
+	}{
+		"Same":         {key: cacheKey("a", http.MethodGet, "/users", "", map[string][]string{"Authorization": {"Bearer token"}}), want: true},
+		"OtherScope":   {key: cacheKey("b", http.MethodGet, "/users", "", map[string][]string{"Authorization": {"Bearer token"}})},
+		"OtherMethod":  {key: cacheKey("a", http.MethodPost, "/users", "", map[string][]string{"Authorization": {"Bearer token"}})},
+		"OtherBody":    {key: cacheKey("a", http.MethodGet, "/users", `{"name":"john_doe"}`, map[string][]string{"Authorization": {"Bearer token"}})},
+		"OtherHeaders": {key: cacheKey("a", http.MethodGet, "/users", "", map[string][]string{"Authorization": {"Bearer other"}})},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.key == key); diff != "" {
+				t.Errorf("cacheKey(...): -want same key, +got same key: %s", diff)
+			}
+		})
+	}
+	if strings.Contains(key, "Bearer token") {
+		t.Errorf("cacheKey(...): want the headers hashed, got %q", key)
+	}
+}
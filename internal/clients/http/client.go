@@ -7,10 +7,23 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/internal/version"
+)
+
+const (
+	errRequestTimeout   = "HTTP %s request to %s timed out"
+	errResponseTooLarge = "response to HTTP %s request to %s exceeds the maximum size of %d bytes"
+
+	// DefaultMaxResponseSize is the size in bytes up to which response bodies are read by default.
+	DefaultMaxResponseSize = 4 << 20
 )
 
 // Client is the interface to interact with Http
@@ -19,14 +32,147 @@ type Client interface {
 }
 
 type client struct {
-	log     logging.Logger
-	timeout time.Duration
+	log       logging.Logger
+	timeout   time.Duration
+	oauth2    *OAuth2Config
+	retry     *RetryPolicy
+	rateLimit *RateLimit
+	proxy     *Proxy
+	session   *Session
+	redirect  RedirectPolicy
+	tracing   bool
+
+	maxResponseSize int64
+
+	// circuitBreaker fails the requests to a host fast while it keeps failing.
+	circuitBreaker *CircuitBreaker
+
+	// secretHeaders are set on every request, but left out of the request details.
+	secretHeaders map[string]string
+
+	// basicAuth is set on every request, but left out of the request details.
+	basicAuth *BasicAuth
+
+	// kerberos negotiates every request, but the token is left out of the request details.
+	kerberos *Kerberos
+
+	// hmac signs every request, but the signature is left out of the request details.
+	hmac *HMAC
+
+	// bearerToken is set on every request, but left out of the request details.
+	bearerToken string
+
+	// userAgent is the User-Agent of the requests that don't set one.
+	userAgent string
+
+	// hostAliases are the IP addresses connected to instead of resolving the hostnames.
+	hostAliases map[string]string
+
+	// grpcStatus maps the gRPC status of successful responses to their status code.
+	grpcStatus bool
+
+	// logRedaction redacts the requests before they're logged.
+	logRedaction func(HttpRequest) HttpRequest
+
+	connectionPool ConnectionPool
+
+	// protocol is the HTTP version of the requests.
+	protocol Protocol
+
+	caCertificates    []byte
+	clientCertificate []byte
+	clientKey         []byte
+	tlsConfig         *tls.Config
+
+	sessionState *sessionState
+}
+
+// An Option configures the Http client.
+type Option func(*client)
+
+// WithOAuth2 authorizes every request with an access token obtained through the
+// OAuth2 client credentials grant.
+func WithOAuth2(cfg OAuth2Config) Option {
+	return func(c *client) {
+		c.oauth2 = &cfg
+	}
+}
+
+// WithLogRedaction redacts the requests before they're logged, e.g. to mask the
+// secret fields of their bodies. The request details returned aren't redacted.
+func WithLogRedaction(redact func(HttpRequest) HttpRequest) Option {
+	return func(c *client) {
+		c.logRedaction = redact
+	}
+}
+
+// WithSecretHeaders sets the headers on every request, overriding request headers
+// with the same name. They aren't part of the returned request details, so they
+// never end up in logs or resource status.
+func WithSecretHeaders(headers map[string]string) Option {
+	return func(c *client) {
+		c.secretHeaders = headers
+	}
+}
+
+// BasicAuth holds the credentials of HTTP basic authentication.
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+// WithBasicAuth authorizes every request with HTTP basic authentication. The
+// Authorization header isn't part of the returned request details.
+func WithBasicAuth(auth BasicAuth) Option {
+	return func(c *client) {
+		c.basicAuth = &auth
+	}
+}
+
+// WithBearerToken authorizes every request with the bearer token. The
+// Authorization header isn't part of the returned request details.
+func WithBearerToken(token string) Option {
+	return func(c *client) {
+		c.bearerToken = token
+	}
+}
+
+// WithUserAgent sets the User-Agent of the requests that don't set one, instead
+// of provider-http/<version>.
+func WithUserAgent(userAgent string) Option {
+	return func(c *client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithRetry retries requests that get a transient failure response according to the policy.
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *client) {
+		c.retry = &policy
+	}
+}
+
+// WithMaxResponseSize limits the size in bytes of the response bodies that are read,
+// instead of DefaultMaxResponseSize.
+func WithMaxResponseSize(size int64) Option {
+	return func(c *client) {
+		c.maxResponseSize = size
+	}
 }
 
 type HttpResponse struct {
 	Body       string
 	Headers    map[string][]string
 	StatusCode int
+
+	// RequestTime is when the request was first sent, and ResponseTime when
+	// the last response was read, so that they span every retry.
+	RequestTime  time.Time
+	ResponseTime time.Time
+
+	// CertificateExpiry is when the certificate the server presented expires,
+	// zero if the response wasn't received over TLS.
+	CertificateExpiry time.Time
 }
 
 type HttpRequest struct {
@@ -41,10 +187,29 @@ type HttpDetails struct {
 	HttpRequest  HttpRequest
 }
 
-func (hc *client) SendRequest(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (details HttpDetails, err error) {
-	requestBody := []byte(body)
-	request, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(requestBody))
+// TimeoutError is returned when a request doesn't complete before its timeout,
+// either the client timeout or the deadline of the request context.
+type TimeoutError struct {
+	Method string
+	URL    string
+	Err    error
+}
 
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf(errRequestTimeout, e.Method, e.URL)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// IsTimeout reports whether err was caused by a request timing out.
+func IsTimeout(err error) bool {
+	var timeoutErr *TimeoutError
+	return errors.As(err, &timeoutErr)
+}
+
+func (hc *client) SendRequest(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (details HttpDetails, err error) {
 	requestDetails := HttpRequest{
 		URL:     url,
 		Body:    body,
@@ -52,70 +217,274 @@ func (hc *client) SendRequest(ctx context.Context, method string, url string, bo
 		Method:  method,
 	}
 
+	if err := hc.login(ctx, skipTLSVerify); err != nil {
+		return HttpDetails{
+			HttpRequest: requestDetails,
+		}, err
+	}
+
+	requestTime := time.Now()
+	response, err := hc.send(ctx, requestDetails, skipTLSVerify)
+	for attempt := 1; err == nil && hc.retry.shouldRetry(method, response.StatusCode, attempt); attempt++ {
+		backoff := hc.retry.backoff(attempt, response.Headers)
+		hc.log.Debug("retrying http request", "method", method, "url", url, "statusCode", response.StatusCode, "backoff", backoff.String())
+		if wait(ctx, backoff) != nil {
+			// Out of time for another attempt, report the last response.
+			break
+		}
+		response, err = hc.send(ctx, requestDetails, skipTLSVerify)
+	}
+
 	if err != nil {
 		return HttpDetails{
 			HttpRequest: requestDetails,
 		}, err
 	}
 
-	for key, values := range headers {
+	response.RequestTime = requestTime
+	response.ResponseTime = time.Now()
+
+	logged := requestDetails
+	if hc.logRedaction != nil {
+		logged = hc.logRedaction(logged)
+	}
+	hc.log.Info(fmt.Sprint("http request sent: ", toJSON(logged)))
+
+	return HttpDetails{
+		HttpResponse: response,
+		HttpRequest:  requestDetails,
+	}, nil
+}
+
+// send sends the request, renewing the OAuth2 access token once if the API rejects it.
+// With a rate limit, requests are throttled per host, and sent once more after backing
+// off when the host answers with 429 Too Many Requests.
+func (hc *client) send(ctx context.Context, requestDetails HttpRequest, skipTLSVerify bool) (HttpResponse, error) {
+	limiter := hc.limiterFor(requestDetails.URL)
+
+	response, err := hc.throttledDo(ctx, limiter, requestDetails, skipTLSVerify)
+	if err == nil && response.StatusCode == http.StatusUnauthorized && hc.oauth2 != nil {
+		// The cached token may have been revoked before its expiry, fetch a new one and try again.
+		tokens.Invalidate(*hc.oauth2)
+		response, err = hc.throttledDo(ctx, limiter, requestDetails, skipTLSVerify)
+	}
+
+	if err == nil && response.StatusCode == http.StatusUnauthorized && hc.kerberos != nil {
+		// The ticket may have been rejected, e.g. after the keytab was rotated, log in again and retry.
+		kerberosClients.Invalidate(*hc.kerberos)
+		response, err = hc.throttledDo(ctx, limiter, requestDetails, skipTLSVerify)
+	}
+
+	if err == nil && response.StatusCode == http.StatusTooManyRequests && limiter != nil {
+		backoff := tooManyRequestsBackoff(response.Headers)
+		hc.log.Debug("backing off after too many requests", "host", limiter.host, "backoff", backoff.String())
+		limiter.BackOff(backoff)
+		response, err = hc.throttledDo(ctx, limiter, requestDetails, skipTLSVerify)
+	}
+
+	return response, err
+}
+
+// throttledDo waits for the limiter, if any, before sending the request.
+func (hc *client) throttledDo(ctx context.Context, limiter *hostLimiter, requestDetails HttpRequest, skipTLSVerify bool) (HttpResponse, error) {
+	if limiter != nil {
+		if err := limiter.Wait(ctx); err != nil {
+			if isTimeoutError(err) {
+				return HttpResponse{}, &TimeoutError{Method: requestDetails.Method, URL: requestDetails.URL, Err: err}
+			}
+			return HttpResponse{}, err
+		}
+	}
+
+	breaker := hc.breakerFor(requestDetails.URL)
+	if breaker == nil {
+		return hc.do(ctx, requestDetails, skipTLSVerify)
+	}
+	if err := breaker.Allow(time.Now()); err != nil {
+		return HttpResponse{}, err
+	}
+
+	response, err := hc.do(ctx, requestDetails, skipTLSVerify)
+	if err != nil && (errors.Is(err, context.Canceled) || !isHostError(err)) {
+		// A request cancelled by the reconcile, e.g. as the provider shuts down, or
+		// failed by the provider itself says nothing about the host.
+		breaker.Release()
+	} else {
+		breaker.Record(time.Now(), isFailedRequest(response, err))
+	}
+	return response, err
+}
+
+// do sends a single HTTP request and reads its response.
+func (hc *client) do(ctx context.Context, requestDetails HttpRequest, skipTLSVerify bool) (response HttpResponse, err error) {
+	request, err := http.NewRequestWithContext(ctx, requestDetails.Method, requestDetails.URL, bytes.NewBuffer([]byte(requestDetails.Body)))
+	if err != nil {
+		return HttpResponse{}, err
+	}
+	if err := validateScheme(request.URL); err != nil {
+		return HttpResponse{}, err
+	}
+
+	for key, values := range requestDetails.Headers {
 		for _, value := range values {
 			request.Header.Add(key, value)
 		}
 	}
 
+	for key, value := range hc.secretHeaders {
+		request.Header.Set(key, value)
+	}
+
+	if request.Header.Get("User-Agent") == "" {
+		request.Header.Set("User-Agent", hc.userAgent)
+	}
+
+	if hc.basicAuth != nil {
+		request.SetBasicAuth(hc.basicAuth.Username, hc.basicAuth.Password)
+	}
+
+	if hc.bearerToken != "" {
+		request.Header.Set("Authorization", "Bearer "+hc.bearerToken)
+	}
+
+	if hc.hmac != nil {
+		request.Header.Set(hc.hmac.Header, hc.hmac.sign(requestDetails.Body))
+	}
+
+	if hc.kerberos != nil {
+		if err := hc.kerberos.negotiate(request); err != nil {
+			return HttpResponse{}, err
+		}
+	}
+
+	if hc.oauth2 != nil {
+		token, err := tokens.Token(hc.tokenContext(ctx), *hc.oauth2)
+		if err != nil {
+			return HttpResponse{}, err
+		}
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	if span := hc.startSpan(ctx, request); span != nil {
+		defer func() {
+			endSpan(span, response.StatusCode, err)
+		}()
+	}
+
 	client := &http.Client{
-		Transport: &http.Transport{
-			// #nosec G402
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: skipTLSVerify},
-		},
-		Timeout: hc.timeout,
+		Transport:     hc.transportFor(skipTLSVerify),
+		CheckRedirect: hc.checkRedirect,
+		Timeout:       hc.timeout,
+	}
+	if hc.sessionState != nil {
+		client.Jar = hc.sessionState.jar
 	}
 
-	response, err := client.Do(request)
+	release, err := inFlight.acquire(ctx)
+	if isTimeoutError(err) {
+		return HttpResponse{}, &TimeoutError{Method: requestDetails.Method, URL: requestDetails.URL, Err: err}
+	}
 	if err != nil {
-		return HttpDetails{
-			HttpRequest: requestDetails,
-		}, err
+		return HttpResponse{}, err
 	}
+	defer release()
 
-	responsebody, err := io.ReadAll(response.Body)
+	start := time.Now()
+	httpResponse, err := client.Do(request)
 	if err != nil {
-		return HttpDetails{
-			HttpRequest: requestDetails,
-		}, err
+		observeRequest(requestDetails.Method, 0, start)
+	}
+	if isTimeoutError(err) {
+		return HttpResponse{}, &hostError{err: &TimeoutError{Method: requestDetails.Method, URL: requestDetails.URL, Err: err}}
+	}
+	if err != nil {
+		return HttpResponse{}, &hostError{err: err}
 	}
 
-	beautifiedResponse := HttpResponse{
-		Body:       string(responsebody),
-		Headers:    response.Header,
-		StatusCode: response.StatusCode,
+	// Read one byte more than allowed, to tell a body of the maximum size from a larger one.
+	responsebody, err := io.ReadAll(io.LimitReader(httpResponse.Body, hc.maxResponseSize+1))
+	observeRequest(requestDetails.Method, httpResponse.StatusCode, start)
+	if err != nil {
+		return HttpResponse{}, &hostError{err: err}
+	}
+	if int64(len(responsebody)) > hc.maxResponseSize {
+		_ = httpResponse.Body.Close()
+		return HttpResponse{}, errors.Errorf(errResponseTooLarge, requestDetails.Method, requestDetails.URL, hc.maxResponseSize)
 	}
 
-	err = response.Body.Close()
+	if err := httpResponse.Body.Close(); err != nil {
+		return HttpResponse{}, err
+	}
+
+	responsebody, headers, err := decodeBody(httpResponse.Header, responsebody, hc.maxResponseSize)
 	if err != nil {
-		return HttpDetails{
-			HttpRequest: requestDetails,
-		}, err
+		return HttpResponse{}, err
+	}
+	if int64(len(responsebody)) > hc.maxResponseSize {
+		return HttpResponse{}, errors.Errorf(errResponseTooLarge, requestDetails.Method, requestDetails.URL, hc.maxResponseSize)
 	}
 
-	hc.log.Info(fmt.Sprint("http request sent: ", toJSON(requestDetails)))
+	statusCode := httpResponse.StatusCode
+	if hc.grpcStatus {
+		statusCode, headers = withGRPCStatus(statusCode, headers, httpResponse.Trailer)
+	}
 
-	return HttpDetails{
-		HttpResponse: beautifiedResponse,
-		HttpRequest:  requestDetails,
+	return HttpResponse{
+		Body:              string(responsebody),
+		Headers:           headers,
+		StatusCode:        statusCode,
+		CertificateExpiry: certificateExpiry(httpResponse.TLS),
 	}, nil
 }
 
 // NewClient returns a new Http Client
-func NewClient(log logging.Logger, timeout time.Duration) (Client, error) {
-	return &client{
-		log:     log,
-		timeout: timeout,
-	}, nil
+func NewClient(log logging.Logger, timeout time.Duration, opts ...Option) (Client, error) {
+	c := &client{
+		log:             log,
+		timeout:         timeout,
+		maxResponseSize: DefaultMaxResponseSize,
+		userAgent:       version.UserAgent(),
+	}
+
+	for _, o := range opts {
+		o(c)
+	}
+
+	if err := c.buildTLSConfig(); err != nil {
+		return nil, err
+	}
+
+	if c.session != nil {
+		state, err := newSessionState()
+		if err != nil {
+			return nil, err
+		}
+		c.sessionState = state
+	}
+
+	return c, nil
 }
 
+func isTimeoutError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// toJSON encodes the request for the logs. Multipart bodies are left out, as
+// their parts may hold the values of secrets.
 func toJSON(request HttpRequest) string {
+	if isMultipart(request.Headers) {
+		request.Body = ""
+	}
+
 	jsonBytes, err := json.Marshal(request)
 	if err != nil {
 		return ""
@@ -123,3 +492,12 @@ func toJSON(request HttpRequest) string {
 
 	return string(jsonBytes)
 }
+
+func isMultipart(headers map[string][]string) bool {
+	for key, values := range headers {
+		if strings.EqualFold(key, "Content-Type") && len(values) > 0 {
+			return strings.HasPrefix(strings.ToLower(values[0]), "multipart/")
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errDecodeBody = "cannot decode %s response body"
+
+	headerContentEncoding = "Content-Encoding"
+	headerContentLength   = "Content-Length"
+
+	encodingGzip    = "gzip"
+	encodingXGzip   = "x-gzip"
+	encodingDeflate = "deflate"
+)
+
+// decodeBody decompresses a gzip or deflate encoded response body according to its
+// Content-Encoding header, which is removed along with the Content-Length once the
+// body is decoded. Bodies that aren't actually encoded, e.g. because an intermediary
+// already decoded them, are returned as is. The decoded body may be at most maxSize bytes.
+func decodeBody(headers http.Header, body []byte, maxSize int64) ([]byte, http.Header, error) {
+	encoding := strings.ToLower(strings.TrimSpace(headers.Get(headerContentEncoding)))
+
+	var reader io.ReadCloser
+	var err error
+	switch {
+	case (encoding == encodingGzip || encoding == encodingXGzip) && isGzip(body):
+		reader, err = gzip.NewReader(bytes.NewReader(body))
+	case encoding == encodingDeflate && isZlib(body):
+		reader, err = zlib.NewReader(bytes.NewReader(body))
+	default:
+		return body, headers, nil
+	}
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, errDecodeBody, encoding)
+	}
+
+	decoded, err := io.ReadAll(io.LimitReader(reader, maxSize+1))
+	_ = reader.Close()
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, errDecodeBody, encoding)
+	}
+
+	decodedHeaders := headers.Clone()
+	decodedHeaders.Del(headerContentEncoding)
+	decodedHeaders.Del(headerContentLength)
+
+	return decoded, decodedHeaders, nil
+}
+
+// isGzip reports whether the body starts with the gzip magic number.
+func isGzip(body []byte) bool {
+	return len(body) >= 2 && body[0] == 0x1f && body[1] == 0x8b
+}
+
+// isZlib reports whether the body starts with a valid zlib header.
+func isZlib(body []byte) bool {
+	return len(body) >= 2 && body[0]&0x0f == 8 && (uint16(body[0])<<8|uint16(body[1]))%31 == 0
+}
@@ -0,0 +1,135 @@
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+const testEncodedBody = `{"name":"john_doe"}`
+
+func gzipped(body string) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, _ = w.Write([]byte(body))
+	_ = w.Close()
+	return buf.Bytes()
+}
+
+func deflated(body string) []byte {
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	_, _ = w.Write([]byte(body))
+	_ = w.Close()
+	return buf.Bytes()
+}
+
+func Test_SendRequest_ContentEncoding(t *testing.T) {
+	type args struct {
+		encoding string
+		body     []byte
+		opts     []Option
+	}
+	type want struct {
+		body     string
+		encoding string
+		err      bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Gzip": {
+			args: args{
+				encoding: "gzip",
+				body:     gzipped(testEncodedBody),
+			},
+			want: want{
+				body: testEncodedBody,
+			},
+		},
+		"Deflate": {
+			args: args{
+				encoding: "deflate",
+				body:     deflated(testEncodedBody),
+			},
+			want: want{
+				body: testEncodedBody,
+			},
+		},
+		"AlreadyDecoded": {
+			args: args{
+				encoding: "gzip",
+				body:     []byte(testEncodedBody),
+			},
+			want: want{
+				body:     testEncodedBody,
+				encoding: "gzip",
+			},
+		},
+		"UnsupportedEncoding": {
+			args: args{
+				encoding: "br",
+				body:     []byte("\x1b\x12"),
+			},
+			want: want{
+				body:     "\x1b\x12",
+				encoding: "br",
+			},
+		},
+		"CorruptBody": {
+			args: args{
+				encoding: "gzip",
+				body:     gzipped(testEncodedBody)[:12],
+			},
+			want: want{
+				err: true,
+			},
+		},
+		"DecodedTooLarge": {
+			args: args{
+				encoding: "gzip",
+				body:     gzipped(`{"name":"` + string(bytes.Repeat([]byte("a"), 1000)) + `"}`),
+				opts:     []Option{WithMaxResponseSize(100)},
+			},
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Encoding", tc.args.encoding)
+				_, _ = w.Write(tc.args.body)
+			}))
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), time.Minute, tc.args.opts...)
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			// Setting Accept-Encoding keeps the transport from decoding gzip bodies itself.
+			headers := map[string][]string{"Accept-Encoding": {"gzip, deflate"}}
+			details, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, "", headers, false)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("SendRequest(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.body, details.HttpResponse.Body); diff != "" {
+				t.Fatalf("SendRequest(...): -want body, +got body: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.encoding, http.Header(details.HttpResponse.Headers).Get("Content-Encoding")); diff != "" {
+				t.Fatalf("SendRequest(...): -want content encoding, +got content encoding: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	headerGRPCStatus  = "Grpc-Status"
+	headerGRPCMessage = "Grpc-Message"
+)
+
+// grpcStatusCodes are the HTTP status codes that the non-zero gRPC status codes map
+// to, as HTTP/JSON transcoding maps them, by gRPC status code.
+var grpcStatusCodes = map[int]int{
+	1:  499,                            // CANCELLED, client closed request
+	2:  http.StatusInternalServerError, // UNKNOWN
+	3:  http.StatusBadRequest,          // INVALID_ARGUMENT
+	4:  http.StatusGatewayTimeout,      // DEADLINE_EXCEEDED
+	5:  http.StatusNotFound,            // NOT_FOUND
+	6:  http.StatusConflict,            // ALREADY_EXISTS
+	7:  http.StatusForbidden,           // PERMISSION_DENIED
+	8:  http.StatusTooManyRequests,     // RESOURCE_EXHAUSTED
+	9:  http.StatusBadRequest,          // FAILED_PRECONDITION
+	10: http.StatusConflict,            // ABORTED
+	11: http.StatusBadRequest,          // OUT_OF_RANGE
+	12: http.StatusNotImplemented,      // UNIMPLEMENTED
+	13: http.StatusInternalServerError, // INTERNAL
+	14: http.StatusServiceUnavailable,  // UNAVAILABLE
+	15: http.StatusInternalServerError, // DATA_LOSS
+	16: http.StatusUnauthorized,        // UNAUTHENTICATED
+}
+
+// WithGRPCStatus interprets the grpc-status header or trailer of the responses of
+// gRPC services transcoded to HTTP/JSON. A successful response with a non-zero gRPC
+// status gets the HTTP status code that the gRPC status maps to, so that it's
+// handled as an error.
+func WithGRPCStatus() Option {
+	return func(c *client) {
+		c.grpcStatus = true
+	}
+}
+
+// withGRPCStatus returns the status code of a response with the gRPC status of its
+// headers or trailers. The gRPC status and message trailers are added to the headers,
+// so that they're kept with the response.
+func withGRPCStatus(statusCode int, headers http.Header, trailers http.Header) (int, http.Header) {
+	for _, key := range []string{headerGRPCStatus, headerGRPCMessage} {
+		if headers.Get(key) == "" && trailers.Get(key) != "" {
+			if headers == nil {
+				headers = http.Header{}
+			}
+			headers.Set(key, trailers.Get(key))
+		}
+	}
+
+	status := strings.TrimSpace(headers.Get(headerGRPCStatus))
+	if status == "" || statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+		return statusCode, headers
+	}
+
+	code, err := strconv.Atoi(status)
+	if err == nil && code == 0 {
+		return statusCode, headers
+	}
+	if mapped, ok := grpcStatusCodes[code]; ok && err == nil {
+		return mapped, headers
+	}
+	// A status that isn't a gRPC status code is UNKNOWN.
+	return http.StatusInternalServerError, headers
+}
@@ -0,0 +1,113 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_withGRPCStatus(t *testing.T) {
+	type args struct {
+		statusCode int
+		headers    http.Header
+		trailers   http.Header
+	}
+	type want struct {
+		statusCode int
+		headers    http.Header
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoGRPCStatus": {
+			args: args{statusCode: http.StatusOK, headers: http.Header{"Content-Type": {"application/json"}}},
+			want: want{statusCode: http.StatusOK, headers: http.Header{"Content-Type": {"application/json"}}},
+		},
+		"OK": {
+			args: args{statusCode: http.StatusOK, headers: http.Header{headerGRPCStatus: {"0"}}},
+			want: want{statusCode: http.StatusOK, headers: http.Header{headerGRPCStatus: {"0"}}},
+		},
+		"NotFoundHeader": {
+			args: args{statusCode: http.StatusOK, headers: http.Header{headerGRPCStatus: {"5"}}},
+			want: want{statusCode: http.StatusNotFound, headers: http.Header{headerGRPCStatus: {"5"}}},
+		},
+		"UnavailableTrailer": {
+			args: args{
+				statusCode: http.StatusOK,
+				trailers:   http.Header{headerGRPCStatus: {"14"}, headerGRPCMessage: {"backend unavailable"}},
+			},
+			want: want{
+				statusCode: http.StatusServiceUnavailable,
+				headers:    http.Header{headerGRPCStatus: {"14"}, headerGRPCMessage: {"backend unavailable"}},
+			},
+		},
+		"UnknownCode": {
+			args: args{statusCode: http.StatusOK, headers: http.Header{headerGRPCStatus: {"42"}}},
+			want: want{statusCode: http.StatusInternalServerError, headers: http.Header{headerGRPCStatus: {"42"}}},
+		},
+		"NotACode": {
+			args: args{statusCode: http.StatusOK, headers: http.Header{headerGRPCStatus: {"error"}}},
+			want: want{statusCode: http.StatusInternalServerError, headers: http.Header{headerGRPCStatus: {"error"}}},
+		},
+		"HTTPErrorKept": {
+			args: args{statusCode: http.StatusBadRequest, headers: http.Header{headerGRPCStatus: {"5"}}},
+			want: want{statusCode: http.StatusBadRequest, headers: http.Header{headerGRPCStatus: {"5"}}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			statusCode, headers := withGRPCStatus(tc.args.statusCode, tc.args.headers, tc.args.trailers)
+			if diff := cmp.Diff(tc.want.statusCode, statusCode); diff != "" {
+				t.Errorf("withGRPCStatus(...): -want status code, +got status code: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.headers, headers); diff != "" {
+				t.Errorf("withGRPCStatus(...): -want headers, +got headers: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequest_GRPCStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Trailer", headerGRPCStatus)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code":5,"message":"user not found"}`))
+		w.Header().Set(headerGRPCStatus, "5")
+	}))
+	defer server.Close()
+
+	cases := map[string]struct {
+		opts []Option
+		want int
+	}{
+		"Ignored": {
+			want: http.StatusOK,
+		},
+		"Interpreted": {
+			opts: []Option{WithGRPCStatus()},
+			want: http.StatusNotFound,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, err := NewClient(logging.NewNopLogger(), time.Minute, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			details, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, "", nil, false)
+			if err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, details.HttpResponse.StatusCode); diff != "" {
+				t.Errorf("SendRequest(...): -want status code, +got status code: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // SHA1 is offered for the APIs still signing with it.
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+)
+
+const (
+	hmacAlgorithmSHA1   = "SHA1"
+	hmacAlgorithmSHA512 = "SHA512"
+	hmacEncodingBase64  = "base64"
+)
+
+// HMAC configures the signature of the request bodies.
+type HMAC struct {
+	Key    []byte
+	Header string
+
+	// Algorithm is SHA1, SHA256 or SHA512, SHA256 by default.
+	Algorithm string
+
+	// Encoding is hex or base64, hex by default.
+	Encoding string
+
+	// Prefix is written before the signature, e.g. sha256=.
+	Prefix string
+}
+
+// WithHMAC signs the body of every request with an HMAC sent in the configured
+// header. The signature is computed for each request, just before it's sent, and
+// isn't part of the returned request details.
+func WithHMAC(h HMAC) Option {
+	return func(c *client) {
+		c.hmac = &h
+	}
+}
+
+// sign returns the header value signing the body.
+func (h *HMAC) sign(body string) string {
+	mac := hmac.New(h.hash(), h.Key)
+	mac.Write([]byte(body))
+	sum := mac.Sum(nil)
+
+	if h.Encoding == hmacEncodingBase64 {
+		return h.Prefix + base64.StdEncoding.EncodeToString(sum)
+	}
+	return h.Prefix + hex.EncodeToString(sum)
+}
+
+func (h *HMAC) hash() func() hash.Hash {
+	switch h.Algorithm {
+	case hmacAlgorithmSHA1:
+		return sha1.New
+	case hmacAlgorithmSHA512:
+		return sha512.New
+	default:
+		return sha256.New
+	}
+}
@@ -0,0 +1,99 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SendRequest_HMAC(t *testing.T) {
+	const body = "The quick brown fox jumps over the lazy dog"
+
+	type args struct {
+		hmac HMAC
+		body string
+	}
+	type want struct {
+		signature string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"DefaultSHA256Hex": {
+			args: args{
+				hmac: HMAC{Key: []byte("key"), Header: "X-Signature"},
+				body: body,
+			},
+			want: want{
+				signature: "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8",
+			},
+		},
+		"SHA1": {
+			args: args{
+				hmac: HMAC{Key: []byte("key"), Header: "X-Signature", Algorithm: "SHA1"},
+				body: body,
+			},
+			want: want{
+				signature: "de7c9b85b8b78aa6bc8a7a36f70a90701c9db4d9",
+			},
+		},
+		"SHA512": {
+			args: args{
+				hmac: HMAC{Key: []byte("key"), Header: "X-Signature", Algorithm: "SHA512"},
+				body: body,
+			},
+			want: want{
+				signature: "b42af09057bac1e2d41708e48a902e09b5ff7f12ab428a4fe86653c73dd248fb82f948a549f7b791a5b41915ee4d1ec3935357e4e2317250d0372afa2ebeeb3a",
+			},
+		},
+		"Base64WithPrefix": {
+			args: args{
+				hmac: HMAC{Key: []byte("key"), Header: "X-Signature", Encoding: "base64", Prefix: "sha256="},
+				body: body,
+			},
+			want: want{
+				signature: "sha256=97yD9DBThCSxMpjmqm+xQ+9NWaFJRhdZl0edvC0aPNg=",
+			},
+		},
+		"EmptyBody": {
+			args: args{
+				hmac: HMAC{Key: []byte("key"), Header: "X-Signature"},
+			},
+			want: want{
+				signature: "5d5d139563c95b5967b9bd9a8c9b233a9dedb45072794cd232dc1b74832607d0",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var signature string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				signature = r.Header.Get(tc.args.hmac.Header)
+			}))
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), time.Minute, WithHMAC(tc.args.hmac))
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			details, err := c.SendRequest(context.Background(), http.MethodPost, server.URL, tc.args.body, nil, false)
+			if err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want.signature, signature); diff != "" {
+				t.Errorf("SendRequest(...): -want signature, +got signature: %s", diff)
+			}
+			if _, ok := details.HttpRequest.Headers[tc.args.hmac.Header]; ok {
+				t.Errorf("SendRequest(...): the signature is part of the request details")
+			}
+		})
+	}
+}
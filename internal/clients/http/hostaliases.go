@@ -0,0 +1,37 @@
+package http
+
+import (
+	"context"
+	"net"
+	"strings"
+)
+
+// WithHostAliases connects to the IP address of each aliased hostname instead of
+// resolving it, like curl's --resolve. The URL keeps the hostname, so the Host
+// header and the TLS server name stay the same.
+func WithHostAliases(aliases map[string]string) Option {
+	return func(c *client) {
+		c.hostAliases = make(map[string]string, len(aliases))
+		for hostname, ip := range aliases {
+			c.hostAliases[strings.ToLower(hostname)] = ip
+		}
+	}
+}
+
+// dialContext dials the address, replacing an aliased hostname by its IP address.
+func (hc *client) dialContext() func(ctx context.Context, network, address string) (net.Conn, error) {
+	dialer := &net.Dialer{}
+	if len(hc.hostAliases) == 0 {
+		return dialer.DialContext
+	}
+
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err == nil {
+			if ip, ok := hc.hostAliases[strings.ToLower(host)]; ok {
+				address = net.JoinHostPort(ip, port)
+			}
+		}
+		return dialer.DialContext(ctx, network, address)
+	}
+}
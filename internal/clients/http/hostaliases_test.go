@@ -0,0 +1,78 @@
+package http
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SendRequest_HostAliases(t *testing.T) {
+	var host string
+	// The certificate of the test server is valid for example.com.
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host = r.Host
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	type args struct {
+		aliases map[string]string
+	}
+	type want struct {
+		statusCode int
+		host       string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Aliased": {
+			args: args{
+				aliases: map[string]string{"example.com": serverURL.Hostname()},
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				host:       "example.com:" + serverURL.Port(),
+			},
+		},
+		"AliasedCaseInsensitive": {
+			args: args{
+				aliases: map[string]string{"otherhost.invalid": "192.0.2.1", "Example.COM": serverURL.Hostname()},
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				host:       "example.com:" + serverURL.Port(),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			host = ""
+
+			c, err := NewClient(logging.NewNopLogger(), 5*time.Second, WithCACertificates(serverCA), WithHostAliases(tc.args.aliases))
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			details, err := c.SendRequest(context.Background(), http.MethodGet, "https://example.com:"+serverURL.Port()+"/", "", nil, false)
+			if err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.statusCode, details.HttpResponse.StatusCode); diff != "" {
+				t.Errorf("SendRequest(...): -want status code, +got status code: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.host, host); diff != "" {
+				t.Errorf("SendRequest(...): -want host, +got host: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package http
+
+import (
+	"context"
+	"sync"
+)
+
+// inFlight caps the requests sent at once by all the clients, so that many
+// resources reconciled in parallel don't overwhelm the APIs.
+var inFlight = &inFlightLimit{}
+
+type inFlightLimit struct {
+	mu    sync.RWMutex
+	slots chan struct{}
+}
+
+// SetMaxInFlight caps the HTTP requests sent at once by all the clients, retries
+// and logins included. Requests over the cap wait for one to complete. Zero
+// removes the cap.
+func SetMaxInFlight(max int) {
+	inFlight.mu.Lock()
+	defer inFlight.mu.Unlock()
+
+	inFlight.slots = nil
+	if max > 0 {
+		inFlight.slots = make(chan struct{}, max)
+	}
+	maxInFlightRequests.Set(float64(max))
+}
+
+// MaxInFlight returns the cap on the HTTP requests sent at once, zero when there's none.
+func MaxInFlight() int {
+	inFlight.mu.RLock()
+	defer inFlight.mu.RUnlock()
+
+	return cap(inFlight.slots)
+}
+
+// acquire waits for a slot to send a request, and returns the function releasing it.
+func (l *inFlightLimit) acquire(ctx context.Context) (func(), error) {
+	l.mu.RLock()
+	slots := l.slots
+	l.mu.RUnlock()
+
+	if slots == nil {
+		return func() {}, nil
+	}
+
+	waitingRequests.Inc()
+	defer waitingRequests.Dec()
+
+	select {
+	case slots <- struct{}{}:
+		inFlightRequests.Inc()
+		return func() {
+			inFlightRequests.Dec()
+			<-slots
+		}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,113 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SendRequest_MaxInFlight(t *testing.T) {
+	type args struct {
+		maxInFlight int
+		requests    int
+	}
+	type want struct {
+		maxConcurrent int
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Uncapped": {
+			args: args{
+				requests: 3,
+			},
+			want: want{
+				maxConcurrent: 3,
+			},
+		},
+		"Capped": {
+			args: args{
+				maxInFlight: 1,
+				requests:    3,
+			},
+			want: want{
+				maxConcurrent: 1,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			SetMaxInFlight(tc.args.maxInFlight)
+			defer SetMaxInFlight(0)
+
+			var mu sync.Mutex
+			concurrent, maxConcurrent := 0, 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				concurrent++
+				if concurrent > maxConcurrent {
+					maxConcurrent = concurrent
+				}
+				mu.Unlock()
+
+				time.Sleep(100 * time.Millisecond)
+
+				mu.Lock()
+				concurrent--
+				mu.Unlock()
+			}))
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), time.Minute)
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			var wg sync.WaitGroup
+			for i := 0; i < tc.args.requests; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if _, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, "", nil, false); err != nil {
+						t.Errorf("SendRequest(...): unexpected error: %s", err)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if diff := cmp.Diff(tc.want.maxConcurrent, maxConcurrent); diff != "" {
+				t.Errorf("SendRequest(...): -want concurrent requests, +got concurrent requests: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequest_MaxInFlightTimeout(t *testing.T) {
+	SetMaxInFlight(1)
+	defer SetMaxInFlight(0)
+
+	release, err := inFlight.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("acquire(...): unexpected error: %s", err)
+	}
+	defer release()
+
+	c, err := NewClient(logging.NewNopLogger(), time.Minute)
+	if err != nil {
+		t.Fatalf("NewClient(...): unexpected error: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = c.SendRequest(ctx, http.MethodGet, "http://example.com", "", nil, false)
+	if !IsTimeout(err) {
+		t.Errorf("SendRequest(...): want a timeout error, got %v", err)
+	}
+}
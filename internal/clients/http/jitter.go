@@ -0,0 +1,45 @@
+package http
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// jitter spreads the waits of all the clients and controllers at random, so that
+// resources reconciled at once, e.g. after a restart, don't keep hitting the APIs
+// at the same moments.
+var jitter = &jitterSource{rand: rand.New(rand.NewSource(time.Now().UnixNano()))} //nolint:gosec // The jitter isn't security sensitive.
+
+type jitterSource struct {
+	mu     sync.Mutex
+	factor float64
+	rand   *rand.Rand
+}
+
+// SetJitter sets the fraction of each wait by which it's spread at random, e.g.
+// 0.1 for waits 10% shorter or longer. The factor is bounded to [0, 1], and zero
+// disables the jitter.
+func SetJitter(factor float64) {
+	jitter.mu.Lock()
+	defer jitter.mu.Unlock()
+
+	switch {
+	case factor < 0:
+		factor = 0
+	case factor > 1:
+		factor = 1
+	}
+	jitter.factor = factor
+}
+
+// Jitter returns the wait spread at random by the jitter factor.
+func Jitter(d time.Duration) time.Duration {
+	jitter.mu.Lock()
+	defer jitter.mu.Unlock()
+
+	if jitter.factor == 0 || d <= 0 {
+		return d
+	}
+	return time.Duration(float64(d) * (1 + jitter.factor*(2*jitter.rand.Float64()-1)))
+}
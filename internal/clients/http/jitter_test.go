@@ -0,0 +1,68 @@
+package http
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_Jitter(t *testing.T) {
+	type args struct {
+		factor float64
+		wait   time.Duration
+	}
+	type want struct {
+		min time.Duration
+		max time.Duration
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoJitter": {
+			args: args{
+				wait: time.Minute,
+			},
+			want: want{
+				min: time.Minute,
+				max: time.Minute,
+			},
+		},
+		"Jitter": {
+			args: args{
+				factor: 0.1,
+				wait:   time.Minute,
+			},
+			want: want{
+				min: 54 * time.Second,
+				max: 66 * time.Second,
+			},
+		},
+		"BoundedFactor": {
+			args: args{
+				factor: 3,
+				wait:   time.Minute,
+			},
+			want: want{
+				min: 0,
+				max: 2 * time.Minute,
+			},
+		},
+		"NoWait": {
+			args: args{
+				factor: 0.5,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			SetJitter(tc.args.factor)
+			defer SetJitter(0)
+
+			for i := 0; i < 100; i++ {
+				if got := Jitter(tc.args.wait); got < tc.want.min || got > tc.want.max {
+					t.Fatalf("Jitter(%s) = %s, want between %s and %s", tc.args.wait, got, tc.want.min, tc.want.max)
+				}
+			}
+		})
+	}
+}
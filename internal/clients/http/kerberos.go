@@ -0,0 +1,155 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+
+	krbclient "github.com/jcmturner/gokrb5/v8/client"
+	"github.com/jcmturner/gokrb5/v8/config"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+	"github.com/jcmturner/gokrb5/v8/spnego"
+	"github.com/pkg/errors"
+)
+
+const (
+	errKerberosConfig = "cannot parse the Kerberos configuration"
+	errKerberosKeytab = "cannot parse the Kerberos keytab"
+	errKerberosLogin  = "cannot log in to the Kerberos realm %s as %s"
+	errKerberosToken  = "cannot create the SPNEGO token"
+)
+
+// Kerberos holds the credentials of SPNEGO authentication with a Kerberos keytab.
+type Kerberos struct {
+	Username string
+	Realm    string
+	Keytab   []byte
+
+	// Config is the krb5.conf locating the KDCs of the realm.
+	Config string
+
+	// SPN is the service principal name of the APIs, HTTP/<host> of the request URL
+	// by default.
+	SPN string
+}
+
+// WithKerberos authorizes every request with a SPNEGO token, obtained from the KDC
+// of the realm with the keytab. The Authorization header isn't part of the
+// returned request details.
+func WithKerberos(k Kerberos) Option {
+	return func(c *client) {
+		c.kerberos = &k
+	}
+}
+
+// kerberosClients caches the logged in Kerberos clients per principal, so that their
+// tickets are reused across reconciles and renewed by the clients as they expire.
+var kerberosClients = &kerberosClientCache{clients: map[string]*kerberosClient{}, login: kerberosLogin}
+
+type kerberosClientCache struct {
+	mu      sync.Mutex
+	clients map[string]*kerberosClient
+
+	// login logs in to the realm with the credentials, talking to the KDC.
+	login func(k Kerberos) (*krbclient.Client, error)
+}
+
+// kerberosClient is a logged in client, and the fingerprint of the credentials it
+// logged in with.
+type kerberosClient struct {
+	fingerprint string
+	client      *krbclient.Client
+}
+
+// principal identifies the cache entry of the credentials, so that the client logged
+// in with a rotated keytab or configuration replaces the previous one.
+func (k Kerberos) principal() string {
+	return k.Username + "@" + k.Realm
+}
+
+// fingerprint tells the credentials of a principal apart without keeping the keytab
+// in memory any longer than the client does.
+func (k Kerberos) fingerprint() string {
+	hash := sha256.Sum256([]byte(strings.Join([]string{k.Username, k.Realm, string(k.Keytab), k.Config}, "\n")))
+	return hex.EncodeToString(hash[:])
+}
+
+// kerberosLogin parses the configuration and the keytab of the credentials, and logs in to
+// the realm with them.
+func kerberosLogin(k Kerberos) (*krbclient.Client, error) {
+	cfg, err := config.NewFromString(k.Config)
+	if err != nil {
+		return nil, errors.Wrap(err, errKerberosConfig)
+	}
+
+	kt := keytab.New()
+	if err := kt.Unmarshal(k.Keytab); err != nil {
+		return nil, errors.Wrap(err, errKerberosKeytab)
+	}
+
+	cl := krbclient.NewWithKeytab(k.Username, k.Realm, kt, cfg, krbclient.DisablePAFXFAST(true))
+	if err := cl.Login(); err != nil {
+		return nil, errors.Wrapf(err, errKerberosLogin, k.Realm, k.Username)
+	}
+	return cl, nil
+}
+
+// client returns the logged in Kerberos client of the credentials. Logging in talks
+// to the KDC, so it happens without holding the lock. A client logged in concurrently
+// with the same credentials wins, while one logged in with other credentials of the
+// principal, e.g. before the keytab was rotated, is destroyed and replaced.
+func (kc *kerberosClientCache) client(k Kerberos) (*krbclient.Client, error) {
+	principal, fingerprint := k.principal(), k.fingerprint()
+
+	kc.mu.Lock()
+	cached, ok := kc.clients[principal]
+	kc.mu.Unlock()
+	if ok && cached.fingerprint == fingerprint {
+		return cached.client, nil
+	}
+
+	cl, err := kc.login(k)
+	if err != nil {
+		return nil, err
+	}
+
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+	if existing, ok := kc.clients[principal]; ok {
+		if existing.fingerprint == fingerprint {
+			cl.Destroy()
+			return existing.client, nil
+		}
+		existing.client.Destroy()
+	}
+	kc.clients[principal] = &kerberosClient{fingerprint: fingerprint, client: cl}
+	return cl, nil
+}
+
+// Invalidate forgets the client of the credentials, e.g. after the API rejected its
+// ticket, so that the next request logs in again.
+func (kc *kerberosClientCache) Invalidate(k Kerberos) {
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+
+	if cached, ok := kc.clients[k.principal()]; ok && cached.fingerprint == k.fingerprint() {
+		cached.client.Destroy()
+		delete(kc.clients, k.principal())
+	}
+}
+
+// negotiate sets the Authorization header of the request to a SPNEGO token for the
+// service principal of the API, unless the request already carries its own.
+func (k Kerberos) negotiate(request *http.Request) error {
+	if request.Header.Get("Authorization") != "" {
+		return nil
+	}
+
+	cl, err := kerberosClients.client(k)
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(spnego.SetSPNEGOHeader(cl, request, k.SPN), errKerberosToken)
+}
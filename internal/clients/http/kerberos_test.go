@@ -0,0 +1,189 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+	krbclient "github.com/jcmturner/gokrb5/v8/client"
+	"github.com/jcmturner/gokrb5/v8/config"
+	"github.com/jcmturner/gokrb5/v8/iana/etypeID"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+)
+
+// testKeytab returns a keytab holding a key of the principal.
+func testKeytab(t *testing.T, username, realm string) []byte {
+	t.Helper()
+
+	kt := keytab.New()
+	if err := kt.AddEntry(username, realm, "s3cr3t", time.Now(), 1, etypeID.AES256_CTS_HMAC_SHA1_96); err != nil {
+		t.Fatalf("cannot add the keytab entry: %s", err)
+	}
+	b, err := kt.Marshal()
+	if err != nil {
+		t.Fatalf("cannot marshal the keytab: %s", err)
+	}
+	return b
+}
+
+// unreachableKDC returns the address of a closed local port.
+func unreachableKDC(t *testing.T) string {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("cannot listen: %s", err)
+	}
+	addr := l.Addr().String()
+	_ = l.Close()
+	return addr
+}
+
+func Test_SendRequest_Kerberos(t *testing.T) {
+	krb5conf := fmt.Sprintf(`[libdefaults]
+  default_realm = EXAMPLE.COM
+  udp_preference_limit = 1
+
+[realms]
+  EXAMPLE.COM = {
+    kdc = %s
+  }
+`, unreachableKDC(t))
+
+	type args struct {
+		kerberos Kerberos
+	}
+	type want struct {
+		err string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"InvalidConfig": {
+			args: args{
+				kerberos: Kerberos{Username: "provider-http", Realm: "EXAMPLE.COM", Keytab: testKeytab(t, "provider-http", "EXAMPLE.COM"), Config: "[libdefaults]\n  forwardable = maybe\n"},
+			},
+			want: want{
+				err: errKerberosConfig,
+			},
+		},
+		"InvalidKeytab": {
+			args: args{
+				kerberos: Kerberos{Username: "provider-http", Realm: "EXAMPLE.COM", Keytab: []byte("not a keytab"), Config: krb5conf},
+			},
+			want: want{
+				err: errKerberosKeytab,
+			},
+		},
+		"KDCUnreachable": {
+			args: args{
+				kerberos: Kerberos{Username: "provider-http", Realm: "EXAMPLE.COM", Keytab: testKeytab(t, "provider-http", "EXAMPLE.COM"), Config: krb5conf},
+			},
+			want: want{
+				err: fmt.Sprintf(errKerberosLogin, "EXAMPLE.COM", "provider-http"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+			}))
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), 5*time.Second, WithKerberos(tc.args.kerberos))
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			_, err = c.SendRequest(context.Background(), http.MethodGet, server.URL, "", nil, false)
+			if err == nil || !strings.HasPrefix(err.Error(), tc.want.err) {
+				t.Fatalf("SendRequest(...): want error %q, got %v", tc.want.err, err)
+			}
+			// Without a SPNEGO token, the request isn't sent.
+			if diff := cmp.Diff(int32(0), requests.Load()); diff != "" {
+				t.Errorf("SendRequest(...): -want requests, +got requests: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_kerberosClientCache_client(t *testing.T) {
+	logins := 0
+	kc := &kerberosClientCache{
+		clients: map[string]*kerberosClient{},
+		login: func(k Kerberos) (*krbclient.Client, error) {
+			logins++
+			kt := keytab.New()
+			if err := kt.Unmarshal(k.Keytab); err != nil {
+				return nil, err
+			}
+			return krbclient.NewWithKeytab(k.Username, k.Realm, kt, config.New()), nil
+		},
+	}
+	k := Kerberos{Username: "provider-http", Realm: "EXAMPLE.COM", Keytab: testKeytab(t, "provider-http", "EXAMPLE.COM")}
+
+	first, err := kc.client(k)
+	if err != nil {
+		t.Fatalf("client(...): unexpected error: %s", err)
+	}
+	cached, err := kc.client(k)
+	if err != nil {
+		t.Fatalf("client(...): unexpected error: %s", err)
+	}
+	if cached != first {
+		t.Errorf("client(...): want the cached client for the same credentials")
+	}
+
+	rotated := k
+	rotated.Config = "[libdefaults]\n  default_realm = EXAMPLE.COM\n"
+	if _, err := kc.client(rotated); err != nil {
+		t.Fatalf("client(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(2, logins); diff != "" {
+		t.Errorf("client(...): -want logins, +got logins: %s", diff)
+	}
+	if diff := cmp.Diff(1, len(kc.clients)); diff != "" {
+		t.Errorf("client(...): -want cached clients, +got cached clients: %s", diff)
+	}
+	// The client of the rotated configuration replaces the previous one, which is destroyed.
+	if diff := cmp.Diff("", first.Credentials.UserName()); diff != "" {
+		t.Errorf("client(...): -want destroyed client, +got client of: %s", diff)
+	}
+}
+
+func Test_Kerberos_negotiate_OwnAuthorization(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "https://api.example.com", nil)
+	request.Header.Set("Authorization", "Bearer s3cr3t")
+
+	// The credentials aren't used, so they aren't valid either.
+	if err := (Kerberos{Config: "[libdefaults]\n  forwardable = maybe\n"}).negotiate(request); err != nil {
+		t.Fatalf("negotiate(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff("Bearer s3cr3t", request.Header.Get("Authorization")); diff != "" {
+		t.Errorf("negotiate(...): -want Authorization, +got Authorization: %s", diff)
+	}
+}
+
+func Test_Kerberos_fingerprint(t *testing.T) {
+	k := Kerberos{Username: "provider-http", Realm: "EXAMPLE.COM", Keytab: []byte("keytab"), Config: "config"}
+	rotated := k
+	rotated.Keytab = []byte("rotated")
+
+	if k.fingerprint() == rotated.fingerprint() {
+		t.Errorf("fingerprint(): want a different fingerprint for a rotated keytab")
+	}
+	if strings.Contains(k.fingerprint(), "keytab") {
+		t.Errorf("fingerprint(): want the keytab hashed")
+	}
+}
@@ -0,0 +1,37 @@
+package http
+
+import (
+	"context"
+	"sync"
+)
+
+// contextMutex is a mutual exclusion lock that callers stop waiting for once their
+// context is done, so that a request holding it while stuck on an unresponsive API
+// doesn't hold up the others, or the provider shutting down. The zero value is an
+// unlocked mutex.
+type contextMutex struct {
+	once  sync.Once
+	slots chan struct{}
+}
+
+// Lock locks the mutex, unless the context is done first.
+func (m *contextMutex) Lock(ctx context.Context) error {
+	m.once.Do(func() { m.slots = make(chan struct{}, 1) })
+
+	// A done context wins over a free mutex, so that nothing is sent after cancellation.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	select {
+	case m.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Unlock unlocks the mutex locked by Lock.
+func (m *contextMutex) Unlock() {
+	<-m.slots
+}
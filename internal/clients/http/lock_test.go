@@ -0,0 +1,66 @@
+package http
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_contextMutex(t *testing.T) {
+	type args struct {
+		locked    bool
+		cancelled bool
+	}
+	type want struct {
+		err error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Unlocked": {},
+		"LockedUntilTimeout": {
+			args: args{
+				locked: true,
+			},
+			want: want{
+				err: context.DeadlineExceeded,
+			},
+		},
+		"Cancelled": {
+			args: args{
+				cancelled: true,
+			},
+			want: want{
+				err: context.Canceled,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &contextMutex{}
+			if tc.args.locked {
+				if err := m.Lock(context.Background()); err != nil {
+					t.Fatalf("Lock(...): unexpected error: %s", err)
+				}
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			if tc.args.cancelled {
+				cancel()
+			}
+
+			err := m.Lock(ctx)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("Lock(...): -want error, +got error: %s", diff)
+			}
+			if err == nil {
+				m.Unlock()
+			}
+		})
+	}
+}
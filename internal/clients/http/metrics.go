@@ -0,0 +1,77 @@
+package http
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	throttleReasonRateLimit = "rate_limit"
+	throttleReasonBackoff   = "backoff"
+
+	// statusCodeError labels requests that didn't get a response.
+	statusCodeError = "error"
+)
+
+// sentRequests counts the HTTP requests sent, including retries.
+var sentRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "provider_http_requests_total",
+	Help: "Total number of HTTP requests sent, by method and response status code.",
+}, []string{"method", "code"})
+
+// requestDuration observes how long HTTP requests take until their response is read.
+var requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "provider_http_request_duration_seconds",
+	Help:    "Duration of HTTP requests in seconds, by method.",
+	Buckets: prometheus.DefBuckets,
+}, []string{"method"})
+
+// throttledRequests counts the requests delayed by the per host rate limit,
+// either by the token bucket or by a host asking to back off.
+var throttledRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "provider_http_throttled_requests_total",
+	Help: "Total number of HTTP requests delayed by the per host rate limit.",
+}, []string{"host", "reason"})
+
+// rejectedRequests counts the requests failed fast by the circuit breaker of their host.
+var rejectedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "provider_http_circuit_breaker_rejected_requests_total",
+	Help: "Total number of HTTP requests failed fast by the per host circuit breaker.",
+}, []string{"host"})
+
+// inFlightRequests counts the requests being sent, under the in flight cap.
+var inFlightRequests = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "provider_http_requests_in_flight",
+	Help: "Number of HTTP requests being sent, when their number is capped.",
+})
+
+// waitingRequests counts the requests waiting for the in flight cap.
+var waitingRequests = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "provider_http_requests_waiting",
+	Help: "Number of HTTP requests waiting to be sent under the in flight cap.",
+})
+
+// maxInFlightRequests exposes the cap on the requests sent at once.
+var maxInFlightRequests = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "provider_http_max_in_flight_requests",
+	Help: "Maximum number of HTTP requests sent at once, zero when uncapped.",
+})
+
+func init() {
+	metrics.Registry.MustRegister(throttledRequests, rejectedRequests, sentRequests, requestDuration, inFlightRequests, waitingRequests, maxInFlightRequests)
+}
+
+// observeRequest records a sent request. A zero status code means that no
+// response was received.
+func observeRequest(method string, statusCode int, start time.Time) {
+	code := statusCodeError
+	if statusCode != 0 {
+		code = strconv.Itoa(statusCode)
+	}
+
+	sentRequests.WithLabelValues(method, code).Inc()
+	requestDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
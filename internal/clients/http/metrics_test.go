@@ -0,0 +1,74 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func Test_SendRequest_Metrics(t *testing.T) {
+	type args struct {
+		method string
+		status int
+		closed bool
+	}
+	type want struct {
+		code string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Response": {
+			args: args{
+				method: http.MethodPut,
+				status: http.StatusCreated,
+			},
+			want: want{
+				code: "201",
+			},
+		},
+		"NoResponse": {
+			args: args{
+				method: http.MethodDelete,
+				closed: true,
+			},
+			want: want{
+				code: statusCodeError,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.args.status)
+			}))
+			if tc.args.closed {
+				server.Close()
+			} else {
+				defer server.Close()
+			}
+
+			c, err := NewClient(logging.NewNopLogger(), time.Minute)
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			sent := testutil.ToFloat64(sentRequests.WithLabelValues(tc.args.method, tc.want.code))
+			_, _ = c.SendRequest(context.Background(), tc.args.method, server.URL, "", nil, false)
+
+			if diff := cmp.Diff(sent+1, testutil.ToFloat64(sentRequests.WithLabelValues(tc.args.method, tc.want.code))); diff != "" {
+				t.Fatalf("SendRequest(...): -want sent requests, +got sent requests: %s", diff)
+			}
+			if testutil.CollectAndCount(requestDuration) == 0 {
+				t.Fatalf("SendRequest(...): expected the request duration to be observed")
+			}
+		})
+	}
+}
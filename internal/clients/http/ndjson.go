@@ -0,0 +1,36 @@
+package http
+
+import (
+	"bufio"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/internal/json"
+)
+
+const errNDJSONLine = "line %d of the NDJSON body isn't a JSON document"
+
+// DecodeNDJSON decodes the documents of a newline-delimited JSON body line by
+// line, skipping blank lines. Numbers are kept as json.Number.
+func DecodeNDJSON(body string) ([]interface{}, error) {
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	// A line may be as long as the body, which is limited by the response size.
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), len(body)+1)
+
+	documents := []interface{}{}
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var document interface{}
+		if err := json.Decode(text, &document); err != nil {
+			return nil, errors.Wrapf(err, errNDJSONLine, line)
+		}
+		documents = append(documents, document)
+	}
+
+	return documents, scanner.Err()
+}
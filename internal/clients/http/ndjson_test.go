@@ -0,0 +1,68 @@
+package http
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_DecodeNDJSON(t *testing.T) {
+	type want struct {
+		documents []interface{}
+		err       bool
+	}
+	cases := map[string]struct {
+		body string
+		want want
+	}{
+		"Documents": {
+			body: "{\"id\":1}\r\n\n[1,2]\n\"done\"",
+			want: want{
+				documents: []interface{}{map[string]interface{}{"id": json.Number("1")}, []interface{}{json.Number("1"), json.Number("2")}, "done"},
+			},
+		},
+		"Empty": {
+			body: "",
+			want: want{
+				documents: []interface{}{},
+			},
+		},
+		"LongLine": {
+			body: `{"data":"` + strings.Repeat("a", 128<<10) + `"}`,
+			want: want{
+				documents: []interface{}{map[string]interface{}{"data": strings.Repeat("a", 128<<10)}},
+			},
+		},
+		"LargeInteger": {
+			body: `{"id":12345678901234567890}`,
+			want: want{
+				documents: []interface{}{map[string]interface{}{"id": json.Number("12345678901234567890")}},
+			},
+		},
+		"TrailingInput": {
+			body: `{"id":1} {"id":2}`,
+			want: want{
+				err: true,
+			},
+		},
+		"InvalidLine": {
+			body: "{\"id\":1}\n{\"id\":",
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := DecodeNDJSON(tc.body)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("DecodeNDJSON(...): -want error, +got error: %s: %v", diff, err)
+			}
+			if diff := cmp.Diff(tc.want.documents, got); diff != "" {
+				t.Errorf("DecodeNDJSON(...): -want documents, +got documents: %s", diff)
+			}
+		})
+	}
+}
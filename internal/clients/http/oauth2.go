@@ -0,0 +1,134 @@
+package http
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	errFetchToken = "cannot fetch OAuth2 access token"
+
+	defaultTokenTTL = 5 * time.Minute
+)
+
+// OAuth2Config holds the resolved client credentials used to authorize requests.
+type OAuth2Config struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	// DefaultTTL is the token lifetime assumed when the token endpoint omits expires_in.
+	DefaultTTL time.Duration
+}
+
+// tokens caches access tokens per credential set, so they are shared between
+// reconciles and the clients built for them.
+var tokens = &tokenCache{tokens: map[string]*oauth2.Token{}}
+
+type tokenCache struct {
+	// mu guards the tokens and the fetches, and is never held while a token is fetched.
+	mu     sync.Mutex
+	tokens map[string]*oauth2.Token
+	// fetches are the locks held while the token of a credential set is fetched, so that
+	// it's fetched once, without holding up the other credential sets.
+	fetches map[string]*contextMutex
+}
+
+// key identifies a credential set without keeping the client secret in memory as a map key.
+func (c OAuth2Config) key() string {
+	hash := sha256.Sum256([]byte(strings.Join([]string{c.TokenURL, c.ClientID, c.ClientSecret, strings.Join(c.Scopes, " ")}, "\n")))
+	return hex.EncodeToString(hash[:])
+}
+
+// Token returns a cached access token for the credential set, fetching a new one when
+// the cached token is missing or expired.
+func (tc *tokenCache) Token(ctx context.Context, cfg OAuth2Config) (string, error) {
+	key := cfg.key()
+
+	token, fetch := tc.cached(key)
+	if token != nil {
+		return token.AccessToken, nil
+	}
+
+	if err := fetch.Lock(ctx); err != nil {
+		return "", errors.Wrap(err, errFetchToken)
+	}
+	defer fetch.Unlock()
+
+	// The token may have been fetched while waiting for the lock.
+	if token, _ := tc.cached(key); token != nil {
+		return token.AccessToken, nil
+	}
+
+	credentials := &clientcredentials.Config{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		TokenURL:     cfg.TokenURL,
+		Scopes:       cfg.Scopes,
+	}
+
+	token, err := credentials.Token(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, errFetchToken)
+	}
+
+	if token.Expiry.IsZero() {
+		ttl := cfg.DefaultTTL
+		if ttl == 0 {
+			ttl = defaultTokenTTL
+		}
+		token.Expiry = time.Now().Add(ttl)
+	}
+
+	tc.mu.Lock()
+	tc.tokens[key] = token
+	tc.mu.Unlock()
+	return token.AccessToken, nil
+}
+
+// cached returns the valid cached token of the credential set, or else the lock to
+// hold while fetching it.
+func (tc *tokenCache) cached(key string) (*oauth2.Token, *contextMutex) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	if token, ok := tc.tokens[key]; ok && token.Valid() {
+		return token, nil
+	}
+
+	if tc.fetches == nil {
+		tc.fetches = map[string]*contextMutex{}
+	}
+	fetch, ok := tc.fetches[key]
+	if !ok {
+		fetch = &contextMutex{}
+		tc.fetches[key] = fetch
+	}
+	return nil, fetch
+}
+
+// tokenContext returns the context access tokens are fetched with, sending the token
+// requests through the same proxy as the other requests of the client.
+func (hc *client) tokenContext(ctx context.Context) context.Context {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = hc.proxyFunc()
+
+	return context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: transport, Timeout: hc.timeout})
+}
+
+// Invalidate drops the cached token for the credential set, e.g. after the API rejected it.
+func (tc *tokenCache) Invalidate(cfg OAuth2Config) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	delete(tc.tokens, cfg.key())
+}
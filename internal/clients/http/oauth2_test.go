@@ -0,0 +1,157 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/oauth2"
+)
+
+func Test_tokenCache_Token(t *testing.T) {
+	type args struct {
+		expiresIn  string
+		cached     *oauth2.Token
+		defaultTTL time.Duration
+	}
+	type want struct {
+		token    string
+		requests int
+		minTTL   time.Duration
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"FetchWithExpiresIn": {
+			args: args{
+				expiresIn: `,"expires_in":3600`,
+			},
+			want: want{
+				token:    "fresh",
+				requests: 1,
+				minTTL:   59 * time.Minute,
+			},
+		},
+		"FetchWithDefaultTTL": {
+			args: args{
+				defaultTTL: 2 * time.Hour,
+			},
+			want: want{
+				token:    "fresh",
+				requests: 1,
+				minTTL:   119 * time.Minute,
+			},
+		},
+		"ReuseCachedToken": {
+			args: args{
+				cached: &oauth2.Token{AccessToken: "cached", Expiry: time.Now().Add(time.Hour)},
+			},
+			want: want{
+				token:    "cached",
+				requests: 0,
+			},
+		},
+		"RefreshExpiredToken": {
+			args: args{
+				cached: &oauth2.Token{AccessToken: "cached", Expiry: time.Now().Add(-time.Minute)},
+			},
+			want: want{
+				token:    "fresh",
+				requests: 1,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"access_token":"fresh","token_type":"bearer"%s}`, tc.args.expiresIn)
+			}))
+			defer server.Close()
+
+			cfg := OAuth2Config{TokenURL: server.URL, ClientID: "id", ClientSecret: "secret", DefaultTTL: tc.args.defaultTTL}
+			cache := &tokenCache{tokens: map[string]*oauth2.Token{}}
+			if tc.args.cached != nil {
+				cache.tokens[cfg.key()] = tc.args.cached
+			}
+
+			got, err := cache.Token(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("Token(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.token, got); diff != "" {
+				t.Fatalf("Token(...): -want token, +got token: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.requests, requests); diff != "" {
+				t.Fatalf("Token(...): -want token requests, +got token requests: %s", diff)
+			}
+			if ttl := time.Until(cache.tokens[cfg.key()].Expiry); ttl < tc.want.minTTL {
+				t.Fatalf("Token(...): expected token to be valid for at least %s, got %s", tc.want.minTTL, ttl)
+			}
+		})
+	}
+}
+
+func Test_tokenCache_Token_SlowTokenEndpoint(t *testing.T) {
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"slow","token_type":"bearer"}`)
+	}))
+	defer slow.Close()
+	defer close(release)
+
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"fast","token_type":"bearer"}`)
+	}))
+	defer fast.Close()
+
+	cache := &tokenCache{tokens: map[string]*oauth2.Token{}}
+	cached := OAuth2Config{TokenURL: fast.URL, ClientID: "cached", ClientSecret: "secret"}
+	cache.tokens[cached.key()] = &oauth2.Token{AccessToken: "cached", Expiry: time.Now().Add(time.Hour)}
+
+	fetching := make(chan struct{})
+	go func() {
+		close(fetching)
+		_, _ = cache.Token(context.Background(), OAuth2Config{TokenURL: slow.URL, ClientID: "slow", ClientSecret: "secret"})
+	}()
+	<-fetching
+	time.Sleep(50 * time.Millisecond)
+
+	cases := map[string]struct {
+		cfg  OAuth2Config
+		want string
+	}{
+		"CachedToken": {
+			cfg:  cached,
+			want: "cached",
+		},
+		"OtherCredentials": {
+			cfg:  OAuth2Config{TokenURL: fast.URL, ClientID: "fast", ClientSecret: "secret"},
+			want: "fast",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			got, err := cache.Token(ctx, tc.cfg)
+			if err != nil {
+				t.Fatalf("Token(...): unexpected error, waiting on the slow token endpoint: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Token(...): -want token, +got token: %s", diff)
+			}
+		})
+	}
+}
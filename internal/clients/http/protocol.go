@@ -0,0 +1,102 @@
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/http2"
+)
+
+const (
+	// ProtocolAuto negotiates HTTP/2 with the servers supporting it over TLS, and
+	// uses HTTP/1.1 otherwise.
+	ProtocolAuto = "Auto"
+	// ProtocolHTTP1 always uses HTTP/1.1.
+	ProtocolHTTP1 = "HTTP1"
+	// ProtocolHTTP2 requires HTTP/2 over TLS, failing the requests to the servers
+	// that don't negotiate it.
+	ProtocolHTTP2 = "HTTP2"
+
+	protocolH2 = "h2"
+
+	errHTTP2NotNegotiated = "server didn't negotiate HTTP/2"
+)
+
+// Protocol configures the HTTP version of the requests. Zero values keep the
+// automatic negotiation over TLS, and HTTP/1.1 without it.
+type Protocol struct {
+	// Version is the HTTP version of the requests to https URLs, ProtocolAuto,
+	// ProtocolHTTP1 or ProtocolHTTP2.
+	Version string
+	// H2C sends the requests to http URLs over HTTP/2 without TLS, with prior
+	// knowledge that the server supports it.
+	H2C bool
+}
+
+// WithProtocol sends the requests with the HTTP version of the protocol, instead of
+// negotiating it.
+func WithProtocol(protocol Protocol) Option {
+	return func(c *client) {
+		c.protocol = protocol
+	}
+}
+
+// configureProtocol configures the transport for the HTTP version of the requests to
+// https URLs, and returns the transport of the requests.
+func (hc *client) configureProtocol(transport *http.Transport) http.RoundTripper {
+	switch hc.protocol.Version {
+	case ProtocolHTTP1:
+		// A non-nil map disables HTTP/2.
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	case ProtocolHTTP2:
+		transport.ForceAttemptHTTP2 = true
+		transport.TLSClientConfig.NextProtos = []string{protocolH2}
+		transport.TLSClientConfig.VerifyConnection = requireHTTP2
+	default:
+		transport.ForceAttemptHTTP2 = true
+	}
+
+	if !hc.protocol.H2C {
+		return transport
+	}
+	return &h2cTransport{
+		Transport: transport,
+		h2c: &http2.Transport{
+			AllowHTTP: true,
+			DialTLSContext: func(ctx context.Context, network, address string, _ *tls.Config) (net.Conn, error) {
+				return hc.dialContext()(ctx, network, address)
+			},
+		},
+	}
+}
+
+// requireHTTP2 fails the TLS handshakes that didn't negotiate HTTP/2, before any
+// request is sent over HTTP/1.1.
+func requireHTTP2(state tls.ConnectionState) error {
+	if state.NegotiatedProtocol != protocolH2 {
+		return errors.New(errHTTP2NotNegotiated)
+	}
+	return nil
+}
+
+// h2cTransport sends the requests to http URLs over HTTP/2 without TLS, and the
+// other requests with the transport.
+type h2cTransport struct {
+	*http.Transport
+	h2c *http2.Transport
+}
+
+func (t *h2cTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.URL.Scheme == "http" {
+		return t.h2c.RoundTrip(request)
+	}
+	return t.Transport.RoundTrip(request)
+}
+
+func (t *h2cTransport) CloseIdleConnections() {
+	t.h2c.CloseIdleConnections()
+	t.Transport.CloseIdleConnections()
+}
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+func Test_SendRequest_Protocol(t *testing.T) {
+	type args struct {
+		protocol Protocol
+		http2    bool
+		tls      bool
+	}
+	type want struct {
+		protoMajor int
+		failed     bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"AutoNegotiatesHTTP2": {
+			args: args{http2: true, tls: true},
+			want: want{protoMajor: 2},
+		},
+		"AutoFallsBackToHTTP1": {
+			args: args{tls: true},
+			want: want{protoMajor: 1},
+		},
+		"HTTP1": {
+			args: args{protocol: Protocol{Version: ProtocolHTTP1}, http2: true, tls: true},
+			want: want{protoMajor: 1},
+		},
+		"HTTP2": {
+			args: args{protocol: Protocol{Version: ProtocolHTTP2}, http2: true, tls: true},
+			want: want{protoMajor: 2},
+		},
+		"HTTP2NotNegotiated": {
+			args: args{protocol: Protocol{Version: ProtocolHTTP2}, tls: true},
+			want: want{failed: true},
+		},
+		"CleartextHTTP1": {
+			args: args{http2: true},
+			want: want{protoMajor: 1},
+		},
+		"H2C": {
+			args: args{protocol: Protocol{H2C: true}, http2: true},
+			want: want{protoMajor: 2},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			protoMajor := 0
+			var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				protoMajor = r.ProtoMajor
+			})
+			if tc.args.http2 && !tc.args.tls {
+				handler = h2c.NewHandler(handler, &http2.Server{})
+			}
+			server := httptest.NewUnstartedServer(handler)
+			server.EnableHTTP2 = tc.args.http2
+			if tc.args.tls {
+				server.StartTLS()
+			} else {
+				server.Start()
+			}
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), 5*time.Second, WithProtocol(tc.args.protocol))
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+			_, err = c.SendRequest(context.Background(), http.MethodGet, server.URL, "", nil, tc.args.tls)
+			if diff := cmp.Diff(tc.want.failed, err != nil); diff != "" {
+				t.Fatalf("SendRequest(...): -want error, +got error: %s: %v", diff, err)
+			}
+			if diff := cmp.Diff(tc.want.protoMajor, protoMajor); diff != "" {
+				t.Errorf("SendRequest(...): -want HTTP major version, +got HTTP major version: %s", diff)
+			}
+		})
+	}
+}
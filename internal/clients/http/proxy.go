@@ -0,0 +1,44 @@
+package http
+
+import (
+	"net/http"
+	"net/url"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// Proxy configures the proxy requests are sent through.
+type Proxy struct {
+	// URL of the proxy, e.g. http://proxy.example.com:3128 or socks5://proxy.example.com:1080.
+	// Basic authentication credentials are taken from its user info.
+	URL *url.URL
+	// NoProxy lists the hosts requests are sent to directly, in the format of the
+	// NO_PROXY environment variable.
+	NoProxy string
+}
+
+// WithProxy sends requests through the proxy, instead of the one set by the
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+func WithProxy(proxy Proxy) Option {
+	return func(c *client) {
+		c.proxy = &proxy
+	}
+}
+
+// proxyFunc returns the proxy of each request, from the environment unless a proxy is configured.
+func (hc *client) proxyFunc() func(*http.Request) (*url.URL, error) {
+	if hc.proxy == nil {
+		return http.ProxyFromEnvironment
+	}
+
+	cfg := &httpproxy.Config{
+		HTTPProxy:  hc.proxy.URL.String(),
+		HTTPSProxy: hc.proxy.URL.String(),
+		NoProxy:    hc.proxy.NoProxy,
+	}
+	proxyForURL := cfg.ProxyFunc()
+
+	return func(request *http.Request) (*url.URL, error) {
+		return proxyForURL(request.URL)
+	}
+}
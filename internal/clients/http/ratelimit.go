@@ -0,0 +1,142 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultTooManyRequestsBackoff = time.Second
+)
+
+// RateLimit configures the token bucket that throttles the requests sent to each host.
+type RateLimit struct {
+	// Scope isolates the buckets of different configurations, e.g. ProviderConfigs,
+	// sending requests to the same host.
+	Scope             string
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// WithRateLimit throttles the requests sent to each host. The token buckets are
+// shared by all clients with the same rate limit scope.
+func WithRateLimit(limit RateLimit) Option {
+	return func(c *client) {
+		c.rateLimit = &limit
+	}
+}
+
+// hostLimiters holds the token buckets per scope and host, so they are shared
+// between reconciles and the clients built for them.
+var hostLimiters = &limiterRegistry{limiters: map[string]*hostLimiter{}}
+
+type limiterRegistry struct {
+	mu       sync.Mutex
+	limiters map[string]*hostLimiter
+}
+
+// hostLimiter is a token bucket that can additionally be paused, e.g. when the
+// host asks the client to back off.
+type hostLimiter struct {
+	host    string
+	limiter *rate.Limiter
+
+	mu           sync.Mutex
+	blockedUntil time.Time
+}
+
+// get returns the limiter of the host, created or updated to match the limit.
+func (r *limiterRegistry) get(limit RateLimit, host string) *hostLimiter {
+	key := limit.Scope + "/" + host
+	burst := limit.Burst
+	if burst == 0 {
+		burst = int(limit.RequestsPerSecond)
+	}
+	if burst < 1 {
+		burst = 1
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	l, ok := r.limiters[key]
+	if !ok {
+		l = &hostLimiter{host: host, limiter: rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), burst)}
+		r.limiters[key] = l
+		return l
+	}
+
+	if l.limiter.Limit() != rate.Limit(limit.RequestsPerSecond) {
+		l.limiter.SetLimit(rate.Limit(limit.RequestsPerSecond))
+	}
+	if l.limiter.Burst() != burst {
+		l.limiter.SetBurst(burst)
+	}
+
+	return l
+}
+
+// Wait blocks until a request may be sent to the host, or until the context is done.
+func (l *hostLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	blocked := time.Until(l.blockedUntil)
+	l.mu.Unlock()
+
+	if blocked > 0 {
+		throttledRequests.WithLabelValues(l.host, throttleReasonBackoff).Inc()
+		if err := wait(ctx, blocked); err != nil {
+			return err
+		}
+	}
+
+	reservation := l.limiter.Reserve()
+	delay := reservation.Delay()
+	if delay == 0 {
+		return nil
+	}
+
+	throttledRequests.WithLabelValues(l.host, throttleReasonRateLimit).Inc()
+	if err := wait(ctx, delay); err != nil {
+		reservation.Cancel()
+		return err
+	}
+
+	return nil
+}
+
+// BackOff pauses the requests to the host for the given duration.
+func (l *hostLimiter) BackOff(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until := time.Now().Add(d); until.After(l.blockedUntil) {
+		l.blockedUntil = until
+	}
+}
+
+// limiterFor returns the limiter of the request's host, or nil when requests aren't throttled.
+func (hc *client) limiterFor(requestURL string) *hostLimiter {
+	if hc.rateLimit == nil {
+		return nil
+	}
+
+	u, err := url.Parse(requestURL)
+	if err != nil {
+		return nil
+	}
+
+	return hostLimiters.get(*hc.rateLimit, u.Host)
+}
+
+// tooManyRequestsBackoff returns how long the host asked to back off after a 429 response.
+func tooManyRequestsBackoff(headers http.Header) time.Duration {
+	if d, ok := RetryAfter(headers); ok {
+		return d
+	}
+	return defaultTooManyRequestsBackoff
+}
@@ -0,0 +1,129 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func Test_SendRequest_RateLimit(t *testing.T) {
+	type args struct {
+		limit     RateLimit
+		responses []int
+		requests  int
+	}
+	type want struct {
+		statusCode  int
+		sent        int
+		minDuration time.Duration
+		rateLimited float64
+		backedOff   float64
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ThrottledByTokenBucket": {
+			args: args{
+				limit:     RateLimit{Scope: "ThrottledByTokenBucket", RequestsPerSecond: 10, Burst: 1},
+				responses: []int{http.StatusOK},
+				requests:  3,
+			},
+			want: want{
+				statusCode:  http.StatusOK,
+				sent:        3,
+				minDuration: 150 * time.Millisecond,
+				rateLimited: 2,
+			},
+		},
+		"BackOffAfterTooManyRequests": {
+			args: args{
+				limit:     RateLimit{Scope: "BackOffAfterTooManyRequests", RequestsPerSecond: 100},
+				responses: []int{http.StatusTooManyRequests, http.StatusOK},
+				requests:  1,
+			},
+			want: want{
+				statusCode:  http.StatusOK,
+				sent:        2,
+				minDuration: time.Second,
+				backedOff:   1,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			sent := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := tc.args.responses[len(tc.args.responses)-1]
+				if sent < len(tc.args.responses) {
+					status = tc.args.responses[sent]
+				}
+				sent++
+				if status == http.StatusTooManyRequests {
+					w.Header().Set("Retry-After", "1")
+				}
+				w.WriteHeader(status)
+			}))
+			defer server.Close()
+
+			u, _ := url.Parse(server.URL)
+			c, err := NewClient(logging.NewNopLogger(), time.Minute, WithRateLimit(tc.args.limit))
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			start := time.Now()
+			var details HttpDetails
+			for i := 0; i < tc.args.requests; i++ {
+				details, err = c.SendRequest(context.Background(), http.MethodGet, server.URL, "", nil, false)
+				if err != nil {
+					t.Fatalf("SendRequest(...): unexpected error: %s", err)
+				}
+			}
+
+			if diff := cmp.Diff(tc.want.statusCode, details.HttpResponse.StatusCode); diff != "" {
+				t.Fatalf("SendRequest(...): -want status code, +got status code: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.sent, sent); diff != "" {
+				t.Fatalf("SendRequest(...): -want sent requests, +got sent requests: %s", diff)
+			}
+			if elapsed := time.Since(start); elapsed < tc.want.minDuration {
+				t.Fatalf("SendRequest(...): expected requests to take at least %s, took %s", tc.want.minDuration, elapsed)
+			}
+			if diff := cmp.Diff(tc.want.rateLimited, testutil.ToFloat64(throttledRequests.WithLabelValues(u.Host, throttleReasonRateLimit))); diff != "" {
+				t.Fatalf("SendRequest(...): -want rate limited requests, +got rate limited requests: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.backedOff, testutil.ToFloat64(throttledRequests.WithLabelValues(u.Host, throttleReasonBackoff))); diff != "" {
+				t.Fatalf("SendRequest(...): -want backed off requests, +got backed off requests: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_limiterRegistry_get(t *testing.T) {
+	registry := &limiterRegistry{limiters: map[string]*hostLimiter{}}
+
+	first := registry.get(RateLimit{Scope: "pc", RequestsPerSecond: 5}, "api.example.com")
+	if diff := cmp.Diff(5, first.limiter.Burst()); diff != "" {
+		t.Fatalf("get(...): -want default burst, +got burst: %s", diff)
+	}
+
+	updated := registry.get(RateLimit{Scope: "pc", RequestsPerSecond: 10, Burst: 2}, "api.example.com")
+	if first != updated {
+		t.Fatalf("get(...): expected the limiter of the host to be reused")
+	}
+	if diff := cmp.Diff(2, updated.limiter.Burst()); diff != "" {
+		t.Fatalf("get(...): -want updated burst, +got burst: %s", diff)
+	}
+
+	if other := registry.get(RateLimit{Scope: "other", RequestsPerSecond: 10}, "api.example.com"); other == first {
+		t.Fatalf("get(...): expected scopes to have separate limiters")
+	}
+}
@@ -0,0 +1,76 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errTooManyRedirects = "stopped after %d redirects"
+
+	// DefaultMaxRedirects is how many redirects are followed by default.
+	DefaultMaxRedirects = 10
+)
+
+// sensitiveHeaders are the headers holding credentials, which aren't forwarded to a
+// different host when following a redirect.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Cookie2"}
+
+// RedirectPolicy configures how redirect responses are followed.
+type RedirectPolicy struct {
+	// NoFollow returns redirect responses instead of following them.
+	NoFollow bool
+	// MaxRedirects is how many redirects are followed before the request fails,
+	// instead of DefaultMaxRedirects.
+	MaxRedirects int
+	// PreserveSensitiveHeaders forwards the sensitive and secret headers to a
+	// different host when following a redirect.
+	PreserveSensitiveHeaders bool
+}
+
+// WithRedirectPolicy follows redirects according to the policy.
+func WithRedirectPolicy(policy RedirectPolicy) Option {
+	return func(c *client) {
+		c.redirect = policy
+	}
+}
+
+// checkRedirect decides whether to follow the redirect to the request. Unless the
+// policy preserves them, credentials are only sent to the host of the original request:
+// the sensitive headers and the secret headers are removed from a request to any other.
+func (hc *client) checkRedirect(request *http.Request, via []*http.Request) error {
+	if hc.redirect.NoFollow {
+		return http.ErrUseLastResponse
+	}
+
+	maxRedirects := hc.redirect.MaxRedirects
+	if maxRedirects == 0 {
+		maxRedirects = DefaultMaxRedirects
+	}
+	if len(via) > maxRedirects {
+		return errors.Errorf(errTooManyRedirects, maxRedirects)
+	}
+
+	original := via[0]
+	if request.URL.Host == original.URL.Host {
+		return nil
+	}
+
+	headers := append([]string{}, sensitiveHeaders...)
+	for name := range hc.secretHeaders {
+		headers = append(headers, name)
+	}
+
+	for _, name := range headers {
+		if hc.redirect.PreserveSensitiveHeaders {
+			if values := original.Header.Values(name); len(values) > 0 {
+				request.Header[http.CanonicalHeaderKey(name)] = values
+			}
+			continue
+		}
+		request.Header.Del(name)
+	}
+
+	return nil
+}
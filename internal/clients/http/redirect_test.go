@@ -0,0 +1,130 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SendRequest_Redirect(t *testing.T) {
+	var received http.Header
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+	}))
+	defer target.Close()
+
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/same-host":
+			http.Redirect(w, r, "/target", http.StatusFound)
+		case "/target":
+			received = r.Header.Clone()
+		case "/loop":
+			http.Redirect(w, r, "/loop", http.StatusFound)
+		default:
+			http.Redirect(w, r, target.URL+"/target", http.StatusFound)
+		}
+	}))
+	defer origin.Close()
+
+	type args struct {
+		path   string
+		policy RedirectPolicy
+	}
+	type want struct {
+		statusCode         int
+		authorization      string
+		proxyAuthorization string
+		apiKey             string
+		err                bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"SameHostKeepsHeaders": {
+			args: args{
+				path: "/same-host",
+			},
+			want: want{
+				statusCode:         http.StatusOK,
+				authorization:      "Bearer s3cr3t",
+				proxyAuthorization: "Basic cHJveHk=",
+				apiKey:             "key",
+			},
+		},
+		"OtherHostStripsHeaders": {
+			args: args{
+				path: "/other-host",
+			},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+		},
+		"OtherHostPreservesHeaders": {
+			args: args{
+				path:   "/other-host",
+				policy: RedirectPolicy{PreserveSensitiveHeaders: true},
+			},
+			want: want{
+				statusCode:         http.StatusOK,
+				authorization:      "Bearer s3cr3t",
+				proxyAuthorization: "Basic cHJveHk=",
+				apiKey:             "key",
+			},
+		},
+		"NoFollow": {
+			args: args{
+				path:   "/other-host",
+				policy: RedirectPolicy{NoFollow: true},
+			},
+			want: want{
+				statusCode: http.StatusFound,
+			},
+		},
+		"TooManyRedirects": {
+			args: args{
+				path:   "/loop",
+				policy: RedirectPolicy{MaxRedirects: 3},
+			},
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			received = nil
+
+			c, err := NewClient(logging.NewNopLogger(), 5*time.Second,
+				WithBearerToken("s3cr3t"),
+				WithSecretHeaders(map[string]string{"X-API-Key": "key"}),
+				WithRedirectPolicy(tc.args.policy))
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			details, err := c.SendRequest(context.Background(), http.MethodGet, origin.URL+tc.args.path, "", map[string][]string{"Proxy-Authorization": {"Basic cHJveHk="}}, false)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("SendRequest(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.statusCode, details.HttpResponse.StatusCode); diff != "" {
+				t.Errorf("SendRequest(...): -want status code, +got status code: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.authorization, received.Get("Authorization")); diff != "" {
+				t.Errorf("SendRequest(...): -want Authorization, +got Authorization: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.proxyAuthorization, received.Get("Proxy-Authorization")); diff != "" {
+				t.Errorf("SendRequest(...): -want Proxy-Authorization, +got Proxy-Authorization: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.apiKey, received.Get("X-API-Key")); diff != "" {
+				t.Errorf("SendRequest(...): -want X-API-Key, +got X-API-Key: %s", diff)
+			}
+		})
+	}
+}
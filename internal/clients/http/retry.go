@@ -0,0 +1,123 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryInitialBackoff = time.Second
+	defaultRetryMultiplier     = 2
+)
+
+var defaultRetryableStatusCodes = []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
+// RetryPolicy configures how requests are retried after a transient failure response.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	MaxAttempts int
+	// InitialBackoff is the wait time before the first retry.
+	InitialBackoff time.Duration
+	// Multiplier is applied to the wait time after each retry.
+	Multiplier int
+	// RetryableStatusCodes are the response status codes that trigger a retry.
+	RetryableStatusCodes []int
+	// RetryNonIdempotent allows retrying non-idempotent methods such as POST.
+	RetryNonIdempotent bool
+}
+
+// shouldRetry reports whether a request that got the given status code on the given attempt should be sent again.
+func (p *RetryPolicy) shouldRetry(method string, statusCode int, attempt int) bool {
+	if p == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+
+	if !p.RetryNonIdempotent && !isIdempotent(method) {
+		return false
+	}
+
+	codes := p.RetryableStatusCodes
+	if len(codes) == 0 {
+		codes = defaultRetryableStatusCodes
+	}
+
+	for _, code := range codes {
+		if code == statusCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff returns how long to wait before the retry following the given attempt. A Retry-After
+// header sent by the server takes precedence over the exponential backoff, which is jittered.
+func (p *RetryPolicy) backoff(attempt int, headers http.Header) time.Duration {
+	if wait, ok := RetryAfter(headers); ok {
+		return wait
+	}
+
+	wait := p.InitialBackoff
+	if wait == 0 {
+		wait = defaultRetryInitialBackoff
+	}
+
+	multiplier := p.Multiplier
+	if multiplier == 0 {
+		multiplier = defaultRetryMultiplier
+	}
+
+	for i := 1; i < attempt; i++ {
+		wait *= time.Duration(multiplier)
+	}
+
+	return Jitter(wait)
+}
+
+// RetryAfter parses a Retry-After header, given either in seconds or as an HTTP date.
+func RetryAfter(headers http.Header) (time.Duration, bool) {
+	value := headers.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// isIdempotent reports whether sending a request with the method more than once has
+// the same effect as sending it once.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
+
+// wait blocks for the given duration, or until the context is done.
+func wait(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
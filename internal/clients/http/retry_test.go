@@ -0,0 +1,172 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_RetryPolicy_shouldRetry(t *testing.T) {
+	type args struct {
+		policy     *RetryPolicy
+		method     string
+		statusCode int
+		attempt    int
+	}
+	type want struct {
+		result bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoPolicy": {
+			args: args{
+				method:     http.MethodGet,
+				statusCode: http.StatusServiceUnavailable,
+				attempt:    1,
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"DefaultRetryableStatusCode": {
+			args: args{
+				policy:     &RetryPolicy{MaxAttempts: 3},
+				method:     http.MethodGet,
+				statusCode: http.StatusServiceUnavailable,
+				attempt:    1,
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"NotRetryableStatusCode": {
+			args: args{
+				policy:     &RetryPolicy{MaxAttempts: 3},
+				method:     http.MethodGet,
+				statusCode: http.StatusBadRequest,
+				attempt:    1,
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"MaxAttemptsReached": {
+			args: args{
+				policy:     &RetryPolicy{MaxAttempts: 3},
+				method:     http.MethodGet,
+				statusCode: http.StatusServiceUnavailable,
+				attempt:    3,
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"PostNotRetried": {
+			args: args{
+				policy:     &RetryPolicy{MaxAttempts: 3},
+				method:     http.MethodPost,
+				statusCode: http.StatusServiceUnavailable,
+				attempt:    1,
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"PostRetriedWhenAllowed": {
+			args: args{
+				policy:     &RetryPolicy{MaxAttempts: 3, RetryNonIdempotent: true},
+				method:     http.MethodPost,
+				statusCode: http.StatusServiceUnavailable,
+				attempt:    1,
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"CustomStatusCodes": {
+			args: args{
+				policy:     &RetryPolicy{MaxAttempts: 3, RetryableStatusCodes: []int{http.StatusConflict}},
+				method:     http.MethodPut,
+				statusCode: http.StatusConflict,
+				attempt:    1,
+			},
+			want: want{
+				result: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.args.policy.shouldRetry(tc.args.method, tc.args.statusCode, tc.args.attempt)
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("shouldRetry(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_RetryPolicy_backoff(t *testing.T) {
+	type args struct {
+		policy  *RetryPolicy
+		attempt int
+		headers http.Header
+	}
+	type want struct {
+		backoff time.Duration
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Defaults": {
+			args: args{
+				policy:  &RetryPolicy{MaxAttempts: 3},
+				attempt: 1,
+			},
+			want: want{
+				backoff: time.Second,
+			},
+		},
+		"Exponential": {
+			args: args{
+				policy:  &RetryPolicy{MaxAttempts: 5, InitialBackoff: 100 * time.Millisecond, Multiplier: 3},
+				attempt: 3,
+			},
+			want: want{
+				backoff: 900 * time.Millisecond,
+			},
+		},
+		"RetryAfterSeconds": {
+			args: args{
+				policy:  &RetryPolicy{MaxAttempts: 3},
+				attempt: 1,
+				headers: http.Header{"Retry-After": []string{"7"}},
+			},
+			want: want{
+				backoff: 7 * time.Second,
+			},
+		},
+		"RetryAfterDateInThePast": {
+			args: args{
+				policy:  &RetryPolicy{MaxAttempts: 3},
+				attempt: 1,
+				headers: http.Header{"Retry-After": []string{"Wed, 21 Oct 2015 07:28:00 GMT"}},
+			},
+			want: want{
+				backoff: 0,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.args.policy.backoff(tc.args.attempt, tc.args.headers)
+			if diff := cmp.Diff(tc.want.backoff, got); diff != "" {
+				t.Fatalf("backoff(...): -want backoff, +got backoff: %s", diff)
+			}
+		})
+	}
+}
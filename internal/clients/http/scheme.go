@@ -0,0 +1,33 @@
+package http
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errUnsupportedScheme = "unsupported scheme %q of the URL %s, only http and https are supported"
+
+	schemeHTTP  = "http"
+	schemeHTTPS = "https"
+)
+
+// validateScheme checks that the URL is sent over plain HTTP or over TLS, so that an
+// http URL never attempts TLS and an https URL always does. Skipping the verification
+// of TLS certificates has no effect on an http URL, which has none.
+func validateScheme(u *url.URL) error {
+	switch strings.ToLower(u.Scheme) {
+	case schemeHTTPS, schemeHTTP:
+		return nil
+	default:
+		return errors.Errorf(errUnsupportedScheme, u.Scheme, u.Redacted())
+	}
+}
+
+// IsPlainHTTP reports whether the URL is sent over plain HTTP, without TLS.
+func IsPlainHTTP(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	return err == nil && strings.EqualFold(u.Scheme, schemeHTTP)
+}
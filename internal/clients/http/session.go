@@ -0,0 +1,84 @@
+package http
+
+import (
+	"context"
+	"net/http/cookiejar"
+	"slices"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errCookieJar       = "cannot create cookie jar"
+	errLogin           = "cannot send login request"
+	errLoginStatusCode = "login request to %s failed with status code: %d"
+)
+
+// Session keeps the cookies set by the API across the requests of the client,
+// scoped by domain and path like a browser does.
+type Session struct {
+	// Login, when set, is sent before the first request to obtain the session cookies.
+	Login *HttpRequest
+	// ExpectedStatusCodes are the status codes of a successful login response.
+	// Any 2xx status code is successful when none are expected.
+	ExpectedStatusCodes []int
+}
+
+// WithSession keeps the cookies set by the API in a jar, which lives as long as the
+// client. As a client is built for every reconcile, cookies don't outlive a change of
+// the credentials.
+func WithSession(session Session) Option {
+	return func(c *client) {
+		c.session = &session
+	}
+}
+
+// sessionState is the cookie jar of a client, and whether it has logged in.
+type sessionState struct {
+	jar *cookiejar.Jar
+
+	mu       contextMutex
+	loggedIn bool
+}
+
+func newSessionState() (*sessionState, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, errors.Wrap(err, errCookieJar)
+	}
+	return &sessionState{jar: jar}, nil
+}
+
+// login sends the login request of the session, unless it was sent successfully
+// already. Its details aren't logged, as they usually hold credentials.
+func (hc *client) login(ctx context.Context, skipTLSVerify bool) error {
+	if hc.session == nil || hc.session.Login == nil {
+		return nil
+	}
+
+	if err := hc.sessionState.mu.Lock(ctx); err != nil {
+		return errors.Wrap(err, errLogin)
+	}
+	defer hc.sessionState.mu.Unlock()
+	if hc.sessionState.loggedIn {
+		return nil
+	}
+
+	response, err := hc.send(ctx, *hc.session.Login, skipTLSVerify)
+	if err != nil {
+		return errors.Wrap(err, errLogin)
+	}
+	if !isLoginSuccess(response.StatusCode, hc.session.ExpectedStatusCodes) {
+		return errors.Errorf(errLoginStatusCode, hc.session.Login.URL, response.StatusCode)
+	}
+
+	hc.sessionState.loggedIn = true
+	return nil
+}
+
+func isLoginSuccess(statusCode int, expectedStatusCodes []int) bool {
+	if len(expectedStatusCodes) == 0 {
+		return statusCode >= 200 && statusCode < 300
+	}
+	return slices.Contains(expectedStatusCodes, statusCode)
+}
@@ -0,0 +1,140 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_SendRequest_Session(t *testing.T) {
+	const sessionID = "abc123"
+
+	newServer := func(logins *int, loginStatusCode int) *httptest.Server {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+			*logins++
+			if loginStatusCode != http.StatusOK {
+				w.WriteHeader(loginStatusCode)
+				return
+			}
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: sessionID, Path: "/api"})
+		})
+		mux.HandleFunc("/api/users", func(w http.ResponseWriter, r *http.Request) {
+			cookie, err := r.Cookie("session")
+			if err != nil || cookie.Value != sessionID {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		})
+		mux.HandleFunc("/other", func(w http.ResponseWriter, r *http.Request) {
+			if _, err := r.Cookie("session"); err == nil {
+				w.WriteHeader(http.StatusBadRequest)
+			}
+		})
+		return httptest.NewServer(mux)
+	}
+
+	type args struct {
+		loginStatusCode     int
+		expectedStatusCodes []int
+		paths               []string
+	}
+	type want struct {
+		statusCodes []int
+		logins      int
+		// loginFailedWith is the status code of a failed login response.
+		loginFailedWith int
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"CookieReused": {
+			args: args{
+				loginStatusCode: http.StatusOK,
+				paths:           []string{"/api/users", "/api/users"},
+			},
+			want: want{
+				statusCodes: []int{http.StatusOK, http.StatusOK},
+				logins:      1,
+			},
+		},
+		"CookieScopedByPath": {
+			args: args{
+				loginStatusCode: http.StatusOK,
+				paths:           []string{"/other"},
+			},
+			want: want{
+				statusCodes: []int{http.StatusOK},
+				logins:      1,
+			},
+		},
+		"LoginFailed": {
+			args: args{
+				loginStatusCode: http.StatusForbidden,
+				paths:           []string{"/api/users"},
+			},
+			want: want{
+				logins:          1,
+				loginFailedWith: http.StatusForbidden,
+			},
+		},
+		"LoginExpectedStatusCode": {
+			args: args{
+				loginStatusCode:     http.StatusOK,
+				expectedStatusCodes: []int{http.StatusNoContent},
+				paths:               []string{"/api/users"},
+			},
+			want: want{
+				logins:          1,
+				loginFailedWith: http.StatusOK,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			logins := 0
+			server := newServer(&logins, tc.args.loginStatusCode)
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), time.Minute, WithSession(Session{
+				Login:               &HttpRequest{Method: http.MethodPost, URL: server.URL + "/login"},
+				ExpectedStatusCodes: tc.args.expectedStatusCodes,
+			}))
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			var statusCodes []int
+			var gotErr error
+			for _, path := range tc.args.paths {
+				details, err := c.SendRequest(context.Background(), http.MethodGet, server.URL+path, "", nil, false)
+				if err != nil {
+					gotErr = err
+					break
+				}
+				statusCodes = append(statusCodes, details.HttpResponse.StatusCode)
+			}
+
+			var wantErr error
+			if tc.want.loginFailedWith != 0 {
+				wantErr = errors.Errorf(errLoginStatusCode, server.URL+"/login", tc.want.loginFailedWith)
+			}
+			if diff := cmp.Diff(wantErr, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("SendRequest(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.statusCodes, statusCodes); diff != "" {
+				t.Errorf("SendRequest(...): -want status codes, +got status codes: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.logins, logins); diff != "" {
+				t.Errorf("SendRequest(...): -want logins, +got logins: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errParseCABundle  = "cannot parse CA bundle, expected PEM encoded certificates"
+	errLoadClientCert = "cannot load client certificate, expected a matching PEM encoded certificate and private key"
+)
+
+// WithCACertificates verifies server certificates against the PEM encoded CA
+// bundle instead of the system roots.
+func WithCACertificates(pemCerts []byte) Option {
+	return func(c *client) {
+		c.caCertificates = pemCerts
+	}
+}
+
+// WithClientCertificate presents the PEM encoded certificate and private key to
+// servers requesting a client certificate.
+func WithClientCertificate(certPEM, keyPEM []byte) Option {
+	return func(c *client) {
+		c.clientCertificate = certPEM
+		c.clientKey = keyPEM
+	}
+}
+
+// buildTLSConfig prepares the TLS configuration shared by all requests of the client.
+func (hc *client) buildTLSConfig() error {
+	hc.tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if len(hc.caCertificates) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(hc.caCertificates) {
+			return errors.New(errParseCABundle)
+		}
+		hc.tlsConfig.RootCAs = pool
+	}
+
+	if len(hc.clientCertificate) > 0 || len(hc.clientKey) > 0 {
+		cert, err := tls.X509KeyPair(hc.clientCertificate, hc.clientKey)
+		if err != nil {
+			return errors.Wrap(err, errLoadClientCert)
+		}
+		hc.tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return nil
+}
+
+// tlsConfigFor returns the TLS configuration of a request, skipping the
+// verification of server certificates when requested.
+func (hc *client) tlsConfigFor(skipTLSVerify bool) *tls.Config {
+	cfg := hc.tlsConfig.Clone()
+	// #nosec G402
+	cfg.InsecureSkipVerify = skipTLSVerify
+	return cfg
+}
+
+// certificateExpiry returns when the leaf certificate presented by the server expires,
+// or the zero time for a connection without TLS.
+func certificateExpiry(state *tls.ConnectionState) time.Time {
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return time.Time{}
+	}
+	return state.PeerCertificates[0].NotAfter
+}
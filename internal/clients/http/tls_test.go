@@ -0,0 +1,215 @@
+package http
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_SendRequest_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	type args struct {
+		opts          []Option
+		skipTLSVerify bool
+	}
+	type want struct {
+		newClientErr      error
+		statusCode        int
+		sendFails         bool
+		certificateExpiry time.Time
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"UnknownAuthority": {
+			args: args{},
+			want: want{
+				sendFails: true,
+			},
+		},
+		"InsecureSkipTLSVerify": {
+			args: args{
+				skipTLSVerify: true,
+			},
+			want: want{
+				statusCode:        http.StatusOK,
+				certificateExpiry: server.Certificate().NotAfter,
+			},
+		},
+		"CustomCABundle": {
+			args: args{
+				opts: []Option{WithCACertificates(serverCA)},
+			},
+			want: want{
+				statusCode:        http.StatusOK,
+				certificateExpiry: server.Certificate().NotAfter,
+			},
+		},
+		"InvalidCABundle": {
+			args: args{
+				opts: []Option{WithCACertificates([]byte("not a certificate"))},
+			},
+			want: want{
+				newClientErr: errors.New(errParseCABundle),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, err := NewClient(logging.NewNopLogger(), time.Minute, tc.args.opts...)
+			if diff := cmp.Diff(tc.want.newClientErr, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("NewClient(...): -want error, +got error: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			details, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, "", nil, tc.args.skipTLSVerify)
+			if diff := cmp.Diff(tc.want.sendFails, err != nil); diff != "" {
+				t.Fatalf("SendRequest(...): -want failure, +got failure: %s (err: %v)", diff, err)
+			}
+			if diff := cmp.Diff(tc.want.statusCode, details.HttpResponse.StatusCode); diff != "" {
+				t.Fatalf("SendRequest(...): -want status code, +got status code: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.certificateExpiry, details.HttpResponse.CertificateExpiry); diff != "" {
+				t.Fatalf("SendRequest(...): -want certificate expiry, +got certificate expiry: %s", diff)
+			}
+		})
+	}
+}
+
+// newClientCertificate returns a self-signed PEM encoded certificate and private key.
+func newClientCertificate(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("cannot generate key: %s", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "provider-http"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("cannot create certificate: %s", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("cannot marshal key: %s", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func Test_SendRequest_ClientCertificate(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	cert, key := newClientCertificate(t)
+	_, otherKey := newClientCertificate(t)
+
+	type args struct {
+		opts          []Option
+		skipTLSVerify bool
+	}
+	type want struct {
+		newClientErr bool
+		statusCode   int
+		sendFails    bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"MissingClientCertificate": {
+			args: args{
+				opts: []Option{WithCACertificates(serverCA)},
+			},
+			want: want{
+				sendFails: true,
+			},
+		},
+		"ClientCertificateWithCABundle": {
+			args: args{
+				opts: []Option{WithCACertificates(serverCA), WithClientCertificate(cert, key)},
+			},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+		},
+		"ClientCertificateWithInsecureSkipTLSVerify": {
+			args: args{
+				opts:          []Option{WithClientCertificate(cert, key)},
+				skipTLSVerify: true,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+		},
+		"MismatchedKeyPair": {
+			args: args{
+				opts: []Option{WithClientCertificate(cert, otherKey)},
+			},
+			want: want{
+				newClientErr: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, err := NewClient(logging.NewNopLogger(), time.Minute, tc.args.opts...)
+			if diff := cmp.Diff(tc.want.newClientErr, err != nil); diff != "" {
+				t.Fatalf("NewClient(...): -want error, +got error: %s (err: %v)", diff, err)
+			}
+			if err != nil {
+				if !strings.Contains(err.Error(), errLoadClientCert) {
+					t.Fatalf("NewClient(...): expected %q in error, got %q", errLoadClientCert, err)
+				}
+				return
+			}
+
+			details, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, "", nil, tc.args.skipTLSVerify)
+			if diff := cmp.Diff(tc.want.sendFails, err != nil); diff != "" {
+				t.Fatalf("SendRequest(...): -want failure, +got failure: %s (err: %v)", diff, err)
+			}
+			if diff := cmp.Diff(tc.want.statusCode, details.HttpResponse.StatusCode); diff != "" {
+				t.Fatalf("SendRequest(...): -want status code, +got status code: %s", diff)
+			}
+		})
+	}
+}
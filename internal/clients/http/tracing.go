@@ -0,0 +1,65 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName = "github.com/crossplane-contrib/provider-http/internal/clients/http"
+
+	attributeRequestMethod      = attribute.Key("http.request.method")
+	attributeURLFull            = attribute.Key("url.full")
+	attributeResponseStatusCode = attribute.Key("http.response.status_code")
+)
+
+// tracePropagator propagates the spans of the outgoing requests in the W3C
+// traceparent and tracestate headers.
+var tracePropagator = propagation.TraceContext{}
+
+// WithTracing creates a span per outgoing request, as a child of the span of the
+// request context if there is one, and propagates it in the traceparent header.
+// Spans are exported by the global OpenTelemetry tracer provider.
+func WithTracing() Option {
+	return func(c *client) {
+		c.tracing = true
+	}
+}
+
+// startSpan starts a span for an outgoing request and injects it in the request
+// headers, or returns nil when tracing is disabled.
+func (hc *client) startSpan(ctx context.Context, request *http.Request) trace.Span {
+	if !hc.tracing {
+		return nil
+	}
+
+	ctx, span := otel.GetTracerProvider().Tracer(tracerName).Start(ctx, request.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attributeRequestMethod.String(request.Method),
+			attributeURLFull.String(request.URL.String()),
+		),
+	)
+	tracePropagator.Inject(ctx, propagation.HeaderCarrier(request.Header))
+	return span
+}
+
+// endSpan ends the span of a request that got the given status code, zero if it got no response.
+func endSpan(span trace.Span, statusCode int, err error) {
+	if statusCode != 0 {
+		span.SetAttributes(attributeResponseStatusCode.Int(statusCode))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else if statusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(statusCode))
+	}
+	span.End()
+}
@@ -0,0 +1,157 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var traceparentPattern = regexp.MustCompile(`^00-([0-9a-f]{32})-([0-9a-f]{16})-01$`)
+
+func Test_SendRequest_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	type args struct {
+		opts       []Option
+		ctx        context.Context
+		statusCode int
+	}
+	type want struct {
+		traced  bool
+		traceID string
+		status  codes.Code
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Disabled": {
+			args: args{
+				ctx:        trace.ContextWithSpanContext(context.Background(), parent),
+				statusCode: http.StatusOK,
+			},
+			want: want{
+				traced: false,
+			},
+		},
+		"NewTrace": {
+			args: args{
+				opts:       []Option{WithTracing()},
+				ctx:        context.Background(),
+				statusCode: http.StatusOK,
+			},
+			want: want{
+				traced: true,
+				status: codes.Unset,
+			},
+		},
+		"ChildOfContextSpan": {
+			args: args{
+				opts:       []Option{WithTracing()},
+				ctx:        trace.ContextWithSpanContext(context.Background(), parent),
+				statusCode: http.StatusOK,
+			},
+			want: want{
+				traced:  true,
+				traceID: parent.TraceID().String(),
+				status:  codes.Unset,
+			},
+		},
+		"ServerError": {
+			args: args{
+				opts:       []Option{WithTracing()},
+				ctx:        context.Background(),
+				statusCode: http.StatusBadGateway,
+			},
+			want: want{
+				traced: true,
+				status: codes.Error,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			traceparent := ""
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				traceparent = r.Header.Get("traceparent")
+				w.WriteHeader(tc.args.statusCode)
+			}))
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), time.Minute, tc.args.opts...)
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			ended := len(recorder.Ended())
+			if _, err := c.SendRequest(tc.args.ctx, http.MethodGet, server.URL, "", nil, false); err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %s", err)
+			}
+			spans := recorder.Ended()[ended:]
+
+			if !tc.want.traced {
+				if diff := cmp.Diff("", traceparent); diff != "" {
+					t.Fatalf("SendRequest(...): -want no traceparent, +got traceparent: %s", diff)
+				}
+				if diff := cmp.Diff(0, len(spans)); diff != "" {
+					t.Fatalf("SendRequest(...): -want spans, +got spans: %s", diff)
+				}
+				return
+			}
+
+			match := traceparentPattern.FindStringSubmatch(traceparent)
+			if match == nil {
+				t.Fatalf("SendRequest(...): invalid traceparent %q", traceparent)
+			}
+			if tc.want.traceID != "" {
+				if diff := cmp.Diff(tc.want.traceID, match[1]); diff != "" {
+					t.Fatalf("SendRequest(...): -want trace ID, +got trace ID: %s", diff)
+				}
+			}
+			if match[2] == parent.SpanID().String() {
+				t.Fatalf("SendRequest(...): expected a new span ID, got the parent span ID")
+			}
+
+			if diff := cmp.Diff(1, len(spans)); diff != "" {
+				t.Fatalf("SendRequest(...): -want spans, +got spans: %s", diff)
+			}
+			span := spans[0]
+			if diff := cmp.Diff(match[2], span.SpanContext().SpanID().String()); diff != "" {
+				t.Errorf("SendRequest(...): -want exported span ID, +got exported span ID: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.status, span.Status().Code); diff != "" {
+				t.Errorf("SendRequest(...): -want span status, +got span status: %s", diff)
+			}
+			wantAttributes := []attribute.KeyValue{
+				attributeRequestMethod.String(http.MethodGet),
+				attributeURLFull.String(server.URL),
+				attributeResponseStatusCode.Int(tc.args.statusCode),
+			}
+			if diff := cmp.Diff(wantAttributes, span.Attributes(), cmp.AllowUnexported(attribute.Value{})); diff != "" {
+				t.Errorf("SendRequest(...): -want span attributes, +got span attributes: %s", diff)
+			}
+		})
+	}
+}
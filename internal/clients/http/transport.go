@@ -0,0 +1,150 @@
+package http
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxIdleConns is how many idle connections are kept open by default.
+	DefaultMaxIdleConns = 100
+	// DefaultMaxIdleConnsPerHost is how many idle connections are kept open per host by default.
+	DefaultMaxIdleConnsPerHost = http.DefaultMaxIdleConnsPerHost
+	// DefaultIdleConnTimeout is how long idle connections are kept open by default.
+	DefaultIdleConnTimeout = 90 * time.Second
+
+	// maxTransports bounds the number of transports kept for reuse.
+	maxTransports = 100
+)
+
+// ConnectionPool configures how many connections are kept open for reuse, and for
+// how long. Zero values keep the defaults.
+type ConnectionPool struct {
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+}
+
+// WithConnectionPool keeps connections open for reuse according to the pool,
+// instead of the defaults.
+func WithConnectionPool(pool ConnectionPool) Option {
+	return func(c *client) {
+		c.connectionPool = pool
+	}
+}
+
+// transports caches transports per configuration, so that the clients built for
+// every reconcile reuse the open connections of the previous ones. The least
+// recently used transports are evicted, as every change of a ProviderConfig or of
+// a client certificate adds one.
+var transports = newTransportCache(maxTransports)
+
+type transportCache struct {
+	mu         sync.Mutex
+	max        int
+	order      *list.List
+	transports map[string]*list.Element
+}
+
+type cachedTransport struct {
+	key       string
+	transport http.RoundTripper
+}
+
+func newTransportCache(max int) *transportCache {
+	return &transportCache{max: max, order: list.New(), transports: map[string]*list.Element{}}
+}
+
+// get returns the transport cached for the key, building it with newTransport when
+// it isn't cached yet.
+func (c *transportCache) get(key string, newTransport func() http.RoundTripper) http.RoundTripper {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.transports[key]; ok {
+		c.order.MoveToFront(element)
+		return element.Value.(*cachedTransport).transport
+	}
+
+	transport := newTransport()
+	c.transports[key] = c.order.PushFront(&cachedTransport{key: key, transport: transport})
+	for c.order.Len() > c.max {
+		c.evict(c.order.Back())
+	}
+	return transport
+}
+
+// evict removes the transport, closing its idle connections. The clients still
+// using it keep working, as only their connections would be reused otherwise.
+func (c *transportCache) evict(element *list.Element) {
+	entry := c.order.Remove(element).(*cachedTransport)
+	delete(c.transports, entry.key)
+	if closer, ok := entry.transport.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
+
+// transportFor returns the transport of a request, shared by the clients with the
+// same proxy, host aliases, TLS, connection pool and protocol configuration.
+func (hc *client) transportFor(skipTLSVerify bool) http.RoundTripper {
+	return transports.get(hc.transportKey(skipTLSVerify), func() http.RoundTripper {
+		return hc.newTransport(skipTLSVerify)
+	})
+}
+
+// newTransport builds the transport of the client's configuration.
+func (hc *client) newTransport(skipTLSVerify bool) http.RoundTripper {
+	transport := &http.Transport{
+		Proxy:               hc.proxyFunc(),
+		DialContext:         hc.dialContext(),
+		TLSClientConfig:     hc.tlsConfigFor(skipTLSVerify),
+		MaxIdleConns:        DefaultMaxIdleConns,
+		MaxIdleConnsPerHost: DefaultMaxIdleConnsPerHost,
+		IdleConnTimeout:     DefaultIdleConnTimeout,
+	}
+	pool := hc.connectionPool
+	if pool.MaxIdleConns != 0 {
+		transport.MaxIdleConns = pool.MaxIdleConns
+	}
+	if pool.MaxIdleConnsPerHost != 0 {
+		transport.MaxIdleConnsPerHost = pool.MaxIdleConnsPerHost
+	}
+	if pool.IdleConnTimeout != 0 {
+		transport.IdleConnTimeout = pool.IdleConnTimeout
+	}
+	return hc.configureProtocol(transport)
+}
+
+// transportKey identifies the configuration of a transport, without keeping the
+// credentials of the proxy or the client key in memory as a map key.
+func (hc *client) transportKey(skipTLSVerify bool) string {
+	var proxy string
+	if hc.proxy != nil {
+		proxy = hc.proxy.URL.String() + " " + hc.proxy.NoProxy
+	}
+
+	aliases := make([]string, 0, len(hc.hostAliases))
+	for hostname, ip := range hc.hostAliases {
+		aliases = append(aliases, hostname+"="+ip)
+	}
+	sort.Strings(aliases)
+
+	hash := sha256.Sum256([]byte(strings.Join([]string{
+		proxy,
+		strings.Join(aliases, ","),
+		string(hc.caCertificates),
+		string(hc.clientCertificate),
+		string(hc.clientKey),
+		fmt.Sprint(skipTLSVerify),
+		fmt.Sprint(hc.connectionPool),
+		fmt.Sprint(hc.protocol),
+	}, "\n")))
+	return hex.EncodeToString(hash[:])
+}
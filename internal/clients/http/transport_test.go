@@ -0,0 +1,172 @@
+package http
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SendRequest_ConnectionReuse(t *testing.T) {
+	var mu sync.Mutex
+	connections := 0
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			mu.Lock()
+			connections++
+			mu.Unlock()
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	type args struct {
+		pools []ConnectionPool
+	}
+	type want struct {
+		connections int
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"SameConfigurationReused": {
+			args: args{
+				pools: []ConnectionPool{{MaxIdleConnsPerHost: 4}, {MaxIdleConnsPerHost: 4}, {MaxIdleConnsPerHost: 4}},
+			},
+			want: want{
+				connections: 1,
+			},
+		},
+		"OtherConfigurationNotShared": {
+			args: args{
+				pools: []ConnectionPool{{MaxIdleConnsPerHost: 5}, {MaxIdleConnsPerHost: 6}},
+			},
+			want: want{
+				connections: 2,
+			},
+		},
+		"IdleConnectionsClosed": {
+			args: args{
+				pools: []ConnectionPool{{IdleConnTimeout: time.Nanosecond}, {IdleConnTimeout: time.Nanosecond}},
+			},
+			want: want{
+				connections: 2,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mu.Lock()
+			connections = 0
+			mu.Unlock()
+
+			// Every request is sent by a new client, like the clients built for each reconcile.
+			for _, pool := range tc.args.pools {
+				c, err := NewClient(logging.NewNopLogger(), 5*time.Second, WithConnectionPool(pool))
+				if err != nil {
+					t.Fatalf("NewClient(...): unexpected error: %s", err)
+				}
+				if _, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, "", nil, false); err != nil {
+					t.Fatalf("SendRequest(...): unexpected error: %s", err)
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if diff := cmp.Diff(tc.want.connections, connections); diff != "" {
+				t.Errorf("SendRequest(...): -want connections, +got connections: %s", diff)
+			}
+		})
+	}
+}
+
+// closeCounter counts the calls to CloseIdleConnections.
+type closeCounter struct {
+	http.RoundTripper
+	closed int
+}
+
+func (c *closeCounter) CloseIdleConnections() {
+	c.closed++
+}
+
+func Test_transportCache_get(t *testing.T) {
+	type want struct {
+		built  []string
+		cached []string
+		closed map[string]int
+	}
+	cases := map[string]struct {
+		keys []string
+		want want
+	}{
+		"Reused": {
+			keys: []string{"a", "a", "b"},
+			want: want{
+				built:  []string{"a", "b"},
+				cached: []string{"b", "a"},
+				closed: map[string]int{"a": 0, "b": 0},
+			},
+		},
+		"LeastRecentlyUsedEvicted": {
+			keys: []string{"a", "b", "a", "c"},
+			want: want{
+				built:  []string{"a", "b", "c"},
+				cached: []string{"c", "a"},
+				closed: map[string]int{"a": 0, "b": 1, "c": 0},
+			},
+		},
+		"EvictedBuiltAgain": {
+			keys: []string{"a", "b", "c", "a"},
+			want: want{
+				built:  []string{"a", "b", "c", "a"},
+				cached: []string{"a", "c"},
+				closed: map[string]int{"a": 1, "b": 1, "c": 0},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cache := newTransportCache(2)
+			built := []string{}
+			transports := map[string]*closeCounter{}
+			for _, key := range tc.keys {
+				cache.get(key, func() http.RoundTripper {
+					built = append(built, key)
+					if transports[key] == nil {
+						transports[key] = &closeCounter{}
+					}
+					return transports[key]
+				})
+			}
+
+			cached := []string{}
+			for element := cache.order.Front(); element != nil; element = element.Next() {
+				cached = append(cached, element.Value.(*cachedTransport).key)
+			}
+			closed := map[string]int{}
+			for key, transport := range transports {
+				closed[key] = transport.closed
+			}
+
+			if diff := cmp.Diff(tc.want.built, built); diff != "" {
+				t.Errorf("get(...): -want built, +got built: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cached, cached); diff != "" {
+				t.Errorf("get(...): -want cached, +got cached: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.closed, closed); diff != "" {
+				t.Errorf("get(...): -want closed, +got closed: %s", diff)
+			}
+		})
+	}
+}
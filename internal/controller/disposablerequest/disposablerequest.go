@@ -19,7 +19,6 @@ package disposablerequest
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"time"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
@@ -40,6 +39,7 @@ import (
 	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha1"
 	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/features"
 	"github.com/crossplane-contrib/provider-http/internal/utils"
 )
 
@@ -64,6 +64,7 @@ func Setup(mgr ctrl.Manager, o controller.Options, timeout time.Duration) error
 			logger:          o.Logger,
 			kube:            mgr.GetClient(),
 			usage:           resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			tracing:         o.Features.Enabled(features.EnableTracing),
 			newHttpClientFn: httpClient.NewClient,
 		}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
@@ -84,7 +85,8 @@ type connector struct {
 	logger          logging.Logger
 	kube            client.Client
 	usage           resource.Tracker
-	newHttpClientFn func(log logging.Logger, timeout time.Duration) (httpClient.Client, error)
+	tracing         bool
+	newHttpClientFn func(log logging.Logger, timeout time.Duration, opts ...httpClient.Option) (httpClient.Client, error)
 }
 
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
@@ -105,7 +107,20 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errProviderNotRetrieved)
 	}
 
-	h, err := c.newHttpClientFn(l, utils.WaitTimeout(cr.Spec.ForProvider.WaitTimeout))
+	opts, err := utils.ProviderConfigOptions(ctx, c.kube, pc)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewHttpClient)
+	}
+	authOpts, err := utils.ProviderConfigAuthOptions(ctx, c.kube, pc)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewHttpClient)
+	}
+	opts = append(opts, authOpts...)
+	if c.tracing {
+		opts = append(opts, httpClient.WithTracing())
+	}
+
+	h, err := c.newHttpClientFn(l, utils.WaitTimeout(cr.Spec.ForProvider.WaitTimeout), opts...)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewHttpClient)
 	}
@@ -164,6 +179,9 @@ func (c *external) deployAction(ctx context.Context, cr *v1alpha1.DisposableRequ
 		LocalClient:    c.localKube,
 		HttpRequest:    details.HttpRequest,
 	}
+	// Only the status is redacted, the expected response is evaluated against the actual headers.
+	resource.HttpRequest.Headers = utils.RedactHeaders(details.HttpRequest.Headers, cr.Spec.ForProvider.RedactHeaders)
+	resource.HttpResponse.Headers = utils.RedactHeaders(details.HttpResponse.Headers, cr.Spec.ForProvider.RedactHeaders)
 
 	// Get the latest version of the resource before updating
 	if err := c.localKube.Get(ctx, types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, cr); err != nil {
@@ -183,7 +201,7 @@ func (c *external) deployAction(ctx context.Context, cr *v1alpha1.DisposableRequ
 			return errors.Wrap(settingError, utils.ErrFailedToSetStatus)
 		}
 
-		return errors.Errorf(utils.ErrStatusCode, cr.Spec.ForProvider.Method, strconv.Itoa(res.StatusCode))
+		return utils.StatusCodeError(cr.Spec.ForProvider.Method, res.StatusCode, res.Body)
 	}
 
 	isExpectedResponse, err := c.isResponseAsExpected(cr, res)
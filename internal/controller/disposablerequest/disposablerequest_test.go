@@ -279,6 +279,7 @@ func Test_deployAction(t *testing.T) {
 		err           error
 		failuresIndex int32
 		statusCode    int
+		headers       map[string][]string
 	}
 	type shouldCheckStatus struct {
 		condition bool
@@ -346,9 +347,10 @@ func Test_deployAction(t *testing.T) {
 				},
 			},
 			want: want{
-				err:           errors.Errorf(utils.ErrStatusCode, testMethod, strconv.Itoa(400)),
+				err:           errors.Errorf(utils.ErrStatusCodeBody, testMethod, strconv.Itoa(400), testBody),
 				failuresIndex: 1,
 				statusCode:    400,
+				headers:       testHeaders,
 			},
 			shouldCheckStatus: shouldCheckStatus{
 				condition: true,
@@ -386,6 +388,45 @@ func Test_deployAction(t *testing.T) {
 			want: want{
 				err:        nil,
 				statusCode: 200,
+				headers:    testHeaders,
+			},
+			shouldCheckStatus: shouldCheckStatus{
+				condition: true,
+			},
+		},
+		"SuccessRedactHeaders": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								StatusCode: 200,
+								Body:       testBody,
+								Headers:    map[string][]string{"Set-Cookie": {"session=s3cr3t"}, "X-Request-Id": {"42"}},
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				cr: &v1alpha1.DisposableRequest{
+					Spec: v1alpha1.DisposableRequestSpec{
+						ForProvider: v1alpha1.DisposableRequestParameters{
+							URL:           testURL,
+							Method:        testMethod,
+							Body:          testBody,
+							RedactHeaders: []string{"set-cookie"},
+						},
+					},
+					Status: v1alpha1.DisposableRequestStatus{},
+				},
+			},
+			want: want{
+				err:        nil,
+				statusCode: 200,
+				headers:    map[string][]string{"Set-Cookie": {"***"}, "X-Request-Id": {"42"}},
 			},
 			shouldCheckStatus: shouldCheckStatus{
 				condition: true,
@@ -422,7 +463,7 @@ func Test_deployAction(t *testing.T) {
 					t.Fatalf("deployAction(...): -want Status.Response.StatusCode, +got Status.Response.StatusCode: %s", diff)
 				}
 
-				if diff := cmp.Diff(tc.args.cr.Spec.ForProvider.Headers, tc.args.cr.Status.Response.Headers); diff != "" {
+				if diff := cmp.Diff(tc.want.headers, tc.args.cr.Status.Response.Headers); diff != "" {
 					t.Fatalf("deployAction(...): -want Status.Response.Headers, +got Status.Response.Headers: %s", diff)
 				}
 			}
@@ -0,0 +1,91 @@
+package request
+
+import (
+	ej "encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/responseconverter"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	"github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	msgAlreadyExists = "object already exists, adopting it: %s request answered with status code %d"
+
+	errAlreadyExistsIdentity = "cannot find the identity of the existing object in the response"
+
+	reasonAlreadyExists event.Reason = "AlreadyExists"
+)
+
+// adoptExisting records an event when the response to the create request of the
+// mapping means that the object already exists, and returns the response with the
+// identity of the existing object merged into its body.
+func (c *external) adoptExisting(cr *v1alpha1.Request, mapping *v1alpha1.Mapping, response httpClient.HttpResponse) (httpClient.HttpResponse, error) {
+	if cr.Spec.ForProvider.RoleOf(mapping.Method) != http.MethodPost {
+		return response, nil
+	}
+
+	if exists, err := utils.IsAlreadyExists(mapping.AlreadyExistsCheck, response); err != nil || !exists {
+		return response, nil
+	}
+	c.recorder.Event(cr, event.Normal(reasonAlreadyExists, fmt.Sprintf(msgAlreadyExists, mapping.Method, response.StatusCode)))
+
+	if mapping.AlreadyExistsCheck.Identity == "" {
+		return response, nil
+	}
+	return withIdentity(mapping.AlreadyExistsCheck.Identity, response)
+}
+
+// withIdentity merges the object returned by the identity expression, evaluated
+// against the response, into the body of the response. A body that isn't a JSON
+// object is replaced by it.
+func withIdentity(identity string, response httpClient.HttpResponse) (httpClient.HttpResponse, error) {
+	responseMap, err := json.StructToMap(responseconverter.HttpResponseToV1alpha1Response(response))
+	if err != nil {
+		return response, errors.Wrap(err, errAlreadyExistsIdentity)
+	}
+	json.ConvertJSONStringsToMaps(&responseMap)
+
+	fields, err := jq.ParseMapInterface(identity, responseMap)
+	if err != nil {
+		return response, errors.Wrap(err, errAlreadyExistsIdentity)
+	}
+
+	body := json.JsonStringToMap(response.Body)
+	if body == nil {
+		body = map[string]interface{}{}
+	}
+	for key, value := range fields {
+		body[key] = value
+	}
+
+	merged, err := ej.Marshal(body)
+	if err != nil {
+		return response, errors.Wrap(err, errAlreadyExistsIdentity)
+	}
+	response.Body = string(merged)
+	return response, nil
+}
+
+// isLastCreateAdopted reports whether the last request recorded in the status is a
+// create request answered with the object already existing.
+func (c *external) isLastCreateAdopted(cr *v1alpha1.Request) bool {
+	if cr.Spec.ForProvider.RoleOf(cr.Status.RequestDetails.Method) != http.MethodPost {
+		return false
+	}
+
+	mapping, ok, err := c.selectMapping(cr, http.MethodPost)
+	if err != nil || !ok {
+		return false
+	}
+
+	exists, err := utils.IsAlreadyExists(mapping.AlreadyExistsCheck, storedResponse(cr))
+	return err == nil && exists
+}
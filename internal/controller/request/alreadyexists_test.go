@@ -0,0 +1,175 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_httpExternal_Create_AlreadyExists(t *testing.T) {
+	type want struct {
+		failed   bool
+		reasons  []event.Reason
+		observed bool
+		// getURL is the URL of the GET request observing the object after the create.
+		getURL string
+	}
+	cases := map[string]struct {
+		check *v1alpha1.AlreadyExistsCheck
+		body  string
+		want  want
+	}{
+		"Adopted": {
+			check: &v1alpha1.AlreadyExistsCheck{},
+			want: want{
+				reasons:  []event.Reason{reasonAlreadyExists},
+				observed: true,
+				getURL:   "https://api.example.com/users/",
+			},
+		},
+		"AdoptedByCondition": {
+			check: &v1alpha1.AlreadyExistsCheck{StatusCodes: []int{422}, Condition: `.body.error == "duplicate"`},
+			body:  `{"error":"duplicate","existingId":"42"}`,
+			want: want{
+				reasons:  []event.Reason{reasonAlreadyExists},
+				observed: true,
+				getURL:   "https://api.example.com/users/",
+			},
+		},
+		"AdoptedWithIdentity": {
+			check: &v1alpha1.AlreadyExistsCheck{StatusCodes: []int{422}, Condition: `.body.error == "duplicate"`, Identity: "{id: .body.existingId}"},
+			body:  `{"error":"duplicate","existingId":"42"}`,
+			want: want{
+				reasons:  []event.Reason{reasonAlreadyExists},
+				observed: true,
+				getURL:   "https://api.example.com/users/42",
+			},
+		},
+		"NoCheck": {
+			want: want{
+				failed: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			recorder := &MockRecorder{}
+			getURL := ""
+			e := &external{
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						if method == http.MethodGet {
+							getURL = url
+							return httpClient.HttpDetails{
+								HttpRequest:  httpClient.HttpRequest{Method: method, URL: url},
+								HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusOK, Body: `{"id":"42","username":"john_doe","email":"john.doe@example.com"}`},
+							}, nil
+						}
+						statusCode := http.StatusConflict
+						if tc.body != "" {
+							statusCode = http.StatusUnprocessableEntity
+						}
+						return httpClient.HttpDetails{
+							HttpRequest:  httpClient.HttpRequest{Method: method, URL: url},
+							HttpResponse: httpClient.HttpResponse{StatusCode: statusCode, Body: tc.body},
+						}, nil
+					},
+				},
+				recorder: recorder,
+			}
+			cr := httpRequest(func(r *v1alpha1.Request) {
+				r.Status.Response = v1alpha1.Response{}
+				post := testPostMapping
+				post.AlreadyExistsCheck = tc.check
+				r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{post, testGetMapping, testPutMapping}
+			})
+
+			_, err := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.failed, err != nil); diff != "" {
+				t.Errorf("e.Create(...): -want error, +got error: %s: %v", diff, err)
+			}
+			if diff := cmp.Diff(tc.want.reasons, recorder.reasons); diff != "" {
+				t.Errorf("e.Create(...): -want event reasons, +got event reasons: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.observed, e.isObjectValidForObservation(cr)); diff != "" {
+				t.Errorf("isObjectValidForObservation(...): -want observed, +got observed: %s", diff)
+			}
+			if !tc.want.observed {
+				return
+			}
+
+			e.forgetObservations()
+			if _, err := e.Observe(context.Background(), cr); err != nil {
+				t.Fatalf("e.Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.getURL, getURL); diff != "" {
+				t.Errorf("e.Observe(...): -want GET URL, +got GET URL: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_withIdentity(t *testing.T) {
+	type want struct {
+		body string
+		err  bool
+	}
+	cases := map[string]struct {
+		identity string
+		body     string
+		want     want
+	}{
+		"MergedIntoBody": {
+			identity: "{id: .body.existingId}",
+			body:     `{"error":"duplicate","existingId":"42"}`,
+			want: want{
+				body: `{"error":"duplicate","existingId":"42","id":"42"}`,
+			},
+		},
+		"ReplacesNonJSONBody": {
+			identity: `{id: .headers.Location[0] | split("/") | last}`,
+			body:     "Conflict",
+			want: want{
+				body: `{"id":"42"}`,
+			},
+		},
+		"NotAnObject": {
+			identity: ".body.existingId",
+			body:     `{"existingId":"42"}`,
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := withIdentity(tc.identity, httpClient.HttpResponse{
+				StatusCode: http.StatusConflict,
+				Body:       tc.body,
+				Headers:    map[string][]string{"Location": {"/users/42"}},
+			})
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("withIdentity(...): -want error, +got error: %s: %v", diff, err)
+			}
+			if tc.want.err {
+				return
+			}
+			if diff := cmp.Diff(tc.want.body, got.Body); diff != "" {
+				t.Errorf("withIdentity(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
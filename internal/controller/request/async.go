@@ -0,0 +1,179 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errAsyncStatusURL = "cannot find the operation status URL in the 202 Accepted response"
+	errAsyncFailed    = "async operation failed with status code %d"
+	errAsyncTimedOut  = "async operation didn't complete within %s"
+	errAsyncCondition = "cannot evaluate the async success condition"
+	errAsyncOperation = "cannot complete the async create operation"
+
+	msgAsyncPending = "waiting for the async create operation to complete"
+
+	reasonAsyncFailed event.Reason = "AsyncOperationFailed"
+
+	headerLocation = "Location"
+
+	defaultAsyncPollInterval = 5 * time.Second
+	defaultAsyncMaxWait      = 5 * time.Minute
+)
+
+// isAwaitingAsyncOperation reports whether the last create request of the resource was
+// accepted with 202 Accepted, and its operation must still complete.
+func isAwaitingAsyncOperation(cr *v1alpha1.Request) bool {
+	return !meta.WasDeleted(cr) && cr.Spec.ForProvider.Async != nil &&
+		cr.Spec.ForProvider.RoleOf(cr.Status.RequestDetails.Method) == http.MethodPost &&
+		cr.Status.Response.StatusCode == http.StatusAccepted
+}
+
+// asyncPollInterval returns the time between the status requests of an operation.
+func asyncPollInterval(async *v1alpha1.AsyncOperation) time.Duration {
+	if async.PollInterval != nil {
+		return async.PollInterval.Duration
+	}
+	return defaultAsyncPollInterval
+}
+
+// pollAsyncOperation sends a single status request for the operation accepted by the
+// last create request, recorded in the status, and reports whether it completed. It
+// fails once the operation fails, or once it's been running for longer than the
+// configured max wait.
+func (c *external) pollAsyncOperation(ctx context.Context, cr *v1alpha1.Request) (bool, error) {
+	async := cr.Spec.ForProvider.Async
+
+	maxWait := defaultAsyncMaxWait
+	if async.MaxWait != nil {
+		maxWait = async.MaxWait.Duration
+	}
+	if started := cr.Status.LastRequestTime; started != nil && time.Since(started.Time) > maxWait {
+		return false, errors.Errorf(errAsyncTimedOut, maxWait)
+	}
+
+	statusURL, err := asyncStatusURL(async, httpClient.HttpDetails{
+		HttpRequest: httpClient.HttpRequest{URL: cr.Status.RequestDetails.URL},
+		HttpResponse: httpClient.HttpResponse{
+			StatusCode: cr.Status.Response.StatusCode,
+			Headers:    cr.Status.Response.Headers,
+			Body:       cr.Status.Response.Body,
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	mapping, ok, err := c.selectMapping(cr, http.MethodPost)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, errors.Errorf(errMappingNotFound, http.MethodPost)
+	}
+
+	requestDetails, err := c.generateValidRequestDetails(cr, mapping)
+	if err != nil {
+		return false, err
+	}
+
+	requestCtx, cancel := requestContext(ctx, mapping)
+	defer cancel()
+
+	details, err := c.http.SendRequest(requestCtx, http.MethodGet, statusURL, "", asyncPollHeaders(cr, requestDetails.Headers), insecureSkipTLSVerify(cr, mapping))
+	if err != nil {
+		return false, err
+	}
+
+	done, err := isAsyncOperationDone(async, details.HttpResponse)
+	if err == nil && !done {
+		c.logger.Debug("async operation is still in progress", "url", statusURL, "statusCode", details.HttpResponse.StatusCode)
+	}
+	return done, err
+}
+
+// asyncPollHeaders returns the headers of the create request without the ones only
+// meant for it, the idempotency key and the description of its body.
+func asyncPollHeaders(cr *v1alpha1.Request, headers map[string][]string) map[string][]string {
+	idempotencyHeader := defaultIdempotencyHeader
+	if idempotency := cr.Spec.ForProvider.Idempotency; idempotency != nil && idempotency.HeaderName != "" {
+		idempotencyHeader = idempotency.HeaderName
+	}
+	return withoutHeaders(headers, idempotencyHeader, "Content-Type", "Content-Length")
+}
+
+// asyncStatusURL returns the status URL of an accepted operation, resolved
+// against the URL of the request that started it.
+func asyncStatusURL(async *v1alpha1.AsyncOperation, accepted httpClient.HttpDetails) (string, error) {
+	statusURL := ""
+	if async.StatusURLPath == "" {
+		if values := accepted.HttpResponse.Headers[headerLocation]; len(values) > 0 {
+			statusURL = values[0]
+		}
+	} else if json.IsJSONString(accepted.HttpResponse.Body) {
+		values, found, err := json.QueryJSONPath(json.JsonStringToMap(accepted.HttpResponse.Body), async.StatusURLPath)
+		if err != nil {
+			return "", errors.Wrap(err, errAsyncStatusURL)
+		}
+		if found && len(values) == 1 {
+			statusURL = fmt.Sprint(values[0])
+		}
+	}
+
+	if statusURL == "" {
+		return "", errors.New(errAsyncStatusURL)
+	}
+
+	base, err := url.Parse(accepted.HttpRequest.URL)
+	if err != nil {
+		return "", errors.Wrap(err, errAsyncStatusURL)
+	}
+
+	ref, err := url.Parse(statusURL)
+	if err != nil {
+		return "", errors.Wrap(err, errAsyncStatusURL)
+	}
+
+	return base.ResolveReference(ref).String(), nil
+}
+
+// isAsyncOperationDone reports whether the status response shows a completed
+// operation, and fails on error status codes.
+func isAsyncOperationDone(async *v1alpha1.AsyncOperation, response httpClient.HttpResponse) (bool, error) {
+	if utils.IsHTTPError(response.StatusCode) {
+		return false, errors.Errorf(errAsyncFailed, response.StatusCode)
+	}
+
+	if len(async.SuccessStatusCodes) > 0 {
+		if !slices.Contains(async.SuccessStatusCodes, response.StatusCode) {
+			return false, nil
+		}
+	} else if !utils.IsHTTPSuccess(response.StatusCode) || response.StatusCode == http.StatusAccepted {
+		return false, nil
+	}
+
+	if async.SuccessCondition == "" {
+		return true, nil
+	}
+
+	done, err := isResponseMatching(async.SuccessCondition, response)
+	if err != nil {
+		return false, errors.Wrap(err, errAsyncCondition)
+	}
+
+	return done, nil
+}
@@ -0,0 +1,392 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_asyncStatusURL(t *testing.T) {
+	type args struct {
+		async    *v1alpha1.AsyncOperation
+		accepted httpClient.HttpDetails
+	}
+	type want struct {
+		url string
+		err error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"LocationHeader": {
+			args: args{
+				async: &v1alpha1.AsyncOperation{},
+				accepted: httpClient.HttpDetails{
+					HttpRequest:  httpClient.HttpRequest{URL: "https://api.example.com/users"},
+					HttpResponse: httpClient.HttpResponse{StatusCode: 202, Headers: map[string][]string{"Location": {"/operations/1"}}},
+				},
+			},
+			want: want{
+				url: "https://api.example.com/operations/1",
+			},
+		},
+		"StatusURLPath": {
+			args: args{
+				async: &v1alpha1.AsyncOperation{StatusURLPath: "$.links.status"},
+				accepted: httpClient.HttpDetails{
+					HttpRequest:  httpClient.HttpRequest{URL: "https://api.example.com/users"},
+					HttpResponse: httpClient.HttpResponse{StatusCode: 202, Body: `{"links":{"status":"https://ops.example.com/1"}}`},
+				},
+			},
+			want: want{
+				url: "https://ops.example.com/1",
+			},
+		},
+		"MissingStatusURL": {
+			args: args{
+				async: &v1alpha1.AsyncOperation{StatusURLPath: "$.links.status"},
+				accepted: httpClient.HttpDetails{
+					HttpRequest:  httpClient.HttpRequest{URL: "https://api.example.com/users"},
+					HttpResponse: httpClient.HttpResponse{StatusCode: 202, Body: `{"id":"1"}`},
+				},
+			},
+			want: want{
+				err: errors.New(errAsyncStatusURL),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := asyncStatusURL(tc.args.async, tc.args.accepted)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("asyncStatusURL(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.url, got); diff != "" {
+				t.Fatalf("asyncStatusURL(...): -want url, +got url: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_isAsyncOperationDone(t *testing.T) {
+	type args struct {
+		async    *v1alpha1.AsyncOperation
+		response httpClient.HttpResponse
+	}
+	type want struct {
+		done bool
+		err  error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"StillAccepted": {
+			args: args{
+				async:    &v1alpha1.AsyncOperation{},
+				response: httpClient.HttpResponse{StatusCode: 202},
+			},
+			want: want{
+				done: false,
+			},
+		},
+		"DefaultSuccess": {
+			args: args{
+				async:    &v1alpha1.AsyncOperation{},
+				response: httpClient.HttpResponse{StatusCode: 200},
+			},
+			want: want{
+				done: true,
+			},
+		},
+		"SuccessStatusCodes": {
+			args: args{
+				async:    &v1alpha1.AsyncOperation{SuccessStatusCodes: []int{303}},
+				response: httpClient.HttpResponse{StatusCode: 200},
+			},
+			want: want{
+				done: false,
+			},
+		},
+		"SuccessConditionPending": {
+			args: args{
+				async:    &v1alpha1.AsyncOperation{SuccessCondition: `.body.status == "succeeded"`},
+				response: httpClient.HttpResponse{StatusCode: 200, Body: `{"status":"running"}`},
+			},
+			want: want{
+				done: false,
+			},
+		},
+		"SuccessConditionMet": {
+			args: args{
+				async:    &v1alpha1.AsyncOperation{SuccessCondition: `.body.status == "succeeded"`},
+				response: httpClient.HttpResponse{StatusCode: 200, Body: `{"status":"succeeded"}`},
+			},
+			want: want{
+				done: true,
+			},
+		},
+		"ErrorStatusCode": {
+			args: args{
+				async:    &v1alpha1.AsyncOperation{},
+				response: httpClient.HttpResponse{StatusCode: 500},
+			},
+			want: want{
+				err: errors.Errorf(errAsyncFailed, 500),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := isAsyncOperationDone(tc.args.async, tc.args.response)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("isAsyncOperationDone(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.done, got); diff != "" {
+				t.Fatalf("isAsyncOperationDone(...): -want done, +got done: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_pollAsyncOperation(t *testing.T) {
+	type args struct {
+		status  int
+		started time.Time
+	}
+	type want struct {
+		polled bool
+		done   bool
+		err    error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"InProgress": {
+			args: args{
+				status:  202,
+				started: time.Now(),
+			},
+			want: want{
+				polled: true,
+			},
+		},
+		"Completed": {
+			args: args{
+				status:  200,
+				started: time.Now(),
+			},
+			want: want{
+				polled: true,
+				done:   true,
+			},
+		},
+		"Failed": {
+			args: args{
+				status:  500,
+				started: time.Now(),
+			},
+			want: want{
+				polled: true,
+				err:    errors.Errorf(errAsyncFailed, 500),
+			},
+		},
+		"TimesOut": {
+			args: args{
+				status:  202,
+				started: time.Now().Add(-10 * time.Minute),
+			},
+			want: want{
+				err: errors.Errorf(errAsyncTimedOut, 5*time.Minute),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			polled := false
+			e := &external{
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						polled = true
+						if method != http.MethodGet || url != "https://api.example.com/operations/1" {
+							t.Fatalf("unexpected %s request to %s", method, url)
+						}
+						// Only the headers that aren't meant for the create request are sent.
+						if diff := cmp.Diff(map[string][]string{"Authorization": {"Bearer token"}}, headers); diff != "" {
+							t.Fatalf("unexpected headers of the status request: -want, +got: %s", diff)
+						}
+						return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: tc.args.status}}, nil
+					},
+				},
+			}
+			cr := httpRequest(func(r *v1alpha1.Request) {
+				r.Spec.ForProvider.Headers = map[string][]string{
+					"Authorization":   {"Bearer token"},
+					"content-type":    {"application/json"},
+					"Idempotency-Key": {"key"},
+				}
+				r.Spec.ForProvider.Async = &v1alpha1.AsyncOperation{}
+				r.Status.RequestDetails = v1alpha1.Mapping{Method: http.MethodPost, URL: "https://api.example.com/users"}
+				r.Status.Response = v1alpha1.Response{StatusCode: 202, Headers: map[string][]string{"Location": {"/operations/1"}}}
+				r.Status.LastRequestTime = &v1.Time{Time: tc.args.started}
+			})
+
+			got, gotErr := e.pollAsyncOperation(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("pollAsyncOperation(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.done, got); diff != "" {
+				t.Errorf("pollAsyncOperation(...): -want done, +got done: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.polled, polled); diff != "" {
+				t.Errorf("pollAsyncOperation(...): -want polled, +got polled: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_isAwaitingAsyncOperation(t *testing.T) {
+	cases := map[string]struct {
+		cr   *v1alpha1.Request
+		want bool
+	}{
+		"Accepted": {
+			cr: httpRequest(func(r *v1alpha1.Request) {
+				r.Spec.ForProvider.Async = &v1alpha1.AsyncOperation{}
+				r.Status.RequestDetails.Method = http.MethodPost
+				r.Status.Response.StatusCode = 202
+			}),
+			want: true,
+		},
+		"Created": {
+			cr: httpRequest(func(r *v1alpha1.Request) {
+				r.Spec.ForProvider.Async = &v1alpha1.AsyncOperation{}
+				r.Status.RequestDetails.Method = http.MethodPost
+				r.Status.Response.StatusCode = 201
+			}),
+			want: false,
+		},
+		"Observed": {
+			cr: httpRequest(func(r *v1alpha1.Request) {
+				r.Spec.ForProvider.Async = &v1alpha1.AsyncOperation{}
+				r.Status.RequestDetails.Method = http.MethodGet
+				r.Status.Response.StatusCode = 202
+			}),
+			want: false,
+		},
+		"NotAsync": {
+			cr: httpRequest(func(r *v1alpha1.Request) {
+				r.Status.RequestDetails.Method = http.MethodPost
+				r.Status.Response.StatusCode = 202
+			}),
+			want: false,
+		},
+		"BeingDeleted": {
+			cr: httpRequest(func(r *v1alpha1.Request) {
+				r.Spec.ForProvider.Async = &v1alpha1.AsyncOperation{}
+				r.Status.RequestDetails.Method = http.MethodPost
+				r.Status.Response.StatusCode = 202
+				r.SetDeletionTimestamp(&v1.Time{Time: time.Now()})
+			}),
+			want: false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, isAwaitingAsyncOperation(tc.cr)); diff != "" {
+				t.Errorf("isAwaitingAsyncOperation(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_httpExternal_Observe_AsyncOperation(t *testing.T) {
+	type want struct {
+		observation managed.ExternalObservation
+		err         error
+		reasons     []event.Reason
+		observed    xpv1.ConditionReason
+	}
+	cases := map[string]struct {
+		status int
+		body   string
+		want   want
+	}{
+		"InProgress": {
+			status: 202,
+			want: want{
+				observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				observed:    v1alpha1.ReasonPending,
+			},
+		},
+		"CompletedWithoutBody": {
+			status: 200,
+			body:   `{"username":"john_doe_new_username"}`,
+			want: want{
+				observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				observed:    v1alpha1.ReasonUpToDate,
+			},
+		},
+		"Failed": {
+			status: 500,
+			want: want{
+				err:      errors.Wrap(errors.Errorf(errAsyncFailed, 500), errAsyncOperation),
+				reasons:  []event.Reason{reasonAsyncFailed},
+				observed: v1alpha1.ReasonObserveFailed,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			recorder := &MockRecorder{}
+			e := &external{
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: tc.status, Body: tc.body}}, nil
+					},
+				},
+				recorder: recorder,
+			}
+			cr := httpRequest(func(r *v1alpha1.Request) {
+				r.Spec.ForProvider.Async = &v1alpha1.AsyncOperation{}
+				r.Status.RequestDetails = v1alpha1.Mapping{Method: http.MethodPost, URL: "https://api.example.com/users"}
+				r.Status.Response = v1alpha1.Response{StatusCode: 202, Headers: map[string][]string{"Location": {"/operations/1"}}}
+			})
+
+			got, err := e.Observe(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("e.Observe(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.observation, got); diff != "" {
+				t.Errorf("e.Observe(...): -want, +got: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.reasons, recorder.reasons); diff != "" {
+				t.Errorf("e.Observe(...): -want events, +got events: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.observed, cr.GetCondition(v1alpha1.TypeObserved).Reason); diff != "" {
+				t.Errorf("e.Observe(...): -want Observed reason, +got Observed reason: %s", diff)
+			}
+		})
+	}
+}
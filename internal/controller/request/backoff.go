@@ -0,0 +1,76 @@
+package request
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	reasonBackingOff event.Reason = "BackingOff"
+	msgBackingOff                 = "retrying in %s after %d consecutive failures"
+
+	defaultInitialBackoff = 10 * time.Second
+	defaultMaxBackoff     = 10 * time.Minute
+)
+
+// failureBackoff is an exponential backoff between the retries of failed creates
+// and updates.
+type failureBackoff struct {
+	initial time.Duration
+	max     time.Duration
+}
+
+// newFailureBackoff returns the failure backoff configured by the ProviderConfig.
+func newFailureBackoff(pc *apisv1alpha1.ProviderConfig) failureBackoff {
+	b := failureBackoff{initial: defaultInitialBackoff, max: defaultMaxBackoff}
+	if pc.Spec.FailureBackoff == nil {
+		return b
+	}
+	if pc.Spec.FailureBackoff.Initial != nil {
+		b.initial = pc.Spec.FailureBackoff.Initial.Duration
+	}
+	if pc.Spec.FailureBackoff.Max != nil {
+		b.max = pc.Spec.FailureBackoff.Max.Duration
+	}
+	return b
+}
+
+// after returns the wait after the given number of consecutive failures.
+func (b failureBackoff) after(failures int32) time.Duration {
+	wait := b.initial
+	for i := int32(1); i < failures && wait < b.max; i++ {
+		wait *= 2
+	}
+	if wait > b.max {
+		return b.max
+	}
+	return wait
+}
+
+// backOff records how long to wait before retrying the create or update that failed
+// with the error, so that the Request is requeued after it. A rate limited request
+// waits for the API instead.
+func (c *external) backOff(cr *v1alpha1.Request, err error) {
+	var rateLimited *utils.RateLimitedError
+	if errors.As(err, &rateLimited) || c.failureBackoff.initial <= 0 {
+		return
+	}
+
+	// Failures that happen before a request is sent aren't counted.
+	failures := cr.Status.Failed
+	if failures < 1 {
+		failures = 1
+	}
+
+	wait := c.failureBackoff.after(failures)
+	cr.Status.RetryBackoff = &metav1.Duration{Duration: wait}
+	c.recorder.Event(cr, event.Normal(reasonBackingOff, fmt.Sprintf(msgBackingOff, wait, failures)))
+}
@@ -0,0 +1,119 @@
+package request
+
+import (
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+func Test_failureBackoff_after(t *testing.T) {
+	cases := map[string]struct {
+		backoff  *apisv1alpha1.FailureBackoff
+		failures int32
+		want     time.Duration
+	}{
+		"FirstFailure": {
+			failures: 1,
+			want:     defaultInitialBackoff,
+		},
+		"Doubled": {
+			failures: 3,
+			want:     4 * defaultInitialBackoff,
+		},
+		"Capped": {
+			failures: 100,
+			want:     defaultMaxBackoff,
+		},
+		"Configured": {
+			backoff: &apisv1alpha1.FailureBackoff{
+				Initial: &metav1.Duration{Duration: time.Minute},
+				Max:     &metav1.Duration{Duration: 3 * time.Minute},
+			},
+			failures: 3,
+			want:     3 * time.Minute,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pc := &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{FailureBackoff: tc.backoff}}
+			if diff := cmp.Diff(tc.want, newFailureBackoff(pc).after(tc.failures)); diff != "" {
+				t.Errorf("after(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_backOff(t *testing.T) {
+	type args struct {
+		backoff  failureBackoff
+		failures int32
+		err      error
+	}
+	type want struct {
+		retryBackoff *metav1.Duration
+		reasons      []event.Reason
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Failed": {
+			args: args{
+				backoff:  failureBackoff{initial: 10 * time.Second, max: time.Minute},
+				failures: 2,
+				err:      errBoom,
+			},
+			want: want{
+				retryBackoff: &metav1.Duration{Duration: 20 * time.Second},
+				reasons:      []event.Reason{reasonBackingOff},
+			},
+		},
+		"FailedBeforeSending": {
+			args: args{
+				backoff: failureBackoff{initial: 10 * time.Second, max: time.Minute},
+				err:     errBoom,
+			},
+			want: want{
+				retryBackoff: &metav1.Duration{Duration: 10 * time.Second},
+				reasons:      []event.Reason{reasonBackingOff},
+			},
+		},
+		"RateLimited": {
+			args: args{
+				backoff:  failureBackoff{initial: 10 * time.Second, max: time.Minute},
+				failures: 2,
+				err:      errors.Wrap(&utils.RateLimitedError{}, errFailedToSendHttpRequest),
+			},
+		},
+		"Disabled": {
+			args: args{
+				failures: 2,
+				err:      errBoom,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			recorder := &MockRecorder{}
+			e := &external{recorder: recorder, failureBackoff: tc.args.backoff}
+			cr := httpRequest(func(r *v1alpha1.Request) {
+				r.Status.Failed = tc.args.failures
+			})
+			e.backOff(cr, tc.args.err)
+			if diff := cmp.Diff(tc.want.retryBackoff, cr.Status.RetryBackoff); diff != "" {
+				t.Errorf("backOff(...): -want Status.RetryBackoff, +got Status.RetryBackoff: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.reasons, recorder.reasons); diff != "" {
+				t.Errorf("backOff(...): -want event reasons, +got event reasons: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package request
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errBodyFrom    = "cannot get the body of the %s mapping"
+	errBodyFromRef = "bodyFrom of the %s mapping must reference either a secret or a config map key"
+)
+
+// mappingBodies resolves the bodies of the mappings referencing a Secret or a
+// ConfigMap, by the method of their role.
+func mappingBodies(ctx context.Context, kube client.Client, mappings []v1alpha1.Mapping) (map[string]string, error) {
+	bodies := map[string]string{}
+	for _, mapping := range mappings {
+		source := mapping.BodyFrom
+		if source == nil {
+			continue
+		}
+
+		if (source.SecretKeyRef == nil) == (source.ConfigMapKeyRef == nil) {
+			return nil, errors.Errorf(errBodyFromRef, mapping.Method)
+		}
+
+		body, err := partValue(ctx, kube, v1alpha1.PartValueSource{SecretKeyRef: source.SecretKeyRef, ConfigMapKeyRef: source.ConfigMapKeyRef})
+		if err != nil {
+			return nil, errors.Wrapf(err, errBodyFrom, mapping.Method)
+		}
+		bodies[mapping.RoleMethod()] = body
+	}
+
+	return bodies, nil
+}
+
+// statusBody returns the body of a request of the mapping as it's kept in the
+// status, masked when it comes from a Secret.
+func statusBody(mapping *v1alpha1.Mapping, body string) string {
+	if mapping.BodyFrom != nil && mapping.BodyFrom.SecretKeyRef != nil {
+		return json_util.RedactedPlaceholder
+	}
+	return body
+}
+
+// hasUpload reports whether a mapping of the Request uploads content in chunks.
+func hasUpload(cr *v1alpha1.Request) bool {
+	for _, mapping := range cr.Spec.ForProvider.Mappings {
+		if mapping.Upload != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// logRedaction returns the redaction of the requests of the Request before they're
+// logged: like in the status, the bodies of the mappings from a Secret are masked,
+// and the configured headers and secret fields are redacted. A body that can't be
+// redacted is masked as a whole, and so are the chunks of an upload, only logged
+// with their byte range.
+func logRedaction(cr *v1alpha1.Request) func(httpClient.HttpRequest) httpClient.HttpRequest {
+	return func(request httpClient.HttpRequest) httpClient.HttpRequest {
+		request.Headers = utils.RedactHeaders(request.Headers, cr.Spec.ForProvider.RedactHeaders)
+		if _, chunk := request.Headers[headerContentRange]; chunk && hasUpload(cr) {
+			request.Body = json_util.RedactedPlaceholder
+			return request
+		}
+
+		for i := range cr.Spec.ForProvider.Mappings {
+			if mapping := &cr.Spec.ForProvider.Mappings[i]; mapping.Method == request.Method && statusBody(mapping, request.Body) != request.Body {
+				request.Body = json_util.RedactedPlaceholder
+				return request
+			}
+		}
+
+		body, err := json_util.RedactJSONString(request.Body, cr.Spec.ForProvider.SecretFields)
+		if err != nil {
+			body = json_util.RedactedPlaceholder
+		}
+		request.Body = body
+		return request
+	}
+}
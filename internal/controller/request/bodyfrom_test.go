@@ -0,0 +1,151 @@
+package request
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_mappingBodies(t *testing.T) {
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *corev1.Secret:
+				o.Data = map[string][]byte{"cert": []byte("-----BEGIN CERTIFICATE-----")}
+			case *corev1.ConfigMap:
+				o.Data = map[string]string{"policy": `{"statements":["read"]}`}
+			}
+			return nil
+		},
+	}
+	secretRef := &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "certs", Namespace: "default"}, Key: "cert"}
+	configMapRef := &v1alpha1.ConfigMapKeySelector{Name: "policies", Namespace: "default", Key: "policy"}
+
+	type want struct {
+		bodies map[string]string
+		err    error
+	}
+	cases := map[string]struct {
+		mappings []v1alpha1.Mapping
+		want     want
+	}{
+		"NoBodyFrom": {
+			mappings: []v1alpha1.Mapping{testPostMapping, testGetMapping},
+			want: want{
+				bodies: map[string]string{},
+			},
+		},
+		"ByRole": {
+			mappings: []v1alpha1.Mapping{
+				{Method: "POST", BodyFrom: &v1alpha1.BodySource{SecretKeyRef: secretRef}},
+				{Method: "MERGE", Action: v1alpha1.ActionUpdate, BodyFrom: &v1alpha1.BodySource{ConfigMapKeyRef: configMapRef}},
+			},
+			want: want{
+				bodies: map[string]string{
+					"POST": "-----BEGIN CERTIFICATE-----",
+					"PUT":  `{"statements":["read"]}`,
+				},
+			},
+		},
+		"BothReferences": {
+			mappings: []v1alpha1.Mapping{
+				{Method: "POST", BodyFrom: &v1alpha1.BodySource{SecretKeyRef: secretRef, ConfigMapKeyRef: configMapRef}},
+			},
+			want: want{
+				err: errors.Errorf(errBodyFromRef, "POST"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := mappingBodies(context.Background(), kube, tc.mappings)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("mappingBodies(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.bodies, got); diff != "" {
+				t.Errorf("mappingBodies(...): -want bodies, +got bodies: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_statusBody(t *testing.T) {
+	cases := map[string]struct {
+		mapping *v1alpha1.Mapping
+		want    string
+	}{
+		"Body": {
+			mapping: &testPutMapping,
+			want:    `{"username":"john_doe"}`,
+		},
+		"BodyFromConfigMap": {
+			mapping: &v1alpha1.Mapping{Method: "PUT", BodyFrom: &v1alpha1.BodySource{ConfigMapKeyRef: &v1alpha1.ConfigMapKeySelector{Key: "policy"}}},
+			want:    `{"username":"john_doe"}`,
+		},
+		"BodyFromSecret": {
+			mapping: &v1alpha1.Mapping{Method: "PUT", BodyFrom: &v1alpha1.BodySource{SecretKeyRef: &xpv1.SecretKeySelector{Key: "policy"}}},
+			want:    "***",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, statusBody(tc.mapping, `{"username":"john_doe"}`)); diff != "" {
+				t.Errorf("statusBody(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_logRedaction(t *testing.T) {
+	cases := map[string]struct {
+		forProvider v1alpha1.RequestParameters
+		request     httpClient.HttpRequest
+		want        httpClient.HttpRequest
+	}{
+		"Unchanged": {
+			forProvider: v1alpha1.RequestParameters{Mappings: []v1alpha1.Mapping{testPutMapping}},
+			request:     httpClient.HttpRequest{Method: "PUT", Body: `{"username":"john_doe"}`},
+			want:        httpClient.HttpRequest{Method: "PUT", Body: `{"username":"john_doe"}`},
+		},
+		"SecretFieldsAndHeaders": {
+			forProvider: v1alpha1.RequestParameters{
+				Mappings:      []v1alpha1.Mapping{testPutMapping},
+				SecretFields:  []string{"$.password"},
+				RedactHeaders: []string{"X-Api-Key"},
+			},
+			request: httpClient.HttpRequest{Method: "PUT", Body: `{"password":"s3cr3t","username":"john_doe"}`, Headers: map[string][]string{"X-Api-Key": {"key"}}},
+			want:    httpClient.HttpRequest{Method: "PUT", Body: `{"password":"***","username":"john_doe"}`, Headers: map[string][]string{"X-Api-Key": {"***"}}},
+		},
+		"BodyFromSecret": {
+			forProvider: v1alpha1.RequestParameters{Mappings: []v1alpha1.Mapping{
+				{Method: "PUT", BodyFrom: &v1alpha1.BodySource{SecretKeyRef: &xpv1.SecretKeySelector{Key: "policy"}}},
+			}},
+			request: httpClient.HttpRequest{Method: "PUT", Body: `{"username":"john_doe"}`},
+			want:    httpClient.HttpRequest{Method: "PUT", Body: "***"},
+		},
+		"UploadChunk": {
+			forProvider: v1alpha1.RequestParameters{Mappings: []v1alpha1.Mapping{
+				{Method: "POST", Upload: &v1alpha1.ChunkedUpload{UploadURL: ".headers.Location[0]"}},
+			}},
+			request: httpClient.HttpRequest{Method: "PUT", Body: "0123", Headers: map[string][]string{"Content-Range": {"bytes 0-3/10"}}},
+			want:    httpClient.HttpRequest{Method: "PUT", Body: "***", Headers: map[string][]string{"Content-Range": {"bytes 0-3/10"}}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := logRedaction(&v1alpha1.Request{Spec: v1alpha1.RequestSpec{ForProvider: tc.forProvider}})(tc.request)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("logRedaction(...): -want request, +got request: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package request
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+const (
+	reasonCertificateExpiring event.Reason = "CertificateExpiring"
+	msgCertificateExpiring                 = "TLS certificate of %s expires at %s, in %s"
+
+	defaultCertificateExpiryWarning = 30 * 24 * time.Hour
+)
+
+// certificateExpiryWarning returns how long before the certificate of an API expires
+// that the Requests using the ProviderConfig warn about it.
+func certificateExpiryWarning(pc *apisv1alpha1.ProviderConfig) time.Duration {
+	if pc.Spec.CertificateExpiryWarning == nil {
+		return defaultCertificateExpiryWarning
+	}
+	return pc.Spec.CertificateExpiryWarning.Duration
+}
+
+// warnCertificateExpiry records a warning event when the certificate presented with
+// the response expires within the warning window. Responses received without TLS
+// aren't checked.
+func (c *external) warnCertificateExpiry(cr *v1alpha1.Request, details httpClient.HttpDetails) {
+	expiry := details.HttpResponse.CertificateExpiry
+	if c.certificateExpiryWarning <= 0 || expiry.IsZero() {
+		return
+	}
+
+	remaining := time.Until(expiry)
+	if remaining > c.certificateExpiryWarning {
+		return
+	}
+
+	host := details.HttpRequest.URL
+	if u, err := url.Parse(details.HttpRequest.URL); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	c.recorder.Event(cr, event.Warning(reasonCertificateExpiring, errors.Errorf(msgCertificateExpiring, host, expiry.UTC().Format(time.RFC3339), remaining.Truncate(time.Minute))))
+}
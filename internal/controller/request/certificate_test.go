@@ -0,0 +1,93 @@
+package request
+
+import (
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_certificateExpiryWarning(t *testing.T) {
+	cases := map[string]struct {
+		warning *metav1.Duration
+		want    time.Duration
+	}{
+		"Default": {
+			want: defaultCertificateExpiryWarning,
+		},
+		"Configured": {
+			warning: &metav1.Duration{Duration: 7 * 24 * time.Hour},
+			want:    7 * 24 * time.Hour,
+		},
+		"Disabled": {
+			warning: &metav1.Duration{},
+			want:    0,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pc := &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{CertificateExpiryWarning: tc.warning}}
+			if diff := cmp.Diff(tc.want, certificateExpiryWarning(pc)); diff != "" {
+				t.Errorf("certificateExpiryWarning(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_warnCertificateExpiry(t *testing.T) {
+	type args struct {
+		warning time.Duration
+		expiry  time.Time
+	}
+	type want struct {
+		reasons []event.Reason
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ExpiresSoon": {
+			args: args{
+				warning: defaultCertificateExpiryWarning,
+				expiry:  time.Now().Add(72 * time.Hour),
+			},
+			want: want{
+				reasons: []event.Reason{reasonCertificateExpiring},
+			},
+		},
+		"ExpiresLater": {
+			args: args{
+				warning: defaultCertificateExpiryWarning,
+				expiry:  time.Now().Add(90 * 24 * time.Hour),
+			},
+		},
+		"WithoutTLS": {
+			args: args{
+				warning: defaultCertificateExpiryWarning,
+			},
+		},
+		"Disabled": {
+			args: args{
+				expiry: time.Now().Add(time.Hour),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			recorder := &MockRecorder{}
+			e := &external{recorder: recorder, certificateExpiryWarning: tc.args.warning}
+			e.warnCertificateExpiry(httpRequest(), httpClient.HttpDetails{
+				HttpRequest:  httpClient.HttpRequest{URL: "https://api.example.com/users/123"},
+				HttpResponse: httpClient.HttpResponse{StatusCode: 200, CertificateExpiry: tc.args.expiry},
+			})
+			if diff := cmp.Diff(tc.want.reasons, recorder.reasons); diff != "" {
+				t.Errorf("warnCertificateExpiry(...): -want event reasons, +got event reasons: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package request
+
+import (
+	"context"
+	"net/http"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errBasicAuthUsername  = "cannot get basic auth username"
+	errBasicAuthPassword  = "cannot get basic auth password"
+	errHMACKey            = "cannot get HMAC signing key"
+	errOAuth2ClientID     = "cannot get OAuth2 client ID"
+	errOAuth2ClientSecret = "cannot get OAuth2 client secret"
+	errKerberosKeytab     = "cannot get Kerberos keytab"
+	errKerberosConfig     = "cannot get Kerberos configuration"
+	errHeaderFromSecret   = "cannot get value of header %s"
+	errSessionLogin       = "cannot generate the login request"
+	errTLSCACert          = "cannot get CA bundle"
+	errTLSClientCert      = "cannot get client certificate"
+	errTLSClientKey       = "cannot get client private key"
+
+	tlsCertKey = "tls.crt"
+	tlsKeyKey  = "tls.key"
+
+	headerAuthorization = "Authorization"
+)
+
+// hasOwnAuth reports whether the Request authorizes its requests itself, with
+// credentials of its own or an Authorization header, in which case none of the
+// ProviderConfig's authentication applies.
+func hasOwnAuth(cr *v1alpha1.Request) bool {
+	forProvider := &cr.Spec.ForProvider
+	if forProvider.BasicAuth != nil || forProvider.HMAC != nil || forProvider.OAuth2 != nil || forProvider.Kerberos != nil {
+		return true
+	}
+
+	for name := range forProvider.HeadersFromSecret {
+		if http.CanonicalHeaderKey(name) == headerAuthorization {
+			return true
+		}
+	}
+	if hasAuthorizationHeader(forProvider.Headers) {
+		return true
+	}
+	for _, mapping := range forProvider.Mappings {
+		if hasAuthorizationHeader(mapping.Headers) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAuthorizationHeader(headers map[string][]string) bool {
+	for name := range headers {
+		if http.CanonicalHeaderKey(name) == headerAuthorization {
+			return true
+		}
+	}
+	return false
+}
+
+// clientOptions resolves the Request's client configuration, including any
+// referenced secrets, into options for the Http client.
+func clientOptions(ctx context.Context, kube client.Client, cr *v1alpha1.Request) ([]httpClient.Option, error) {
+	var opts []httpClient.Option
+
+	if basicAuth := cr.Spec.ForProvider.BasicAuth; basicAuth != nil {
+		username, err := utils.GetSecretValue(ctx, kube, basicAuth.UsernameSecretRef)
+		if err != nil {
+			return nil, errors.Wrap(err, errBasicAuthUsername)
+		}
+
+		password, err := utils.GetSecretValue(ctx, kube, basicAuth.PasswordSecretRef)
+		if err != nil {
+			return nil, errors.Wrap(err, errBasicAuthPassword)
+		}
+
+		opts = append(opts, httpClient.WithBasicAuth(httpClient.BasicAuth{Username: username, Password: password}))
+	}
+
+	if hmac := cr.Spec.ForProvider.HMAC; hmac != nil {
+		key, err := utils.GetSecretValue(ctx, kube, hmac.SecretRef)
+		if err != nil {
+			return nil, errors.Wrap(err, errHMACKey)
+		}
+
+		opts = append(opts, httpClient.WithHMAC(httpClient.HMAC{
+			Key:       []byte(key),
+			Header:    hmac.Header,
+			Algorithm: hmac.Algorithm,
+			Encoding:  hmac.Encoding,
+			Prefix:    hmac.Prefix,
+		}))
+	}
+
+	if oauth2 := cr.Spec.ForProvider.OAuth2; oauth2 != nil {
+		clientID, err := utils.GetSecretValue(ctx, kube, oauth2.ClientIDSecretRef)
+		if err != nil {
+			return nil, errors.Wrap(err, errOAuth2ClientID)
+		}
+
+		clientSecret, err := utils.GetSecretValue(ctx, kube, oauth2.ClientSecretSecretRef)
+		if err != nil {
+			return nil, errors.Wrap(err, errOAuth2ClientSecret)
+		}
+
+		cfg := httpClient.OAuth2Config{
+			TokenURL:     oauth2.TokenURL,
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			Scopes:       oauth2.Scopes,
+		}
+		if oauth2.DefaultTokenTTL != nil {
+			cfg.DefaultTTL = oauth2.DefaultTokenTTL.Duration
+		}
+
+		opts = append(opts, httpClient.WithOAuth2(cfg))
+	}
+
+	if kerberos := cr.Spec.ForProvider.Kerberos; kerberos != nil {
+		keytab, err := utils.GetSecretValue(ctx, kube, kerberos.KeytabSecretRef)
+		if err != nil {
+			return nil, errors.Wrap(err, errKerberosKeytab)
+		}
+
+		config, err := utils.GetSecretValue(ctx, kube, kerberos.ConfigSecretRef)
+		if err != nil {
+			return nil, errors.Wrap(err, errKerberosConfig)
+		}
+
+		opts = append(opts, httpClient.WithKerberos(httpClient.Kerberos{
+			Username: kerberos.Username,
+			Realm:    kerberos.Realm,
+			Keytab:   []byte(keytab),
+			Config:   config,
+			SPN:      kerberos.SPN,
+		}))
+	}
+
+	if len(cr.Spec.ForProvider.HeadersFromSecret) > 0 {
+		headers := make(map[string]string, len(cr.Spec.ForProvider.HeadersFromSecret))
+		for name, ref := range cr.Spec.ForProvider.HeadersFromSecret {
+			value, err := utils.GetSecretValue(ctx, kube, ref)
+			if err != nil {
+				return nil, errors.Wrapf(err, errHeaderFromSecret, name)
+			}
+			headers[name] = value
+		}
+
+		opts = append(opts, httpClient.WithSecretHeaders(headers))
+	}
+
+	if session := cr.Spec.ForProvider.Session; session != nil {
+		httpSession := httpClient.Session{}
+		if login := session.Login; login != nil {
+			requestDetails, err, ok := requestgen.GenerateRequestDetails(*login, cr.Spec.ForProvider, cr.Status.Response)
+			if err != nil {
+				return nil, errors.Wrap(err, errSessionLogin)
+			}
+			if !ok || !requestgen.IsRequestValid(requestDetails) {
+				return nil, errors.New(errSessionLogin)
+			}
+
+			httpSession.Login = &httpClient.HttpRequest{
+				Method:  login.Method,
+				URL:     requestDetails.Url,
+				Body:    requestDetails.Body,
+				Headers: requestDetails.Headers,
+			}
+			httpSession.ExpectedStatusCodes = login.ExpectedStatusCodes
+		}
+
+		opts = append(opts, httpClient.WithSession(httpSession))
+	}
+
+	// The CA bundle is read on every connect, so changes to the secret are picked up by the next reconcile.
+	if ref := cr.Spec.ForProvider.TLSCACertSecretRef; ref != nil && !skipsAllTLSVerify(cr) {
+		caBundle, err := utils.GetSecretValue(ctx, kube, *ref)
+		if err != nil {
+			return nil, errors.Wrap(err, errTLSCACert)
+		}
+
+		opts = append(opts, httpClient.WithCACertificates([]byte(caBundle)))
+	}
+
+	if ref := cr.Spec.ForProvider.TLSClientCertSecretRef; ref != nil {
+		cert, err := utils.GetSecretValue(ctx, kube, xpv1.SecretKeySelector{SecretReference: *ref, Key: tlsCertKey})
+		if err != nil {
+			return nil, errors.Wrap(err, errTLSClientCert)
+		}
+
+		key, err := utils.GetSecretValue(ctx, kube, xpv1.SecretKeySelector{SecretReference: *ref, Key: tlsKeyKey})
+		if err != nil {
+			return nil, errors.Wrap(err, errTLSClientKey)
+		}
+
+		opts = append(opts, httpClient.WithClientCertificate([]byte(cert), []byte(key)))
+	}
+
+	if retry := cr.Spec.ForProvider.Retry; retry != nil {
+		policy := httpClient.RetryPolicy{
+			MaxAttempts:          retry.MaxAttempts,
+			Multiplier:           retry.Multiplier,
+			RetryableStatusCodes: retry.RetryableStatusCodes,
+			RetryNonIdempotent:   retry.RetryNonIdempotent,
+		}
+		if retry.InitialBackoff != nil {
+			policy.InitialBackoff = retry.InitialBackoff.Duration
+		}
+
+		opts = append(opts, httpClient.WithRetry(policy))
+	}
+
+	if cr.Spec.ForProvider.GRPCStatus {
+		opts = append(opts, httpClient.WithGRPCStatus())
+	}
+
+	return opts, nil
+}
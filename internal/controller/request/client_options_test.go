@@ -0,0 +1,170 @@
+package request
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_connector_Connect_Auth(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"request-token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer tokenServer.Close()
+
+	secretKey := func(key string) xpv1.SecretKeySelector {
+		return xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "api", Namespace: testNamespace}, Key: key}
+	}
+	secret := map[string][]byte{
+		"pc-username": []byte("pc-user"),
+		"pc-password": []byte("pc-pass"),
+		"username":    []byte("user"),
+		"password":    []byte("pass"),
+		"hmac-key":    []byte("s3cr3t"),
+		"token":       []byte("Bearer secret-token"),
+	}
+	basic := func(username, password string) string {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+	}
+	pcBasicAuth := &apisv1alpha1.BasicAuth{UsernameSecretRef: secretKey("pc-username"), PasswordSecretRef: secretKey("pc-password")}
+
+	type want struct {
+		authorization string
+		signed        bool
+	}
+	cases := map[string]struct {
+		pc          apisv1alpha1.ProviderConfigSpec
+		forProvider v1alpha1.RequestParameters
+		want        want
+	}{
+		"ProviderConfigBasicAuth": {
+			pc: apisv1alpha1.ProviderConfigSpec{BasicAuth: pcBasicAuth},
+			want: want{
+				authorization: basic("pc-user", "pc-pass"),
+			},
+		},
+		"RequestBasicAuth": {
+			pc: apisv1alpha1.ProviderConfigSpec{BasicAuth: pcBasicAuth},
+			forProvider: v1alpha1.RequestParameters{
+				BasicAuth: &v1alpha1.BasicAuth{UsernameSecretRef: secretKey("username"), PasswordSecretRef: secretKey("password")},
+			},
+			want: want{
+				authorization: basic("user", "pass"),
+			},
+		},
+		"RequestOAuth2": {
+			pc: apisv1alpha1.ProviderConfigSpec{BasicAuth: pcBasicAuth},
+			forProvider: v1alpha1.RequestParameters{
+				OAuth2: &v1alpha1.OAuth2{TokenURL: tokenServer.URL, ClientIDSecretRef: secretKey("username"), ClientSecretSecretRef: secretKey("password")},
+			},
+			want: want{
+				authorization: "Bearer request-token",
+			},
+		},
+		"RequestHMAC": {
+			pc: apisv1alpha1.ProviderConfigSpec{BasicAuth: pcBasicAuth},
+			forProvider: v1alpha1.RequestParameters{
+				HMAC: &v1alpha1.HMAC{SecretRef: secretKey("hmac-key"), Header: "X-Signature"},
+			},
+			want: want{
+				signed: true,
+			},
+		},
+		"RequestHMACOverServiceAccountToken": {
+			// The token isn't read at all, as it can't be outside of a pod.
+			pc: apisv1alpha1.ProviderConfigSpec{ServiceAccountToken: &apisv1alpha1.ServiceAccountToken{}},
+			forProvider: v1alpha1.RequestParameters{
+				HMAC: &v1alpha1.HMAC{SecretRef: secretKey("hmac-key"), Header: "X-Signature"},
+			},
+			want: want{
+				signed: true,
+			},
+		},
+		"RequestBearerHeaderFromSecret": {
+			pc: apisv1alpha1.ProviderConfigSpec{BasicAuth: pcBasicAuth},
+			forProvider: v1alpha1.RequestParameters{
+				HeadersFromSecret: map[string]xpv1.SecretKeySelector{"authorization": secretKey("token")},
+			},
+			want: want{
+				authorization: "Bearer secret-token",
+			},
+		},
+		"MappingAuthorizationHeader": {
+			// The header of the mapping is set by the requests it generates, so the
+			// ProviderConfig's authentication doesn't apply to any request.
+			pc: apisv1alpha1.ProviderConfigSpec{BasicAuth: pcBasicAuth},
+			forProvider: v1alpha1.RequestParameters{
+				Mappings: []v1alpha1.Mapping{{Method: "GET", URL: ".payload.baseUrl", Headers: map[string][]string{"Authorization": {`"Bearer " + .payload.body.token`}}}},
+			},
+			want: want{
+				authorization: "",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got http.Header
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Clone()
+			}))
+			defer server.Close()
+
+			c := &connector{
+				logger: logging.NewNopLogger(),
+				kube: &test.MockClient{
+					MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+						switch o := obj.(type) {
+						case *apisv1alpha1.ProviderConfig:
+							o.Spec = tc.pc
+						case *corev1.Secret:
+							o.Data = secret
+						}
+						return nil
+					},
+				},
+				usage:           resource.TrackerFn(func(context.Context, resource.Managed) error { return nil }),
+				recorder:        event.NewNopRecorder(),
+				newHttpClientFn: httpClient.NewClient,
+			}
+			cr := httpRequest(func(r *v1alpha1.Request) {
+				forProvider := tc.forProvider
+				forProvider.Payload = r.Spec.ForProvider.Payload
+				if forProvider.Mappings == nil {
+					forProvider.Mappings = r.Spec.ForProvider.Mappings
+				}
+				r.Spec.ForProvider = forProvider
+			})
+
+			e, err := c.Connect(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("Connect(...): unexpected error: %s", err)
+			}
+			if _, err := e.(*external).http.SendRequest(context.Background(), http.MethodGet, server.URL, "", nil, false); err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want.authorization, got.Get("Authorization")); diff != "" {
+				t.Errorf("Connect(...): -want Authorization, +got Authorization: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.signed, got.Get("X-Signature") != ""); diff != "" {
+				t.Errorf("Connect(...): -want signed, +got signed: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package request
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	headerIfMatch     = "If-Match"
+	headerIfNoneMatch = "If-None-Match"
+)
+
+// isConditionalUpdate reports whether requests of the method are sent with If-Match.
+func isConditionalUpdate(cr *v1alpha1.Request, method string) bool {
+	return cr.Spec.ForProvider.ConditionalUpdate != nil && (method == http.MethodPut || method == http.MethodPatch)
+}
+
+// etagHeader returns the name of the response header holding the entity tag.
+func etagHeader(conditionalUpdate *v1alpha1.ConditionalUpdate) string {
+	if conditionalUpdate == nil || conditionalUpdate.ETagHeader == "" {
+		return utils.DefaultETagHeader
+	}
+	return conditionalUpdate.ETagHeader
+}
+
+// withIfMatch returns a copy of the headers with If-Match set to the entity tag.
+// The headers are returned as is when there's no entity tag to match.
+func withIfMatch(headers map[string][]string, etag string) map[string][]string {
+	if etag == "" {
+		return headers
+	}
+	return withHeader(headers, headerIfMatch, etag)
+}
+
+// conditionalObserveETag returns the entity tag to send as If-None-Match with the GET
+// request. There's none unless the status holds the response to a GET request, as
+// only that response can stand in for a 304 Not Modified one.
+func conditionalObserveETag(cr *v1alpha1.Request) string {
+	if !cr.Spec.ForProvider.ConditionalObserve || cr.Spec.ForProvider.RoleOf(cr.Status.RequestDetails.Method) != http.MethodGet || cr.Status.Response.StatusCode == 0 {
+		return ""
+	}
+	return cr.Status.ETag
+}
+
+// storedResponse returns the response stored in the status, standing in for a 304
+// Not Modified response. It has already been aggregated and selected.
+func storedResponse(cr *v1alpha1.Request) httpClient.HttpResponse {
+	return httpClient.HttpResponse{
+		StatusCode: cr.Status.Response.StatusCode,
+		Body:       cr.Status.Response.Body,
+		Headers:    cr.Status.Response.Headers,
+	}
+}
+
+// resendConditionalUpdate observes the object again after an update was rejected with
+// 412 Precondition Failed, and sends the update once more with the new entity tag.
+func (c *external) resendConditionalUpdate(ctx context.Context, cr *v1alpha1.Request, mapping *v1alpha1.Mapping, requestDetails requestgen.RequestDetails, rejected httpClient.HttpDetails) (httpClient.HttpDetails, error) {
+	observed, err := c.isUpToDate(ctx, cr)
+	if err != nil || observed.ResponseError != nil {
+		c.logger.Debug("cannot observe the object after a rejected conditional update", "error", err, "responseError", observed.ResponseError)
+		return rejected, nil
+	}
+
+	etag := http.Header(observed.Details.HttpResponse.Headers).Get(etagHeader(cr.Spec.ForProvider.ConditionalUpdate))
+	defer c.forgetObservations()
+
+	requestCtx, cancel := requestContext(ctx, mapping)
+	defer cancel()
+
+	return c.http.SendRequest(requestCtx, mapping.Method, requestDetails.Url, requestDetails.Body, withIfMatch(requestDetails.Headers, etag), insecureSkipTLSVerify(cr, mapping))
+}
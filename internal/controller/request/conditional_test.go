@@ -0,0 +1,56 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_withIfMatch(t *testing.T) {
+	type args struct {
+		headers map[string][]string
+		etag    string
+	}
+	type want struct {
+		headers map[string][]string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoETag": {
+			args: args{
+				headers: map[string][]string{"Content-Type": {"application/json"}},
+			},
+			want: want{
+				headers: map[string][]string{"Content-Type": {"application/json"}},
+			},
+		},
+		"SetIfMatch": {
+			args: args{
+				headers: map[string][]string{"Content-Type": {"application/json"}},
+				etag:    `"v1"`,
+			},
+			want: want{
+				headers: map[string][]string{"Content-Type": {"application/json"}, "If-Match": {`"v1"`}},
+			},
+		},
+		"ReplaceIfMatch": {
+			args: args{
+				headers: map[string][]string{"if-match": {`"v0"`}},
+				etag:    `"v1"`,
+			},
+			want: want{
+				headers: map[string][]string{"If-Match": {`"v1"`}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := withIfMatch(tc.args.headers, tc.args.etag)
+			if diff := cmp.Diff(tc.want.headers, got); diff != "" {
+				t.Fatalf("withIfMatch(...): -want headers, +got headers: %s", diff)
+			}
+		})
+	}
+}
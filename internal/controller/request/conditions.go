@@ -0,0 +1,72 @@
+package request
+
+import (
+	"fmt"
+	"net/http"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	msgStatusCode = "GET request answered with status code %d"
+)
+
+// observedCondition returns the Observed condition telling the outcome of an
+// observation, given its error, or else its result and the message of its failed
+// checks.
+func observedCondition(observed ObserveRequestDetails, failedChecks string, err error) xpv1.Condition {
+	condition := xpv1.Condition{
+		Type:               v1alpha1.TypeObserved,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+	}
+
+	var rateLimited *utils.RateLimitedError
+	statusCode := observed.Details.HttpResponse.StatusCode
+	switch {
+	case err != nil && err.Error() == errObjectNotFound:
+		condition.Reason, condition.Message = v1alpha1.ReasonNotFound, err.Error()
+	case errors.As(err, &rateLimited):
+		condition.Reason, condition.Message = v1alpha1.ReasonRateLimited, rateLimited.Error()
+	case isObservationFailed(err):
+		condition.Reason, condition.Message = v1alpha1.ReasonUnavailable, err.Error()
+	case err != nil:
+		condition.Reason, condition.Message = v1alpha1.ReasonObserveFailed, err.Error()
+	case observed.Synced:
+		condition.Status, condition.Reason = corev1.ConditionTrue, v1alpha1.ReasonUpToDate
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		condition.Reason, condition.Message = v1alpha1.ReasonAuthFailed, fmt.Sprintf(msgStatusCode, statusCode)
+	case statusCode >= http.StatusBadRequest && statusCode < http.StatusInternalServerError:
+		condition.Reason, condition.Message = v1alpha1.ReasonClientError, fmt.Sprintf(msgStatusCode, statusCode)
+	default:
+		condition.Reason, condition.Message = v1alpha1.ReasonDriftDetected, failedChecks
+	}
+	return condition
+}
+
+// pendingCondition returns the Observed condition of an object that waits for an
+// operation to complete before it's compared to the desired state.
+func pendingCondition(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               v1alpha1.TypeObserved,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             v1alpha1.ReasonPending,
+		Message:            message,
+	}
+}
+
+// deletionCondition returns the Observed condition of an object whose deletion is
+// confirmed by the GET mapping: not found once deleted, and pending until then.
+func deletionCondition(deleted bool, pending string) xpv1.Condition {
+	if deleted {
+		return observedCondition(ObserveRequestDetails{}, "", errors.New(errObjectNotFound))
+	}
+	return pendingCondition(pending)
+}
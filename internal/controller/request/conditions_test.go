@@ -0,0 +1,130 @@
+package request
+
+import (
+	"net/http"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+func Test_observedCondition(t *testing.T) {
+	type args struct {
+		observed     ObserveRequestDetails
+		failedChecks string
+		err          error
+	}
+	type want struct {
+		status corev1.ConditionStatus
+		reason xpv1.ConditionReason
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"UpToDate": {
+			args: args{
+				observed: ObserveRequestDetails{Synced: true, Details: httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusOK}}},
+			},
+			want: want{status: corev1.ConditionTrue, reason: v1alpha1.ReasonUpToDate},
+		},
+		"DriftDetected": {
+			args: args{
+				observed:     ObserveRequestDetails{Details: httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusOK}}},
+				failedChecks: failedChecksMessage([]string{defaultCompareCheck}),
+			},
+			want: want{status: corev1.ConditionFalse, reason: v1alpha1.ReasonDriftDetected},
+		},
+		"AuthFailed": {
+			args: args{
+				observed:     ObserveRequestDetails{Details: httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusForbidden}}},
+				failedChecks: failedChecksMessage([]string{defaultCompareCheck}),
+			},
+			want: want{status: corev1.ConditionFalse, reason: v1alpha1.ReasonAuthFailed},
+		},
+		"ClientError": {
+			args: args{
+				observed:     ObserveRequestDetails{Details: httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusGone}}},
+				failedChecks: failedChecksMessage([]string{defaultCompareCheck}),
+			},
+			want: want{status: corev1.ConditionFalse, reason: v1alpha1.ReasonClientError},
+		},
+		"NotFound": {
+			args: args{
+				err: errors.New(errObjectNotFound),
+			},
+			want: want{status: corev1.ConditionFalse, reason: v1alpha1.ReasonNotFound},
+		},
+		"RateLimited": {
+			args: args{
+				err: &observationFailedError{err: &utils.RateLimitedError{Method: http.MethodGet}},
+			},
+			want: want{status: corev1.ConditionFalse, reason: v1alpha1.ReasonRateLimited},
+		},
+		"Unavailable": {
+			args: args{
+				err: &observationFailedError{err: errors.New("connection refused")},
+			},
+			want: want{status: corev1.ConditionFalse, reason: v1alpha1.ReasonUnavailable},
+		},
+		"ObserveFailed": {
+			args: args{
+				err: errors.New(errReadinessCheck),
+			},
+			want: want{status: corev1.ConditionFalse, reason: v1alpha1.ReasonObserveFailed},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := observedCondition(tc.args.observed, tc.args.failedChecks, tc.args.err)
+			if diff := cmp.Diff(v1alpha1.TypeObserved, got.Type); diff != "" {
+				t.Errorf("observedCondition(...): -want type, +got type: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.status, got.Status); diff != "" {
+				t.Errorf("observedCondition(...): -want status, +got status: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.reason, got.Reason); diff != "" {
+				t.Errorf("observedCondition(...): -want reason, +got reason: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_deletionCondition(t *testing.T) {
+	type want struct {
+		reason  xpv1.ConditionReason
+		message string
+	}
+	cases := map[string]struct {
+		deleted bool
+		want    want
+	}{
+		"Deleted": {
+			deleted: true,
+			want:    want{reason: v1alpha1.ReasonNotFound, message: errObjectNotFound},
+		},
+		"Pending": {
+			want: want{reason: v1alpha1.ReasonPending, message: msgDeletionPending},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := deletionCondition(tc.deleted, msgDeletionPending)
+			if diff := cmp.Diff(v1alpha1.TypeObserved, got.Type); diff != "" {
+				t.Errorf("deletionCondition(...): -want type, +got type: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.reason, got.Reason); diff != "" {
+				t.Errorf("deletionCondition(...): -want reason, +got reason: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.message, got.Message); diff != "" {
+				t.Errorf("deletionCondition(...): -want message, +got message: %s", diff)
+			}
+		})
+	}
+}
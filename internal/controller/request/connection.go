@@ -0,0 +1,67 @@
+package request
+
+import (
+	ej "encoding/json"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/pkg/errors"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errSecretOutputs = "failed to extract secret outputs from response"
+)
+
+// extractSecretOutputs returns the connection details selected by the secret outputs from a
+// successful response, along with the response with those values redacted.
+func extractSecretOutputs(outputs map[string]string, details httpClient.HttpDetails, expectedStatusCodes []int) (managed.ConnectionDetails, httpClient.HttpDetails, error) {
+	body := details.HttpResponse.Body
+	if len(outputs) == 0 || !utils.IsHTTPSuccessFor(details.HttpResponse.StatusCode, expectedStatusCodes) || !json.IsJSONString(body) {
+		return nil, details, nil
+	}
+
+	bodyMap := json.JsonStringToMap(body)
+	connectionDetails := managed.ConnectionDetails{}
+	paths := make([]string, 0, len(outputs))
+
+	for key, path := range outputs {
+		values, found, err := json.QueryJSONPath(bodyMap, path)
+		if err != nil {
+			return nil, details, errors.Wrap(err, errSecretOutputs)
+		}
+		if !found {
+			continue
+		}
+
+		value, err := connectionDetailValue(values)
+		if err != nil {
+			return nil, details, errors.Wrap(err, errSecretOutputs)
+		}
+
+		connectionDetails[key] = value
+		paths = append(paths, path)
+	}
+
+	redacted, err := json.RedactJSONString(body, paths)
+	if err != nil {
+		return nil, details, errors.Wrap(err, errSecretOutputs)
+	}
+	details.HttpResponse.Body = redacted
+
+	return connectionDetails, details, nil
+}
+
+// connectionDetailValue stores strings as is and any other selected value as JSON.
+func connectionDetailValue(values []interface{}) ([]byte, error) {
+	if len(values) == 1 {
+		if str, ok := values[0].(string); ok {
+			return []byte(str), nil
+		}
+		return ej.Marshal(values[0])
+	}
+
+	return ej.Marshal(values)
+}
@@ -0,0 +1,76 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/google/go-cmp/cmp"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_extractSecretOutputs(t *testing.T) {
+	type args struct {
+		outputs map[string]string
+		details httpClient.HttpDetails
+	}
+	type want struct {
+		connectionDetails managed.ConnectionDetails
+		body              string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoOutputs": {
+			args: args{
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{StatusCode: 200, Body: `{"id":"1","token":"abc"}`},
+				},
+			},
+			want: want{
+				body: `{"id":"1","token":"abc"}`,
+			},
+		},
+		"FailedResponse": {
+			args: args{
+				outputs: map[string]string{"token": "$.token"},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{StatusCode: 400, Body: `{"token":"abc"}`},
+				},
+			},
+			want: want{
+				body: `{"token":"abc"}`,
+			},
+		},
+		"ExtractAndRedact": {
+			args: args{
+				outputs: map[string]string{"token": "$.token", "port": "$.endpoint.port", "missing": "$.missing"},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{StatusCode: 201, Body: `{"endpoint":{"port":5432},"id":"1","token":"abc"}`},
+				},
+			},
+			want: want{
+				connectionDetails: managed.ConnectionDetails{
+					"token": []byte("abc"),
+					"port":  []byte("5432"),
+				},
+				body: `{"endpoint":{"port":"***"},"id":"1","token":"***"}`,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gotConnectionDetails, gotDetails, err := extractSecretOutputs(tc.args.outputs, tc.args.details, nil)
+			if err != nil {
+				t.Fatalf("extractSecretOutputs(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.connectionDetails, gotConnectionDetails); diff != "" {
+				t.Fatalf("extractSecretOutputs(...): -want connection details, +got connection details: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.body, gotDetails.HttpResponse.Body); diff != "" {
+				t.Fatalf("extractSecretOutputs(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
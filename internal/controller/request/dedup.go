@@ -0,0 +1,54 @@
+package request
+
+import (
+	"context"
+	ej "encoding/json"
+	"net/http"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+// sentRequest identifies a request sent during a reconcile.
+type sentRequest struct {
+	Method        string              `json:"method"`
+	URL           string              `json:"url"`
+	Body          string              `json:"body"`
+	Headers       map[string][]string `json:"headers"`
+	SkipTLSVerify bool                `json:"skipTLSVerify"`
+}
+
+// sendObservation sends a GET or HEAD request observing the object, unless an
+// identical one was already sent during this reconcile, in which case its
+// response is reused. The requests changing the object forget the responses, so
+// that it's observed again afterwards. A request verifying the TLS certificate of
+// the server never reuses the response to one that didn't.
+func (c *external) sendObservation(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (httpClient.HttpDetails, error) {
+	if method != http.MethodGet && method != http.MethodHead {
+		return c.http.SendRequest(ctx, method, url, body, headers, skipTLSVerify)
+	}
+
+	key, err := ej.Marshal(sentRequest{Method: method, URL: url, Body: body, Headers: headers, SkipTLSVerify: skipTLSVerify})
+	if err != nil {
+		return c.http.SendRequest(ctx, method, url, body, headers, skipTLSVerify)
+	}
+	if details, ok := c.observations[string(key)]; ok {
+		c.logger.Debug("reusing the response to an identical request", "method", method, "url", url)
+		return details, nil
+	}
+
+	details, err := c.http.SendRequest(ctx, method, url, body, headers, skipTLSVerify)
+	if err != nil {
+		return details, err
+	}
+	if c.observations == nil {
+		c.observations = map[string]httpClient.HttpDetails{}
+	}
+	c.observations[string(key)] = details
+	return details, nil
+}
+
+// forgetObservations forgets the responses to the requests observing the object,
+// after a request changed it.
+func (c *external) forgetObservations() {
+	c.observations = nil
+}
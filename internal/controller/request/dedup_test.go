@@ -0,0 +1,101 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_sendObservation(t *testing.T) {
+	type request struct {
+		method        string
+		url           string
+		headers       map[string][]string
+		skipTLSVerify bool
+		forget        bool
+	}
+	cases := map[string]struct {
+		requests []request
+		want     int
+	}{
+		"Identical": {
+			requests: []request{
+				{method: http.MethodGet, url: "https://api.example.com/users/123", headers: map[string][]string{"Accept": {"application/json"}}},
+				{method: http.MethodGet, url: "https://api.example.com/users/123", headers: map[string][]string{"Accept": {"application/json"}}},
+			},
+			want: 1,
+		},
+		"DifferentHeaders": {
+			requests: []request{
+				{method: http.MethodGet, url: "https://api.example.com/users/123", headers: map[string][]string{"Accept": {"application/json"}}},
+				{method: http.MethodGet, url: "https://api.example.com/users/123", headers: map[string][]string{"Accept": {"text/plain"}}},
+			},
+			want: 2,
+		},
+		"DifferentTLSVerification": {
+			requests: []request{
+				{method: http.MethodGet, url: "https://api.example.com/users/123", skipTLSVerify: true},
+				{method: http.MethodGet, url: "https://api.example.com/users/123"},
+			},
+			want: 2,
+		},
+		"DifferentMethods": {
+			requests: []request{
+				{method: http.MethodGet, url: "https://api.example.com/users/123"},
+				{method: http.MethodHead, url: "https://api.example.com/users/123"},
+			},
+			want: 2,
+		},
+		"NotObserving": {
+			requests: []request{
+				{method: http.MethodPost, url: "https://api.example.com/users"},
+				{method: http.MethodPost, url: "https://api.example.com/users"},
+			},
+			want: 2,
+		},
+		"ForgottenAfterChange": {
+			requests: []request{
+				{method: http.MethodGet, url: "https://api.example.com/users/123", forget: true},
+				{method: http.MethodGet, url: "https://api.example.com/users/123"},
+			},
+			want: 2,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			sent := 0
+			e := &external{
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						sent++
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusOK, Body: `{"id":"123"}`},
+						}, nil
+					},
+				},
+			}
+
+			for _, r := range tc.requests {
+				details, err := e.sendObservation(context.Background(), r.method, r.url, "", r.headers, r.skipTLSVerify)
+				if err != nil {
+					t.Fatalf("sendObservation(...): unexpected error: %s", err)
+				}
+				if diff := cmp.Diff(`{"id":"123"}`, details.HttpResponse.Body); diff != "" {
+					t.Fatalf("sendObservation(...): -want body, +got body: %s", diff)
+				}
+				if r.forget {
+					e.forgetObservations()
+				}
+			}
+			if diff := cmp.Diff(tc.want, sent); diff != "" {
+				t.Errorf("sendObservation(...): -want requests sent, +got requests sent: %s", diff)
+			}
+		})
+	}
+}
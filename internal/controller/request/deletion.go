@@ -0,0 +1,84 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+)
+
+const (
+	msgDeletionPending = "waiting for the deletion of the object to be confirmed"
+
+	defaultDeletionPollInterval = 5 * time.Second
+	defaultDeletionMaxWait      = 5 * time.Minute
+)
+
+// deletionPollInterval returns the time between the GET requests confirming a deletion.
+func deletionPollInterval(wait *v1alpha1.WaitForDeletion) time.Duration {
+	if wait.PollInterval != nil {
+		return wait.PollInterval.Duration
+	}
+	return defaultDeletionPollInterval
+}
+
+// isDeletionPending reports whether the DELETE request of the resource succeeded less
+// than the configured max wait ago, so that it isn't sent again while the API deletes
+// the object.
+func isDeletionPending(cr *v1alpha1.Request) bool {
+	if !isAwaitingDeletion(cr) || cr.Status.Failed > 0 || cr.Status.LastRequestTime == nil {
+		return false
+	}
+
+	maxWait := defaultDeletionMaxWait
+	if wait := cr.Spec.ForProvider.WaitForDeletion; wait.MaxWait != nil {
+		maxWait = wait.MaxWait.Duration
+	}
+	return time.Since(cr.Status.LastRequestTime.Time) < maxWait
+}
+
+// isAwaitingDeletion reports whether the resource is being deleted, and its DELETE
+// request was sent but the deletion must still be confirmed.
+func isAwaitingDeletion(cr *v1alpha1.Request) bool {
+	return meta.WasDeleted(cr) && cr.Spec.ForProvider.WaitForDeletion != nil && cr.Spec.ForProvider.RoleOf(cr.Status.RequestDetails.Method) == http.MethodDelete
+}
+
+// isDeleted sends the GET mapping request, and reports whether its response means
+// that the object doesn't exist.
+func (c *external) isDeleted(ctx context.Context, cr *v1alpha1.Request) (bool, error) {
+	mapping, ok := getMappingByMethod(&cr.Spec.ForProvider, http.MethodGet)
+	if !ok {
+		return false, errors.Errorf(errMappingNotFound, http.MethodGet)
+	}
+
+	requestDetails, err := c.generateValidRequestDetails(cr, mapping)
+	if err != nil {
+		return false, err
+	}
+
+	requestCtx, cancel := requestContext(ctx, mapping)
+	defer cancel()
+
+	details, err := c.http.SendRequest(requestCtx, mapping.Method, requestDetails.Url, requestDetails.Body, requestDetails.Headers, insecureSkipTLSVerify(cr, mapping))
+	if err != nil {
+		return false, err
+	}
+
+	notFound, err := isNotFound(mapping.NotFoundCheck, details.HttpResponse)
+	if err != nil || notFound {
+		return notFound, err
+	}
+
+	if _, err := selectResponse(cr, mapping, details.HttpResponse); err != nil {
+		if err.Error() == errObjectNotFound {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return false, nil
+}
@@ -0,0 +1,161 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_isDeleted(t *testing.T) {
+	type args struct {
+		response      httpClient.HttpResponse
+		notFoundCheck *v1alpha1.NotFoundCheck
+	}
+	type want struct {
+		deleted bool
+		err     error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Deleted": {
+			args: args{
+				response: httpClient.HttpResponse{StatusCode: 404},
+			},
+			want: want{
+				deleted: true,
+			},
+		},
+		"DeletedByCondition": {
+			args: args{
+				response:      httpClient.HttpResponse{StatusCode: 200, Body: `{"status":"deleted"}`},
+				notFoundCheck: &v1alpha1.NotFoundCheck{Condition: `.body.status == "deleted"`},
+			},
+			want: want{
+				deleted: true,
+			},
+		},
+		"StillBeingDeleted": {
+			args: args{
+				response:      httpClient.HttpResponse{StatusCode: 200, Body: `{"status":"deleting"}`},
+				notFoundCheck: &v1alpha1.NotFoundCheck{Condition: `.body.status == "deleted"`},
+			},
+			want: want{
+				deleted: false,
+			},
+		},
+		"RequestFailed": {
+			args: args{
+				response: httpClient.HttpResponse{},
+			},
+			want: want{
+				err: errBoom,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						if method != http.MethodGet || url != "https://api.example.com/users/123" {
+							t.Fatalf("unexpected %s request to %s", method, url)
+						}
+						if tc.want.err != nil {
+							return httpClient.HttpDetails{}, tc.want.err
+						}
+						return httpClient.HttpDetails{HttpResponse: tc.args.response}, nil
+					},
+				},
+			}
+			cr := httpRequest(func(r *v1alpha1.Request) {
+				r.Status.Response.Body = `{"id":"123"}`
+				r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+					testPostMapping,
+					{
+						Method:        "GET",
+						URL:           testGetMapping.URL,
+						NotFoundCheck: tc.args.notFoundCheck,
+					},
+					testDeleteMapping,
+				}
+			})
+
+			got, gotErr := e.isDeleted(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("isDeleted(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, got); diff != "" {
+				t.Errorf("isDeleted(...): -want deleted, +got deleted: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_isDeletionPending(t *testing.T) {
+	type args struct {
+		method string
+		failed int32
+		sent   time.Duration
+	}
+	cases := map[string]struct {
+		args args
+		want bool
+	}{
+		"DeleteSent": {
+			args: args{
+				method: http.MethodDelete,
+				sent:   time.Minute,
+			},
+			want: true,
+		},
+		"MaxWaitExceeded": {
+			args: args{
+				method: http.MethodDelete,
+				sent:   10 * time.Minute,
+			},
+			want: false,
+		},
+		"DeleteFailed": {
+			args: args{
+				method: http.MethodDelete,
+				failed: 1,
+				sent:   time.Minute,
+			},
+			want: false,
+		},
+		"DeleteNotSent": {
+			args: args{
+				method: http.MethodGet,
+				sent:   time.Minute,
+			},
+			want: false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := httpRequest(func(r *v1alpha1.Request) {
+				r.SetDeletionTimestamp(&v1.Time{Time: time.Now()})
+				r.Spec.ForProvider.WaitForDeletion = &v1alpha1.WaitForDeletion{}
+				r.Status.RequestDetails.Method = tc.args.method
+				r.Status.Failed = tc.args.failed
+				r.Status.LastRequestTime = &v1.Time{Time: time.Now().Add(-tc.args.sent)}
+			})
+
+			if diff := cmp.Diff(tc.want, isDeletionPending(cr)); diff != "" {
+				t.Errorf("isDeletionPending(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
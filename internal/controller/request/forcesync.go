@@ -0,0 +1,22 @@
+package request
+
+import (
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+)
+
+const (
+	msgForceSync = "force-sync annotation %q is not applied yet"
+)
+
+// forceSync returns the value of the force-sync annotation, and whether it changed
+// since the object was last created or updated, so that it must be updated.
+func forceSync(cr *v1alpha1.Request) (string, bool) {
+	nonce := cr.GetAnnotations()[v1alpha1.AnnotationForceSync]
+	return nonce, nonce != "" && nonce != cr.Status.ForceSync
+}
+
+// applyForceSync records the value of the force-sync annotation as applied, so that
+// it only forces the next update once.
+func applyForceSync(cr *v1alpha1.Request) {
+	cr.Status.ForceSync = cr.GetAnnotations()[v1alpha1.AnnotationForceSync]
+}
@@ -0,0 +1,137 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_httpExternal_Observe_ForceSync(t *testing.T) {
+	type args struct {
+		body    string
+		nonce   string
+		applied string
+	}
+	cases := map[string]struct {
+		args args
+		want managed.ExternalObservation
+	}{
+		"NotAnnotated": {
+			args: args{
+				body: `{"username":"john_doe_new_username"}`,
+			},
+			want: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+		"Changed": {
+			args: args{
+				body:    `{"username":"john_doe_new_username"}`,
+				nonce:   "2",
+				applied: "1",
+			},
+			want: managed.ExternalObservation{ResourceExists: true, Diff: fmt.Sprintf(msgForceSync, "2")},
+		},
+		"Applied": {
+			args: args{
+				body:    `{"username":"john_doe_new_username"}`,
+				nonce:   "2",
+				applied: "2",
+			},
+			want: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+		"Drifted": {
+			args: args{
+				body:  `{"username":"john_doe"}`,
+				nonce: "2",
+			},
+			want: managed.ExternalObservation{ResourceExists: true, Diff: failedChecksMessage([]string{defaultCompareCheck})},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{Body: tc.args.body, StatusCode: http.StatusOK},
+						}, nil
+					},
+				},
+				recorder: &MockRecorder{},
+			}
+			cr := httpRequest(func(r *v1alpha1.Request) {
+				r.Status.Response.Body = `{"id":"123"}`
+				r.Status.ForceSync = tc.args.applied
+				if tc.args.nonce != "" {
+					r.SetAnnotations(map[string]string{v1alpha1.AnnotationForceSync: tc.args.nonce})
+				}
+			})
+
+			got, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("e.Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("e.Observe(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_httpExternal_Update_ForceSync(t *testing.T) {
+	cases := map[string]struct {
+		statusCode int
+		want       string
+	}{
+		"Updated": {
+			statusCode: http.StatusOK,
+			want:       "2",
+		},
+		"UpdateFailed": {
+			statusCode: http.StatusBadRequest,
+			want:       "1",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{Body: `{"id":"123"}`, StatusCode: tc.statusCode},
+						}, nil
+					},
+				},
+				recorder: &MockRecorder{},
+			}
+			cr := httpRequest(func(r *v1alpha1.Request) {
+				r.Status.Response.Body = `{"id":"123"}`
+				r.Status.ForceSync = "1"
+				r.SetAnnotations(map[string]string{v1alpha1.AnnotationForceSync: "2"})
+			})
+
+			_, _ = e.Update(context.Background(), cr)
+			if diff := cmp.Diff(tc.want, cr.Status.ForceSync); diff != "" {
+				t.Errorf("e.Update(...): -want force sync, +got force sync: %s", diff)
+			}
+		})
+	}
+}
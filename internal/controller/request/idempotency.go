@@ -0,0 +1,27 @@
+package request
+
+import (
+	"fmt"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+)
+
+const (
+	defaultIdempotencyHeader = "Idempotency-Key"
+)
+
+// idempotencyKey returns the key identifying the create operation of the resource's
+// current revision. It only depends on the resource, so it stays the same across
+// retries and controller restarts.
+func idempotencyKey(cr *v1alpha1.Request) string {
+	return fmt.Sprintf("%s-%d", cr.UID, cr.Generation)
+}
+
+// withIdempotencyKey returns a copy of the headers with the idempotency key set.
+func withIdempotencyKey(cr *v1alpha1.Request, headers map[string][]string) map[string][]string {
+	header := cr.Spec.ForProvider.Idempotency.HeaderName
+	if header == "" {
+		header = defaultIdempotencyHeader
+	}
+	return withHeader(headers, header, idempotencyKey(cr))
+}
@@ -0,0 +1,59 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+)
+
+func Test_withIdempotencyKey(t *testing.T) {
+	type args struct {
+		mg      *v1alpha1.Request
+		headers map[string][]string
+	}
+	type want struct {
+		headers map[string][]string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"DefaultHeader": {
+			args: args{
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.UID = types.UID("4f2b6d1c")
+					r.Generation = 2
+					r.Spec.ForProvider.Idempotency = &v1alpha1.Idempotency{}
+				}),
+				headers: map[string][]string{"Content-Type": {"application/json"}},
+			},
+			want: want{
+				headers: map[string][]string{"Content-Type": {"application/json"}, "Idempotency-Key": {"4f2b6d1c-2"}},
+			},
+		},
+		"CustomHeader": {
+			args: args{
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.UID = types.UID("4f2b6d1c")
+					r.Generation = 1
+					r.Spec.ForProvider.Idempotency = &v1alpha1.Idempotency{HeaderName: "X-Request-Id"}
+				}),
+				headers: map[string][]string{"x-request-id": {"manual"}},
+			},
+			want: want{
+				headers: map[string][]string{"X-Request-Id": {"4f2b6d1c-1"}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := withIdempotencyKey(tc.args.mg, tc.args.headers)
+			if diff := cmp.Diff(tc.want.headers, got); diff != "" {
+				t.Fatalf("withIdempotencyKey(...): -want headers, +got headers: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package request
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+	"github.com/crossplane-contrib/provider-http/internal/json"
+)
+
+const (
+	errImmutableFields = "cannot compare the immutable fields of the response and the desired state"
+	errRecreateDelete  = "cannot delete the object to create it again"
+
+	msgRecreateIssued  = "immutable fields changed, deleting and creating the object again"
+	msgRecreatePending = "waiting for the deletion of the object to be confirmed to create it again"
+
+	reasonRecreateIssued event.Reason = "RecreateIssued"
+)
+
+// immutableFieldsChanged reports whether one of the immutable fields of the desired
+// state differs from the response, so that the object must be created again to be
+// synced. Only JSON bodies are compared, and so are the fields present in both.
+func immutableFieldsChanged(cr *v1alpha1.Request, mapping *v1alpha1.Mapping, response string, desiredState string, bodyType string) (bool, error) {
+	paths := cr.Spec.ForProvider.ImmutableFields
+	if len(paths) == 0 {
+		return false, nil
+	}
+
+	response, found, err := responseRoot(mapping.ResponseRoot, response)
+	if err != nil {
+		return false, err
+	}
+	if !requestgen.IsJSONBody(bodyType) || !found || !json.IsJSONString(response) || !json.IsJSONString(desiredState) {
+		return false, nil
+	}
+
+	opts, err := compareOptions(mapping.CompareOptions)
+	if err != nil {
+		return false, err
+	}
+
+	responseMap := json.JsonStringToMap(response)
+	desiredStateMap := json.JsonStringToMap(desiredState)
+	for _, path := range paths {
+		_, found, err := json.QueryJSONPath(responseMap, path)
+		if err != nil {
+			return false, errors.Wrap(err, errImmutableFields)
+		}
+		if !found {
+			continue
+		}
+
+		equal, err := json.EqualAtJSONPaths(responseMap, desiredStateMap, []string{path}, opts)
+		if err != nil {
+			return false, errors.Wrap(err, errImmutableFields)
+		}
+		if !equal {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// recreateObject sends the DELETE request of an object whose immutable fields
+// changed. It's created again by a later reconcile, once the GET mapping confirms
+// that it's gone, as the API may still be deleting it.
+func (c *external) recreateObject(ctx context.Context, cr *v1alpha1.Request) (managed.ConnectionDetails, error) {
+	_, ok, err := c.selectMapping(cr, http.MethodDelete)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.Errorf(errMappingNotFound, http.MethodDelete)
+	}
+
+	connectionDetails, err := c.deployAction(ctx, cr, http.MethodDelete)
+	return connectionDetails, errors.Wrap(err, errRecreateDelete)
+}
+
+// isAwaitingRecreate reports whether the object was deleted to be created again, and
+// its deletion must still be confirmed before it's created.
+func isAwaitingRecreate(cr *v1alpha1.Request) bool {
+	return !meta.WasDeleted(cr) && cr.Status.Failed == 0 && cr.Spec.ForProvider.RoleOf(cr.Status.RequestDetails.Method) == http.MethodDelete
+}
@@ -0,0 +1,190 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+)
+
+func Test_immutableFieldsChanged(t *testing.T) {
+	type args struct {
+		immutableFields []string
+		response        string
+		bodyType        string
+	}
+	cases := map[string]struct {
+		args args
+		want bool
+	}{
+		"NoImmutableFields": {
+			args: args{
+				response: `{"username":"john_doe","region":"us-east-1"}`,
+			},
+			want: false,
+		},
+		"Unchanged": {
+			args: args{
+				immutableFields: []string{"$.region"},
+				response:        `{"username":"john_doe","region":"eu-west-1"}`,
+			},
+			want: false,
+		},
+		"Changed": {
+			args: args{
+				immutableFields: []string{"$.username", "$.region"},
+				response:        `{"username":"john_doe_new_username","region":"us-east-1"}`,
+			},
+			want: true,
+		},
+		"MissingInResponse": {
+			args: args{
+				immutableFields: []string{"$.region"},
+				response:        `{"username":"john_doe_new_username"}`,
+			},
+			want: false,
+		},
+		"NotJSON": {
+			args: args{
+				immutableFields: []string{"$.region"},
+				response:        `region=us-east-1`,
+				bodyType:        requestgen.BodyTypeRaw,
+			},
+			want: false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := httpRequest(func(r *v1alpha1.Request) {
+				r.Spec.ForProvider.ImmutableFields = tc.args.immutableFields
+			})
+			bodyType := tc.args.bodyType
+			if bodyType == "" {
+				bodyType = requestgen.BodyTypeJSON
+			}
+
+			got, err := immutableFieldsChanged(cr, &testGetMapping, tc.args.response, `{"username":"john_doe_new_username","region":"eu-west-1"}`, bodyType)
+			if err != nil {
+				t.Fatalf("immutableFieldsChanged(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("immutableFieldsChanged(...): -want changed, +got changed: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_httpExternal_Update_Recreate(t *testing.T) {
+	type want struct {
+		update []string
+		// recreate are the requests of the reconcile following the update.
+		recreate []string
+		exists   bool
+	}
+	cases := map[string]struct {
+		immutableFields []string
+		deleted         bool
+		want            want
+	}{
+		"ImmutableFieldChanged": {
+			immutableFields: []string{"$.username"},
+			deleted:         true,
+			want: want{
+				update:   []string{http.MethodGet, http.MethodDelete},
+				recreate: []string{http.MethodGet, http.MethodPost},
+			},
+		},
+		"DeletionNotConfirmed": {
+			immutableFields: []string{"$.username"},
+			want: want{
+				update:   []string{http.MethodGet, http.MethodDelete},
+				recreate: []string{http.MethodGet},
+				exists:   true,
+			},
+		},
+		"MutableFieldChanged": {
+			immutableFields: []string{"$.email"},
+			want: want{
+				update: []string{http.MethodGet, http.MethodPut},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var methods []string
+			deleted := false
+			e := &external{
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						methods = append(methods, method)
+						if method == http.MethodDelete {
+							deleted = tc.deleted
+						}
+						if method == http.MethodGet && deleted {
+							return httpClient.HttpDetails{
+								HttpRequest:  httpClient.HttpRequest{Method: method, URL: url},
+								HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusNotFound},
+							}, nil
+						}
+						return httpClient.HttpDetails{
+							HttpRequest:  httpClient.HttpRequest{Method: method, URL: url},
+							HttpResponse: httpClient.HttpResponse{Body: `{"id":"123","username":"john_doe","email":"john@example.com"}`, StatusCode: http.StatusOK},
+						}, nil
+					},
+				},
+				recorder: &MockRecorder{},
+			}
+			cr := httpRequest(func(r *v1alpha1.Request) {
+				r.Status.Response.Body = `{"id":"123"}`
+				r.Spec.ForProvider.ImmutableFields = tc.immutableFields
+			})
+
+			observation, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("e.Observe(...): unexpected error: %s", err)
+			}
+			if observation.ResourceUpToDate {
+				t.Fatalf("e.Observe(...): want the object out of date")
+			}
+			if _, err := e.Update(context.Background(), cr); err != nil {
+				t.Fatalf("e.Update(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.update, methods); diff != "" {
+				t.Errorf("e.Update(...): -want methods, +got methods: %s", diff)
+			}
+			if tc.want.recreate == nil {
+				return
+			}
+
+			methods = nil
+			e.forgetObservations()
+			observation, err = e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("e.Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.exists, observation.ResourceExists); diff != "" {
+				t.Errorf("e.Observe(...): -want exists, +got exists: %s", diff)
+			}
+			if !observation.ResourceExists {
+				if _, err := e.Create(context.Background(), cr); err != nil {
+					t.Fatalf("e.Create(...): unexpected error: %s", err)
+				}
+			}
+			if diff := cmp.Diff(tc.want.recreate, methods); diff != "" {
+				t.Errorf("e.Observe(...): -want methods, +got methods: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package request
+
+import (
+	"net/http"
+	"net/url"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+const (
+	headerContentLocation = "Content-Location"
+)
+
+// createdLocation returns the URL of the created object that the response points at
+// with its Location header, or else its Content-Location header, resolved against
+// the URL of the request. It's empty if the response has neither, or is a 202
+// Accepted response, whose Location is the status of the operation instead.
+func createdLocation(details httpClient.HttpDetails) string {
+	if details.HttpResponse.StatusCode == http.StatusAccepted {
+		return ""
+	}
+
+	headers := http.Header(details.HttpResponse.Headers)
+	location := headers.Get(headerLocation)
+	if location == "" {
+		location = headers.Get(headerContentLocation)
+	}
+	if location == "" {
+		return ""
+	}
+
+	ref, err := url.Parse(location)
+	if err != nil {
+		return ""
+	}
+	base, err := url.Parse(details.HttpRequest.URL)
+	if err != nil {
+		return location
+	}
+	return base.ResolveReference(ref).String()
+}
@@ -0,0 +1,103 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_createdLocation(t *testing.T) {
+	cases := map[string]struct {
+		statusCode int
+		headers    map[string][]string
+		want       string
+	}{
+		"Location": {
+			statusCode: http.StatusCreated,
+			headers:    map[string][]string{"Location": {"https://api.example.com/v2/users/123"}},
+			want:       "https://api.example.com/v2/users/123",
+		},
+		"RelativeLocation": {
+			statusCode: http.StatusCreated,
+			headers:    map[string][]string{"Location": {"/v2/users/123"}},
+			want:       "https://api.example.com/v2/users/123",
+		},
+		"ContentLocation": {
+			statusCode: http.StatusOK,
+			headers:    map[string][]string{"Content-Location": {"users/123"}},
+			want:       "https://api.example.com/users/123",
+		},
+		"Accepted": {
+			statusCode: http.StatusAccepted,
+			headers:    map[string][]string{"Location": {"/operations/1"}},
+		},
+		"NoLocation": {
+			statusCode: http.StatusCreated,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := createdLocation(httpClient.HttpDetails{
+				HttpRequest:  httpClient.HttpRequest{URL: "https://api.example.com/users"},
+				HttpResponse: httpClient.HttpResponse{StatusCode: tc.statusCode, Headers: tc.headers},
+			})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("createdLocation(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_httpExternal_Create_Location(t *testing.T) {
+	cases := map[string]struct {
+		statusCode int
+		want       string
+	}{
+		"Created": {
+			statusCode: http.StatusCreated,
+			want:       "https://api.example.com/v2/users/123",
+		},
+		"CreateFailed": {
+			statusCode: http.StatusBadRequest,
+			want:       "https://api.example.com/v1/users/1",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpRequest: httpClient.HttpRequest{URL: url},
+							HttpResponse: httpClient.HttpResponse{
+								StatusCode: tc.statusCode,
+								Headers:    map[string][]string{"Location": {"/v2/users/123"}},
+							},
+						}, nil
+					},
+				},
+				recorder: &MockRecorder{},
+			}
+			cr := httpRequest(func(r *v1alpha1.Request) {
+				r.Status.Location = "https://api.example.com/v1/users/1"
+			})
+
+			_, _ = e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want, cr.Status.Location); diff != "" {
+				t.Errorf("e.Create(...): -want location, +got location: %s", diff)
+			}
+		})
+	}
+}
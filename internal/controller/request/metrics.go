@@ -0,0 +1,58 @@
+package request
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	compareResultSynced    = "synced"
+	compareResultNotSynced = "not_synced"
+)
+
+// compareResults counts the outcomes of comparing the observed state of a Request
+// to its desired state. Requests are cluster scoped, so their name identifies them.
+// The series of a Request are deleted along with it, by metricsFinalizer.
+var compareResults = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "provider_http_compare_results_total",
+	Help: "Total number of comparisons between the observed and desired state, by Request and result.",
+}, []string{"request", "result"})
+
+func init() {
+	metrics.Registry.MustRegister(compareResults)
+}
+
+// observeCompareResult records the outcome of comparing the observed state of the Request.
+func observeCompareResult(name string, synced bool) {
+	result := compareResultNotSynced
+	if synced {
+		result = compareResultSynced
+	}
+
+	compareResults.WithLabelValues(name, result).Inc()
+}
+
+// forgetCompareResults deletes the series of the Request, so that the label of a
+// deleted Request isn't exposed forever.
+func forgetCompareResults(name string) {
+	compareResults.DeleteLabelValues(name, compareResultSynced)
+	compareResults.DeleteLabelValues(name, compareResultNotSynced)
+}
+
+// metricsFinalizer forgets the compare results of a Request once its finalizer is
+// removed, that is once it's no longer observed.
+type metricsFinalizer struct {
+	resource.Finalizer
+}
+
+func (f metricsFinalizer) RemoveFinalizer(ctx context.Context, obj resource.Object) error {
+	if err := f.Finalizer.RemoveFinalizer(ctx, obj); err != nil {
+		return err
+	}
+
+	forgetCompareResults(obj.GetName())
+	return nil
+}
@@ -0,0 +1,78 @@
+package request
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+)
+
+func Test_observeCompareResult(t *testing.T) {
+	observeCompareResult("metrics-test", true)
+	observeCompareResult("metrics-test", false)
+	observeCompareResult("metrics-test", false)
+
+	if diff := cmp.Diff(float64(1), testutil.ToFloat64(compareResults.WithLabelValues("metrics-test", compareResultSynced))); diff != "" {
+		t.Fatalf("observeCompareResult(...): -want synced results, +got synced results: %s", diff)
+	}
+	if diff := cmp.Diff(float64(2), testutil.ToFloat64(compareResults.WithLabelValues("metrics-test", compareResultNotSynced))); diff != "" {
+		t.Fatalf("observeCompareResult(...): -want not synced results, +got not synced results: %s", diff)
+	}
+}
+
+func Test_metricsFinalizer_RemoveFinalizer(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		err    error
+		series int
+	}
+	cases := map[string]struct {
+		finalizer resource.Finalizer
+		want      want
+	}{
+		"Removed": {
+			finalizer: resource.FinalizerFns{
+				RemoveFinalizerFn: func(context.Context, resource.Object) error { return nil },
+			},
+			want: want{
+				series: 0,
+			},
+		},
+		"RemoveFailed": {
+			// The Request is observed again, so its series are kept.
+			finalizer: resource.FinalizerFns{
+				RemoveFinalizerFn: func(context.Context, resource.Object) error { return errBoom },
+			},
+			want: want{
+				err:    errBoom,
+				series: 1,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			compareResults.Reset()
+			observeCompareResult("finalized", true)
+			observeCompareResult("other", true)
+
+			cr := &v1alpha1.Request{}
+			cr.SetName("finalized")
+			err := metricsFinalizer{Finalizer: tc.finalizer}.RemoveFinalizer(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("RemoveFinalizer(...): -want error, +got error: %s", diff)
+			}
+
+			// The series of other Requests are never deleted.
+			if diff := cmp.Diff(tc.want.series+1, testutil.CollectAndCount(compareResults)); diff != "" {
+				t.Errorf("RemoveFinalizer(...): -want series, +got series: %s", diff)
+			}
+		})
+	}
+}
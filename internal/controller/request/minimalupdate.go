@@ -0,0 +1,56 @@
+package request
+
+import (
+	ej "encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	"github.com/crossplane-contrib/provider-http/internal/json"
+)
+
+const (
+	errMinimalUpdate = "cannot compute the changed fields of the update"
+
+	msgNothingChanged = "skipping the minimal update, none of the fields of its body changed"
+)
+
+// isMinimalUpdate reports whether requests of the mapping only send the fields of
+// the body that differ from the observed state. Only a PATCH request can leave out
+// fields, which a PUT request would reset.
+func isMinimalUpdate(mapping *v1alpha1.Mapping) bool {
+	return mapping.MinimalUpdate && mapping.RoleMethod() == http.MethodPatch
+}
+
+// changedFieldsBody returns the fields of the JSON body that differ from the observed
+// state, compared like the desired state is by the GET mapping. The observed state is
+// the GET response of this reconcile, or the last one stored in the status. A body
+// that can't be compared as JSON is returned as is. It also reports whether none of
+// the fields changed, so that there's nothing to send.
+func (c *external) changedFieldsBody(cr *v1alpha1.Request, body string) (string, bool, error) {
+	observed := cr.Status.Response.Body
+	if response, ok := c.responses[http.MethodGet]; ok {
+		observed = response.Body
+	}
+
+	if !json.IsJSONString(body) || !json.IsJSONString(observed) {
+		return body, false, nil
+	}
+
+	var options *v1alpha1.CompareOptions
+	if get, ok := getMappingByMethod(&cr.Spec.ForProvider, http.MethodGet); ok {
+		options = get.CompareOptions
+	}
+	opts, err := compareOptions(options)
+	if err != nil {
+		return "", false, err
+	}
+
+	fields := json.ChangedFields(json.JsonStringToMap(observed), json.JsonStringToMap(body), opts)
+	changed, err := ej.Marshal(fields)
+	if err != nil {
+		return "", false, errors.Wrap(err, errMinimalUpdate)
+	}
+	return string(changed), len(fields) == 0, nil
+}
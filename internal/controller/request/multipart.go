@@ -0,0 +1,60 @@
+package request
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errPartValue    = "cannot get the value of multipart part %s"
+	errPartValueRef = "multipart part %s must reference either a secret or a config map key"
+)
+
+// hasPartValueRefs reports whether any multipart part of the mapping references
+// a Secret or a ConfigMap for its value.
+func hasPartValueRefs(mapping *v1alpha1.Mapping) bool {
+	for _, part := range mapping.Multipart {
+		if part.ValueFrom != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// partValues resolves the values of the multipart parts referencing a Secret or
+// a ConfigMap, by part name.
+func partValues(ctx context.Context, kube client.Client, parts []v1alpha1.MultipartPart) (map[string]string, error) {
+	values := map[string]string{}
+	for _, part := range parts {
+		if part.ValueFrom == nil {
+			continue
+		}
+
+		source := part.ValueFrom
+		if (source.SecretKeyRef == nil) == (source.ConfigMapKeyRef == nil) {
+			return nil, errors.Errorf(errPartValueRef, part.Name)
+		}
+
+		value, err := partValue(ctx, kube, *source)
+		if err != nil {
+			return nil, errors.Wrapf(err, errPartValue, part.Name)
+		}
+		values[part.Name] = value
+	}
+
+	return values, nil
+}
+
+func partValue(ctx context.Context, kube client.Client, source v1alpha1.PartValueSource) (string, error) {
+	if source.SecretKeyRef != nil {
+		return utils.GetSecretValue(ctx, kube, *source.SecretKeyRef)
+	}
+
+	ref := source.ConfigMapKeyRef
+	return utils.GetConfigMapValue(ctx, kube, ref.Namespace, ref.Name, ref.Key)
+}
@@ -0,0 +1,77 @@
+package request
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errNotify           = "failed to notify the sync state change"
+	errNotifyStatusCode = "notification rejected with status code %d"
+)
+
+// notification is the payload POSTed to the notify webhook.
+type notification struct {
+	Name string `json:"name"`
+	// PreviousSynced is left out when the object wasn't observed before, e.g.
+	// right after it was created.
+	PreviousSynced *bool `json:"previousSynced,omitempty"`
+	Synced         bool  `json:"synced"`
+	StatusCode     int   `json:"statusCode"`
+}
+
+// previouslySynced returns whether the last observation found the object synced,
+// as recorded by its Ready condition, or nil when it wasn't observed.
+func previouslySynced(cr *v1alpha1.Request) *bool {
+	ready := cr.Status.GetCondition(xpv1.TypeReady)
+	if ready.Reason != xpv1.ReasonAvailable && ready.Reason != xpv1.ReasonUnavailable {
+		return nil
+	}
+	// The message of the Ready condition lists the failed checks of an object out of date.
+	synced := ready.Message == "" || ready.Message == msgNotReady
+	return &synced
+}
+
+// notifyURL returns the URL of the webhook the ProviderConfig notifies, if any.
+func notifyURL(pc *apisv1alpha1.ProviderConfig) string {
+	if pc.Spec.Notify == nil {
+		return ""
+	}
+	return pc.Spec.Notify.URL
+}
+
+// notify POSTs the sync state of the object to the notify webhook when it changed.
+// Failures are logged, the reconcile goes on regardless.
+func (c *external) notify(ctx context.Context, cr *v1alpha1.Request, previous *bool, synced bool, statusCode int) {
+	if c.notifyURL == "" || (previous != nil && *previous == synced) {
+		return
+	}
+
+	payload, err := json.Marshal(notification{
+		Name:           cr.Name,
+		PreviousSynced: previous,
+		Synced:         synced,
+		StatusCode:     statusCode,
+	})
+	if err != nil {
+		c.logger.Info(errNotify, "error", err)
+		return
+	}
+
+	headers := map[string][]string{"Content-Type": {"application/json"}}
+	details, err := c.http.SendRequest(ctx, http.MethodPost, c.notifyURL, string(payload), headers, false)
+	if err == nil && utils.IsHTTPError(details.HttpResponse.StatusCode) {
+		err = errors.Errorf(errNotifyStatusCode, details.HttpResponse.StatusCode)
+	}
+	if err != nil {
+		c.logger.Info(errNotify, "error", err)
+	}
+}
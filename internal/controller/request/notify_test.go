@@ -0,0 +1,162 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_previouslySynced(t *testing.T) {
+	synced, drifted := true, false
+
+	type args struct {
+		ready xpv1.Condition
+	}
+	type want struct {
+		synced *bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NotObserved": {
+			args: args{
+				ready: xpv1.Creating(),
+			},
+			want: want{},
+		},
+		"Synced": {
+			args: args{
+				ready: xpv1.Available(),
+			},
+			want: want{
+				synced: &synced,
+			},
+		},
+		"SyncedNotReady": {
+			args: args{
+				ready: readyCondition("", false),
+			},
+			want: want{
+				synced: &synced,
+			},
+		},
+		"Drifted": {
+			args: args{
+				ready: readyCondition(failedChecksMessage([]string{defaultCompareCheck}), false),
+			},
+			want: want{
+				synced: &drifted,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := httpRequest(func(r *v1alpha1.Request) {
+				r.Status.SetConditions(tc.args.ready)
+			})
+			got := previouslySynced(cr)
+			if diff := cmp.Diff(tc.want.synced, got); diff != "" {
+				t.Errorf("previouslySynced(...): -want synced, +got synced: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_notify(t *testing.T) {
+	synced, drifted := true, false
+
+	type args struct {
+		notifyURL string
+		previous  *bool
+		synced    bool
+		err       error
+	}
+	type want struct {
+		bodies []string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoWebhook": {
+			args: args{
+				previous: &drifted,
+				synced:   true,
+			},
+			want: want{},
+		},
+		"Unchanged": {
+			args: args{
+				notifyURL: "http://notify.example.com",
+				previous:  &synced,
+				synced:    true,
+			},
+			want: want{},
+		},
+		"Changed": {
+			args: args{
+				notifyURL: "http://notify.example.com",
+				previous:  &drifted,
+				synced:    true,
+			},
+			want: want{
+				bodies: []string{`{"name":"example-request","previousSynced":false,"synced":true,"statusCode":200}`},
+			},
+		},
+		"FirstObservation": {
+			args: args{
+				notifyURL: "http://notify.example.com",
+				synced:    false,
+			},
+			want: want{
+				bodies: []string{`{"name":"example-request","synced":false,"statusCode":200}`},
+			},
+		},
+		"NotificationFailed": {
+			args: args{
+				notifyURL: "http://notify.example.com",
+				previous:  &synced,
+				synced:    false,
+				err:       errors.New("boom"),
+			},
+			want: want{
+				bodies: []string{`{"name":"example-request","previousSynced":true,"synced":false,"statusCode":200}`},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var bodies []string
+			e := &external{
+				logger:    logging.NewNopLogger(),
+				notifyURL: tc.args.notifyURL,
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						if method != http.MethodPost || url != tc.args.notifyURL {
+							t.Errorf("SendRequest(...): unexpected %s request to %s", method, url)
+						}
+						bodies = append(bodies, body)
+						return httpClient.HttpDetails{}, tc.args.err
+					},
+				},
+			}
+			cr := httpRequest(func(r *v1alpha1.Request) {
+				r.Name = "example-request"
+			})
+
+			e.notify(context.Background(), cr, tc.args.previous, tc.args.synced, http.StatusOK)
+			if diff := cmp.Diff(tc.want.bodies, bodies); diff != "" {
+				t.Errorf("notify(...): -want bodies, +got bodies: %s", diff)
+			}
+		})
+	}
+}
@@ -5,27 +5,95 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	ej "encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	"github.com/crossplane-contrib/provider-http/internal/cel"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/responseconverter"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
 	"github.com/crossplane-contrib/provider-http/internal/json"
 	"github.com/crossplane-contrib/provider-http/internal/utils"
+	"github.com/crossplane-contrib/provider-http/internal/xml"
 	"github.com/pkg/errors"
 )
 
 const (
-	errObjectNotFound = "object wasn't found"
-	errNotValidJSON   = "%s is not a valid JSON string: %s"
+	errObjectNotFound     = "object wasn't found"
+	errNotValidJSON       = "%s is not a valid JSON string: %s"
+	errNotFoundCondition  = "cannot evaluate the not found condition"
+	errCompareExpression  = "cannot evaluate the compare expression"
+	errCompareXML         = "cannot compare the response to the desired state as XML"
+	errComparePatch       = "cannot apply the JSON Patch to the response"
+	errCompareList        = "cannot compare the list of the response to the desired state"
+	errDiff               = "cannot diff the response and the desired state"
+	errRedactDesiredState = "cannot redact the desired state"
+	errIgnoreFields       = "cannot remove the ignored fields from the response and the desired state"
+	errObservationFailed  = "cannot determine the state of the object, will retry"
+	errAggregateResponse  = "cannot aggregate the NDJSON response"
+	errNumericTolerance   = "numeric tolerance %q is not a non-negative number"
+	errResponseRoot       = "cannot select the response root"
+
+	responseFormatNDJSON       = "ndjson"
+	defaultResponseAggregation = "last"
+
+	defaultCompareCheck = "desired state comparison"
+	statusCompareCheck  = "response status comparison"
+	msgChecksFailed     = "observed state is out of date: %s failed"
+	msgPatchOperation   = "%s (%s %s)"
+	msgListDifferences  = "%s (%s)"
 )
 
 type ObserveRequestDetails struct {
 	Details       httpClient.HttpDetails
 	ResponseError error
 	Synced        bool
+	// FailedChecks are the names of the comparisons to the desired state that
+	// failed, if the observed state isn't synced.
+	FailedChecks []string
+	// Diff describes the fields of the desired state that differ from the
+	// observed state, if the default comparison failed.
+	Diff string
+	// DesiredState is the desired state the observed state was compared to, as
+	// it's kept in the status.
+	DesiredState string
+	// Recreate means that the observed state isn't synced because of a changed
+	// immutable field, so the object must be created again rather than updated.
+	Recreate bool
+}
+
+// observationFailedError means that the state of the object couldn't be determined,
+// e.g. because of a transient server error, as opposed to the object being confirmed
+// absent by the not found check.
+type observationFailedError struct {
+	err error
+}
+
+func (e *observationFailedError) Error() string {
+	return errObservationFailed + ": " + e.err.Error()
+}
+
+func (e *observationFailedError) Unwrap() error {
+	return e.err
+}
+
+// isObservationFailed reports whether err means that the state of the object couldn't
+// be determined.
+func isObservationFailed(err error) bool {
+	var observationErr *observationFailedError
+	return errors.As(err, &observationErr)
+}
+
+// isTransientFailure reports whether the status code of the GET response means that
+// the API couldn't answer for now, rather than anything about the object.
+func isTransientFailure(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
 }
 
 // NewObserveRequestDetails is a constructor function that initializes
@@ -52,48 +120,603 @@ func (c *external) isUpToDate(ctx context.Context, cr *v1alpha1.Request) (Observ
 		return FailedObserve(), errors.New(errObjectNotFound)
 	}
 
-	requestDetails, err := c.requestDetails(cr, http.MethodGet)
+	mapping, ok := getMappingByMethod(&cr.Spec.ForProvider, http.MethodGet)
+	if !ok {
+		return FailedObserve(), errors.Errorf(errMappingNotFound, http.MethodGet)
+	}
+
+	if head, ok := getMappingByMethod(&cr.Spec.ForProvider, http.MethodHead); ok {
+		if err := c.checkExistence(ctx, cr, head); err != nil {
+			return FailedObserve(), err
+		}
+	}
+
+	requestDetails, err := c.generateValidRequestDetails(cr, mapping)
 	if err != nil {
 		return FailedObserve(), err
 	}
 
-	details, responseErr := c.http.SendRequest(ctx, http.MethodGet, requestDetails.Url, requestDetails.Body, requestDetails.Headers, cr.Spec.ForProvider.InsecureSkipTLSVerify)
-	if details.HttpResponse.StatusCode == http.StatusNotFound {
+	// The following pages of a paginated response are requested without the entity tag.
+	pageHeaders := requestDetails.Headers
+	etag := conditionalObserveETag(cr)
+	if etag != "" {
+		requestDetails.Headers = withHeader(requestDetails.Headers, headerIfNoneMatch, etag)
+	}
+
+	requestCtx, cancel := requestContext(ctx, mapping)
+	defer cancel()
+
+	details, responseErr := c.sendObserveRequest(requestCtx, cr, mapping, requestDetails)
+	if responseErr != nil {
+		return FailedObserve(), &observationFailedError{err: responseErr}
+	}
+
+	if etag != "" && details.HttpResponse.StatusCode == http.StatusNotModified {
+		expiry := details.HttpResponse.CertificateExpiry
+		details.HttpResponse = storedResponse(cr)
+		details.HttpResponse.CertificateExpiry = expiry
+		return c.compareObserved(cr, mapping, details)
+	}
+
+	// Only the not found check confirms that the object is absent, a failure to get it doesn't.
+	notFound, err := isNotFound(mapping.NotFoundCheck, details.HttpResponse)
+	if err != nil {
+		return FailedObserve(), err
+	}
+	if notFound {
 		return FailedObserve(), errors.New(errObjectNotFound)
 	}
+	if utils.IsRateLimited(details.HttpResponse.StatusCode) {
+		return FailedObserve(), &observationFailedError{err: utils.RateLimitError(mapping.Method, details.HttpResponse.Headers)}
+	}
+	if isTransientFailure(details.HttpResponse.StatusCode) {
+		body := redactedBody(details.HttpResponse.Body, cr.Spec.ForProvider.SecretFields)
+		return FailedObserve(), &observationFailedError{err: utils.StatusCodeError(mapping.Method, details.HttpResponse.StatusCode, body)}
+	}
+
+	details.HttpResponse, err = aggregateResponse(mapping, details.HttpResponse)
+	if err != nil {
+		return FailedObserve(), err
+	}
 
-	desiredState, err := c.desiredState(cr)
+	details, err = c.selectPage(ctx, cr, mapping, requestDetails.Url, pageHeaders, details)
 	if err != nil {
 		return FailedObserve(), err
 	}
 
-	var comparetype string
-	for _, s := range cr.Spec.ForProvider.Mappings {
-		if s.CompareType != "" {
-			comparetype = s.CompareType
-			break
+	if err := c.validateResponse(ctx, mapping, details.HttpResponse); err != nil {
+		return FailedObserve(), err
+	}
+
+	return c.compareObserved(cr, mapping, details)
+}
+
+// compareObserved compares the observed response to the desired state.
+func (c *external) compareObserved(cr *v1alpha1.Request, mapping *v1alpha1.Mapping, details httpClient.HttpDetails) (ObserveRequestDetails, error) {
+	c.recordResponse(http.MethodGet, details.HttpResponse)
+
+	success, err := utils.IsResponseSuccess(details.HttpResponse, mapping.SuccessExpression, mapping.ExpectedStatusCodes)
+	if err != nil {
+		return FailedObserve(), err
+	}
+
+	// A successful response is all that's compared when the GET mapping compares its status only.
+	if mapping.CompareStatusOnly {
+		observeRequestDetails := NewObserve(details, nil, success)
+		if !success {
+			observeRequestDetails.FailedChecks = []string{statusCompareCheck}
+		}
+		observeCompareResult(cr.Name, success)
+		return observeRequestDetails, nil
+	}
+
+	desiredState, bodyType, err := c.desiredState(cr)
+	if err != nil {
+		return FailedObserve(), err
+	}
+
+	// The observed state is synced only if it passes every check.
+	observeRequestDetails := NewObserve(details, nil, true)
+	observeRequestDetails.DesiredState, err = c.statusDesiredState(cr, desiredState, bodyType)
+	if err != nil {
+		return FailedObserve(), err
+	}
+	for _, check := range getCompareChecks(&cr.Spec.ForProvider) {
+		name := check.name
+		var result ObserveRequestDetails
+		switch check.mapping.CompareType {
+		case "jsonpatch":
+			result, name, err = c.comparePatch(cr, check, details, success)
+		case "keyedlist":
+			result, name, err = c.compareKeyedList(check, details, desiredState, bodyType, success)
+		default:
+			result, err = c.compareResponseAndDesiredState(details, nil, desiredState, bodyType, check.mapping, success)
+		}
+		if err != nil {
+			return FailedObserve(), err
+		}
+		if !result.Synced {
+			observeRequestDetails.Synced = false
+			observeRequestDetails.FailedChecks = append(observeRequestDetails.FailedChecks, name)
+		}
+	}
+
+	if slices.Contains(observeRequestDetails.FailedChecks, defaultCompareCheck) && success {
+		fromSecret, err := c.isDesiredStateFromSecret(cr)
+		if err != nil {
+			return FailedObserve(), err
 		}
+		observeRequestDetails.Diff, err = diffDesiredState(cr, details.HttpResponse.Body, desiredState, bodyType, mapping, fromSecret)
+		if err != nil {
+			return FailedObserve(), err
+		}
+	}
+	if !observeRequestDetails.Synced && success {
+		observeRequestDetails.Recreate, err = immutableFieldsChanged(cr, mapping, details.HttpResponse.Body, desiredState, bodyType)
+		if err != nil {
+			return FailedObserve(), err
+		}
+	}
+
+	observeCompareResult(cr.Name, observeRequestDetails.Synced)
+	return observeRequestDetails, nil
+}
+
+// comparePatch reports whether applying the JSON Patch body of the check's mapping
+// leaves the response as it is. When it doesn't, the name of the check is returned
+// with the first operation that would change the response.
+func (c *external) comparePatch(cr *v1alpha1.Request, check compareCheck, details httpClient.HttpDetails, success bool) (ObserveRequestDetails, string, error) {
+	observeRequestDetails := NewObserve(details, nil, false)
+	if !success {
+		return observeRequestDetails, check.name, nil
+	}
+
+	requestDetails, err := c.generateValidRequestDetails(cr, &check.mapping)
+	if err != nil {
+		return FailedObserve(), "", err
+	}
+	opts, err := compareOptions(check.mapping.CompareOptions)
+	if err != nil {
+		return FailedObserve(), "", err
+	}
+
+	operation, err := json.ChangingOperation(details.HttpResponse.Body, requestDetails.Body, opts)
+	if err != nil {
+		return FailedObserve(), "", errors.Wrap(err, errComparePatch)
+	}
+	if operation != nil {
+		return observeRequestDetails, fmt.Sprintf(msgPatchOperation, check.name, operation.Op, operation.Path), nil
+	}
+
+	observeRequestDetails.Synced = true
+	return observeRequestDetails, check.name, nil
+}
+
+// compareKeyedList compares the list of the check's mapping item by item, matching
+// the items of the response and the desired state by their key. When they differ,
+// the name of the check is returned with the keys of the missing, extra and changed
+// items.
+func (c *external) compareKeyedList(check compareCheck, details httpClient.HttpDetails, desiredState string, bodyType string, success bool) (ObserveRequestDetails, string, error) {
+	body, found, err := responseRoot(check.mapping.ResponseRoot, details.HttpResponse.Body)
+	if err != nil {
+		return FailedObserve(), "", err
+	}
+	if !requestgen.IsJSONBody(bodyType) || !found || !json.IsJSONString(body) || !json.IsJSONString(desiredState) || check.mapping.CompareList == nil {
+		result, err := c.compareResponseAndDesiredState(details, nil, desiredState, bodyType, check.mapping, success)
+		return result, check.name, err
+	}
+
+	observeRequestDetails := NewObserve(details, nil, false)
+	if !success {
+		return observeRequestDetails, check.name, nil
+	}
+
+	responseBodyMap := json.JsonStringToMap(body)
+	desiredStateMap := json.JsonStringToMap(desiredState)
+	opts, err := compareOptions(check.mapping.CompareOptions)
+	if err != nil {
+		return FailedObserve(), "", err
+	}
+	if err := ignoreFields(check.mapping.IgnoreFields, responseBodyMap, desiredStateMap); err != nil {
+		return FailedObserve(), "", err
+	}
+
+	list := check.mapping.CompareList
+	synced, differences, err := json.CompareKeyedList(responseBodyMap, desiredStateMap, list.Path, list.Key, opts)
+	if err != nil {
+		return FailedObserve(), "", errors.Wrap(err, errCompareList)
+	}
+	observeRequestDetails.Synced = synced
+	if differences.Empty() {
+		return observeRequestDetails, check.name, nil
+	}
+	return observeRequestDetails, fmt.Sprintf(msgListDifferences, check.name, listDifferences(list.Key, differences)), nil
+}
+
+// listDifferences describes the items of a list that differ, e.g. `missing id 3; changed id 1, 2`.
+func listDifferences(key string, differences json.ListDifferences) string {
+	var described []string
+	for _, group := range []struct {
+		name string
+		keys []string
+	}{
+		{name: "missing", keys: differences.Missing},
+		{name: "extra", keys: differences.Extra},
+		{name: "changed", keys: differences.Changed},
+	} {
+		if len(group.keys) > 0 {
+			described = append(described, fmt.Sprintf("%s %s %s", group.name, key, strings.Join(group.keys, ", ")))
+		}
+	}
+	return strings.Join(described, "; ")
+}
+
+// diffDesiredState describes the fields of a JSON desired state that differ from the
+// observed state per the GET mapping, with the secret fields redacted in both, and
+// every value masked when the desired state comes from a Secret.
+func diffDesiredState(cr *v1alpha1.Request, observed string, desiredState string, bodyType string, mapping *v1alpha1.Mapping, fromSecret bool) (string, error) {
+	observed, found, err := responseRoot(mapping.ResponseRoot, observed)
+	if err != nil {
+		return "", err
+	}
+	if !requestgen.IsJSONBody(bodyType) || !found || !json.IsJSONString(observed) || !json.IsJSONString(desiredState) {
+		return "", nil
+	}
+
+	opts, err := compareOptions(mapping.CompareOptions)
+	if err != nil {
+		return "", err
+	}
+
+	observed, err = json.RedactJSONString(observed, cr.Spec.ForProvider.SecretFields)
+	if err != nil {
+		return "", errors.Wrap(err, errDiff)
+	}
+	desiredState, err = json.RedactJSONString(desiredState, cr.Spec.ForProvider.SecretFields)
+	if err != nil {
+		return "", errors.Wrap(err, errDiff)
+	}
+
+	observedMap, desiredStateMap := json.JsonStringToMap(observed), json.JsonStringToMap(desiredState)
+	if err := ignoreFields(mapping.IgnoreFields, observedMap, desiredStateMap); err != nil {
+		return "", errors.Wrap(err, errDiff)
+	}
+
+	differences := json.Diff(observedMap, desiredStateMap, opts)
+	if len(differences) == 0 {
+		return "", nil
+	}
+	if fromSecret {
+		for path, difference := range differences {
+			masked := json.Difference{Desired: json.RedactedPlaceholder}
+			if difference.Observed != nil {
+				masked.Observed = json.RedactedPlaceholder
+			}
+			differences[path] = masked
+		}
+	}
+
+	diff, err := ej.Marshal(differences)
+	if err != nil {
+		return "", errors.Wrap(err, errDiff)
+	}
+	return string(diff), nil
+}
+
+// statusDesiredState returns the desired state as it's kept in the status, with the
+// secret fields redacted, or masked when it's the body of a mapping from a Secret.
+// Multipart bodies aren't compared, so they aren't kept.
+func (c *external) statusDesiredState(cr *v1alpha1.Request, desiredState string, bodyType string) (string, error) {
+	if bodyType == requestgen.BodyTypeMultipart {
+		return "", nil
+	}
+	fromSecret, err := c.isDesiredStateFromSecret(cr)
+	if err != nil {
+		return "", err
+	}
+	if fromSecret {
+		return json.RedactedPlaceholder, nil
+	}
+
+	redacted, err := json.RedactJSONString(desiredState, cr.Spec.ForProvider.SecretFields)
+	if err != nil {
+		return "", errors.Wrap(err, errRedactDesiredState)
+	}
+	return redacted, nil
+}
+
+// isDesiredStateFromSecret reports whether the desired state is the body of a mapping
+// read from a Secret, rather than the expected response of the GET mapping.
+func (c *external) isDesiredStateFromSecret(cr *v1alpha1.Request) (bool, error) {
+	if get, ok := getMappingByMethod(&cr.Spec.ForProvider, http.MethodGet); ok && get.ExpectedResponse != "" {
+		return false, nil
+	}
+
+	mapping, ok, err := c.desiredStateMapping(cr)
+	if err != nil || !ok {
+		return false, err
+	}
+	return mapping.BodyFrom != nil && mapping.BodyFrom.SecretKeyRef != nil, nil
+}
+
+// failedChecksMessage describes the checks that the observed state failed.
+func failedChecksMessage(failedChecks []string) string {
+	if len(failedChecks) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(msgChecksFailed, strings.Join(failedChecks, ", "))
+}
+
+// aggregateResponse replaces the body of a successful NDJSON response to the GET mapping
+// with the aggregation of its documents, so that it's observed as a single document.
+func aggregateResponse(mapping *v1alpha1.Mapping, response httpClient.HttpResponse) (httpClient.HttpResponse, error) {
+	if mapping.ResponseFormat != responseFormatNDJSON || !utils.IsHTTPSuccessFor(response.StatusCode, mapping.ExpectedStatusCodes) {
+		return response, nil
+	}
+
+	documents, err := httpClient.DecodeNDJSON(response.Body)
+	if err != nil {
+		return httpClient.HttpResponse{}, errors.Wrap(err, errAggregateResponse)
+	}
+
+	aggregation := mapping.ResponseAggregation
+	if aggregation == "" {
+		aggregation = defaultResponseAggregation
 	}
 
-	return c.compareResponseAndDesiredState(details, responseErr, desiredState, comparetype)
+	aggregated, err := jq.ParseValue(aggregation, documents)
+	if err != nil {
+		return httpClient.HttpResponse{}, errors.Wrap(err, errAggregateResponse)
+	}
+	if aggregated == nil {
+		return httpClient.HttpResponse{}, errors.New(errObjectNotFound)
+	}
+
+	body, err := ej.Marshal(aggregated)
+	if err != nil {
+		return httpClient.HttpResponse{}, errors.Wrap(err, errAggregateResponse)
+	}
+
+	response.Body = string(body)
+	return response, nil
+}
+
+// selectResponse narrows a successful response to the GET mapping down to the object
+// chosen by its responseSelector, if one is set.
+func selectResponse(cr *v1alpha1.Request, mapping *v1alpha1.Mapping, response httpClient.HttpResponse) (httpClient.HttpResponse, error) {
+	if mapping.ResponseSelector == "" || !utils.IsHTTPSuccessFor(response.StatusCode, mapping.ExpectedStatusCodes) {
+		return response, nil
+	}
+
+	selected, ok, err := requestgen.SelectResponse(mapping.ResponseSelector, mapping.UniqueResponse, cr.Spec.ForProvider, responseconverter.HttpResponseToV1alpha1Response(response))
+	if err != nil {
+		return httpClient.HttpResponse{}, err
+	}
+	if !ok {
+		return httpClient.HttpResponse{}, errors.New(errObjectNotFound)
+	}
+
+	response.Body = selected.Body
+	return response, nil
+}
+
+// responseRoot returns the part of a JSON response body that is compared to the desired
+// state: the first value selected by the response root, or the whole body without
+// one. It isn't found when the response root selects nothing.
+func responseRoot(root string, body string) (string, bool, error) {
+	if root == "" || !json.IsJSONString(body) {
+		return body, true, nil
+	}
+
+	values, found, err := json.QueryJSONPath(json.JsonStringToMap(body), root)
+	if err != nil {
+		return "", false, errors.Wrap(err, errResponseRoot)
+	}
+	if !found {
+		return "", false, nil
+	}
+
+	selected, err := ej.Marshal(values[0])
+	if err != nil {
+		return "", false, errors.Wrap(err, errResponseRoot)
+	}
+	return string(selected), true, nil
 }
 
 func (c *external) isObjectValidForObservation(cr *v1alpha1.Request) bool {
-	return cr.Status.Response.Body != "" &&
-		!(cr.Status.RequestDetails.Method == http.MethodPost && utils.IsHTTPError(cr.Status.Response.StatusCode))
+	// An object adopted after its create found it already existing, or created by a completed
+	// async operation, is observed even if the response had no body. Observe only gets here
+	// while the operation is awaited once it completed.
+	return (cr.Status.Response.Body != "" || c.isLastCreateAdopted(cr) || isAwaitingAsyncOperation(cr)) &&
+		!(c.isLastCreateFailed(cr) && !cr.Spec.ForProvider.ObserveAfterFailedCreate) &&
+		!c.isLastResponseNotFound(cr)
 }
 
-func (c *external) compareResponseAndDesiredState(details httpClient.HttpDetails, err error, desiredState string, comparetype string) (ObserveRequestDetails, error) {
+// isLastCreateFailed reports whether the last request recorded in the status is a
+// create request that failed, rather than found the object already existing.
+func (c *external) isLastCreateFailed(cr *v1alpha1.Request) bool {
+	if cr.Spec.ForProvider.RoleOf(cr.Status.RequestDetails.Method) != http.MethodPost {
+		return false
+	}
+
+	var expectedStatusCodes []int
+	if mapping, ok, err := c.selectMapping(cr, http.MethodPost); err == nil && ok {
+		expectedStatusCodes = mapping.ExpectedStatusCodes
+	}
+	return utils.IsHTTPErrorFor(cr.Status.Response.StatusCode, expectedStatusCodes) && !c.isLastCreateAdopted(cr)
+}
+
+// isLastResponseNotFound reports whether the last response recorded in the status is
+// an observe response meaning that the object doesn't exist.
+func (c *external) isLastResponseNotFound(cr *v1alpha1.Request) bool {
+	if cr.Spec.ForProvider.RoleOf(cr.Status.RequestDetails.Method) != http.MethodGet {
+		return false
+	}
+
+	mapping, ok := getMappingByMethod(&cr.Spec.ForProvider, http.MethodGet)
+	if !ok {
+		return false
+	}
+
+	notFound, err := isNotFound(mapping.NotFoundCheck, responseconverter.V1alpha1ResponseToHttpResponse(cr.Status.Response))
+	return err == nil && notFound
+}
+
+// checkExistence sends the HEAD mapping request, so that an object that doesn't exist
+// is detected without getting its body. It returns errObjectNotFound if the object
+// doesn't exist, and an observationFailedError if its existence can't be determined.
+func (c *external) checkExistence(ctx context.Context, cr *v1alpha1.Request, mapping *v1alpha1.Mapping) error {
+	requestDetails, err := c.generateValidRequestDetails(cr, mapping)
+	if err != nil {
+		return err
+	}
+
+	requestCtx, cancel := requestContext(ctx, mapping)
+	defer cancel()
+
+	details, err := c.sendObservation(requestCtx, mapping.Method, requestDetails.Url, "", requestDetails.Headers, insecureSkipTLSVerify(cr, mapping))
+	if err != nil {
+		return &observationFailedError{err: err}
+	}
+
+	notFound, err := isNotFound(mapping.NotFoundCheck, details.HttpResponse)
+	if err != nil {
+		return err
+	}
+	if notFound {
+		return errors.New(errObjectNotFound)
+	}
+	if utils.IsRateLimited(details.HttpResponse.StatusCode) {
+		return &observationFailedError{err: utils.RateLimitError(mapping.Method, details.HttpResponse.Headers)}
+	}
+	if isTransientFailure(details.HttpResponse.StatusCode) {
+		return &observationFailedError{err: utils.StatusCodeError(mapping.Method, details.HttpResponse.StatusCode, "")}
+	}
+
+	return nil
+}
+
+// isNotFound reports whether the response to the GET mapping means that the object
+// doesn't exist, by default when its status code is 404.
+func isNotFound(check *v1alpha1.NotFoundCheck, response httpClient.HttpResponse) (bool, error) {
+	if check == nil {
+		return response.StatusCode == http.StatusNotFound, nil
+	}
+
+	statusCodes := check.StatusCodes
+	if len(statusCodes) == 0 {
+		statusCodes = []int{http.StatusNotFound}
+	}
+	if slices.Contains(statusCodes, response.StatusCode) {
+		return true, nil
+	}
+
+	if check.Condition == "" {
+		return false, nil
+	}
+
+	notFound, err := isResponseMatching(check.Condition, response)
+	if err != nil {
+		return false, errors.Wrap(err, errNotFoundCondition)
+	}
+
+	return notFound, nil
+}
+
+// compareResponseAndDesiredState compares the response to the desired state, as JSON or XML
+// documents according to the body type of the desired state. A multipart desired state is
+// never compared. Any other desired state must be contained in the response, and the
+// response must be successful.
+func (c *external) compareResponseAndDesiredState(details httpClient.HttpDetails, err error, desiredState string, bodyType string, compareMapping v1alpha1.Mapping, success bool) (ObserveRequestDetails, error) {
 	observeRequestDetails := NewObserve(details, err, false)
 
+	body, found, rootErr := responseRoot(compareMapping.ResponseRoot, details.HttpResponse.Body)
+	if rootErr != nil {
+		return FailedObserve(), rootErr
+	}
+	if !found {
+		return observeRequestDetails, nil
+	}
+	details.HttpResponse.Body = body
+
+	// A multipart body describes an upload rather than the uploaded object, so only a
+	// jq or CEL comparison, which gets the response alone, can tell whether it's up to date.
+	if bodyType == requestgen.BodyTypeMultipart {
+		observeRequestDetails.Synced = success
+		switch compareMapping.CompareType {
+		case "jq":
+			equal, err := jq.ParseBool(compareMapping.CompareExpression, map[string]interface{}{
+				"response": json.JsonStringToMap(details.HttpResponse.Body),
+			})
+			if err != nil {
+				return FailedObserve(), errors.Wrap(err, errCompareExpression)
+			}
+			observeRequestDetails.Synced = equal && success
+		case "cel":
+			equal, err := cel.ParseBool(compareMapping.CompareExpression, json.JsonStringToMap(details.HttpResponse.Body), nil)
+			if err != nil {
+				return FailedObserve(), errors.Wrap(err, errCompareExpression)
+			}
+			observeRequestDetails.Synced = equal && success
+		}
+		return observeRequestDetails, nil
+	}
+
+	if bodyType == requestgen.BodyTypeXML {
+		contains, err := xml.Contains(details.HttpResponse.Body, desiredState)
+		if err != nil {
+			return FailedObserve(), errors.Wrap(err, errCompareXML)
+		}
+		observeRequestDetails.Synced = contains && success
+		return observeRequestDetails, nil
+	}
+
+	if bodyType == requestgen.BodyTypeForm {
+		observeRequestDetails.Synced = containsForm(details.HttpResponse.Body, desiredState, compareMapping.CompareOptions) && success
+		return observeRequestDetails, nil
+	}
+
+	if !requestgen.IsJSONBody(bodyType) {
+		observeRequestDetails.Synced = containsText(details.HttpResponse.Body, desiredState, compareMapping.CompareOptions) && success
+		return observeRequestDetails, nil
+	}
+
 	if json.IsJSONString(details.HttpResponse.Body) && json.IsJSONString(desiredState) {
 		responseBodyMap := json.JsonStringToMap(details.HttpResponse.Body)
 		desiredStateMap := json.JsonStringToMap(desiredState)
+		opts, err := compareOptions(compareMapping.CompareOptions)
+		if err != nil {
+			return FailedObserve(), err
+		}
+		if err := ignoreFields(compareMapping.IgnoreFields, responseBodyMap, desiredStateMap); err != nil {
+			return FailedObserve(), err
+		}
 
-		switch comparetype {
+		switch compareMapping.CompareType {
+		case "jq":
+			equal, err := jq.ParseBool(compareMapping.CompareExpression, map[string]interface{}{
+				"response": responseBodyMap,
+				"desired":  desiredStateMap,
+			})
+			if err != nil {
+				return FailedObserve(), errors.Wrap(err, errCompareExpression)
+			}
+			observeRequestDetails.Synced = equal && success
+		case "cel":
+			equal, err := cel.ParseBool(compareMapping.CompareExpression, responseBodyMap, desiredStateMap)
+			if err != nil {
+				return FailedObserve(), errors.Wrap(err, errCompareExpression)
+			}
+			observeRequestDetails.Synced = equal && success
+		case "jsonpath":
+			equal, err := json.EqualAtJSONPaths(responseBodyMap, desiredStateMap, compareMapping.ComparePaths, opts)
+			if err != nil {
+				return FailedObserve(), err
+			}
+			observeRequestDetails.Synced = equal && success
 		case "gitlab-file":
 			hash := sha256.Sum256([]byte(desiredStateMap["content"].(string)))
-			observeRequestDetails.Synced = hex.EncodeToString(hash[:]) == responseBodyMap["content_sha256"].(string) && utils.IsHTTPSuccess(details.HttpResponse.StatusCode)
+			observeRequestDetails.Synced = hex.EncodeToString(hash[:]) == responseBodyMap["content_sha256"].(string) && success
 		case "harbor-robot":
 			delete(responseBodyMap, "update_time")
 			delete(desiredStateMap, "update_time")
@@ -115,7 +738,7 @@ func (c *external) compareResponseAndDesiredState(details httpClient.HttpDetails
 			slices.SortStableFunc(desiredStateMap["permissions"].([]interface{}), comp)
 			fallthrough
 		default:
-			observeRequestDetails.Synced = json.Contains(responseBodyMap, desiredStateMap) && utils.IsHTTPSuccess(details.HttpResponse.StatusCode)
+			observeRequestDetails.Synced = json.Contains(responseBodyMap, desiredStateMap, opts) && success
 		}
 
 		return observeRequestDetails, nil
@@ -126,23 +749,124 @@ func (c *external) compareResponseAndDesiredState(details httpClient.HttpDetails
 	}
 
 	if json.IsJSONString(details.HttpResponse.Body) && !json.IsJSONString(desiredState) {
-		return FailedObserve(), errors.Errorf(errNotValidJSON, "PUT mapping result", desiredState)
+		return FailedObserve(), errors.Errorf(errNotValidJSON, "desired state", desiredState)
 	}
 
-	observeRequestDetails.Synced = strings.Contains(details.HttpResponse.Body, desiredState) && utils.IsHTTPSuccess(details.HttpResponse.StatusCode)
+	observeRequestDetails.Synced = containsText(details.HttpResponse.Body, desiredState, compareMapping.CompareOptions) && success
 	return observeRequestDetails, nil
 }
 
-func (c *external) desiredState(cr *v1alpha1.Request) (string, error) {
-	requestDetails, err := c.requestDetails(cr, http.MethodPut)
-	return requestDetails.Body, err
+// redactedBody returns the body with its secret fields redacted, for an error
+// message. A body that can't be redacted is replaced by the placeholder as a whole.
+func redactedBody(body string, secretFields []string) string {
+	redacted, err := json.RedactJSONString(body, secretFields)
+	if err != nil {
+		return json.RedactedPlaceholder
+	}
+	return redacted
+}
+
+// containsText reports whether a response that isn't compared as a JSON or XML
+// document contains the desired state, normalized as the options allow.
+func containsText(response string, desiredState string, options *v1alpha1.CompareOptions) bool {
+	if options != nil && options.NormalizeWhitespace {
+		response, desiredState = normalizeWhitespace(response), normalizeWhitespace(desiredState)
+	}
+	if options != nil && options.IgnoreCase {
+		response, desiredState = strings.ToLower(response), strings.ToLower(desiredState)
+	}
+	return strings.Contains(response, desiredState)
+}
+
+// containsForm reports whether every field of a form body is in the response, with
+// each of its values. The response is either form-encoded or a JSON object, whose
+// fields are encoded like the form body's, so fields may come in any order.
+func containsForm(response string, desiredState string, options *v1alpha1.CompareOptions) bool {
+	desired, err := url.ParseQuery(desiredState)
+	if err != nil {
+		return false
+	}
+
+	var observed url.Values
+	if json.IsJSONString(response) {
+		observed = requestgen.FormValues(json.JsonStringToMap(response))
+	} else if observed, err = url.ParseQuery(strings.TrimSpace(response)); err != nil {
+		return false
+	}
+
+	for key, values := range desired {
+		for _, value := range values {
+			if !slices.ContainsFunc(observed[key], func(v string) bool { return equalText(v, value, options) }) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// equalText reports whether two values are equal, normalized as the options allow.
+func equalText(observed string, desired string, options *v1alpha1.CompareOptions) bool {
+	if options != nil && options.NormalizeWhitespace {
+		observed, desired = normalizeWhitespace(observed), normalizeWhitespace(desired)
+	}
+	if options != nil && options.IgnoreCase {
+		return strings.EqualFold(observed, desired)
+	}
+	return observed == desired
+}
+
+// normalizeWhitespace replaces every run of whitespace, line endings included, with a
+// single space, and trims the text.
+func normalizeWhitespace(text string) string {
+	return strings.Join(strings.Fields(text), " ")
 }
 
-func (c *external) requestDetails(cr *v1alpha1.Request, method string) (requestgen.RequestDetails, error) {
-	mapping, ok := getMappingByMethod(&cr.Spec.ForProvider, method)
+// ignoreFields removes the fields ignored by a comparison from the response and the desired state.
+func ignoreFields(paths []string, response map[string]interface{}, desiredState map[string]interface{}) error {
+	if err := json.DeleteJSONPaths(response, paths); err != nil {
+		return errors.Wrap(err, errIgnoreFields)
+	}
+	return errors.Wrap(json.DeleteJSONPaths(desiredState, paths), errIgnoreFields)
+}
+
+// desiredState returns the body describing the desired state, and its body type.
+func (c *external) desiredState(cr *v1alpha1.Request) (string, string, error) {
+	if get, ok := getMappingByMethod(&cr.Spec.ForProvider, http.MethodGet); ok && get.ExpectedResponse != "" {
+		return get.ExpectedResponse, requestgen.BodyTypeJSON, nil
+	}
+
+	mapping, ok, err := c.desiredStateMapping(cr)
+	if err != nil {
+		return "", "", err
+	}
 	if !ok {
-		return requestgen.RequestDetails{}, errors.Errorf(errMappingNotFound, method)
+		return "", "", errors.Errorf(errMappingNotFound, http.MethodPatch)
 	}
 
-	return generateValidRequestDetails(cr, mapping)
+	requestDetails, err := c.generateValidRequestDetails(cr, mapping)
+	if err != nil {
+		return "", "", err
+	}
+	return requestDetails.Body, requestgen.BodyType(*mapping), nil
+}
+
+// compareOptions converts the compare options of a mapping to those of the JSON
+// comparison.
+func compareOptions(options *v1alpha1.CompareOptions) (json.CompareOptions, error) {
+	if options == nil {
+		return json.CompareOptions{}, nil
+	}
+
+	opts := json.CompareOptions{
+		IgnoreArrayOrder:  options.IgnoreArrayOrder,
+		TreatNullAsAbsent: options.TreatNullAsAbsent,
+	}
+	if options.NumericTolerance != "" {
+		tolerance, err := strconv.ParseFloat(options.NumericTolerance, 64)
+		if err != nil || tolerance < 0 {
+			return json.CompareOptions{}, errors.Errorf(errNumericTolerance, options.NumericTolerance)
+		}
+		opts.NumericTolerance = tolerance
+	}
+	return opts, nil
 }
@@ -4,9 +4,13 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
@@ -70,6 +74,41 @@ func Test_isUpToDate(t *testing.T) {
 				err: errNotFound,
 			},
 		},
+		"ObserveAfterFailedCreate": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"john_doe_new_username"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Spec.ForProvider.ObserveAfterFailedCreate = true
+					r.Status.RequestDetails.Method = http.MethodPost
+					r.Status.Response.Body = `{"id":"123","error":"username is taken"}`
+					r.Status.Response.StatusCode = 409
+				}),
+			},
+			want: want{
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"john_doe_new_username"}`,
+							StatusCode: 200,
+						},
+					},
+					Synced:       true,
+					DesiredState: `{"username":"john_doe_new_username"}`,
+				},
+			},
+		},
 		"ObjectNotFound404StatusCode": {
 			args: args{
 				http: &MockHttpClient{
@@ -88,13 +127,47 @@ func Test_isUpToDate(t *testing.T) {
 				err: errNotFound,
 			},
 		},
-		"FailBodyNotJSON": {
+		"HeadNotFound": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						if method != http.MethodHead {
+							return httpClient.HttpDetails{}, errBoom
+						}
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{StatusCode: 404},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = append(r.Spec.ForProvider.Mappings, v1alpha1.Mapping{
+						Method: "HEAD",
+						URL:    "(.payload.baseUrl + \"/\" + .response.body.id)",
+					})
+				}),
+			},
+			want: want{
+				err: errNotFound,
+			},
+		},
+		"HeadFound": {
 			args: args{
 				http: &MockHttpClient{
 					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						if method == http.MethodHead {
+							return httpClient.HttpDetails{
+								HttpResponse: httpClient.HttpResponse{StatusCode: 200},
+							}, nil
+						}
 						return httpClient.HttpDetails{
 							HttpResponse: httpClient.HttpResponse{
-								Body: "not a JSON",
+								Body:       `{"username":"john_doe_new_username"}`,
+								StatusCode: 200,
 							},
 						}, nil
 					},
@@ -104,19 +177,38 @@ func Test_isUpToDate(t *testing.T) {
 				},
 				mg: httpRequest(func(r *v1alpha1.Request) {
 					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = append(r.Spec.ForProvider.Mappings, v1alpha1.Mapping{
+						Method: "HEAD",
+						URL:    "(.payload.baseUrl + \"/\" + .response.body.id)",
+					})
 				}),
 			},
 			want: want{
-				err: errors.Errorf(errNotValidJSON, "response body", "not a JSON"),
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"john_doe_new_username"}`,
+							StatusCode: 200,
+						},
+					},
+					Synced:       true,
+					DesiredState: `{"username":"john_doe_new_username"}`,
+				},
 			},
 		},
-		"SuccessNotSynced": {
+		"NotModifiedStoredResponse": {
 			args: args{
 				http: &MockHttpClient{
 					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						if len(headers["If-None-Match"]) == 1 && headers["If-None-Match"][0] == `"v1"` {
+							return httpClient.HttpDetails{
+								HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusNotModified},
+							}, nil
+						}
 						return httpClient.HttpDetails{
 							HttpResponse: httpClient.HttpResponse{
-								Body:       `{"username":"old_name"}`,
+								Body:       `{"username":"john_doe_new_username"}`,
 								StatusCode: 200,
 							},
 						}, nil
@@ -126,28 +218,37 @@ func Test_isUpToDate(t *testing.T) {
 					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
 				},
 				mg: httpRequest(func(r *v1alpha1.Request) {
-					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+					r.Spec.ForProvider.ConditionalObserve = true
+					r.Status.RequestDetails.Method = http.MethodGet
+					r.Status.Response.Body = `{"username":"john_doe"}`
+					r.Status.Response.StatusCode = 200
+					r.Status.ETag = `"v1"`
 				}),
 			},
 			want: want{
-				err: nil,
 				result: ObserveRequestDetails{
 					Details: httpClient.HttpDetails{
 						HttpResponse: httpClient.HttpResponse{
-							Body:       `{"username":"old_name"}`,
-							Headers:    nil,
+							Body:       `{"username":"john_doe"}`,
 							StatusCode: 200,
 						},
 					},
-					ResponseError: nil,
-					Synced:        false,
+					Synced:       false,
+					FailedChecks: []string{defaultCompareCheck},
+					Diff:         `{"$.username":{"desired":"john_doe_new_username","observed":"john_doe"}}`,
+					DesiredState: `{"username":"john_doe_new_username"}`,
 				},
 			},
 		},
-		"SuccessJSONBody": {
+		"ConditionalObserveAfterUpdate": {
 			args: args{
 				http: &MockHttpClient{
 					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						if len(headers["If-None-Match"]) == 1 && headers["If-None-Match"][0] == `"v1"` {
+							return httpClient.HttpDetails{
+								HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusNotModified},
+							}, nil
+						}
 						return httpClient.HttpDetails{
 							HttpResponse: httpClient.HttpResponse{
 								Body:       `{"username":"john_doe_new_username"}`,
@@ -160,41 +261,2157 @@ func Test_isUpToDate(t *testing.T) {
 					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
 				},
 				mg: httpRequest(func(r *v1alpha1.Request) {
-					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+					r.Spec.ForProvider.ConditionalObserve = true
+					r.Status.RequestDetails.Method = http.MethodPut
+					r.Status.Response.Body = `{"username":"john_doe"}`
 					r.Status.Response.StatusCode = 200
+					r.Status.ETag = `"v1"`
 				}),
 			},
 			want: want{
-				err: nil,
 				result: ObserveRequestDetails{
 					Details: httpClient.HttpDetails{
 						HttpResponse: httpClient.HttpResponse{
 							Body:       `{"username":"john_doe_new_username"}`,
-							Headers:    nil,
 							StatusCode: 200,
 						},
 					},
-					ResponseError: nil,
-					Synced:        true,
+					Synced:       true,
+					DesiredState: `{"username":"john_doe_new_username"}`,
 				},
 			},
 		},
-	}
-	for name, tc := range cases {
-		tc := tc // Create local copies of loop variables
-
-		t.Run(name, func(t *testing.T) {
-			e := &external{
-				localKube: tc.args.localKube,
-				logger:    logging.NewNopLogger(),
-				http:      tc.args.http,
-			}
-			got, gotErr := e.isUpToDate(context.Background(), tc.args.mg)
-			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
-				t.Fatalf("isUpToDate(...): -want error, +got error: %s", diff)
-			}
-			if diff := cmp.Diff(tc.want.result, got); diff != "" {
-				t.Errorf("isUpToDate(...): -want result, +got result: %s", diff)
+		"ExpectedResponseSynced": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"id":"123","username":"john_doe","status":"active"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{testPostMapping, {
+						Method:           "GET",
+						URL:              "(.payload.baseUrl + \"/\" + .response.body.id)",
+						ExpectedResponse: `{"status":"active"}`,
+					}}
+				}),
+			},
+			want: want{
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"id":"123","username":"john_doe","status":"active"}`,
+							StatusCode: 200,
+						},
+					},
+					Synced:       true,
+					DesiredState: `{"status":"active"}`,
+				},
+			},
+		},
+		"ExpectedResponseNotSynced": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"id":"123","username":"john_doe","status":"active"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{testPostMapping, {
+						Method:           "GET",
+						URL:              "(.payload.baseUrl + \"/\" + .response.body.id)",
+						ExpectedResponse: `{"status":"suspended"}`,
+					}}
+				}),
+			},
+			want: want{
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"id":"123","username":"john_doe","status":"active"}`,
+							StatusCode: 200,
+						},
+					},
+					Synced:       false,
+					FailedChecks: []string{defaultCompareCheck},
+					Diff:         `{"$.status":{"desired":"suspended","observed":"active"}}`,
+					DesiredState: `{"status":"suspended"}`,
+				},
+			},
+		},
+		"CustomObserveMethod": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						if method != "PROPFIND" {
+							return httpClient.HttpDetails{
+								HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusMethodNotAllowed},
+							}, nil
+						}
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"john_doe_new_username"}`,
+								StatusCode: 207,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{testPostMapping, testPutMapping, {
+						Method: "PROPFIND",
+						Action: v1alpha1.ActionObserve,
+						URL:    "(.payload.baseUrl + \"/\" + .response.body.id)",
+					}}
+				}),
+			},
+			want: want{
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"john_doe_new_username"}`,
+							StatusCode: 207,
+						},
+					},
+					Synced:       true,
+					DesiredState: `{"username":"john_doe_new_username"}`,
+				},
+			},
+		},
+		"HeadServerErrorObservationFailed": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{StatusCode: 503},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = append(r.Spec.ForProvider.Mappings, v1alpha1.Mapping{
+						Method: "HEAD",
+						URL:    "(.payload.baseUrl + \"/\" + .response.body.id)",
+					})
+				}),
+			},
+			want: want{
+				err: &observationFailedError{err: utils.StatusCodeError(http.MethodHead, 503, "")},
+			},
+		},
+		"FailBodyNotJSON": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body: "not a JSON",
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+				}),
+			},
+			want: want{
+				err: errors.Errorf(errNotValidJSON, "response body", "not a JSON"),
+			},
+		},
+		"RequestErrorObservationFailed": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{}, errBoom
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"id":"123"}`
+				}),
+			},
+			want: want{
+				err:    &observationFailedError{err: errBoom},
+				result: FailedObserve(),
+			},
+		},
+		"ServerErrorObservationFailed": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusServiceUnavailable},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"id":"123"}`
+				}),
+			},
+			want: want{
+				err:    &observationFailedError{err: errors.Errorf(utils.ErrStatusCode, http.MethodGet, "503")},
+				result: FailedObserve(),
+			},
+		},
+		"TooManyRequestsObservationFailed": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								StatusCode: http.StatusTooManyRequests,
+								Body:       `{"error":"slow down"}`,
+								Headers:    map[string][]string{"Retry-After": {"120"}},
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"id":"123"}`
+				}),
+			},
+			want: want{
+				err:    &observationFailedError{err: &utils.RateLimitedError{Method: http.MethodGet, RetryAfter: 2 * time.Minute}},
+				result: FailedObserve(),
+			},
+		},
+		"SuccessNotSynced": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"old_name"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"old_name"}`,
+							Headers:    nil,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        false,
+					FailedChecks:  []string{defaultCompareCheck},
+					Diff:          `{"$.username":{"desired":"john_doe_new_username","observed":"old_name"}}`,
+					DesiredState:  `{"username":"john_doe_new_username"}`,
+				},
+			},
+		},
+		"SuccessNotSyncedSecretFieldsRedacted": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"old_name","password":"0ld"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Spec.ForProvider.SecretFields = []string{"$.password"}
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						testGetMapping,
+						{
+							Method: "PUT",
+							Body:   "{ username: \"john_doe\", password: \"n3w\" }",
+							URL:    "(.payload.baseUrl + \"/\" + .response.body.id)",
+						},
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"old_name","password":"0ld"}`,
+							Headers:    nil,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        false,
+					FailedChecks:  []string{defaultCompareCheck},
+					Diff:          `{"$.username":{"desired":"john_doe","observed":"old_name"}}`,
+					DesiredState:  `{"password":"***","username":"john_doe"}`,
+				},
+			},
+		},
+		"SuccessJSONBody": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"john_doe_new_username"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+					r.Status.Response.StatusCode = 200
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"john_doe_new_username"}`,
+							Headers:    nil,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        true,
+					DesiredState:  `{"username":"john_doe_new_username"}`,
+				},
+			},
+		},
+		"SuccessDefaultCompareOptions": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"john_doe_new_username","groups":["dev","admin"]}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						{
+							Method:         "GET",
+							URL:            "(.payload.baseUrl + \"/\" + .response.body.id)",
+							CompareOptions: &v1alpha1.CompareOptions{IgnoreArrayOrder: true, TreatNullAsAbsent: true},
+						},
+						{
+							Method: "PUT",
+							Body:   "{ username: \"john_doe_new_username\", groups: [\"admin\", \"dev\"], email: null }",
+							URL:    "(.payload.baseUrl + \"/\" + .response.body.id)",
+						},
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"john_doe_new_username","groups":["dev","admin"]}`,
+							Headers:    nil,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        true,
+					DesiredState:  `{"email":null,"groups":["admin","dev"],"username":"john_doe_new_username"}`,
+				},
+			},
+		},
+		"SuccessIgnoreFields": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"john_doe_new_username","metadata":{"updated":"2024-01-02"}}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						{
+							Method:       "GET",
+							URL:          "(.payload.baseUrl + \"/\" + .response.body.id)",
+							IgnoreFields: []string{"$.metadata.updated"},
+						},
+						{
+							Method: "PUT",
+							Body:   "{ username: \"john_doe_new_username\", metadata: { updated: \"2024-01-01\" } }",
+							URL:    "(.payload.baseUrl + \"/\" + .response.body.id)",
+						},
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"john_doe_new_username","metadata":{"updated":"2024-01-02"}}`,
+							Headers:    nil,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        true,
+					DesiredState:  `{"metadata":{"updated":"2024-01-01"},"username":"john_doe_new_username"}`,
+				},
+			},
+		},
+		"SuccessResponseRoot": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"data":{"id":"123","attributes":{"username":"john_doe_new_username"}}}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						{
+							Method:       "GET",
+							URL:          "(.payload.baseUrl + \"/\" + .response.body.id)",
+							ResponseRoot: "$.data.attributes",
+						},
+						testPutMapping,
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"data":{"id":"123","attributes":{"username":"john_doe_new_username"}}}`,
+							Headers:    nil,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        true,
+					DesiredState:  `{"username":"john_doe_new_username"}`,
+				},
+			},
+		},
+		"NotSyncedResponseRootMissing": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"data":{"id":"123","username":"john_doe_new_username"}}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						{
+							Method:       "GET",
+							URL:          "(.payload.baseUrl + \"/\" + .response.body.id)",
+							ResponseRoot: "$.data.attributes",
+						},
+						testPutMapping,
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"data":{"id":"123","username":"john_doe_new_username"}}`,
+							Headers:    nil,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        false,
+					FailedChecks:  []string{defaultCompareCheck},
+					DesiredState:  `{"username":"john_doe_new_username"}`,
+				},
+			},
+		},
+		"NotSyncedIgnoreFieldsOmittedFromDiff": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"old_name","update_time":"2024-01-02"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						{
+							Method:       "GET",
+							URL:          "(.payload.baseUrl + \"/\" + .response.body.id)",
+							IgnoreFields: []string{"update_time"},
+						},
+						{
+							Method: "PUT",
+							Body:   "{ username: \"john_doe\", update_time: \"2024-01-01\" }",
+							URL:    "(.payload.baseUrl + \"/\" + .response.body.id)",
+						},
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"old_name","update_time":"2024-01-02"}`,
+							Headers:    nil,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        false,
+					FailedChecks:  []string{defaultCompareCheck},
+					Diff:          `{"$.username":{"desired":"john_doe","observed":"old_name"}}`,
+					DesiredState:  `{"update_time":"2024-01-01","username":"john_doe"}`,
+				},
+			},
+		},
+		"InvalidIgnoreFields": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"john_doe_new_username"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						{
+							Method:       "GET",
+							URL:          "(.payload.baseUrl + \"/\" + .response.body.id)",
+							IgnoreFields: []string{"$.items[?(@.id==1)]"},
+						},
+						testPutMapping,
+					}
+				}),
+			},
+			want: want{
+				err: errors.Wrap(errors.Errorf("unsupported path %s, only .key, [index], [*] and .* segments are supported", "$.items[?(@.id==1)]"), errIgnoreFields),
+			},
+		},
+		"InvalidNumericTolerance": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"john_doe_new_username"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						{
+							Method:         "GET",
+							URL:            "(.payload.baseUrl + \"/\" + .response.body.id)",
+							CompareOptions: &v1alpha1.CompareOptions{NumericTolerance: "-1"},
+						},
+						testPutMapping,
+					}
+				}),
+			},
+			want: want{
+				err: errors.Errorf(errNumericTolerance, "-1"),
+			},
+		},
+		"SuccessJSONPathCompare": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"john_doe_new_username","updated_at":"now"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						testGetMapping,
+						{
+							Method:       "PUT",
+							Body:         "{ username: \"john_doe_new_username\", updated_at: \"before\" }",
+							URL:          "(.payload.baseUrl + \"/\" + .response.body.id)",
+							CompareType:  "jsonpath",
+							ComparePaths: []string{"$.username"},
+						},
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"john_doe_new_username","updated_at":"now"}`,
+							Headers:    nil,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        true,
+					DesiredState:  `{"updated_at":"before","username":"john_doe_new_username"}`,
+				},
+			},
+		},
+		"SuccessJQCompare": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"JOHN_DOE_NEW_USERNAME","updated_at":"now"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						testGetMapping,
+						{
+							Method:            "PUT",
+							Body:              "{ username: \"john_doe_new_username\" }",
+							URL:               "(.payload.baseUrl + \"/\" + .response.body.id)",
+							CompareType:       "jq",
+							CompareExpression: "(.response.username | ascii_downcase) == .desired.username",
+						},
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"JOHN_DOE_NEW_USERNAME","updated_at":"now"}`,
+							Headers:    nil,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        true,
+					DesiredState:  `{"username":"john_doe_new_username"}`,
+				},
+			},
+		},
+		"SuccessCELCompare": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"JOHN_DOE_NEW_USERNAME","updated_at":"now"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						testGetMapping,
+						{
+							Method:            "PUT",
+							Body:              "{ username: \"john_doe_new_username\" }",
+							URL:               "(.payload.baseUrl + \"/\" + .response.body.id)",
+							CompareType:       "cel",
+							CompareExpression: "response.username.lowerAscii() == desired.username",
+						},
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"JOHN_DOE_NEW_USERNAME","updated_at":"now"}`,
+							Headers:    nil,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        true,
+					DesiredState:  `{"username":"john_doe_new_username"}`,
+				},
+			},
+		},
+		"SuccessMultipleCompares": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"john_doe_new_username","acl":["admin"]}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						{
+							Method:            "GET",
+							URL:               "(.payload.baseUrl + \"/\" + .response.body.id)",
+							CompareType:       "jq",
+							CompareExpression: ".response.acl == [\"admin\"]",
+						},
+						{
+							Method:       "PUT",
+							Body:         "{ username: \"john_doe_new_username\" }",
+							URL:          "(.payload.baseUrl + \"/\" + .response.body.id)",
+							CompareType:  "jsonpath",
+							ComparePaths: []string{"$.username"},
+						},
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"john_doe_new_username","acl":["admin"]}`,
+							Headers:    nil,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        true,
+					DesiredState:  `{"username":"john_doe_new_username"}`,
+				},
+			},
+		},
+		"MultipleComparesOneFailed": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"john_doe_new_username","acl":["admin","guest"]}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						{
+							Method:            "GET",
+							URL:               "(.payload.baseUrl + \"/\" + .response.body.id)",
+							CompareType:       "jq",
+							CompareExpression: ".response.acl == [\"admin\"]",
+						},
+						{
+							Method:       "PUT",
+							Body:         "{ username: \"john_doe_new_username\" }",
+							URL:          "(.payload.baseUrl + \"/\" + .response.body.id)",
+							CompareType:  "jsonpath",
+							ComparePaths: []string{"$.username"},
+						},
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"john_doe_new_username","acl":["admin","guest"]}`,
+							Headers:    nil,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        false,
+					FailedChecks:  []string{"GET jq comparison"},
+					DesiredState:  `{"username":"john_doe_new_username"}`,
+				},
+			},
+		},
+		"FailSuccessExpression": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"ok":false,"username":"john_doe_new_username"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+					r.Status.Response.StatusCode = 200
+					getMapping := testGetMapping
+					getMapping.SuccessExpression = "response.body.ok"
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						getMapping,
+						testPutMapping,
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"ok":false,"username":"john_doe_new_username"}`,
+							Headers:    nil,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        false,
+					FailedChecks:  []string{defaultCompareCheck},
+					DesiredState:  `{"username":"john_doe_new_username"}`,
+				},
+			},
+		},
+		"SuccessJSONPatchCompare": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"john_doe_new_username","updated_at":"now"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						testGetMapping,
+						{
+							Method:      "PATCH",
+							Body:        "[{ op: \"replace\", path: \"/username\", value: \"john_doe_new_username\" }, { op: \"remove\", path: \"/locked\" }]",
+							URL:         "(.payload.baseUrl + \"/\" + .response.body.id)",
+							ContentType: "application/json-patch+json",
+							CompareType: "jsonpatch",
+						},
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"john_doe_new_username","updated_at":"now"}`,
+							Headers:    nil,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        true,
+					DesiredState:  `[{"op":"replace","path":"/username","value":"john_doe_new_username"},{"op":"remove","path":"/locked"}]`,
+				},
+			},
+		},
+		"FailJSONPatchCompare": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"john_doe_new_username","locked":true}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						testGetMapping,
+						{
+							Method:      "PATCH",
+							Body:        "[{ op: \"replace\", path: \"/username\", value: \"john_doe_new_username\" }, { op: \"remove\", path: \"/locked\" }]",
+							URL:         "(.payload.baseUrl + \"/\" + .response.body.id)",
+							ContentType: "application/json-patch+json",
+							CompareType: "jsonpatch",
+						},
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"john_doe_new_username","locked":true}`,
+							Headers:    nil,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        false,
+					FailedChecks:  []string{"PATCH jsonpatch comparison (remove /locked)"},
+					DesiredState:  `[{"op":"replace","path":"/username","value":"john_doe_new_username"},{"op":"remove","path":"/locked"}]`,
+				},
+			},
+		},
+		"SuccessCompareStatusOnly": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"id":"123","name":"unrelated"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Status.Response.StatusCode = 200
+					get := testGetMapping
+					get.CompareStatusOnly = true
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{testPostMapping, get}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"id":"123","name":"unrelated"}`,
+							Headers:    nil,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        true,
+				},
+			},
+		},
+		"FailCompareStatusOnly": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"id":"123","name":"unrelated"}`,
+								StatusCode: 409,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Status.Response.StatusCode = 200
+					get := testGetMapping
+					get.CompareStatusOnly = true
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{testPostMapping, get}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"id":"123","name":"unrelated"}`,
+							Headers:    nil,
+							StatusCode: 409,
+						},
+					},
+					ResponseError: nil,
+					Synced:        false,
+					FailedChecks:  []string{statusCompareCheck},
+				},
+			},
+		},
+		"SuccessKeyedListCompare": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"id":"123","name":"acl","rules":[{"id":2,"action":"deny","priority":20},{"id":1,"action":"allow","priority":10}]}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"id":"123","name":"acl"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						testGetMapping,
+						{
+							Method:      "PUT",
+							Body:        "{ name: \"acl\", rules: [{ id: 1, action: \"allow\" }, { id: 2, action: \"deny\" }] }",
+							URL:         "(.payload.baseUrl + \"/\" + .response.body.id)",
+							CompareType: "keyedlist",
+							CompareList: &v1alpha1.CompareList{Path: "$.rules", Key: "id"},
+						},
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"id":"123","name":"acl","rules":[{"id":2,"action":"deny","priority":20},{"id":1,"action":"allow","priority":10}]}`,
+							Headers:    nil,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        true,
+					DesiredState:  `{"name":"acl","rules":[{"action":"allow","id":1},{"action":"deny","id":2}]}`,
+				},
+			},
+		},
+		"FailKeyedListCompare": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"id":"123","name":"acl","rules":[{"id":1,"action":"deny"},{"id":3,"action":"allow"}]}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"id":"123","name":"acl"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						testGetMapping,
+						{
+							Method:      "PUT",
+							Body:        "{ name: \"acl\", rules: [{ id: 1, action: \"allow\" }, { id: 2, action: \"deny\" }] }",
+							URL:         "(.payload.baseUrl + \"/\" + .response.body.id)",
+							CompareType: "keyedlist",
+							CompareList: &v1alpha1.CompareList{Path: "$.rules", Key: "id"},
+						},
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"id":"123","name":"acl","rules":[{"id":1,"action":"deny"},{"id":3,"action":"allow"}]}`,
+							Headers:    nil,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        false,
+					FailedChecks:  []string{"PUT keyedlist comparison (missing id 2; extra id 3; changed id 1)"},
+					DesiredState:  `{"name":"acl","rules":[{"action":"allow","id":1},{"action":"deny","id":2}]}`,
+				},
+			},
+		},
+		"FailJQCompareNotBoolean": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"john_doe_new_username"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						testGetMapping,
+						{
+							Method:            "PUT",
+							Body:              "{ username: \"john_doe_new_username\" }",
+							URL:               "(.payload.baseUrl + \"/\" + .response.body.id)",
+							CompareType:       "jq",
+							CompareExpression: ".response.username",
+						},
+					}
+				}),
+			},
+			want: want{
+				err:    errors.Wrap(errors.Errorf("failed to parse string: %s", "john_doe_new_username"), errCompareExpression),
+				result: FailedObserve(),
+			},
+		},
+		"SuccessFormBodyCompare": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"id":"123","username":"john_doe","email":"john.doe@example.com"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						testGetMapping,
+						{
+							Method:   "PUT",
+							Body:     "{ username: .payload.body.username, email: .payload.body.email }",
+							URL:      "(.payload.baseUrl + \"/\" + .response.body.id)",
+							BodyType: "form",
+						},
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"id":"123","username":"john_doe","email":"john.doe@example.com"}`,
+							Headers:    nil,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        true,
+					DesiredState:  "email=john.doe%40example.com&username=john_doe",
+				},
+			},
+		},
+		"SuccessSelectFromCollection": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"items":[{"id":"122","username":"jane_doe"},{"id":"123","username":"john_doe_new_username"}]}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						{
+							Method:           "GET",
+							URL:              ".payload.baseUrl",
+							ResponseSelector: ".response.body.items[] | select(.id == \"123\")",
+						},
+						testPutMapping,
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"id":"123","username":"john_doe_new_username"}`,
+							Headers:    nil,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        true,
+					DesiredState:  `{"username":"john_doe_new_username"}`,
+				},
+			},
+		},
+		"SelectFromCollectionNotFound": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"items":[{"id":"122","username":"jane_doe"}]}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						{
+							Method:           "GET",
+							URL:              ".payload.baseUrl",
+							ResponseSelector: ".response.body.items[] | select(.id == \"123\")",
+						},
+						testPutMapping,
+					}
+				}),
+			},
+			want: want{
+				err:    errors.New(errObjectNotFound),
+				result: FailedObserve(),
+			},
+		},
+		"SuccessXMLBodyCompare": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `<u:user xmlns:u="urn:users" id="123"><u:email>john.doe@example.com</u:email><u:name>john_doe</u:name></u:user>`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						testGetMapping,
+						{
+							Method:   "PUT",
+							Body:     "(\"<user xmlns=\\\"urn:users\\\"> <name>\" + .payload.body.username + \"</name> </user>\")",
+							URL:      "(.payload.baseUrl + \"/\" + .response.body.id)",
+							BodyType: "xml",
+						},
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `<u:user xmlns:u="urn:users" id="123"><u:email>john.doe@example.com</u:email><u:name>john_doe</u:name></u:user>`,
+							Headers:    nil,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        true,
+					DesiredState:  `<user xmlns="urn:users"> <name>john_doe</name> </user>`,
+				},
+			},
+		},
+		"SuccessTextContentTypeCompare": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `user {"username":"john_doe"} created`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						testGetMapping,
+						{
+							Method:      "PUT",
+							Body:        "{ username: .payload.body.username }",
+							URL:         "(.payload.baseUrl + \"/\" + .response.body.id)",
+							ContentType: "text/plain",
+						},
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `user {"username":"john_doe"} created`,
+							Headers:    nil,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        true,
+					DesiredState:  `{"username":"john_doe"}`,
+				},
+			},
+		},
+		"MultipartNotCompared": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"name":"old.csv"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						testGetMapping,
+						{
+							Method:   "PUT",
+							URL:      "(.payload.baseUrl + \"/\" + .response.body.id)",
+							BodyType: "multipart",
+							Multipart: []v1alpha1.MultipartPart{
+								{Name: "description", Value: ".payload.body.username"},
+								{
+									Name:     "file",
+									FileName: "report.csv",
+									ValueFrom: &v1alpha1.PartValueSource{
+										ConfigMapKeyRef: &v1alpha1.ConfigMapKeySelector{Name: "reports", Namespace: "default", Key: "report.csv"},
+									},
+								},
+							},
+						},
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"name":"old.csv"}`,
+							Headers:    nil,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        true,
+				},
+			},
+		},
+		"MultipartComparedByExpression": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"name":"old.csv"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						{
+							Method:            "GET",
+							URL:               "(.payload.baseUrl + \"/\" + .response.body.id)",
+							CompareType:       "jq",
+							CompareExpression: ".response.name == \"report.csv\"",
+						},
+						{
+							Method:   "PUT",
+							URL:      "(.payload.baseUrl + \"/\" + .response.body.id)",
+							BodyType: "multipart",
+							Multipart: []v1alpha1.MultipartPart{
+								{Name: "description", Value: ".payload.body.username"},
+								{
+									Name:     "file",
+									FileName: "report.csv",
+									ValueFrom: &v1alpha1.PartValueSource{
+										ConfigMapKeyRef: &v1alpha1.ConfigMapKeySelector{Name: "reports", Namespace: "default", Key: "report.csv"},
+									},
+								},
+							},
+						},
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"name":"old.csv"}`,
+							Headers:    nil,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        false,
+					FailedChecks:  []string{"GET jq comparison"},
+				},
+			},
+		},
+		"UnexpectedStatusCodeNotSynced": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"john_doe_new_username"}`,
+								StatusCode: 203,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Status.Response.StatusCode = 200
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						{
+							Method:              "GET",
+							URL:                 "(.payload.baseUrl + \"/\" + .response.body.id)",
+							ExpectedStatusCodes: []int{200},
+						},
+						testPutMapping,
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"john_doe_new_username"}`,
+							Headers:    nil,
+							StatusCode: 203,
+						},
+					},
+					ResponseError: nil,
+					Synced:        false,
+					FailedChecks:  []string{defaultCompareCheck},
+					DesiredState:  `{"username":"john_doe_new_username"}`,
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				localKube: tc.args.localKube,
+				logger:    logging.NewNopLogger(),
+				http:      tc.args.http,
+			}
+			got, gotErr := e.isUpToDate(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("isUpToDate(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("isUpToDate(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_isNotFound(t *testing.T) {
+	type args struct {
+		check    *v1alpha1.NotFoundCheck
+		response httpClient.HttpResponse
+	}
+	type want struct {
+		notFound bool
+		err      error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"DefaultNotFound": {
+			args: args{
+				response: httpClient.HttpResponse{StatusCode: 404},
+			},
+			want: want{
+				notFound: true,
+			},
+		},
+		"DefaultFound": {
+			args: args{
+				response: httpClient.HttpResponse{StatusCode: 200, Body: `{"error":"not_found"}`},
+			},
+			want: want{
+				notFound: false,
+			},
+		},
+		"StatusCodes": {
+			args: args{
+				check:    &v1alpha1.NotFoundCheck{StatusCodes: []int{410}},
+				response: httpClient.HttpResponse{StatusCode: 410},
+			},
+			want: want{
+				notFound: true,
+			},
+		},
+		"ConditionKeepsDefaultStatusCode": {
+			args: args{
+				check:    &v1alpha1.NotFoundCheck{Condition: `.body.error == "not_found"`},
+				response: httpClient.HttpResponse{StatusCode: 404},
+			},
+			want: want{
+				notFound: true,
+			},
+		},
+		"ConditionMatches": {
+			args: args{
+				check:    &v1alpha1.NotFoundCheck{Condition: `.body.error == "not_found"`},
+				response: httpClient.HttpResponse{StatusCode: 200, Body: `{"error":"not_found"}`},
+			},
+			want: want{
+				notFound: true,
+			},
+		},
+		"EmptyListCondition": {
+			args: args{
+				check:    &v1alpha1.NotFoundCheck{Condition: `.body.items == []`},
+				response: httpClient.HttpResponse{StatusCode: 200, Body: `{"items":[{"id":"123"}]}`},
+			},
+			want: want{
+				notFound: false,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := isNotFound(tc.args.check, tc.args.response)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("isNotFound(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.notFound, got); diff != "" {
+				t.Fatalf("isNotFound(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_aggregateResponse(t *testing.T) {
+	const stream = "{\"status\":\"pending\"}\n\n{\"status\":\"ready\",\"replicas\":3}\n"
+
+	type args struct {
+		mapping  *v1alpha1.Mapping
+		response httpClient.HttpResponse
+	}
+	type want struct {
+		body string
+		err  error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"JSON": {
+			args: args{
+				mapping:  &v1alpha1.Mapping{Method: "GET"},
+				response: httpClient.HttpResponse{StatusCode: 200, Body: `{"status":"ready"}`},
+			},
+			want: want{
+				body: `{"status":"ready"}`,
+			},
+		},
+		"LastDocument": {
+			args: args{
+				mapping:  &v1alpha1.Mapping{Method: "GET", ResponseFormat: "ndjson"},
+				response: httpClient.HttpResponse{StatusCode: 200, Body: stream},
+			},
+			want: want{
+				body: `{"replicas":3,"status":"ready"}`,
+			},
+		},
+		"Aggregation": {
+			args: args{
+				mapping:  &v1alpha1.Mapping{Method: "GET", ResponseFormat: "ndjson", ResponseAggregation: `{ statuses: map(.status) }`},
+				response: httpClient.HttpResponse{StatusCode: 200, Body: stream},
+			},
+			want: want{
+				body: `{"statuses":["pending","ready"]}`,
+			},
+		},
+		"EmptyStream": {
+			args: args{
+				mapping:  &v1alpha1.Mapping{Method: "GET", ResponseFormat: "ndjson"},
+				response: httpClient.HttpResponse{StatusCode: 200, Body: "\n"},
+			},
+			want: want{
+				err: errors.New(errObjectNotFound),
+			},
+		},
+		"ErrorResponse": {
+			args: args{
+				mapping:  &v1alpha1.Mapping{Method: "GET", ResponseFormat: "ndjson"},
+				response: httpClient.HttpResponse{StatusCode: 400, Body: `{"error":"bad request"}`},
+			},
+			want: want{
+				body: `{"error":"bad request"}`,
+			},
+		},
+		"InvalidLine": {
+			args: args{
+				mapping:  &v1alpha1.Mapping{Method: "GET", ResponseFormat: "ndjson"},
+				response: httpClient.HttpResponse{StatusCode: 200, Body: "{\"status\":\"pending\"}\nready\n"},
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrapf(errors.New("invalid character 'r' looking for beginning of value"), "line %d of the NDJSON body isn't a JSON document", 2), errAggregateResponse),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := aggregateResponse(tc.args.mapping, tc.args.response)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("aggregateResponse(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.body, got.Body); diff != "" {
+				t.Errorf("aggregateResponse(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_statusDesiredState(t *testing.T) {
+	secretRef := &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "bodies", Namespace: "default"}, Key: "user"}
+
+	type args struct {
+		forProvider  v1alpha1.RequestParameters
+		desiredState string
+		bodyType     string
+	}
+	type want struct {
+		desiredState string
+		err          error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"SecretFieldsRedacted": {
+			args: args{
+				forProvider: v1alpha1.RequestParameters{
+					Mappings:     []v1alpha1.Mapping{testGetMapping, testPutMapping},
+					SecretFields: []string{"$.password"},
+				},
+				desiredState: `{"username":"john_doe","password":"s3cr3t"}`,
+				bodyType:     "json",
+			},
+			want: want{
+				desiredState: `{"password":"***","username":"john_doe"}`,
+			},
+		},
+		"BodyFromSecretMasked": {
+			args: args{
+				forProvider: v1alpha1.RequestParameters{
+					Mappings: []v1alpha1.Mapping{testGetMapping, {Method: "PUT", BodyFrom: &v1alpha1.BodySource{SecretKeyRef: secretRef}}},
+				},
+				desiredState: `{"username":"john_doe","password":"s3cr3t"}`,
+				bodyType:     "json",
+			},
+			want: want{
+				desiredState: "***",
+			},
+		},
+		"ExpectedResponseKept": {
+			args: args{
+				forProvider: v1alpha1.RequestParameters{
+					Mappings: []v1alpha1.Mapping{
+						{Method: "GET", ExpectedResponse: `{"status":"active"}`},
+						{Method: "PUT", BodyFrom: &v1alpha1.BodySource{SecretKeyRef: secretRef}},
+					},
+				},
+				desiredState: `{"status":"active"}`,
+				bodyType:     "json",
+			},
+			want: want{
+				desiredState: `{"status":"active"}`,
+			},
+		},
+		"MultipartNotKept": {
+			args: args{
+				forProvider: v1alpha1.RequestParameters{
+					Mappings: []v1alpha1.Mapping{testGetMapping, {Method: "PUT", BodyType: "multipart"}},
+				},
+				desiredState: "--boundary--",
+				bodyType:     "multipart",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Request{Spec: v1alpha1.RequestSpec{ForProvider: tc.args.forProvider}}
+			e := &external{}
+			got, gotErr := e.statusDesiredState(cr, tc.args.desiredState, tc.args.bodyType)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("statusDesiredState(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.desiredState, got); diff != "" {
+				t.Errorf("statusDesiredState(...): -want desired state, +got desired state: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_diffDesiredState(t *testing.T) {
+	type args struct {
+		forProvider v1alpha1.RequestParameters
+		observed    string
+		fromSecret  bool
+	}
+	cases := map[string]struct {
+		args args
+		want string
+	}{
+		"Diff": {
+			args: args{
+				forProvider: v1alpha1.RequestParameters{Mappings: []v1alpha1.Mapping{testGetMapping, testPutMapping}},
+				observed:    `{"username":"old_name"}`,
+			},
+			want: `{"$.password":{"desired":"s3cr3t"},"$.username":{"desired":"john_doe","observed":"old_name"}}`,
+		},
+		"SecretFieldsRedacted": {
+			args: args{
+				forProvider: v1alpha1.RequestParameters{
+					Mappings:     []v1alpha1.Mapping{testGetMapping, testPutMapping},
+					SecretFields: []string{"$.password"},
+				},
+				observed: `{"username":"old_name","password":"0ld"}`,
+			},
+			want: `{"$.username":{"desired":"john_doe","observed":"old_name"}}`,
+		},
+		"FromSecretMasked": {
+			args: args{
+				forProvider: v1alpha1.RequestParameters{Mappings: []v1alpha1.Mapping{testGetMapping, testPutMapping}},
+				observed:    `{"username":"old_name"}`,
+				fromSecret:  true,
+			},
+			want: `{"$.password":{"desired":"***"},"$.username":{"desired":"***","observed":"***"}}`,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Request{Spec: v1alpha1.RequestSpec{ForProvider: tc.args.forProvider}}
+			got, err := diffDesiredState(cr, tc.args.observed, `{"username":"john_doe","password":"s3cr3t"}`, "json", &testGetMapping, tc.args.fromSecret)
+			if err != nil {
+				t.Fatalf("diffDesiredState(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("diffDesiredState(...): -want diff, +got diff: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_redactedBody(t *testing.T) {
+	type args struct {
+		body         string
+		secretFields []string
+	}
+	cases := map[string]struct {
+		args args
+		want string
+	}{
+		"Redacted": {
+			args: args{
+				body:         `{"error":"unavailable","token":"s3cr3t"}`,
+				secretFields: []string{"$.token"},
+			},
+			want: `{"error":"unavailable","token":"***"}`,
+		},
+		"NotJSON": {
+			args: args{
+				body:         "Service Unavailable",
+				secretFields: []string{"$.token"},
+			},
+			want: "Service Unavailable",
+		},
+		"RedactionFailed": {
+			// The body isn't returned as is, as it may hold the secret fields.
+			args: args{
+				body:         `{"keys":[{"id":1,"token":"s3cr3t"}]}`,
+				secretFields: []string{"$.keys[?(@.id==1)]"},
+			},
+			want: json.RedactedPlaceholder,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, redactedBody(tc.args.body, tc.args.secretFields)); diff != "" {
+				t.Errorf("redactedBody(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_containsText(t *testing.T) {
+	type args struct {
+		response     string
+		desiredState string
+		options      *v1alpha1.CompareOptions
+	}
+	cases := map[string]struct {
+		args args
+		want bool
+	}{
+		"Strict": {
+			args: args{
+				response:     "name: web\r\nreplicas:  3\r\n",
+				desiredState: "name: web\nreplicas: 3",
+			},
+			want: false,
+		},
+		"NormalizeWhitespace": {
+			args: args{
+				response:     "kind: Deployment\r\nname: web\r\nreplicas:  3\r\n",
+				desiredState: "name: web\nreplicas: 3",
+				options:      &v1alpha1.CompareOptions{NormalizeWhitespace: true},
+			},
+			want: true,
+		},
+		"CaseDiffers": {
+			args: args{
+				response:     "Status: ENABLED",
+				desiredState: "status: enabled",
+				options:      &v1alpha1.CompareOptions{NormalizeWhitespace: true},
+			},
+			want: false,
+		},
+		"IgnoreCase": {
+			args: args{
+				response:     "Status:\tENABLED\n",
+				desiredState: "status: enabled",
+				options:      &v1alpha1.CompareOptions{NormalizeWhitespace: true, IgnoreCase: true},
+			},
+			want: true,
+		},
+		"ValueDiffers": {
+			args: args{
+				response:     "name: web\nreplicas: 2\n",
+				desiredState: "name: web\nreplicas: 3",
+				options:      &v1alpha1.CompareOptions{NormalizeWhitespace: true, IgnoreCase: true},
+			},
+			want: false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := containsText(tc.args.response, tc.args.desiredState, tc.args.options)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("containsText(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_containsForm(t *testing.T) {
+	type args struct {
+		response     string
+		desiredState string
+		options      *v1alpha1.CompareOptions
+	}
+	cases := map[string]struct {
+		args args
+		want bool
+	}{
+		"FormResponseInAnyOrder": {
+			args: args{
+				response:     "username=john_doe&id=123&email=john.doe%40example.com",
+				desiredState: "email=john.doe%40example.com&username=john_doe",
+			},
+			want: true,
+		},
+		"ValueIsPrefix": {
+			// The value of the response contains the desired one, but isn't equal to it.
+			args: args{
+				response:     "email=john.doe%40example.com&username=john_doe_new",
+				desiredState: "email=john.doe%40example.com&username=john_doe",
+			},
+			want: false,
+		},
+		"EncodedDifferently": {
+			args: args{
+				response:     "name=John+Doe",
+				desiredState: "name=John%20Doe",
+			},
+			want: true,
+		},
+		"FieldMissing": {
+			args: args{
+				response:     "username=john_doe",
+				desiredState: "email=john.doe%40example.com&username=john_doe",
+			},
+			want: false,
+		},
+		"RepeatedValues": {
+			args: args{
+				response:     "groups=dev&groups=admin",
+				desiredState: "groups=admin&groups=dev",
+			},
+			want: true,
+		},
+		"RepeatedValueMissing": {
+			args: args{
+				response:     "groups=dev",
+				desiredState: "groups=admin&groups=dev",
+			},
+			want: false,
+		},
+		"JSONResponse": {
+			args: args{
+				response:     `{"id":123,"username":"john_doe","groups":["dev","admin"],"enabled":true}`,
+				desiredState: "enabled=true&groups=admin&username=john_doe",
+			},
+			want: true,
+		},
+		"JSONResponseValueDiffers": {
+			args: args{
+				response:     `{"username":"john_doe","enabled":false}`,
+				desiredState: "enabled=true&username=john_doe",
+			},
+			want: false,
+		},
+		"IgnoreCase": {
+			args: args{
+				response:     "status=ENABLED",
+				desiredState: "status=enabled",
+				options:      &v1alpha1.CompareOptions{IgnoreCase: true},
+			},
+			want: true,
+		},
+		"InvalidResponse": {
+			args: args{
+				response:     "status=%zz",
+				desiredState: "status=enabled",
+			},
+			want: false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := containsForm(tc.args.response, tc.args.desiredState, tc.args.options)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("containsForm(...): -want, +got: %s", diff)
 			}
 		})
 	}
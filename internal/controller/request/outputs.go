@@ -0,0 +1,68 @@
+package request
+
+import (
+	ej "encoding/json"
+
+	"github.com/pkg/errors"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errStatusOutputs = "failed to extract status outputs from response"
+)
+
+// statusOutputs returns the status outputs of the Request updated with the values
+// selected from a successful response. Outputs that the response doesn't hold keep
+// their last value, and secret fields are redacted.
+func statusOutputs(cr *v1alpha1.Request, details httpClient.HttpDetails, expectedStatusCodes []int) (map[string]extv1.JSON, error) {
+	outputs := map[string]extv1.JSON{}
+	for key := range cr.Spec.ForProvider.StatusOutputs {
+		if value, ok := cr.Status.Outputs[key]; ok {
+			outputs[key] = value
+		}
+	}
+
+	body := details.HttpResponse.Body
+	if len(cr.Spec.ForProvider.StatusOutputs) > 0 && utils.IsHTTPSuccessFor(details.HttpResponse.StatusCode, expectedStatusCodes) && json.IsJSONString(body) {
+		body, err := json.RedactJSONString(body, cr.Spec.ForProvider.SecretFields)
+		if err != nil {
+			return nil, errors.Wrap(err, errStatusOutputs)
+		}
+
+		bodyMap := json.JsonStringToMap(body)
+		for key, path := range cr.Spec.ForProvider.StatusOutputs {
+			values, found, err := json.QueryJSONPath(bodyMap, path)
+			if err != nil {
+				return nil, errors.Wrap(err, errStatusOutputs)
+			}
+			if !found {
+				continue
+			}
+
+			value, err := statusOutputValue(values)
+			if err != nil {
+				return nil, errors.Wrap(err, errStatusOutputs)
+			}
+			outputs[key] = extv1.JSON{Raw: value}
+		}
+	}
+
+	if len(outputs) == 0 {
+		return nil, nil
+	}
+	return outputs, nil
+}
+
+// statusOutputValue keeps a single selected value as is, with its type, and several
+// selected values as an array.
+func statusOutputValue(values []interface{}) ([]byte, error) {
+	if len(values) == 1 {
+		return ej.Marshal(values[0])
+	}
+	return ej.Marshal(values)
+}
@@ -0,0 +1,112 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_statusOutputs(t *testing.T) {
+	type args struct {
+		outputs      map[string]string
+		secretFields []string
+		current      map[string]extv1.JSON
+		response     httpClient.HttpResponse
+	}
+	type want struct {
+		outputs map[string]extv1.JSON
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoOutputs": {
+			args: args{
+				current:  map[string]extv1.JSON{"id": {Raw: []byte(`"1"`)}},
+				response: httpClient.HttpResponse{StatusCode: 200, Body: `{"id":"1"}`},
+			},
+		},
+		"TypedValues": {
+			args: args{
+				outputs:  map[string]string{"id": "$.id", "port": "$.endpoint.port", "enabled": "$.enabled", "tags": "$.tags[*].name"},
+				response: httpClient.HttpResponse{StatusCode: 201, Body: `{"endpoint":{"port":5432},"enabled":true,"id":"1","tags":[{"name":"a"},{"name":"b"}]}`},
+			},
+			want: want{
+				outputs: map[string]extv1.JSON{
+					"id":      {Raw: []byte(`"1"`)},
+					"port":    {Raw: []byte(`5432`)},
+					"enabled": {Raw: []byte(`true`)},
+					"tags":    {Raw: []byte(`["a","b"]`)},
+				},
+			},
+		},
+		"LargeIntegers": {
+			args: args{
+				outputs:      map[string]string{"id": "$.id", "owner": "$.members[?(@.role==\"owner\")].id", "ratio": "$.ratio"},
+				secretFields: []string{"$.token"},
+				response:     httpClient.HttpResponse{StatusCode: 200, Body: `{"id":12345678901234567890,"members":[{"id":9007199254740993,"role":"owner"}],"ratio":0.1,"token":"s3cr3t"}`},
+			},
+			want: want{
+				outputs: map[string]extv1.JSON{
+					"id":    {Raw: []byte(`12345678901234567890`)},
+					"owner": {Raw: []byte(`9007199254740993`)},
+					"ratio": {Raw: []byte(`0.1`)},
+				},
+			},
+		},
+		"MissingValueKept": {
+			args: args{
+				outputs:  map[string]string{"id": "$.id", "url": "$.url"},
+				current:  map[string]extv1.JSON{"url": {Raw: []byte(`"https://api.example.com/1"`)}, "removed": {Raw: []byte(`"x"`)}},
+				response: httpClient.HttpResponse{StatusCode: 200, Body: `{"id":"1"}`},
+			},
+			want: want{
+				outputs: map[string]extv1.JSON{
+					"id":  {Raw: []byte(`"1"`)},
+					"url": {Raw: []byte(`"https://api.example.com/1"`)},
+				},
+			},
+		},
+		"FailedResponse": {
+			args: args{
+				outputs:  map[string]string{"id": "$.id"},
+				current:  map[string]extv1.JSON{"id": {Raw: []byte(`"1"`)}},
+				response: httpClient.HttpResponse{StatusCode: 400, Body: `{"id":"2"}`},
+			},
+			want: want{
+				outputs: map[string]extv1.JSON{"id": {Raw: []byte(`"1"`)}},
+			},
+		},
+		"SecretFieldRedacted": {
+			args: args{
+				outputs:      map[string]string{"token": "$.token"},
+				secretFields: []string{"$.token"},
+				response:     httpClient.HttpResponse{StatusCode: 200, Body: `{"token":"s3cr3t"}`},
+			},
+			want: want{
+				outputs: map[string]extv1.JSON{"token": {Raw: []byte(`"***"`)}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := httpRequest(func(r *v1alpha1.Request) {
+				r.Spec.ForProvider.StatusOutputs = tc.args.outputs
+				r.Spec.ForProvider.SecretFields = tc.args.secretFields
+				r.Status.Outputs = tc.args.current
+			})
+
+			got, err := statusOutputs(cr, httpClient.HttpDetails{HttpResponse: tc.args.response}, nil)
+			if err != nil {
+				t.Fatalf("statusOutputs(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.outputs, got); diff != "" {
+				t.Errorf("statusOutputs(...): -want outputs, +got outputs: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/responseconverter"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	errNextPageURL    = "cannot resolve the URL of the next page %q"
+	errNextPageOrigin = "the next page %q isn't on the origin of the collection %q"
+
+	headerLink      = "Link"
+	defaultMaxPages = 10
+)
+
+// selectPage narrows a response to the GET mapping down to the object chosen by its
+// responseSelector. A paginated response is followed page by page until the object
+// is found, the pages run out or the maximum number of pages has been requested.
+func (c *external) selectPage(ctx context.Context, cr *v1alpha1.Request, mapping *v1alpha1.Mapping, pageURL string, headers map[string][]string, details httpClient.HttpDetails) (httpClient.HttpDetails, error) {
+	for page := 1; ; page++ {
+		selected, err := selectResponse(cr, mapping, details.HttpResponse)
+		if err == nil {
+			details.HttpResponse = selected
+			return details, nil
+		}
+		if mapping.Pagination == nil || err.Error() != errObjectNotFound || page >= maxPages(mapping.Pagination) {
+			return httpClient.HttpDetails{}, err
+		}
+
+		pageURL, err = nextPageURL(cr, mapping.Pagination, pageURL, details.HttpResponse)
+		if err != nil {
+			return httpClient.HttpDetails{}, err
+		}
+		if pageURL == "" {
+			return httpClient.HttpDetails{}, errors.New(errObjectNotFound)
+		}
+
+		details, err = c.getPage(ctx, cr, mapping, pageURL, headers)
+		if err != nil {
+			return httpClient.HttpDetails{}, err
+		}
+	}
+}
+
+// getPage sends the GET mapping's request for a following page of its response.
+func (c *external) getPage(ctx context.Context, cr *v1alpha1.Request, mapping *v1alpha1.Mapping, pageURL string, headers map[string][]string) (httpClient.HttpDetails, error) {
+	requestCtx, cancel := requestContext(ctx, mapping)
+	defer cancel()
+
+	details, err := c.http.SendRequest(requestCtx, mapping.Method, pageURL, "", headers, insecureSkipTLSVerify(cr, mapping))
+	if err != nil {
+		return httpClient.HttpDetails{}, &observationFailedError{err: err}
+	}
+
+	// Unlike the first page, a following page that isn't there doesn't mean the object is absent.
+	if !utils.IsHTTPSuccessFor(details.HttpResponse.StatusCode, mapping.ExpectedStatusCodes) {
+		body := redactedBody(details.HttpResponse.Body, cr.Spec.ForProvider.SecretFields)
+		return httpClient.HttpDetails{}, &observationFailedError{err: utils.StatusCodeError(mapping.Method, details.HttpResponse.StatusCode, body)}
+	}
+
+	details.HttpResponse, err = aggregateResponse(mapping, details.HttpResponse)
+	return details, err
+}
+
+// nextPageURL returns the URL of the page following the response, resolved against the
+// URL of the page, or an empty string if the response is the last page.
+func nextPageURL(cr *v1alpha1.Request, pagination *v1alpha1.Pagination, pageURL string, response httpClient.HttpResponse) (string, error) {
+	next := linkNext(response.Headers)
+	if pagination.NextURL != "" {
+		var err error
+		next, err = requestgen.NextPageURL(pagination.NextURL, cr.Spec.ForProvider, responseconverter.HttpResponseToV1alpha1Response(response))
+		if err != nil {
+			return "", err
+		}
+	}
+	if next == "" {
+		return "", nil
+	}
+
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return "", errors.Wrapf(err, errNextPageURL, next)
+	}
+	ref, err := url.Parse(next)
+	if err != nil {
+		return "", errors.Wrapf(err, errNextPageURL, next)
+	}
+
+	// The pages are requested with the mapping's headers, credentials included, so
+	// they're never sent to another host or downgraded to another scheme.
+	resolved := base.ResolveReference(ref)
+	if !strings.EqualFold(resolved.Scheme, base.Scheme) || !strings.EqualFold(resolved.Host, base.Host) {
+		return "", errors.Errorf(errNextPageOrigin, resolved.Redacted(), base.Scheme+"://"+base.Host)
+	}
+	return resolved.String(), nil
+}
+
+// linkNext returns the URL of the next link of the Link headers, e.g.
+// `<https://api.example.com/users?page=2>; rel="next"`, if there's one.
+func linkNext(headers map[string][]string) string {
+	for _, header := range http.Header(headers).Values(headerLink) {
+		for _, link := range strings.Split(header, ",") {
+			target, params, ok := strings.Cut(link, ";")
+			if !ok {
+				continue
+			}
+			for _, param := range strings.Split(params, ";") {
+				key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if strings.EqualFold(key, "rel") && slices.Contains(strings.Fields(strings.Trim(value, `"`)), "next") {
+					return strings.Trim(strings.TrimSpace(target), "<>")
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// maxPages returns the number of pages of a paginated response that are requested at most.
+func maxPages(pagination *v1alpha1.Pagination) int {
+	if pagination.MaxPages == nil {
+		return defaultMaxPages
+	}
+	return *pagination.MaxPages
+}
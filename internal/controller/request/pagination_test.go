@@ -0,0 +1,214 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+func Test_selectPage(t *testing.T) {
+	const firstPage = "http://example/users"
+
+	// pages serves the responses by URL, counting the requests.
+	pages := func(requests *int, responses map[string]httpClient.HttpResponse) *MockHttpClient {
+		return &MockHttpClient{
+			MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (httpClient.HttpDetails, error) {
+				*requests++
+				response, ok := responses[url]
+				if !ok {
+					return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusNotFound}}, nil
+				}
+				return httpClient.HttpDetails{HttpResponse: response}, nil
+			},
+		}
+	}
+
+	selector := `.response.body.items[] | select(.id == "123")`
+	maxPages := 2
+
+	type args struct {
+		pagination *v1alpha1.Pagination
+		first      httpClient.HttpResponse
+		responses  map[string]httpClient.HttpResponse
+	}
+	type want struct {
+		body     string
+		requests int
+		err      error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"FoundOnFirstPage": {
+			args: args{
+				pagination: &v1alpha1.Pagination{NextURL: ".response.body.next"},
+				first:      httpClient.HttpResponse{StatusCode: 200, Body: `{"items":[{"id":"123"}],"next":"/users?page=2"}`},
+			},
+			want: want{
+				body: `{"id":"123"}`,
+			},
+		},
+		"FoundByNextURL": {
+			args: args{
+				pagination: &v1alpha1.Pagination{NextURL: ".response.body.next"},
+				first:      httpClient.HttpResponse{StatusCode: 200, Body: `{"items":[{"id":"1"}],"next":"/users?page=2"}`},
+				responses: map[string]httpClient.HttpResponse{
+					"http://example/users?page=2": {StatusCode: 200, Body: `{"items":[{"id":"2"}],"next":"http://example/users?page=3"}`},
+					"http://example/users?page=3": {StatusCode: 200, Body: `{"items":[{"id":"123","name":"john"}],"next":null}`},
+				},
+			},
+			want: want{
+				body:     `{"id":"123","name":"john"}`,
+				requests: 2,
+			},
+		},
+		"FoundByLinkHeader": {
+			args: args{
+				pagination: &v1alpha1.Pagination{},
+				first: httpClient.HttpResponse{
+					StatusCode: 200,
+					Body:       `{"items":[{"id":"1"}]}`,
+					Headers:    map[string][]string{"Link": {`<http://example/users?page=1>; rel="prev", <http://example/users?page=2>; rel="next"`}},
+				},
+				responses: map[string]httpClient.HttpResponse{
+					"http://example/users?page=2": {StatusCode: 200, Body: `{"items":[{"id":"123"}]}`},
+				},
+			},
+			want: want{
+				body:     `{"id":"123"}`,
+				requests: 1,
+			},
+		},
+		"PagesExhausted": {
+			args: args{
+				pagination: &v1alpha1.Pagination{NextURL: ".response.body.next"},
+				first:      httpClient.HttpResponse{StatusCode: 200, Body: `{"items":[{"id":"1"}],"next":"/users?page=2"}`},
+				responses: map[string]httpClient.HttpResponse{
+					"http://example/users?page=2": {StatusCode: 200, Body: `{"items":[{"id":"2"}]}`},
+				},
+			},
+			want: want{
+				requests: 1,
+				err:      errors.New(errObjectNotFound),
+			},
+		},
+		"MaxPages": {
+			args: args{
+				pagination: &v1alpha1.Pagination{NextURL: ".response.body.next", MaxPages: &maxPages},
+				first:      httpClient.HttpResponse{StatusCode: 200, Body: `{"items":[{"id":"1"}],"next":"/users?page=2"}`},
+				responses: map[string]httpClient.HttpResponse{
+					"http://example/users?page=2": {StatusCode: 200, Body: `{"items":[{"id":"2"}],"next":"/users?page=3"}`},
+					"http://example/users?page=3": {StatusCode: 200, Body: `{"items":[{"id":"123"}]}`},
+				},
+			},
+			want: want{
+				requests: 1,
+				err:      errors.New(errObjectNotFound),
+			},
+		},
+		"NotPaginated": {
+			args: args{
+				first: httpClient.HttpResponse{StatusCode: 200, Body: `{"items":[{"id":"1"}],"next":"/users?page=2"}`},
+			},
+			want: want{
+				err: errors.New(errObjectNotFound),
+			},
+		},
+		"NextPageOnOtherHost": {
+			args: args{
+				pagination: &v1alpha1.Pagination{NextURL: ".response.body.next"},
+				first:      httpClient.HttpResponse{StatusCode: 200, Body: `{"items":[{"id":"1"}],"next":"http://attacker.example/users?page=2"}`},
+				responses: map[string]httpClient.HttpResponse{
+					"http://attacker.example/users?page=2": {StatusCode: 200, Body: `{"items":[{"id":"123"}]}`},
+				},
+			},
+			want: want{
+				err: errors.Errorf(errNextPageOrigin, "http://attacker.example/users?page=2", "http://example"),
+			},
+		},
+		"LinkToOtherScheme": {
+			args: args{
+				pagination: &v1alpha1.Pagination{},
+				first: httpClient.HttpResponse{
+					StatusCode: 200,
+					Body:       `{"items":[{"id":"1"}]}`,
+					Headers:    map[string][]string{"Link": {`<https://example/users?page=2>; rel="next"`}},
+				},
+			},
+			want: want{
+				err: errors.Errorf(errNextPageOrigin, "https://example/users?page=2", "http://example"),
+			},
+		},
+		"PageFailed": {
+			args: args{
+				pagination: &v1alpha1.Pagination{NextURL: ".response.body.next"},
+				first:      httpClient.HttpResponse{StatusCode: 200, Body: `{"items":[{"id":"1"}],"next":"/users?page=2"}`},
+			},
+			want: want{
+				requests: 1,
+				err:      &observationFailedError{err: utils.StatusCodeError(http.MethodGet, http.StatusNotFound, "")},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			requests := 0
+			e := &external{
+				logger: logging.NewNopLogger(),
+				http:   pages(&requests, tc.args.responses),
+			}
+			cr := httpRequest()
+			mapping := &v1alpha1.Mapping{Method: http.MethodGet, URL: ".payload.baseUrl", ResponseSelector: selector, Pagination: tc.args.pagination}
+
+			got, gotErr := e.selectPage(context.Background(), cr, mapping, firstPage, nil, httpClient.HttpDetails{HttpResponse: tc.args.first})
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("selectPage(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.body, got.HttpResponse.Body); diff != "" {
+				t.Errorf("selectPage(...): -want body, +got body: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.requests, requests); diff != "" {
+				t.Errorf("selectPage(...): -want requests, +got requests: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_linkNext(t *testing.T) {
+	cases := map[string]struct {
+		headers map[string][]string
+		want    string
+	}{
+		"NoLinkHeader": {
+			headers: map[string][]string{"Content-Type": {"application/json"}},
+		},
+		"Next": {
+			headers: map[string][]string{"Link": {`<https://api.example.com/users?page=3>; rel="next", <https://api.example.com/users?page=9>; rel="last"`}},
+			want:    "https://api.example.com/users?page=3",
+		},
+		"MultipleRelations": {
+			headers: map[string][]string{"Link": {`</users?cursor=abc>; title="more"; rel="next prefetch"`}},
+			want:    "/users?cursor=abc",
+		},
+		"LastPage": {
+			headers: map[string][]string{"Link": {`<https://api.example.com/users?page=1>; rel="first"`}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, linkNext(tc.headers)); diff != "" {
+				t.Errorf("linkNext(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
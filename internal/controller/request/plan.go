@@ -0,0 +1,24 @@
+package request
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+)
+
+// plannedAction returns the action the provider would take to reconcile an
+// ObserveOnly Request, given whether its object exists and is synced. It's
+// empty for other management policies, whose actions are taken right away.
+func plannedAction(cr *v1alpha1.Request, exists bool, synced bool) string {
+	if cr.Spec.ManagementPolicy != xpv1.ManagementObserveOnly {
+		return ""
+	}
+	switch {
+	case !exists:
+		return v1alpha1.PlannedActionCreate
+	case !synced:
+		return v1alpha1.PlannedActionUpdate
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,68 @@
+package request
+
+import (
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+)
+
+func Test_plannedAction(t *testing.T) {
+	type args struct {
+		policy xpv1.ManagementPolicy
+		exists bool
+		synced bool
+	}
+	type want struct {
+		action string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"FullControl": {
+			args: args{
+				policy: xpv1.ManagementFullControl,
+			},
+			want: want{},
+		},
+		"NotFound": {
+			args: args{
+				policy: xpv1.ManagementObserveOnly,
+			},
+			want: want{
+				action: v1alpha1.PlannedActionCreate,
+			},
+		},
+		"Drifted": {
+			args: args{
+				policy: xpv1.ManagementObserveOnly,
+				exists: true,
+			},
+			want: want{
+				action: v1alpha1.PlannedActionUpdate,
+			},
+		},
+		"Synced": {
+			args: args{
+				policy: xpv1.ManagementObserveOnly,
+				exists: true,
+				synced: true,
+			},
+			want: want{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := httpRequest(func(r *v1alpha1.Request) {
+				r.Spec.ManagementPolicy = tc.args.policy
+			})
+			got := plannedAction(cr, tc.args.exists, tc.args.synced)
+			if diff := cmp.Diff(tc.want.action, got); diff != "" {
+				t.Errorf("plannedAction(...): -want action, +got action: %s", diff)
+			}
+		})
+	}
+}
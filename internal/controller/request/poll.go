@@ -0,0 +1,95 @@
+package request
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+// pollIntervalReconciler requeues Requests after the poll interval set by the
+// resource or its ProviderConfig, instead of the one of the managed reconciler.
+// Rate limited Requests are requeued once the API allows the next request,
+// Requests whose create or update failed after their retry backoff, and Requests
+// whose create is completed by an async operation, or whose deletion is still to be
+// confirmed, after their poll interval. The poll interval and the backoff are spread
+// by the jitter of the Http clients.
+type pollIntervalReconciler struct {
+	kube         client.Reader
+	reconciler   reconcile.Reconciler
+	pollInterval time.Duration
+}
+
+// newPollIntervalReconciler wraps a managed reconciler polling every pollInterval.
+func newPollIntervalReconciler(kube client.Reader, r reconcile.Reconciler, pollInterval time.Duration) *pollIntervalReconciler {
+	return &pollIntervalReconciler{kube: kube, reconciler: r, pollInterval: pollInterval}
+}
+
+func (r *pollIntervalReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	result, err := r.reconciler.Reconcile(ctx, req)
+	// Only a successful observation is requeued after the poll interval, and only a
+	// failed one after being rate limited or backing off.
+	if err != nil || (result.RequeueAfter != r.pollInterval && !result.Requeue) {
+		return result, err
+	}
+
+	cr := &v1alpha1.Request{}
+	if err := r.kube.Get(ctx, req.NamespacedName, cr); err != nil {
+		return result, nil
+	}
+
+	if result.Requeue {
+		if wait := rateLimitWait(cr); wait > 0 {
+			return reconcile.Result{RequeueAfter: wait}, nil
+		}
+		if isDeletionPending(cr) {
+			return reconcile.Result{RequeueAfter: httpClient.Jitter(deletionPollInterval(cr.Spec.ForProvider.WaitForDeletion))}, nil
+		}
+		if cr.Status.RetryBackoff != nil {
+			return reconcile.Result{RequeueAfter: httpClient.Jitter(cr.Status.RetryBackoff.Duration)}, nil
+		}
+		return result, nil
+	}
+
+	if isAwaitingAsyncOperation(cr) {
+		result.RequeueAfter = asyncPollInterval(cr.Spec.ForProvider.Async)
+	} else if interval, ok := r.pollIntervalFor(ctx, cr); ok {
+		result.RequeueAfter = interval
+	}
+	result.RequeueAfter = httpClient.Jitter(result.RequeueAfter)
+	return result, nil
+}
+
+// rateLimitWait returns how long until the API allows the next request of the rate
+// limited Request, to the second.
+func rateLimitWait(cr *v1alpha1.Request) time.Duration {
+	if cr.Status.RateLimitedUntil == nil {
+		return 0
+	}
+	return time.Until(cr.Status.RateLimitedUntil.Time).Round(time.Second)
+}
+
+// pollIntervalFor returns the poll interval of the Request, falling back to the one
+// of its ProviderConfig.
+func (r *pollIntervalReconciler) pollIntervalFor(ctx context.Context, cr *v1alpha1.Request) (time.Duration, bool) {
+	if interval := cr.Spec.ForProvider.PollInterval; interval != nil {
+		return interval.Duration, true
+	}
+
+	ref := cr.GetProviderConfigReference()
+	if ref == nil {
+		return 0, false
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := r.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, pc); err != nil || pc.Spec.PollInterval == nil {
+		return 0, false
+	}
+	return pc.Spec.PollInterval.Duration, true
+}
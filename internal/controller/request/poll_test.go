@@ -0,0 +1,221 @@
+package request
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+)
+
+type mockReconciler struct {
+	result reconcile.Result
+	err    error
+}
+
+func (r *mockReconciler) Reconcile(context.Context, reconcile.Request) (reconcile.Result, error) {
+	return r.result, r.err
+}
+
+func Test_pollIntervalReconciler(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	getFn := func(requestInterval, providerConfigInterval *metav1.Duration) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *v1alpha1.Request:
+				*o = *httpRequest(func(r *v1alpha1.Request) {
+					r.Spec.ForProvider.PollInterval = requestInterval
+				})
+			case *apisv1alpha1.ProviderConfig:
+				o.Spec.PollInterval = providerConfigInterval
+			}
+			return nil
+		}
+	}
+
+	rateLimitedGetFn := func(until time.Time) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			if o, ok := obj.(*v1alpha1.Request); ok {
+				*o = *httpRequest(func(r *v1alpha1.Request) {
+					r.Status.RateLimitedUntil = &metav1.Time{Time: until}
+				})
+			}
+			return nil
+		}
+	}
+
+	backingOffGetFn := func(backoff time.Duration) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			if o, ok := obj.(*v1alpha1.Request); ok {
+				*o = *httpRequest(func(r *v1alpha1.Request) {
+					r.Status.RetryBackoff = &metav1.Duration{Duration: backoff}
+				})
+			}
+			return nil
+		}
+	}
+
+	awaitingAsyncGetFn := func(pollInterval time.Duration) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			if o, ok := obj.(*v1alpha1.Request); ok {
+				*o = *httpRequest(func(r *v1alpha1.Request) {
+					r.Spec.ForProvider.Async = &v1alpha1.AsyncOperation{PollInterval: &metav1.Duration{Duration: pollInterval}}
+					r.Status.RequestDetails.Method = "POST"
+					r.Status.Response.StatusCode = 202
+				})
+			}
+			return nil
+		}
+	}
+
+	deletionPendingGetFn := func(pollInterval time.Duration) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			if o, ok := obj.(*v1alpha1.Request); ok {
+				*o = *httpRequest(func(r *v1alpha1.Request) {
+					r.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+					r.Spec.ForProvider.WaitForDeletion = &v1alpha1.WaitForDeletion{PollInterval: &metav1.Duration{Duration: pollInterval}}
+					r.Status.RequestDetails.Method = "DELETE"
+					r.Status.LastRequestTime = &metav1.Time{Time: time.Now()}
+				})
+			}
+			return nil
+		}
+	}
+
+	type args struct {
+		kube   client.Reader
+		result reconcile.Result
+		err    error
+	}
+	type want struct {
+		result reconcile.Result
+		err    error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"DefaultInterval": {
+			args: args{
+				kube:   &test.MockClient{MockGet: getFn(nil, nil)},
+				result: reconcile.Result{RequeueAfter: time.Minute},
+			},
+			want: want{
+				result: reconcile.Result{RequeueAfter: time.Minute},
+			},
+		},
+		"RequestInterval": {
+			args: args{
+				kube:   &test.MockClient{MockGet: getFn(&metav1.Duration{Duration: time.Hour}, &metav1.Duration{Duration: 10 * time.Minute})},
+				result: reconcile.Result{RequeueAfter: time.Minute},
+			},
+			want: want{
+				result: reconcile.Result{RequeueAfter: time.Hour},
+			},
+		},
+		"ProviderConfigInterval": {
+			args: args{
+				kube:   &test.MockClient{MockGet: getFn(nil, &metav1.Duration{Duration: 10 * time.Minute})},
+				result: reconcile.Result{RequeueAfter: time.Minute},
+			},
+			want: want{
+				result: reconcile.Result{RequeueAfter: 10 * time.Minute},
+			},
+		},
+		"NotObserved": {
+			args: args{
+				kube:   &test.MockClient{MockGet: getFn(&metav1.Duration{Duration: time.Hour}, nil)},
+				result: reconcile.Result{Requeue: true},
+			},
+			want: want{
+				result: reconcile.Result{Requeue: true},
+			},
+		},
+		"ReconcileFailed": {
+			args: args{
+				kube:   &test.MockClient{MockGet: getFn(&metav1.Duration{Duration: time.Hour}, nil)},
+				result: reconcile.Result{RequeueAfter: time.Minute},
+				err:    errBoom,
+			},
+			want: want{
+				result: reconcile.Result{RequeueAfter: time.Minute},
+				err:    errBoom,
+			},
+		},
+		"RateLimited": {
+			args: args{
+				kube:   &test.MockClient{MockGet: rateLimitedGetFn(time.Now().Add(time.Hour))},
+				result: reconcile.Result{Requeue: true},
+			},
+			want: want{
+				result: reconcile.Result{RequeueAfter: time.Hour},
+			},
+		},
+		"RateLimitPassed": {
+			args: args{
+				kube:   &test.MockClient{MockGet: rateLimitedGetFn(time.Now().Add(-time.Minute))},
+				result: reconcile.Result{Requeue: true},
+			},
+			want: want{
+				result: reconcile.Result{Requeue: true},
+			},
+		},
+		"BackingOff": {
+			args: args{
+				kube:   &test.MockClient{MockGet: backingOffGetFn(40 * time.Second)},
+				result: reconcile.Result{Requeue: true},
+			},
+			want: want{
+				result: reconcile.Result{RequeueAfter: 40 * time.Second},
+			},
+		},
+		"AwaitingAsyncOperation": {
+			args: args{
+				kube:   &test.MockClient{MockGet: awaitingAsyncGetFn(10 * time.Second)},
+				result: reconcile.Result{RequeueAfter: time.Minute},
+			},
+			want: want{
+				result: reconcile.Result{RequeueAfter: 10 * time.Second},
+			},
+		},
+		"DeletionPending": {
+			args: args{
+				kube:   &test.MockClient{MockGet: deletionPendingGetFn(10 * time.Second)},
+				result: reconcile.Result{Requeue: true},
+			},
+			want: want{
+				result: reconcile.Result{RequeueAfter: 10 * time.Second},
+			},
+		},
+		"RequestNotFound": {
+			args: args{
+				kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				result: reconcile.Result{RequeueAfter: time.Minute},
+			},
+			want: want{
+				result: reconcile.Result{RequeueAfter: time.Minute},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := newPollIntervalReconciler(tc.args.kube, &mockReconciler{result: tc.args.result, err: tc.args.err}, time.Minute)
+			got, gotErr := r.Reconcile(context.Background(), reconcile.Request{})
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("Reconcile(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("Reconcile(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
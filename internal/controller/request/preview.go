@@ -0,0 +1,140 @@
+package request
+
+import (
+	ej "encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errPreviewRequest = "cannot preview the %s request"
+	errPreviewMapping = "no %s mapping applies to the observed state"
+
+	reasonRequestPreview event.Reason = "RequestPreview"
+
+	// maxPreviewedRequests bounds the number of Requests whose previews are remembered.
+	maxPreviewedRequests = 1000
+)
+
+// previews remembers the last previews recorded for each Request, by method, so
+// that a preview is only recorded again once it changes, rather than on every
+// observation.
+var previews = &previewCache{previews: map[types.UID]map[string]string{}}
+
+type previewCache struct {
+	mu       sync.Mutex
+	previews map[types.UID]map[string]string
+}
+
+// update remembers the previews of the Request, and returns the methods whose
+// preview changed. The cache is emptied once full, at the cost of recording the
+// previews of the Requests still annotated again.
+func (c *previewCache) update(uid types.UID, previews map[string]string) map[string]bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	last := c.previews[uid]
+	changed := map[string]bool{}
+	for method, preview := range previews {
+		if last[method] != preview {
+			changed[method] = true
+		}
+	}
+
+	if len(previews) == 0 {
+		delete(c.previews, uid)
+		return changed
+	}
+	if _, ok := c.previews[uid]; !ok && len(c.previews) >= maxPreviewedRequests {
+		c.previews = map[types.UID]map[string]string{}
+	}
+	c.previews[uid] = previews
+	return changed
+}
+
+// previewRequests records an event with the request that would be sent for each
+// method listed in the preview-request annotation, without sending it, whenever it
+// changes. The headers and body are redacted like in the status, and the
+// credentials and signatures added by the Http client aren't part of them.
+func (c *external) previewRequests(cr *v1alpha1.Request) {
+	methods := previewMethods(cr)
+	generated := make(map[string]string, len(methods))
+	failures := map[string]error{}
+	for _, method := range methods {
+		preview, err := c.previewRequest(cr, method)
+		if err != nil {
+			err = errors.Wrapf(err, errPreviewRequest, method)
+			failures[method] = err
+			preview = err.Error()
+		}
+		generated[method] = preview
+	}
+
+	changed := previews.update(cr.GetUID(), generated)
+	for _, method := range methods {
+		if !changed[method] {
+			continue
+		}
+		delete(changed, method)
+		if err, ok := failures[method]; ok {
+			c.recorder.Event(cr, event.Warning(reasonRequestPreview, err))
+			continue
+		}
+
+		c.logger.Debug("request preview", "method", method, "request", generated[method])
+		c.recorder.Event(cr, event.Normal(reasonRequestPreview, generated[method]))
+	}
+}
+
+// previewRequest returns the request that would be sent for the method, as JSON.
+func (c *external) previewRequest(cr *v1alpha1.Request, method string) (string, error) {
+	mapping, ok, err := c.selectMapping(cr, method)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", errors.Errorf(errPreviewMapping, method)
+	}
+
+	requestDetails, err := c.generateValidRequestDetails(cr, mapping)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.RedactJSONString(statusBody(mapping, requestDetails.Body), cr.Spec.ForProvider.SecretFields)
+	if err != nil {
+		return "", err
+	}
+
+	preview, err := ej.Marshal(httpClient.HttpRequest{
+		Method:  mapping.Method,
+		URL:     requestDetails.Url,
+		Headers: utils.RedactHeaders(requestDetails.Headers, cr.Spec.ForProvider.RedactHeaders),
+		Body:    body,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(preview), nil
+}
+
+// previewMethods returns the methods listed in the preview-request annotation, e.g.
+// `PUT,POST`.
+func previewMethods(cr *v1alpha1.Request) []string {
+	var methods []string
+	for _, method := range strings.Split(cr.GetAnnotations()[v1alpha1.AnnotationPreviewRequest], ",") {
+		if method = strings.ToUpper(strings.TrimSpace(method)); method != "" {
+			methods = append(methods, method)
+		}
+	}
+	return methods
+}
@@ -0,0 +1,154 @@
+package request
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+)
+
+func Test_previewRequest(t *testing.T) {
+	type args struct {
+		mg     *v1alpha1.Request
+		method string
+	}
+	type want struct {
+		preview string
+		err     error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Update": {
+			args: args{
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Spec.ForProvider.Headers = map[string][]string{"Authorization": {"Bearer s3cr3t"}}
+					r.Spec.ForProvider.RedactHeaders = []string{"authorization"}
+					r.Spec.ForProvider.SecretFields = []string{"$.username"}
+				}),
+				method: "PUT",
+			},
+			want: want{
+				preview: `{"method":"PUT","body":"{\"username\":\"***\"}","url":"https://api.example.com/users/123","headers":{"Authorization":["***"]}}`,
+			},
+		},
+		"NoMapping": {
+			args: args{
+				mg:     httpRequest(),
+				method: "PATCH",
+			},
+			want: want{
+				err: errors.Errorf(errPreviewMapping, "PATCH"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{logger: logging.NewNopLogger()}
+
+			got, gotErr := e.previewRequest(tc.args.mg, tc.args.method)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("previewRequest(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.preview, got); diff != "" {
+				t.Errorf("previewRequest(...): -want preview, +got preview: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_previewRequests(t *testing.T) {
+	// observation is the state of the Request when it's observed.
+	type observation struct {
+		annotation string
+		id         string
+	}
+	cases := map[string]struct {
+		observations []observation
+		want         []event.Reason
+	}{
+		"NotAnnotated": {
+			observations: []observation{{id: "123"}},
+		},
+		"SeveralMethods": {
+			observations: []observation{{annotation: "put, post", id: "123"}},
+			want:         []event.Reason{reasonRequestPreview, reasonRequestPreview},
+		},
+		"DuplicateMethods": {
+			observations: []observation{{annotation: "PUT,put", id: "123"}},
+			want:         []event.Reason{reasonRequestPreview},
+		},
+		"Unchanged": {
+			observations: []observation{{annotation: "PUT", id: "123"}, {annotation: "PUT", id: "123"}},
+			want:         []event.Reason{reasonRequestPreview},
+		},
+		"Changed": {
+			observations: []observation{{annotation: "PUT", id: "123"}, {annotation: "PUT", id: "456"}},
+			want:         []event.Reason{reasonRequestPreview, reasonRequestPreview},
+		},
+		"MethodAdded": {
+			// Only the preview of the added method is recorded.
+			observations: []observation{{annotation: "PUT", id: "123"}, {annotation: "PUT,POST", id: "123"}},
+			want:         []event.Reason{reasonRequestPreview, reasonRequestPreview},
+		},
+		"AnnotatedAgain": {
+			observations: []observation{{annotation: "PUT", id: "123"}, {id: "123"}, {annotation: "PUT", id: "123"}},
+			want:         []event.Reason{reasonRequestPreview, reasonRequestPreview},
+		},
+		"FailureUnchanged": {
+			observations: []observation{{annotation: "PATCH", id: "123"}, {annotation: "PATCH", id: "123"}},
+			want:         []event.Reason{reasonRequestPreview},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			recorder := &MockRecorder{}
+			e := &external{logger: logging.NewNopLogger(), recorder: recorder}
+
+			for _, o := range tc.observations {
+				cr := httpRequest(func(r *v1alpha1.Request) {
+					r.SetUID(types.UID(name))
+					r.Status.Response.Body = `{"id":"` + o.id + `"}`
+					if o.annotation != "" {
+						r.SetAnnotations(map[string]string{v1alpha1.AnnotationPreviewRequest: o.annotation})
+					}
+				})
+				e.previewRequests(cr)
+			}
+
+			if diff := cmp.Diff(tc.want, recorder.reasons); diff != "" {
+				t.Errorf("previewRequests(...): -want event reasons, +got event reasons: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_previewCache_update(t *testing.T) {
+	cache := &previewCache{previews: map[types.UID]map[string]string{}}
+	for i := 0; i < maxPreviewedRequests; i++ {
+		cache.update(types.UID(fmt.Sprint(i)), map[string]string{"PUT": "preview"})
+	}
+
+	// A full cache is emptied, rather than growing, for another Request.
+	if diff := cmp.Diff(map[string]bool{"PUT": true}, cache.update("other", map[string]string{"PUT": "preview"})); diff != "" {
+		t.Errorf("update(...): -want changed, +got changed: %s", diff)
+	}
+	if diff := cmp.Diff(1, len(cache.previews)); diff != "" {
+		t.Errorf("update(...): -want cached requests, +got cached requests: %s", diff)
+	}
+
+	// Previews are forgotten once the Request has none.
+	cache.update("other", nil)
+	if diff := cmp.Diff(0, len(cache.previews)); diff != "" {
+		t.Errorf("update(...): -want cached requests, +got cached requests: %s", diff)
+	}
+}
@@ -0,0 +1,105 @@
+package request
+
+import (
+	ej "encoding/json"
+	"net/http"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errReadinessCheck = "cannot evaluate the readiness check"
+
+	msgNotReady = "readiness check hasn't passed"
+)
+
+// isObjectReady reports whether the response to the GET mapping passes its readiness
+// check. Without one, the object is ready.
+func isObjectReady(cr *v1alpha1.Request, response httpClient.HttpResponse) (bool, error) {
+	mapping, ok := getMappingByMethod(&cr.Spec.ForProvider, http.MethodGet)
+	if !ok || mapping.ReadinessCheck == nil {
+		return true, nil
+	}
+
+	if !utils.IsHTTPSuccessFor(response.StatusCode, mapping.ExpectedStatusCodes) {
+		return false, nil
+	}
+
+	return isReady(mapping.ReadinessCheck, response)
+}
+
+// isReady reports whether the response passes the readiness check.
+func isReady(check *v1alpha1.ReadinessCheck, response httpClient.HttpResponse) (bool, error) {
+	if check.Path != "" {
+		ready, err := isValueAtPath(check.Path, check.Value, response.Body)
+		if err != nil || !ready {
+			return false, err
+		}
+	}
+
+	if check.Condition == "" {
+		return true, nil
+	}
+
+	ready, err := isResponseMatching(check.Condition, response)
+	if err != nil {
+		return false, errors.Wrap(err, errReadinessCheck)
+	}
+	return ready, nil
+}
+
+// isValueAtPath reports whether the JSON body has values at the path, and all of them
+// equal the value.
+func isValueAtPath(path string, value string, body string) (bool, error) {
+	if !json.IsJSONString(body) {
+		return false, nil
+	}
+
+	values, found, err := json.QueryJSONPath(json.JsonStringToMap(body), path)
+	if err != nil {
+		return false, errors.Wrap(err, errReadinessCheck)
+	}
+	if !found {
+		return false, nil
+	}
+
+	for _, selected := range values {
+		if valueString(selected) != value {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// valueString returns a string as is, and any other value as JSON.
+func valueString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+
+	encoded, err := ej.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(encoded)
+}
+
+// readyCondition returns the Ready condition of an existing object: available,
+// unless it hasn't passed its readiness check. Its message starts with the failed
+// comparisons, if any.
+func readyCondition(failedChecks string, ready bool) xpv1.Condition {
+	if ready {
+		return xpv1.Available().WithMessage(failedChecks)
+	}
+
+	if failedChecks == "" {
+		return xpv1.Unavailable().WithMessage(msgNotReady)
+	}
+	return xpv1.Unavailable().WithMessage(failedChecks + "; " + msgNotReady)
+}
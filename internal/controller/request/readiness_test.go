@@ -0,0 +1,199 @@
+package request
+
+import (
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_isObjectReady(t *testing.T) {
+	withCheck := func(check *v1alpha1.ReadinessCheck) *v1alpha1.Request {
+		return httpRequest(func(r *v1alpha1.Request) {
+			r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+				testPostMapping,
+				{
+					Method:         "GET",
+					URL:            testGetMapping.URL,
+					ReadinessCheck: check,
+				},
+			}
+		})
+	}
+
+	type args struct {
+		cr       *v1alpha1.Request
+		response httpClient.HttpResponse
+	}
+	type want struct {
+		ready bool
+		err   error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoCheck": {
+			args: args{
+				cr:       httpRequest(),
+				response: httpClient.HttpResponse{StatusCode: 200, Body: `{"status":"pending"}`},
+			},
+			want: want{
+				ready: true,
+			},
+		},
+		"PathMatches": {
+			args: args{
+				cr:       withCheck(&v1alpha1.ReadinessCheck{Path: "$.status", Value: "active"}),
+				response: httpClient.HttpResponse{StatusCode: 200, Body: `{"status":"active"}`},
+			},
+			want: want{
+				ready: true,
+			},
+		},
+		"PathDiffers": {
+			args: args{
+				cr:       withCheck(&v1alpha1.ReadinessCheck{Path: "$.status", Value: "active"}),
+				response: httpClient.HttpResponse{StatusCode: 200, Body: `{"status":"pending"}`},
+			},
+			want: want{
+				ready: false,
+			},
+		},
+		"PathMissing": {
+			args: args{
+				cr:       withCheck(&v1alpha1.ReadinessCheck{Path: "$.status", Value: "active"}),
+				response: httpClient.HttpResponse{StatusCode: 200, Body: `{"name":"db"}`},
+			},
+			want: want{
+				ready: false,
+			},
+		},
+		"WildcardNotAllMatch": {
+			args: args{
+				cr:       withCheck(&v1alpha1.ReadinessCheck{Path: "$.nodes[*].ready", Value: "true"}),
+				response: httpClient.HttpResponse{StatusCode: 200, Body: `{"nodes":[{"ready":true},{"ready":false}]}`},
+			},
+			want: want{
+				ready: false,
+			},
+		},
+		"WildcardAllMatch": {
+			args: args{
+				cr:       withCheck(&v1alpha1.ReadinessCheck{Path: "$.nodes[*].ready", Value: "true"}),
+				response: httpClient.HttpResponse{StatusCode: 200, Body: `{"nodes":[{"ready":true},{"ready":true}]}`},
+			},
+			want: want{
+				ready: true,
+			},
+		},
+		"ConditionMatches": {
+			args: args{
+				cr:       withCheck(&v1alpha1.ReadinessCheck{Condition: `.body.status == "active" and .statusCode == 200`}),
+				response: httpClient.HttpResponse{StatusCode: 200, Body: `{"status":"active"}`},
+			},
+			want: want{
+				ready: true,
+			},
+		},
+		"PathMatchesConditionFails": {
+			args: args{
+				cr:       withCheck(&v1alpha1.ReadinessCheck{Path: "$.status", Value: "active", Condition: ".body.replicas > 0"}),
+				response: httpClient.HttpResponse{StatusCode: 200, Body: `{"status":"active","replicas":0}`},
+			},
+			want: want{
+				ready: false,
+			},
+		},
+		"UnexpectedStatusCode": {
+			args: args{
+				cr:       withCheck(&v1alpha1.ReadinessCheck{Condition: "true"}),
+				response: httpClient.HttpResponse{StatusCode: 500},
+			},
+			want: want{
+				ready: false,
+			},
+		},
+		"ConditionNotBoolean": {
+			args: args{
+				cr:       withCheck(&v1alpha1.ReadinessCheck{Condition: ".body.status"}),
+				response: httpClient.HttpResponse{StatusCode: 200, Body: `{"status":"active"}`},
+			},
+			want: want{
+				err: errors.Wrap(errors.New("failed to parse string: active"), errReadinessCheck),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := isObjectReady(tc.args.cr, tc.args.response)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("isObjectReady(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.ready, got); diff != "" {
+				t.Errorf("isObjectReady(...): -want ready, +got ready: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_readyCondition(t *testing.T) {
+	drifted := failedChecksMessage([]string{defaultCompareCheck})
+
+	type args struct {
+		failedChecks string
+		ready        bool
+	}
+	type want struct {
+		condition xpv1.Condition
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Ready": {
+			args: args{
+				ready: true,
+			},
+			want: want{
+				condition: xpv1.Available(),
+			},
+		},
+		"ReadyDrifted": {
+			args: args{
+				failedChecks: drifted,
+				ready:        true,
+			},
+			want: want{
+				condition: xpv1.Available().WithMessage(drifted),
+			},
+		},
+		"NotReady": {
+			args: args{},
+			want: want{
+				condition: xpv1.Unavailable().WithMessage(msgNotReady),
+			},
+		},
+		"NotReadyDrifted": {
+			args: args{
+				failedChecks: drifted,
+			},
+			want: want{
+				condition: xpv1.Unavailable().WithMessage(drifted + "; " + msgNotReady),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := readyCondition(tc.args.failedChecks, tc.args.ready)
+			if diff := cmp.Diff(tc.want.condition, got, test.EquateConditions()); diff != "" {
+				t.Errorf("readyCondition(...): -want condition, +got condition: %s", diff)
+			}
+		})
+	}
+}
@@ -18,11 +18,14 @@ package request
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/pkg/errors"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -38,7 +41,9 @@ import (
 	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/responseconverter"
 	"github.com/crossplane-contrib/provider-http/internal/controller/request/statushandler"
+	"github.com/crossplane-contrib/provider-http/internal/features"
 	"github.com/crossplane-contrib/provider-http/internal/utils"
 )
 
@@ -52,33 +57,53 @@ const (
 	errFailedToUpdateStatusFailures = "failed to reset status failures counter"
 	errFailedUpdateStatusConditions = "failed updating status conditions"
 	errMappingNotFound              = "%s mapping doesn't exist in request, skipping operation"
+	errInsecureOverridesCA          = "insecureSkipTLSVerify is set, the CA bundle in tlsCACertSecretRef is ignored"
+	errInsecurePlainHTTP            = "insecureSkipTLSVerify has no effect on the plain HTTP base URL %s, which is never sent over TLS"
+
+	msgUpdateIssued    = "sending %s request to update the object"
+	msgUpdateSucceeded = "%s request updated the object"
+
+	reasonInsecureTLS     event.Reason = "InsecureTLS"
+	reasonDrifted         event.Reason = "ObservedDrift"
+	reasonUpdateIssued    event.Reason = "UpdateIssued"
+	reasonUpdateSucceeded event.Reason = "UpdateSucceeded"
+	reasonUpdateFailed    event.Reason = "UpdateFailed"
 )
 
 // Setup adds a controller that reconciles Request managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options, timeout time.Duration) error {
 	name := managed.ControllerName(v1alpha1.RequestGroupKind)
 	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
-	r := managed.NewReconciler(mgr,
-		resource.ManagedKind(v1alpha1.RequestGroupVersionKind),
+	opts := []managed.ReconcilerOption{
 		managed.WithExternalConnecter(&connector{
 			logger:          o.Logger,
 			kube:            mgr.GetClient(),
 			usage:           resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			recorder:        recorder,
+			tracing:         o.Features.Enabled(features.EnableTracing),
 			newHttpClientFn: httpClient.NewClient,
 		}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
 		managed.WithTimeout(timeout),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
-		managed.WithConnectionPublishers(cps...))
+		managed.WithRecorder(recorder),
+		managed.WithConnectionPublishers(cps...),
+		managed.WithFinalizer(metricsFinalizer{Finalizer: resource.NewAPIFinalizer(mgr.GetClient(), managed.FinalizerName)}),
+	}
+	if o.Features.Enabled(features.EnableAlphaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.RequestGroupVersionKind), opts...)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&v1alpha1.Request{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		Complete(ratelimiter.NewReconciler(name, newPollIntervalReconciler(mgr.GetClient(), r, o.PollInterval), o.GlobalRateLimiter))
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
@@ -87,7 +112,9 @@ type connector struct {
 	logger          logging.Logger
 	kube            client.Client
 	usage           resource.Tracker
-	newHttpClientFn func(log logging.Logger, timeout time.Duration) (httpClient.Client, error)
+	recorder        event.Recorder
+	tracing         bool
+	newHttpClientFn func(log logging.Logger, timeout time.Duration, opts ...httpClient.Option) (httpClient.Client, error)
 }
 
 // Connect typically produces an ExternalClient by:
@@ -113,15 +140,57 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errProviderNotRetrieved)
 	}
 
-	h, err := c.newHttpClientFn(l, utils.WaitTimeout(cr.Spec.ForProvider.WaitTimeout))
+	if skipsAllTLSVerify(cr) && cr.Spec.ForProvider.TLSCACertSecretRef != nil {
+		c.recorder.Event(cr, event.Warning(reasonInsecureTLS, errors.New(errInsecureOverridesCA)))
+	}
+	if baseURL := cr.Spec.ForProvider.Payload.BaseUrl; cr.Spec.ForProvider.InsecureSkipTLSVerify && httpClient.IsPlainHTTP(baseURL) {
+		c.recorder.Event(cr, event.Warning(reasonInsecureTLS, errors.Errorf(errInsecurePlainHTTP, baseURL)))
+	}
+
+	opts, err := clientOptions(ctx, c.kube, cr)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewHttpClient)
+	}
+
+	pcOpts, err := utils.ProviderConfigOptions(ctx, c.kube, pc)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewHttpClient)
+	}
+	// The Request's own authentication replaces the ProviderConfig's, rather than adding to it.
+	if !hasOwnAuth(cr) {
+		authOpts, err := utils.ProviderConfigAuthOptions(ctx, c.kube, pc)
+		if err != nil {
+			return nil, errors.Wrap(err, errNewHttpClient)
+		}
+		pcOpts = append(pcOpts, authOpts...)
+	}
+	opts = append(pcOpts, opts...)
+	opts = append(opts, httpClient.WithLogRedaction(logRedaction(cr)))
+	if c.tracing {
+		opts = append(opts, httpClient.WithTracing())
+	}
+
+	h, err := c.newHttpClientFn(l, utils.WaitTimeout(cr.Spec.ForProvider.WaitTimeout), opts...)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewHttpClient)
 	}
 
+	bodies, err := mappingBodies(ctx, c.kube, cr.Spec.ForProvider.Mappings)
+	if err != nil {
+		return nil, err
+	}
+
 	return &external{
-		localKube: c.kube,
-		logger:    l,
-		http:      h,
+		localKube:   c.kube,
+		logger:      l,
+		http:        h,
+		recorder:    c.recorder,
+		environment: utils.TemplateEnvironment(pc),
+		bodies:      bodies,
+		notifyURL:   notifyURL(pc),
+
+		certificateExpiryWarning: certificateExpiryWarning(pc),
+		failureBackoff:           newFailureBackoff(pc),
 	}, nil
 }
 
@@ -131,6 +200,39 @@ type external struct {
 	localKube client.Client
 	logger    logging.Logger
 	http      httpClient.Client
+	recorder  event.Recorder
+
+	// responses are the responses received during this reconcile by method. They're
+	// available to the templates of the requests sent afterwards, but never stored.
+	responses map[string]v1alpha1.Response
+
+	// environment holds the environment variables the ProviderConfig allows the
+	// templates to use.
+	environment map[string]string
+
+	// bodies are the bodies of the mappings referencing a Secret or a ConfigMap,
+	// by the method of their role.
+	bodies map[string]string
+
+	// notifyURL is the webhook notified of the sync state changes, if any.
+	notifyURL string
+
+	// certificateExpiryWarning is how long before the certificate of the API
+	// expires that it's warned about, zero if it isn't.
+	certificateExpiryWarning time.Duration
+
+	// failureBackoff is the backoff between the retries of failed creates and updates.
+	failureBackoff failureBackoff
+
+	// observations are the responses to the GET and HEAD requests observing the
+	// object during this reconcile, by request, so that identical requests reuse
+	// them. As the external client is connected for every reconcile, they're
+	// never reused by the next one.
+	observations map[string]httpClient.HttpDetails
+
+	// recreate means that the last observation found a changed immutable field, so
+	// the update deletes the object and creates it again.
+	recreate bool
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -138,24 +240,96 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotRequest)
 	}
+	c.previewRequests(cr)
+
+	if isAwaitingDeletion(cr) {
+		// The DELETE request was sent, but the object is only gone once the GET mapping says so.
+		deleted, err := c.isDeleted(ctx, cr)
+		if err != nil {
+			cr.Status.SetConditions(observedCondition(ObserveRequestDetails{}, "", err))
+			return managed.ExternalObservation{}, errors.Wrap(err, errFailedToCheckIfUpToDate)
+		}
+		cr.Status.SetConditions(deletionCondition(deleted, msgDeletionPending))
+		return managed.ExternalObservation{ResourceExists: !deleted}, nil
+	}
+
+	if isAwaitingRecreate(cr) {
+		// The object deleted to be created again is only created once the GET mapping says it's gone.
+		deleted, err := c.isDeleted(ctx, cr)
+		if err != nil {
+			cr.Status.SetConditions(observedCondition(ObserveRequestDetails{}, "", err))
+			return managed.ExternalObservation{}, errors.Wrap(err, errFailedToCheckIfUpToDate)
+		}
+		cr.Status.SetConditions(deletionCondition(deleted, msgRecreatePending))
+		if !deleted {
+			cr.SetConditions(xpv1.Deleting().WithMessage(msgRecreatePending))
+			return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+		}
+		cr.Status.PlannedAction = plannedAction(cr, false, false)
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if isAwaitingAsyncOperation(cr) {
+		// The create request was accepted, but the object is only available once its operation completes.
+		done, err := c.pollAsyncOperation(ctx, cr)
+		if err != nil {
+			// The object may have been created anyway, so the create request isn't sent again.
+			c.recorder.Event(cr, event.Warning(reasonAsyncFailed, err))
+			cr.SetConditions(xpv1.Unavailable().WithMessage(err.Error()), observedCondition(ObserveRequestDetails{}, "", err))
+			return managed.ExternalObservation{}, errors.Wrap(err, errAsyncOperation)
+		}
+		if !done {
+			cr.SetConditions(xpv1.Creating(), pendingCondition(msgAsyncPending))
+			return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+		}
+	}
 
 	observeRequestDetails, err := c.isUpToDate(ctx, cr)
 	if err != nil && err.Error() == errObjectNotFound {
+		// The status is saved by the managed reconciler when it reports the missing object.
+		cr.Status.PlannedAction = plannedAction(cr, false, false)
+		cr.Status.SetConditions(observedCondition(observeRequestDetails, "", err))
 		return managed.ExternalObservation{
 			ResourceExists: false,
 		}, nil
 	}
 
+	if isObservationFailed(err) {
+		// Only the error is recorded, keeping the last observed response, so that
+		// the next reconcile doesn't mistake the object for a deleted one.
+		statusHandler, handlerErr := statushandler.NewStatusHandler(ctx, cr, httpClient.HttpDetails{}, err, c.localKube, c.logger)
+		if handlerErr != nil {
+			return managed.ExternalObservation{}, handlerErr
+		}
+		cr.Status.SetConditions(observedCondition(observeRequestDetails, "", err))
+		return managed.ExternalObservation{}, errors.Wrap(statusHandler.SetRequestStatus(), errFailedToCheckIfUpToDate)
+	}
+
 	if err != nil {
+		// The status is saved by the managed reconciler when it reports the error.
+		cr.Status.SetConditions(observedCondition(observeRequestDetails, "", err))
 		return managed.ExternalObservation{}, errors.Wrap(err, errFailedToCheckIfUpToDate)
 	}
+	c.warnCertificateExpiry(cr, observeRequestDetails.Details)
 
 	// Get the latest version of the resource before updating
 	if err := c.localKube.Get(ctx, types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, cr); err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, "failed to get the latest version of the resource")
 	}
 
-	statusHandler, err := statushandler.NewStatusHandler(ctx, cr, observeRequestDetails.Details, observeRequestDetails.ResponseError, c.localKube, c.logger)
+	connectionDetails, details, err := extractSecretOutputs(cr.Spec.ForProvider.SecretOutputs, observeRequestDetails.Details, getExpectedStatusCodes(&cr.Spec.ForProvider, http.MethodGet))
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	outputs, err := statusOutputs(cr, details, getExpectedStatusCodes(&cr.Spec.ForProvider, http.MethodGet))
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if get, ok := getMappingByMethod(&cr.Spec.ForProvider, http.MethodGet); ok {
+		details.HttpRequest.Body = statusBody(get, details.HttpRequest.Body)
+	}
+
+	statusHandler, err := statushandler.NewStatusHandler(ctx, cr, details, observeRequestDetails.ResponseError, c.localKube, c.logger)
 	if err != nil {
 		return managed.ExternalObservation{}, err
 	}
@@ -164,40 +338,171 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	if synced {
 		statusHandler.ResetFailures()
 	}
+	statusHandler.SetDiff(observeRequestDetails.Diff)
+	statusHandler.SetDesiredState(observeRequestDetails.DesiredState)
+
+	ready, err := isObjectReady(cr, observeRequestDetails.Details.HttpResponse)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errFailedToCheckIfUpToDate)
+	}
 
-	cr.Status.SetConditions(xpv1.Available())
+	// Drift is recorded once, when the failed checks change, rather than on every observation.
+	message := failedChecksMessage(observeRequestDetails.FailedChecks)
+	if message != "" && !strings.HasPrefix(cr.Status.GetCondition(xpv1.TypeReady).Message, message) {
+		c.recorder.Event(cr, event.Normal(reasonDrifted, message))
+	}
+
+	// A changed force-sync annotation updates the object even though it's synced.
+	upToDate, diff := synced, message
+	if nonce, forced := forceSync(cr); forced && synced {
+		upToDate, diff = false, fmt.Sprintf(msgForceSync, nonce)
+	}
+
+	previous := previouslySynced(cr)
+	cr.Status.SetConditions(readyCondition(message, ready), observedCondition(observeRequestDetails, message, nil))
+	cr.Status.PlannedAction = plannedAction(cr, true, upToDate)
+	if observeRequestDetails.Recreate && cr.Status.PlannedAction == v1alpha1.PlannedActionUpdate {
+		cr.Status.PlannedAction = v1alpha1.PlannedActionRecreate
+	}
+	cr.Status.Outputs = outputs
 	err = statusHandler.SetRequestStatus()
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, " failed updating status")
 	}
+	c.notify(ctx, cr, previous, synced, observeRequestDetails.Details.HttpResponse.StatusCode)
 
+	c.recreate = observeRequestDetails.Recreate
 	return managed.ExternalObservation{
 		ResourceExists:    true,
-		ResourceUpToDate:  synced,
-		ConnectionDetails: nil,
+		ResourceUpToDate:  upToDate,
+		Diff:              diff,
+		ConnectionDetails: connectionDetails,
 	}, nil
 }
 
-func (c *external) deployAction(ctx context.Context, cr *v1alpha1.Request, method string) error {
-	mapping, ok := getMappingByMethod(&cr.Spec.ForProvider, method)
+func (c *external) deployAction(ctx context.Context, cr *v1alpha1.Request, method string) (managed.ConnectionDetails, error) {
+	mapping, ok, err := c.selectMapping(cr, method)
+	if err != nil {
+		return nil, err
+	}
 	if !ok {
 		c.logger.Info(errMappingNotFound, method)
-		return nil
+		return nil, nil
 	}
 
-	requestDetails, err := generateValidRequestDetails(cr, mapping)
+	requestDetails, err := c.generateValidRequestDetails(cr, mapping)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if isMinimalUpdate(mapping) {
+		var unchanged bool
+		requestDetails.Body, unchanged, err = c.changedFieldsBody(cr, requestDetails.Body)
+		if err != nil {
+			return nil, err
+		}
+		if unchanged {
+			c.logger.Debug(msgNothingChanged, "method", method)
+			return nil, nil
+		}
+	}
+
+	// The status keeps the body with the referenced multipart values, or a body from
+	// a Secret, masked.
+	maskedBody := statusBody(mapping, requestDetails.Body)
+	if hasPartValueRefs(mapping) {
+		values, err := partValues(ctx, c.localKube, mapping.Multipart)
+		if err != nil {
+			return nil, err
+		}
+
+		requestDetails, err = c.generateValidRequestDetailsWithValues(cr, mapping, values)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if method == http.MethodPost && cr.Spec.ForProvider.Idempotency != nil {
+		requestDetails.Headers = withIdempotencyKey(cr, requestDetails.Headers)
+	}
+
+	conditional := isConditionalUpdate(cr, method)
+	if conditional {
+		requestDetails.Headers = withIfMatch(requestDetails.Headers, cr.Status.ETag)
+	}
+
+	requestCtx, cancel := requestContext(ctx, mapping)
+	defer cancel()
+
+	// An upload interrupted by an earlier reconcile is resumed, rather than sending the
+	// request creating its session again.
+	details, resumed, err := c.resumeUpload(ctx, cr, mapping, requestDetails.Headers)
+	if !resumed {
+		details, err = c.http.SendRequest(requestCtx, mapping.Method, requestDetails.Url, requestDetails.Body, requestDetails.Headers, insecureSkipTLSVerify(cr, mapping))
+	}
+	httpClient.InvalidateResponses(string(cr.GetUID()), changedURLs(requestDetails.Url, details)...)
+	c.forgetObservations()
+	if err == nil && conditional && details.HttpResponse.StatusCode == http.StatusPreconditionFailed {
+		details, err = c.resendConditionalUpdate(ctx, cr, mapping, requestDetails, details)
+	}
+	if err == nil && !resumed && mapping.Upload != nil && utils.IsHTTPSuccessFor(details.HttpResponse.StatusCode, mapping.ExpectedStatusCodes) {
+		details, err = c.uploadChunks(ctx, cr, mapping, requestDetails.Headers, details)
+	}
+	if err == nil {
+		details.HttpResponse, err = c.adoptExisting(cr, mapping, details.HttpResponse)
+	}
+	if err == nil {
+		c.recordResponse(method, details.HttpResponse)
+	}
+
+	// The operation of an accepted create is polled by the next observations, from
+	// the status URL found in the response.
+	var asyncErr error
+	if err == nil && method == http.MethodPost && cr.Spec.ForProvider.Async != nil && details.HttpResponse.StatusCode == http.StatusAccepted {
+		_, asyncErr = asyncStatusURL(cr.Spec.ForProvider.Async, details)
 	}
 
-	details, err := c.http.SendRequest(ctx, mapping.Method, requestDetails.Url, requestDetails.Body, requestDetails.Headers, cr.Spec.ForProvider.InsecureSkipTLSVerify)
+	connectionDetails, details, outputsErr := extractSecretOutputs(cr.Spec.ForProvider.SecretOutputs, details, mapping.ExpectedStatusCodes)
+	if outputsErr != nil {
+		return nil, outputsErr
+	}
+	details.HttpRequest.Body = maskedBody
+	applied := err == nil && method != http.MethodDelete && utils.IsHTTPSuccessFor(details.HttpResponse.StatusCode, mapping.ExpectedStatusCodes)
+
+	var outputs map[string]extv1.JSON
+	if applied {
+		if outputs, outputsErr = statusOutputs(cr, details, mapping.ExpectedStatusCodes); outputsErr != nil {
+			return nil, outputsErr
+		}
+	}
 
 	statusHandler, err := statushandler.NewStatusHandler(ctx, cr, details, err, c.localKube, c.logger)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	statusHandler.SetMapping(mapping)
+	if applied {
+		applyForceSync(cr)
+		if method == http.MethodPost {
+			cr.Status.Location = createdLocation(details)
+		}
+		cr.Status.Outputs = outputs
+	}
+
+	if err := statusHandler.SetRequestStatus(); err != nil {
+		return connectionDetails, err
 	}
 
-	return statusHandler.SetRequestStatus()
+	return connectionDetails, asyncErr
+}
+
+// recordResponse keeps the response to the method for the templates of the requests
+// sent later in this reconcile.
+func (c *external) recordResponse(method string, response httpClient.HttpResponse) {
+	if c.responses == nil {
+		c.responses = map[string]v1alpha1.Response{}
+	}
+	c.responses[method] = responseconverter.HttpResponseToV1alpha1Response(response)
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
@@ -206,7 +511,11 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotRequest)
 	}
 
-	return managed.ExternalCreation{}, errors.Wrap(c.deployAction(ctx, cr, http.MethodPost), errFailedToSendHttpRequest)
+	connectionDetails, err := c.deployAction(ctx, cr, http.MethodPost)
+	if err != nil {
+		c.backOff(cr, err)
+	}
+	return managed.ExternalCreation{ConnectionDetails: connectionDetails}, errors.Wrap(err, errFailedToSendHttpRequest)
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
@@ -215,7 +524,31 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotRequest)
 	}
 
-	return managed.ExternalUpdate{}, errors.Wrap(c.deployAction(ctx, cr, http.MethodPut), errFailedToSendHttpRequest)
+	// A changed immutable field can't be updated, the object is created again instead.
+	if c.recreate {
+		c.recorder.Event(cr, event.Normal(reasonRecreateIssued, msgRecreateIssued))
+		connectionDetails, err := c.recreateObject(ctx, cr)
+		if err != nil {
+			c.recorder.Event(cr, event.Warning(reasonUpdateFailed, err))
+			c.backOff(cr, err)
+		}
+		return managed.ExternalUpdate{ConnectionDetails: connectionDetails}, errors.Wrap(err, errFailedToSendHttpRequest)
+	}
+
+	method, err := c.updateMethod(cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errFailedToSendHttpRequest)
+	}
+	c.recorder.Event(cr, event.Normal(reasonUpdateIssued, fmt.Sprintf(msgUpdateIssued, method)))
+
+	connectionDetails, err := c.deployAction(ctx, cr, method)
+	if err != nil {
+		c.recorder.Event(cr, event.Warning(reasonUpdateFailed, err))
+		c.backOff(cr, err)
+	} else {
+		c.recorder.Event(cr, event.Normal(reasonUpdateSucceeded, fmt.Sprintf(msgUpdateSucceeded, method)))
+	}
+	return managed.ExternalUpdate{ConnectionDetails: connectionDetails}, errors.Wrap(err, errFailedToSendHttpRequest)
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
@@ -224,7 +557,15 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return errors.New(errNotRequest)
 	}
 
-	return errors.Wrap(c.deployAction(ctx, cr, http.MethodDelete), errFailedToSendHttpRequest)
+	// The deletion is confirmed by the next observations, the DELETE request is only
+	// sent again once it's been pending for longer than the max wait.
+	if isDeletionPending(cr) {
+		c.logger.Debug("object is still being deleted", "request", cr.Name)
+		return nil
+	}
+
+	_, err := c.deployAction(ctx, cr, http.MethodDelete)
+	return errors.Wrap(err, errFailedToSendHttpRequest)
 }
 
 // generateValidRequestDetails generates valid request details based on the given Request resource and Mapping configuration.
@@ -232,13 +573,27 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 // details are valid, the function returns them. If not, it falls back to using the cached response in the Request's status
 // and attempts to generate request details again. The function returns the generated request details or an error if the
 // generation process fails.
-func generateValidRequestDetails(cr *v1alpha1.Request, mapping *v1alpha1.Mapping) (requestgen.RequestDetails, error) {
-	requestDetails, _, ok := requestgen.GenerateRequestDetails(*mapping, cr.Spec.ForProvider, cr.Status.Response)
+// Multipart parts referencing a Secret or a ConfigMap are masked.
+func (c *external) generateValidRequestDetails(cr *v1alpha1.Request, mapping *v1alpha1.Mapping) (requestgen.RequestDetails, error) {
+	return c.generateValidRequestDetailsWithValues(cr, mapping, nil)
+}
+
+// generateValidRequestDetailsWithValues generates valid request details like generateValidRequestDetails,
+// with the given values of the multipart parts referencing a Secret or a ConfigMap.
+func (c *external) generateValidRequestDetailsWithValues(cr *v1alpha1.Request, mapping *v1alpha1.Mapping, partValues map[string]string) (requestgen.RequestDetails, error) {
+	templateContext := requestgen.TemplateContext{
+		Responses:   c.responses,
+		PartValues:  partValues,
+		Environment: c.environment,
+		Body:        c.bodies[mapping.RoleMethod()],
+		Location:    cr.Status.Location,
+	}
+	requestDetails, _, ok := requestgen.GenerateRequestDetailsWithContext(*mapping, cr.Spec.ForProvider, cr.Status.Response, templateContext)
 	if requestgen.IsRequestValid(requestDetails) && ok {
 		return requestDetails, nil
 	}
 
-	requestDetails, err, _ := requestgen.GenerateRequestDetails(*mapping, cr.Spec.ForProvider, cr.Status.Cache.Response)
+	requestDetails, err, _ := requestgen.GenerateRequestDetailsWithContext(*mapping, cr.Spec.ForProvider, cr.Status.Cache.Response, templateContext)
 	if err != nil {
 		return requestgen.RequestDetails{}, err
 	}
@@ -2,14 +2,19 @@ package request
 
 import (
 	"context"
+	"net/http"
 	"testing"
+	"time"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
@@ -82,6 +87,19 @@ func (c *MockHttpClient) SendRequest(ctx context.Context, method string, url str
 	return c.MockSendRequest(ctx, method, url, body, headers, skipTLSVerify)
 }
 
+// MockRecorder records the reasons of the events.
+type MockRecorder struct {
+	reasons []event.Reason
+}
+
+func (r *MockRecorder) Event(_ runtime.Object, e event.Event) {
+	r.reasons = append(r.reasons, e.Reason)
+}
+
+func (r *MockRecorder) WithAnnotations(...string) event.Recorder {
+	return r
+}
+
 type MockSetRequestStatusFn func() error
 
 type MockResetFailuresFn func()
@@ -176,6 +194,76 @@ func Test_httpExternal_Create(t *testing.T) {
 	}
 }
 
+func Test_httpExternal_Observe_DriftEvents(t *testing.T) {
+	drifted := failedChecksMessage([]string{defaultCompareCheck})
+
+	type args struct {
+		body    string
+		message string
+	}
+	type want struct {
+		reasons []event.Reason
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Drifted": {
+			args: args{
+				body: `{"username":"john_doe"}`,
+			},
+			want: want{
+				reasons: []event.Reason{reasonDrifted},
+			},
+		},
+		"StillDrifted": {
+			args: args{
+				body:    `{"username":"john_doe"}`,
+				message: drifted,
+			},
+			want: want{},
+		},
+		"Synced": {
+			args: args{
+				body:    `{"username":"john_doe_new_username"}`,
+				message: drifted,
+			},
+			want: want{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			recorder := &MockRecorder{}
+			e := &external{
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{Body: tc.args.body, StatusCode: http.StatusOK},
+						}, nil
+					},
+				},
+				recorder: recorder,
+			}
+			cr := httpRequest(func(r *v1alpha1.Request) {
+				r.Status.Response.Body = `{"id":"123"}`
+				r.Status.SetConditions(xpv1.Available().WithMessage(tc.args.message))
+			})
+
+			if _, err := e.Observe(context.Background(), cr); err != nil {
+				t.Fatalf("e.Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.reasons, recorder.reasons); diff != "" {
+				t.Errorf("e.Observe(...): -want event reasons, +got event reasons: %s", diff)
+			}
+		})
+	}
+}
+
 func Test_httpExternal_Update(t *testing.T) {
 	type args struct {
 		http      httpClient.Client
@@ -183,7 +271,8 @@ func Test_httpExternal_Update(t *testing.T) {
 		mg        resource.Managed
 	}
 	type want struct {
-		err error
+		err     error
+		reasons []event.Reason
 	}
 
 	cases := map[string]struct {
@@ -212,7 +301,8 @@ func Test_httpExternal_Update(t *testing.T) {
 				mg: httpRequest(),
 			},
 			want: want{
-				err: errors.Wrap(errBoom, errFailedToSendHttpRequest),
+				err:     errors.Wrap(errBoom, errFailedToSendHttpRequest),
+				reasons: []event.Reason{reasonUpdateIssued, reasonUpdateFailed},
 			},
 		},
 		"Success": {
@@ -230,7 +320,80 @@ func Test_httpExternal_Update(t *testing.T) {
 				mg: httpRequest(),
 			},
 			want: want{
-				err: nil,
+				err:     nil,
+				reasons: []event.Reason{reasonUpdateIssued, reasonUpdateSucceeded},
+			},
+		},
+		"ConditionalUpdateRetried": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						if method == http.MethodGet {
+							return httpClient.HttpDetails{
+								HttpResponse: httpClient.HttpResponse{
+									Body:       `{"id":"123","username":"john_doe"}`,
+									Headers:    map[string][]string{"Etag": {`"v2"`}},
+									StatusCode: http.StatusOK,
+								},
+							}, nil
+						}
+						if headers["If-Match"][0] != `"v2"` {
+							return httpClient.HttpDetails{
+								HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusPreconditionFailed},
+							}, nil
+						}
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusOK},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Spec.ForProvider.ConditionalUpdate = &v1alpha1.ConditionalUpdate{}
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Status.ETag = `"v1"`
+				}),
+			},
+			want: want{
+				err:     nil,
+				reasons: []event.Reason{reasonUpdateIssued, reasonUpdateSucceeded},
+			},
+		},
+		"ConditionalUpdateStillRejected": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						if method == http.MethodGet {
+							return httpClient.HttpDetails{
+								HttpResponse: httpClient.HttpResponse{
+									Body:       `{"id":"123","username":"john_doe"}`,
+									Headers:    map[string][]string{"Etag": {`"v2"`}},
+									StatusCode: http.StatusOK,
+								},
+							}, nil
+						}
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusPreconditionFailed},
+							HttpRequest:  httpClient.HttpRequest{Method: method},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Spec.ForProvider.ConditionalUpdate = &v1alpha1.ConditionalUpdate{}
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Status.ETag = `"v1"`
+				}),
+			},
+			want: want{
+				err:     errors.Wrap(errors.Errorf(utils.ErrStatusCode, http.MethodPut, "412"), errFailedToSendHttpRequest),
+				reasons: []event.Reason{reasonUpdateIssued, reasonUpdateFailed},
 			},
 		},
 	}
@@ -238,15 +401,20 @@ func Test_httpExternal_Update(t *testing.T) {
 		tc := tc // Create local copies of loop variables
 
 		t.Run(name, func(t *testing.T) {
+			recorder := &MockRecorder{}
 			e := &external{
 				localKube: tc.args.localKube,
 				logger:    logging.NewNopLogger(),
 				http:      tc.args.http,
+				recorder:  recorder,
 			}
 			_, gotErr := e.Update(context.Background(), tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
 				t.Fatalf("e.Update(...): -want error, +got error: %s", diff)
 			}
+			if diff := cmp.Diff(tc.want.reasons, recorder.reasons); diff != "" {
+				t.Errorf("e.Update(...): -want event reasons, +got event reasons: %s", diff)
+			}
 		})
 	}
 }
@@ -308,6 +476,83 @@ func Test_httpExternal_Delete(t *testing.T) {
 				err: nil,
 			},
 		},
+		"SuccessWithBody": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						if body != `{"cascade":true,"id":"123"}` {
+							return httpClient.HttpDetails{}, errors.Errorf("unexpected body %s", body)
+						}
+						return httpClient.HttpDetails{}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						testGetMapping,
+						{
+							Method: "DELETE",
+							Body:   "{ id: .response.body.id, cascade: true }",
+							URL:    "(.payload.baseUrl + \"/\" + .response.body.id)",
+						},
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"DeletionPending": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{}, errBoom
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.SetDeletionTimestamp(&v1.Time{Time: time.Now()})
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Status.RequestDetails.Method = http.MethodDelete
+					r.Status.LastRequestTime = &v1.Time{Time: time.Now()}
+					r.Spec.ForProvider.WaitForDeletion = &v1alpha1.WaitForDeletion{}
+				}),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"DeletionMaxWaitExceeded": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{}, errBoom
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.SetDeletionTimestamp(&v1.Time{Time: time.Now()})
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Status.RequestDetails.Method = http.MethodDelete
+					r.Status.LastRequestTime = &v1.Time{Time: time.Now().Add(-time.Hour)}
+					r.Spec.ForProvider.WaitForDeletion = &v1alpha1.WaitForDeletion{}
+				}),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errFailedToSendHttpRequest),
+			},
+		},
 	}
 	for name, tc := range cases {
 		tc := tc // Create local copies of loop variables
@@ -325,3 +570,180 @@ func Test_httpExternal_Delete(t *testing.T) {
 		})
 	}
 }
+
+func Test_httpExternal_ResponsesWithinReconcile(t *testing.T) {
+	var putBody string
+	e := &external{
+		localKube: &test.MockClient{
+			MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+			MockGet:          test.NewMockGetFn(nil),
+		},
+		logger:   logging.NewNopLogger(),
+		recorder: event.NewNopRecorder(),
+		http: &MockHttpClient{
+			MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+				if method == http.MethodPut {
+					putBody = body
+				}
+				return httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `{"id":"123","username":"john_doe","version":7}`,
+						StatusCode: http.StatusOK,
+					},
+				}, nil
+			},
+		},
+	}
+	cr := httpRequest(func(r *v1alpha1.Request) {
+		r.Status.Response.Body = `{"id":"123"}`
+		r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+			testPostMapping,
+			testGetMapping,
+			{
+				Method: "PUT",
+				Body:   "{ username: .payload.body.username, version: .responses.GET.body.version }",
+				URL:    "(.payload.baseUrl + \"/\" + .response.body.id)",
+			},
+		}
+	})
+
+	if _, err := e.isUpToDate(context.Background(), cr); err != nil {
+		t.Fatalf("e.isUpToDate(...): unexpected error: %s", err)
+	}
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("e.Update(...): unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(`{"username":"john_doe","version":7}`, putBody); diff != "" {
+		t.Errorf("e.Update(...): -want PUT body, +got PUT body: %s", diff)
+	}
+}
+
+func Test_httpExternal_MinimalUpdate(t *testing.T) {
+	patchMapping := v1alpha1.Mapping{
+		Method: "PATCH",
+		Body:   "{ username: .payload.body.username, email: .payload.body.email, settings: { theme: \"dark\", language: \"en\" } }",
+		URL:    "(.payload.baseUrl + \"/\" + .response.body.id)",
+	}
+
+	type args struct {
+		minimalUpdate bool
+		// method is the method of the update mapping, PATCH by default.
+		method     string
+		observe    bool
+		statusBody string
+	}
+	type want struct {
+		// body is the body of the PATCH request, if it's sent.
+		body string
+		sent bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"WholeBody": {
+			args: args{
+				statusBody: `{"id":"123","username":"john_doe","email":"john@example.com"}`,
+			},
+			want: want{
+				body: `{"email":"john.doe@example.com","settings":{"language":"en","theme":"dark"},"username":"john_doe"}`,
+				sent: true,
+			},
+		},
+		"ChangedFieldsOfStatus": {
+			args: args{
+				minimalUpdate: true,
+				statusBody:    `{"id":"123","username":"john_doe","email":"john@example.com","settings":{"theme":"light","language":"en"}}`,
+			},
+			want: want{
+				body: `{"email":"john.doe@example.com","settings":{"theme":"dark"}}`,
+				sent: true,
+			},
+		},
+		"NothingChanged": {
+			args: args{
+				minimalUpdate: true,
+				statusBody:    `{"id":"123","username":"john_doe","email":"john.doe@example.com","settings":{"theme":"dark","language":"en"}}`,
+			},
+			want: want{
+				sent: false,
+			},
+		},
+		"PutSendsWholeBody": {
+			args: args{
+				minimalUpdate: true,
+				method:        "PUT",
+				statusBody:    `{"id":"123","username":"john_doe","email":"john@example.com","settings":{"theme":"light","language":"en"}}`,
+			},
+			want: want{
+				body: `{"email":"john.doe@example.com","settings":{"language":"en","theme":"dark"},"username":"john_doe"}`,
+				sent: true,
+			},
+		},
+		"ChangedFieldsOfObservedResponse": {
+			args: args{
+				minimalUpdate: true,
+				observe:       true,
+				statusBody:    `{"id":"123"}`,
+			},
+			want: want{
+				body: `{"settings":{"language":"en","theme":"dark"}}`,
+				sent: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var patchBody string
+			sent := false
+			e := &external{
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				logger:   logging.NewNopLogger(),
+				recorder: event.NewNopRecorder(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						if method == http.MethodPatch || method == http.MethodPut {
+							patchBody, sent = body, true
+						}
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"id":"123","username":"john_doe","email":"john.doe@example.com"}`,
+								StatusCode: http.StatusOK,
+							},
+						}, nil
+					},
+				},
+			}
+			mapping := patchMapping
+			mapping.MinimalUpdate = tc.args.minimalUpdate
+			if tc.args.method != "" {
+				mapping.Method = tc.args.method
+			}
+			cr := httpRequest(func(r *v1alpha1.Request) {
+				r.Spec.ForProvider.Payload.Body = `{"username": "john_doe", "email": "john.doe@example.com"}`
+				r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{testPostMapping, testGetMapping, mapping}
+				r.Status.Response.Body = tc.args.statusBody
+			})
+
+			if tc.args.observe {
+				if _, err := e.isUpToDate(context.Background(), cr); err != nil {
+					t.Fatalf("e.isUpToDate(...): unexpected error: %s", err)
+				}
+			}
+			if _, err := e.Update(context.Background(), cr); err != nil {
+				t.Fatalf("e.Update(...): unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want.sent, sent); diff != "" {
+				t.Errorf("e.Update(...): -want sent, +got sent: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.body, patchBody); diff != "" {
+				t.Errorf("e.Update(...): -want PATCH body, +got PATCH body: %s", diff)
+			}
+		})
+	}
+}
@@ -1,19 +1,92 @@
 package requestgen
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
 	"strings"
 
 	"github.com/pkg/errors"
 
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
 	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestprocessing"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
 	json_util "github.com/crossplane-contrib/provider-http/internal/json"
 	"github.com/crossplane-contrib/provider-http/internal/utils"
+	xml_util "github.com/crossplane-contrib/provider-http/internal/xml"
 
 	"golang.org/x/exp/maps"
 )
 
+const (
+	errInvalidJSONPatch  = "body of %s request must be an array of patch operations"
+	errInvalidMergePatch = "body of %s request must be a JSON object"
+	errInvalidFormBody   = "form body must be a JSON object, got: %s"
+	errInvalidXMLBody    = "XML body must be a well-formed XML document, got: %s"
+	errResponseSelector  = "cannot select the object from the response"
+	errMultipleSelected  = "response selector selected %d objects, expected at most one"
+	errMultipartBody     = "cannot build the multipart body"
+	errNextPageURL       = "cannot get the URL of the next page from the response"
+	errNextPageURLType   = "URL of the next page must be a string, got: %v"
+
+	// contentTypeFile is the default Content-Type of the file parts of a multipart body.
+	contentTypeFile = "application/octet-stream"
+
+	headerContentType     = "Content-Type"
+	contentTypeMergePatch = "application/merge-patch+json"
+	contentTypeJSONPatch  = "application/json-patch+json"
+	contentTypeForm       = "application/x-www-form-urlencoded"
+	contentTypeXML        = "application/xml"
+	contentTypeMultipart  = "multipart/form-data"
+)
+
+// Body types of a mapping.
+const (
+	BodyTypeJSON      = "json"
+	BodyTypeForm      = "form"
+	BodyTypeRaw       = "raw"
+	BodyTypeXML       = "xml"
+	BodyTypeMultipart = "multipart"
+)
+
+// BodyType returns the body type of the mapping, derived from its content type
+// unless it's set.
+func BodyType(mapping v1alpha1.Mapping) string {
+	if mapping.BodyType != "" || mapping.ContentType == "" {
+		return mapping.BodyType
+	}
+
+	mediaType, _, err := mime.ParseMediaType(mapping.ContentType)
+	if err != nil {
+		return BodyTypeRaw
+	}
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		return BodyTypeJSON
+	case mediaType == contentTypeXML || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml"):
+		return BodyTypeXML
+	case mediaType == contentTypeForm:
+		return BodyTypeForm
+	case mediaType == contentTypeMultipart:
+		return BodyTypeMultipart
+	default:
+		return BodyTypeRaw
+	}
+}
+
+// IsJSONBody reports whether bodies of the body type are JSON documents.
+func IsJSONBody(bodyType string) bool {
+	return bodyType == "" || bodyType == BodyTypeJSON
+}
+
 type RequestDetails struct {
 	Url     string
 	Body    string
@@ -22,17 +95,73 @@ type RequestDetails struct {
 
 // GenerateRequestDetails generates request details.
 func GenerateRequestDetails(methodMapping v1alpha1.Mapping, forProvider v1alpha1.RequestParameters, response v1alpha1.Response) (RequestDetails, error, bool) {
+	return generateRequestDetails(methodMapping, forProvider, generateRequestObject(forProvider, response), TemplateContext{})
+}
+
+// TemplateContext holds what the request is generated from besides the Request itself.
+type TemplateContext struct {
+	// Responses to the mappings sent before, available to the templates as
+	// `.responses.<METHOD>`, e.g. `.responses.GET.body.id`.
+	Responses map[string]v1alpha1.Response
+
+	// PartValues are the values of the multipart parts referencing a Secret or
+	// a ConfigMap, by part name. Parts without a value are masked.
+	PartValues map[string]string
+
+	// Environment holds the provider's environment variables allowed by the
+	// ProviderConfig, available to the templates as `.env.<NAME>`.
+	Environment map[string]string
+
+	// Body is the content of the key referenced by the bodyFrom of the
+	// mapping, if it has one.
+	Body string
+
+	// Location is the URL of the object captured from the response to its
+	// create request, available to the templates as `.location`.
+	Location string
+}
+
+// GenerateRequestDetailsWithContext generates request details like GenerateRequestDetails,
+// within the given template context.
+func GenerateRequestDetailsWithContext(methodMapping v1alpha1.Mapping, forProvider v1alpha1.RequestParameters, response v1alpha1.Response, templateContext TemplateContext) (RequestDetails, error, bool) {
 	jqObject := generateRequestObject(forProvider, response)
+	if len(templateContext.Responses) > 0 {
+		responsesMap, _ := json_util.StructToMap(templateContext.Responses)
+		json_util.ConvertJSONStringsToMaps(&responsesMap)
+		jqObject["responses"] = responsesMap
+	}
+	if len(templateContext.Environment) > 0 {
+		environment := map[string]interface{}{}
+		for name, value := range templateContext.Environment {
+			environment[name] = value
+		}
+		jqObject["env"] = environment
+	}
+	if templateContext.Location != "" {
+		jqObject["location"] = templateContext.Location
+	}
+
+	return generateRequestDetails(methodMapping, forProvider, jqObject, templateContext)
+}
+
+func generateRequestDetails(methodMapping v1alpha1.Mapping, forProvider v1alpha1.RequestParameters, jqObject map[string]interface{}, templateContext TemplateContext) (RequestDetails, error, bool) {
 	url, err := generateURL(methodMapping.URL, jqObject)
 	if err != nil {
 		return RequestDetails{}, err, false
 	}
 
+	if len(methodMapping.QueryParams) > 0 {
+		url, err = withQueryParams(url, methodMapping.QueryParams, jqObject)
+		if err != nil {
+			return RequestDetails{}, err, false
+		}
+	}
+
 	if !utils.IsUrlValid(url) {
 		return RequestDetails{}, errors.Errorf(utils.ErrInvalidURL, url), false
 	}
 
-	body, err := generateBody(methodMapping.Body, jqObject)
+	body, err := generateBody(methodMapping, jqObject, templateContext.Body)
 	if err != nil {
 		return RequestDetails{}, err, false
 	}
@@ -42,9 +171,97 @@ func GenerateRequestDetails(methodMapping v1alpha1.Mapping, forProvider v1alpha1
 		return RequestDetails{}, err, false
 	}
 
+	if methodMapping.ContentType != "" {
+		headers = defaultContentType(headers, methodMapping.ContentType)
+	}
+
+	bodyType := BodyType(methodMapping)
+	switch bodyType {
+	case BodyTypeForm:
+		body, headers, err = formBody(body, headers)
+	case BodyTypeXML:
+		headers, err = xmlHeaders(headers, body)
+	case BodyTypeMultipart:
+		body, headers, err = multipartBody(methodMapping, url, headers, jqObject, templateContext.PartValues)
+	}
+	if err != nil {
+		return RequestDetails{}, err, false
+	}
+
+	if methodMapping.Method == http.MethodPatch && IsJSONBody(bodyType) {
+		headers, err = patchHeaders(headers, body)
+		if err != nil {
+			return RequestDetails{}, err, false
+		}
+	}
+
+	// Strict servers reject a GET request with a Content-Type but no body.
+	if body == "" && methodMapping.RoleMethod() == http.MethodGet {
+		headers = withoutContentType(headers)
+	}
+
 	return RequestDetails{Body: body, Url: url, Headers: headers}, nil, true
 }
 
+// SelectResponse applies the selector of a GET mapping to its response, e.g. to pick the
+// object out of a collection. The selector has the same input as the mapping templates,
+// with the response as `.response`, and its first result replaces the response body.
+// A response body holding a JSON array is parsed into an array too. It returns false
+// when the selector has no result, meaning that the object doesn't exist. When the
+// selection must be unique, more than one result is an error.
+func SelectResponse(selector string, unique bool, forProvider v1alpha1.RequestParameters, response v1alpha1.Response) (v1alpha1.Response, bool, error) {
+	jqObject := generateResponseObject(forProvider, response)
+	results, err := jq.ParseValue("[("+selector+")]", jqObject)
+	if err != nil {
+		return v1alpha1.Response{}, false, errors.Wrap(err, errResponseSelector)
+	}
+
+	selected, _ := results.([]interface{})
+	if len(selected) == 0 {
+		return v1alpha1.Response{}, false, nil
+	}
+	if unique && len(selected) > 1 {
+		return v1alpha1.Response{}, false, errors.Errorf(errMultipleSelected, len(selected))
+	}
+
+	body, err := json.Marshal(selected[0])
+	if err != nil {
+		return v1alpha1.Response{}, false, errors.Wrap(err, errResponseSelector)
+	}
+
+	response.Body = string(body)
+	return response, true, nil
+}
+
+// NextPageURL returns the URL of the page following the response by the jq expression,
+// or an empty string if the response is the last page.
+func NextPageURL(expression string, forProvider v1alpha1.RequestParameters, response v1alpha1.Response) (string, error) {
+	next, err := jq.ParseValue(expression, generateResponseObject(forProvider, response))
+	if err != nil {
+		return "", errors.Wrap(err, errNextPageURL)
+	}
+
+	switch nextURL := next.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return nextURL, nil
+	default:
+		return "", errors.Errorf(errNextPageURLType, next)
+	}
+}
+
+// generateResponseObject creates the object of a jq expression evaluated on a response,
+// where a response body that is a JSON array is available as an array.
+func generateResponseObject(forProvider v1alpha1.RequestParameters, response v1alpha1.Response) map[string]interface{} {
+	jqObject := generateRequestObject(forProvider, response)
+	var items []interface{}
+	if json_util.Decode(response.Body, &items) == nil {
+		jqObject["response"].(map[string]interface{})["body"] = items
+	}
+	return jqObject
+}
+
 // generateRequestObject creates a JSON-compatible map from the specified Request's ForProvider and Response fields.
 // It merges the two maps, converts JSON strings to nested maps, and returns the resulting map.
 func generateRequestObject(forProvider v1alpha1.RequestParameters, response v1alpha1.Response) map[string]interface{} {
@@ -81,8 +298,38 @@ func generateURL(urlJQFilter string, jqObject map[string]interface{}) (string, e
 	return getURL, nil
 }
 
-// generateBody applies a mapping body to generate the request body.
-func generateBody(mappingBody string, jqObject map[string]interface{}) (string, error) {
+// withQueryParams adds the query parameters generated by applying JQ queries to the
+// query of the URL, keeping the parameters it already has as they are.
+func withQueryParams(rawURL string, queryParams map[string][]string, jqObject map[string]interface{}) (string, error) {
+	params, err := requestprocessing.ApplyJQOnMapStrings(queryParams, jqObject)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", errors.Errorf(utils.ErrInvalidURL, rawURL)
+	}
+
+	encoded := url.Values(params).Encode()
+	if u.RawQuery != "" {
+		u.RawQuery += "&" + encoded
+	} else {
+		u.RawQuery = encoded
+	}
+	return u.String(), nil
+}
+
+// generateBody renders the body of the mapping. A body from a Secret or ConfigMap is
+// sent as is, unless it's a template.
+func generateBody(mapping v1alpha1.Mapping, jqObject map[string]interface{}, content string) (string, error) {
+	mappingBody := mapping.Body
+	if mapping.BodyFrom != nil {
+		if !mapping.BodyFrom.Template {
+			return content, nil
+		}
+		mappingBody = content
+	}
 	if mappingBody == "" {
 		return "", nil
 	}
@@ -105,3 +352,207 @@ func generateHeaders(headers map[string][]string, jqObject map[string]interface{
 
 	return generatedHeaders, nil
 }
+
+// patchHeaders defaults the Content-Type of a PATCH request to a JSON merge patch,
+// and checks that the body matches the declared patch format.
+func patchHeaders(headers map[string][]string, body string) (map[string][]string, error) {
+	patchHeaders := make(map[string][]string, len(headers)+1)
+	contentType := ""
+	for key, values := range headers {
+		patchHeaders[key] = values
+		if strings.EqualFold(key, headerContentType) && len(values) > 0 {
+			contentType, _, _ = mime.ParseMediaType(values[0])
+		}
+	}
+
+	if contentType == "" {
+		contentType = contentTypeMergePatch
+		patchHeaders[headerContentType] = []string{contentTypeMergePatch}
+	}
+
+	if body == "" {
+		return patchHeaders, nil
+	}
+
+	switch contentType {
+	case contentTypeJSONPatch:
+		var operations []interface{}
+		if err := json.Unmarshal([]byte(body), &operations); err != nil {
+			return nil, errors.Errorf(errInvalidJSONPatch, contentTypeJSONPatch)
+		}
+	case contentTypeMergePatch:
+		if !json_util.IsJSONString(body) {
+			return nil, errors.Errorf(errInvalidMergePatch, contentTypeMergePatch)
+		}
+	}
+
+	return patchHeaders, nil
+}
+
+// formBody encodes the fields of a JSON object body as form values. Arrays are
+// encoded as repeated values, and anything else but strings as JSON. The
+// Content-Type defaults to application/x-www-form-urlencoded.
+func formBody(body string, headers map[string][]string) (string, map[string][]string, error) {
+	formHeaders := defaultContentType(headers, contentTypeForm)
+
+	if body == "" {
+		return "", formHeaders, nil
+	}
+
+	if !json_util.IsJSONString(body) {
+		return "", nil, errors.Errorf(errInvalidFormBody, body)
+	}
+
+	return FormValues(json_util.JsonStringToMap(body)).Encode(), formHeaders, nil
+}
+
+// FormValues returns the fields of a JSON object as form values, the way form
+// bodies encode them.
+func FormValues(object map[string]interface{}) url.Values {
+	values := url.Values{}
+	for key, value := range object {
+		items, ok := value.([]interface{})
+		if !ok {
+			items = []interface{}{value}
+		}
+		for _, item := range items {
+			values.Add(key, formValue(item))
+		}
+	}
+	return values
+}
+
+// xmlHeaders defaults the Content-Type of an XML body to application/xml, and checks
+// that the body is a well-formed XML document.
+func xmlHeaders(headers map[string][]string, body string) (map[string][]string, error) {
+	if body != "" && !xml_util.IsXMLString(body) {
+		return nil, errors.Errorf(errInvalidXMLBody, body)
+	}
+
+	return defaultContentType(headers, contentTypeXML), nil
+}
+
+// defaultContentType returns a copy of the headers, with the Content-Type set to
+// the given one unless it's already set.
+func defaultContentType(headers map[string][]string, contentType string) map[string][]string {
+	result := make(map[string][]string, len(headers)+1)
+	hasContentType := false
+	for key, values := range headers {
+		result[key] = values
+		hasContentType = hasContentType || strings.EqualFold(key, headerContentType)
+	}
+	if !hasContentType {
+		result[headerContentType] = []string{contentType}
+	}
+
+	return result
+}
+
+// withoutContentType returns a copy of the headers without the Content-Type.
+func withoutContentType(headers map[string][]string) map[string][]string {
+	result := make(map[string][]string, len(headers))
+	for key, values := range headers {
+		if !strings.EqualFold(key, headerContentType) {
+			result[key] = values
+		}
+	}
+
+	return result
+}
+
+// multipartBody builds a multipart/form-data body from the parts of the mapping.
+// The boundary is derived from the request, so that the same parts always make
+// the same body. The Content-Type is set to multipart/form-data with the boundary,
+// as the body can't be read with any other.
+func multipartBody(mapping v1alpha1.Mapping, url string, headers map[string][]string, jqObject map[string]interface{}, partValues map[string]string) (string, map[string][]string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	if err := writer.SetBoundary(multipartBoundary(mapping, url)); err != nil {
+		return "", nil, errors.Wrap(err, errMultipartBody)
+	}
+
+	for _, part := range mapping.Multipart {
+		value, err := partValue(part, jqObject, partValues)
+		if err != nil {
+			return "", nil, err
+		}
+
+		partWriter, err := writer.CreatePart(partHeader(part))
+		if err != nil {
+			return "", nil, errors.Wrap(err, errMultipartBody)
+		}
+		if _, err := partWriter.Write([]byte(value)); err != nil {
+			return "", nil, errors.Wrap(err, errMultipartBody)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", nil, errors.Wrap(err, errMultipartBody)
+	}
+
+	multipartHeaders := make(map[string][]string, len(headers)+1)
+	for key, values := range headers {
+		if !strings.EqualFold(key, headerContentType) {
+			multipartHeaders[key] = values
+		}
+	}
+	multipartHeaders[headerContentType] = []string{writer.FormDataContentType()}
+
+	return body.String(), multipartHeaders, nil
+}
+
+// partValue returns the value of a multipart part: the referenced value if the
+// part has a reference, or else its templated value.
+func partValue(part v1alpha1.MultipartPart, jqObject map[string]interface{}, partValues map[string]string) (string, error) {
+	if part.ValueFrom != nil {
+		if value, ok := partValues[part.Name]; ok {
+			return value, nil
+		}
+		return json_util.RedactedPlaceholder, nil
+	}
+
+	if part.Value == "" {
+		return "", nil
+	}
+
+	return requestprocessing.ApplyJQOnStr(part.Value, jqObject)
+}
+
+func partHeader(part v1alpha1.MultipartPart) textproto.MIMEHeader {
+	params := map[string]string{"name": part.Name}
+	contentType := part.ContentType
+	if part.FileName != "" {
+		params["filename"] = part.FileName
+		if contentType == "" {
+			contentType = contentTypeFile
+		}
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", mime.FormatMediaType("form-data", params))
+	if contentType != "" {
+		header.Set(headerContentType, contentType)
+	}
+
+	return header
+}
+
+// multipartBoundary derives the boundary of a multipart body from the request.
+func multipartBoundary(mapping v1alpha1.Mapping, url string) string {
+	hash := sha256.New()
+	hash.Write([]byte(mapping.Method + " " + url))
+	for _, part := range mapping.Multipart {
+		hash.Write([]byte("\n" + part.Name))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))[:32]
+}
+
+func formValue(value interface{}) string {
+	if str, ok := value.(string); ok {
+		return str
+	}
+
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
@@ -1,12 +1,18 @@
 package requestgen
 
 import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"strings"
 	"testing"
 
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 )
 
 var testHeaders = map[string][]string{
@@ -155,6 +161,166 @@ func Test_GenerateRequestDetails(t *testing.T) {
 				ok:  true,
 			},
 		},
+		"GetWithoutBodyOmitsContentType": {
+			args: args{
+				methodMapping: v1alpha1.Mapping{
+					Method:      "GET",
+					URL:         "(.payload.baseUrl + \"/\" + .response.body.id)",
+					Headers:     map[string][]string{"content-type": {"application/json"}, "Accept": {"application/json"}},
+					ContentType: "application/json",
+				},
+				forProvider: testForProvider,
+				response: v1alpha1.Response{
+					StatusCode: 200,
+					Body:       `{"id":"123","username":"john_doe"}`,
+				},
+				logger: logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url:     "https://api.example.com/users/123",
+					Headers: map[string][]string{"Accept": {"application/json"}},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"GetWithBodyKeepsContentType": {
+			args: args{
+				methodMapping: v1alpha1.Mapping{
+					Method:  "GET",
+					Body:    "{ username: .payload.body.username }",
+					URL:     ".payload.baseUrl",
+					Headers: map[string][]string{"Content-Type": {"application/json"}},
+				},
+				forProvider: testForProvider,
+				response:    v1alpha1.Response{},
+				logger:      logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url:     "https://api.example.com/users",
+					Body:    `{"username":"john_doe"}`,
+					Headers: map[string][]string{"Content-Type": {"application/json"}},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"PostWithoutBodyKeepsContentType": {
+			args: args{
+				methodMapping: v1alpha1.Mapping{
+					Method:  "POST",
+					URL:     ".payload.baseUrl",
+					Headers: map[string][]string{"Content-Type": {"application/json"}},
+				},
+				forProvider: testForProvider,
+				response:    v1alpha1.Response{},
+				logger:      logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url:     "https://api.example.com/users",
+					Headers: map[string][]string{"Content-Type": {"application/json"}},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"DeleteWithBody": {
+			args: args{
+				methodMapping: v1alpha1.Mapping{
+					Method:  "DELETE",
+					Body:    "{ id: .response.body.id, cascade: true }",
+					URL:     "(.payload.baseUrl + \"/\" + .response.body.id)",
+					Headers: map[string][]string{"Content-Type": {"application/json"}},
+				},
+				forProvider: testForProvider,
+				response: v1alpha1.Response{
+					StatusCode: 200,
+					Body:       `{"id":"123","username":"john_doe"}`,
+				},
+				logger: logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url:     "https://api.example.com/users/123",
+					Body:    `{"cascade":true,"id":"123"}`,
+					Headers: map[string][]string{"Content-Type": {"application/json"}},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"SuccessQueryParams": {
+			args: args{
+				methodMapping: v1alpha1.Mapping{
+					Method: "GET",
+					URL:    "(.payload.baseUrl + \"?expand=true\")",
+					QueryParams: map[string][]string{
+						"tag":  {`"a b"`, `"c"`},
+						"name": {".payload.body.username"},
+					},
+					Headers: map[string][]string{"Accept": {`["application/json", "text/plain"]`}},
+				},
+				forProvider: testForProvider,
+				response:    v1alpha1.Response{},
+				logger:      logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url:     "https://api.example.com/users?expand=true&name=john_doe&tag=a+b&tag=c",
+					Headers: map[string][]string{"Accept": {"application/json", "text/plain"}},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"SuccessContentType": {
+			args: args{
+				methodMapping: v1alpha1.Mapping{
+					Method:      "POST",
+					Body:        "{ username: .payload.body.username }",
+					URL:         ".payload.baseUrl",
+					ContentType: "application/vnd.api+json",
+				},
+				forProvider: testForProvider,
+				response:    v1alpha1.Response{},
+				logger:      logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url:     "https://api.example.com/users",
+					Body:    `{"username":"john_doe"}`,
+					Headers: map[string][]string{"Content-Type": {"application/vnd.api+json"}},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"SuccessContentTypeSetByHeaders": {
+			args: args{
+				methodMapping: v1alpha1.Mapping{
+					Method:      "POST",
+					Body:        "{ username: .payload.body.username }",
+					URL:         ".payload.baseUrl",
+					Headers:     map[string][]string{"content-type": {"application/json"}},
+					ContentType: "text/plain",
+				},
+				forProvider: testForProvider,
+				response:    v1alpha1.Response{},
+				logger:      logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url:     "https://api.example.com/users",
+					Body:    `{"username":"john_doe"}`,
+					Headers: map[string][]string{"content-type": {"application/json"}},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -175,6 +341,205 @@ func Test_GenerateRequestDetails(t *testing.T) {
 
 }
 
+func Test_GenerateRequestDetailsWithContext(t *testing.T) {
+	type args struct {
+		methodMapping v1alpha1.Mapping
+		responses     map[string]v1alpha1.Response
+		environment   map[string]string
+		body          string
+		location      string
+	}
+	type want struct {
+		requestDetails RequestDetails
+		ok             bool
+	}
+	mapping := v1alpha1.Mapping{
+		Method: "PUT",
+		Body:   "{ username: .payload.body.username, version: .responses.GET.body.version }",
+		URL:    "(.payload.baseUrl + \"/\" + .response.body.id)",
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ResponseReferenced": {
+			args: args{
+				methodMapping: mapping,
+				responses: map[string]v1alpha1.Response{
+					"GET": {StatusCode: 200, Body: `{"id":"123","version":7}`},
+				},
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url:     "https://api.example.com/users/123",
+					Body:    `{"username":"john_doe","version":7}`,
+					Headers: map[string][]string{},
+				},
+				ok: true,
+			},
+		},
+		"EnvironmentReferenced": {
+			args: args{
+				methodMapping: v1alpha1.Mapping{
+					Method:  "GET",
+					URL:     "(\"https://\" + .env.API_HOST + \"/users/\" + .response.body.id)",
+					Headers: map[string][]string{"X-Region": {".env.REGION"}},
+				},
+				environment: map[string]string{"API_HOST": "api.staging.example.com", "REGION": "eu-west-1"},
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url:     "https://api.staging.example.com/users/123",
+					Headers: map[string][]string{"X-Region": {"eu-west-1"}},
+				},
+				ok: true,
+			},
+		},
+		"BodyFrom": {
+			args: args{
+				methodMapping: v1alpha1.Mapping{
+					Method:   "PUT",
+					URL:      "(.payload.baseUrl + \"/\" + .response.body.id)",
+					BodyFrom: &v1alpha1.BodySource{SecretKeyRef: &xpv1.SecretKeySelector{Key: "policy"}},
+				},
+				body: "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n",
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url:     "https://api.example.com/users/123",
+					Body:    "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n",
+					Headers: map[string][]string{},
+				},
+				ok: true,
+			},
+		},
+		"BodyFromTemplate": {
+			args: args{
+				methodMapping: v1alpha1.Mapping{
+					Method:   "PUT",
+					URL:      "(.payload.baseUrl + \"/\" + .response.body.id)",
+					BodyFrom: &v1alpha1.BodySource{SecretKeyRef: &xpv1.SecretKeySelector{Key: "policy"}, Template: true},
+				},
+				body: "{\n  owner: .payload.body.username,\n  statements: [\"read\"]\n}",
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url:     "https://api.example.com/users/123",
+					Body:    `{"owner":"john_doe","statements":["read"]}`,
+					Headers: map[string][]string{},
+				},
+				ok: true,
+			},
+		},
+		"LocationReferenced": {
+			args: args{
+				methodMapping: v1alpha1.Mapping{
+					Method: "GET",
+					URL:    ".location // (.payload.baseUrl + \"/\" + .response.body.id)",
+				},
+				location: "https://api.example.com/v2/users/123",
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url:     "https://api.example.com/v2/users/123",
+					Headers: map[string][]string{},
+				},
+				ok: true,
+			},
+		},
+		"LocationMissing": {
+			args: args{
+				methodMapping: v1alpha1.Mapping{
+					Method: "GET",
+					URL:    ".location // (.payload.baseUrl + \"/\" + .response.body.id)",
+				},
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url:     "https://api.example.com/users/123",
+					Headers: map[string][]string{},
+				},
+				ok: true,
+			},
+		},
+		"NoResponses": {
+			args: args{
+				methodMapping: mapping,
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url:     "https://api.example.com/users/123",
+					Body:    `{"username":"john_doe","version":null}`,
+					Headers: map[string][]string{},
+				},
+				ok: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr, ok := GenerateRequestDetailsWithContext(tc.args.methodMapping, testForProvider, v1alpha1.Response{Body: `{"id":"123"}`}, TemplateContext{Responses: tc.args.responses, Environment: tc.args.environment, Body: tc.args.body, Location: tc.args.location})
+			if gotErr != nil {
+				t.Fatalf("GenerateRequestDetailsWithContext(...): unexpected error: %s", gotErr)
+			}
+			if diff := cmp.Diff(tc.want.ok, ok); diff != "" {
+				t.Fatalf("GenerateRequestDetailsWithContext(...): -want ok, +got ok: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.requestDetails, got); diff != "" {
+				t.Errorf("GenerateRequestDetailsWithContext(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_BodyType(t *testing.T) {
+	cases := map[string]struct {
+		mapping v1alpha1.Mapping
+		want    string
+	}{
+		"Default": {
+			mapping: v1alpha1.Mapping{},
+			want:    "",
+		},
+		"BodyTypeTakesPrecedence": {
+			mapping: v1alpha1.Mapping{BodyType: BodyTypeForm, ContentType: "text/plain"},
+			want:    BodyTypeForm,
+		},
+		"JSONSuffix": {
+			mapping: v1alpha1.Mapping{ContentType: "application/vnd.api+json"},
+			want:    BodyTypeJSON,
+		},
+		"JSONWithParameters": {
+			mapping: v1alpha1.Mapping{ContentType: "application/json; charset=utf-8"},
+			want:    BodyTypeJSON,
+		},
+		"XML": {
+			mapping: v1alpha1.Mapping{ContentType: "text/xml"},
+			want:    BodyTypeXML,
+		},
+		"Form": {
+			mapping: v1alpha1.Mapping{ContentType: "application/x-www-form-urlencoded"},
+			want:    BodyTypeForm,
+		},
+		"Multipart": {
+			mapping: v1alpha1.Mapping{ContentType: "multipart/form-data"},
+			want:    BodyTypeMultipart,
+		},
+		"Text": {
+			mapping: v1alpha1.Mapping{ContentType: "text/plain"},
+			want:    BodyTypeRaw,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, BodyType(tc.mapping)); diff != "" {
+				t.Errorf("BodyType(...): -want body type, +got body type: %s", diff)
+			}
+		})
+	}
+}
+
 func Test_IsRequestValid(t *testing.T) {
 	type args struct {
 		requestDetails RequestDetails
@@ -306,55 +671,519 @@ func Test_coalesceHeaders(t *testing.T) {
 	}
 }
 
-func Test_generateRequestObject(t *testing.T) {
+func Test_patchHeaders(t *testing.T) {
 	type args struct {
-		forProvider v1alpha1.RequestParameters
-		response    v1alpha1.Response
+		headers map[string][]string
+		body    string
 	}
 	type want struct {
-		result map[string]interface{}
+		headers map[string][]string
+		err     error
 	}
 	cases := map[string]struct {
 		args args
 		want want
 	}{
-		"Success": {
+		"DefaultToMergePatch": {
 			args: args{
-				forProvider: testForProvider,
-				response: v1alpha1.Response{
-					StatusCode: 200,
-					Body:       `{"id": "123"}`,
-					Headers:    nil,
+				headers: testHeaders2,
+				body:    `{"username":"john_doe"}`,
+			},
+			want: want{
+				headers: map[string][]string{
+					"countries":    {"USA", "UK", "India", "Germany"},
+					"Content-Type": {"application/merge-patch+json"},
 				},
 			},
+		},
+		"KeepJSONPatch": {
+			args: args{
+				headers: map[string][]string{"content-type": {"application/json-patch+json; charset=utf-8"}},
+				body:    `[{"op":"replace","path":"/username","value":"john_doe"}]`,
+			},
 			want: want{
-				result: map[string]any{
-					"mappings": []any{
-						map[string]any{
-							"body":   "{ username: .payload.body.username, email: .payload.body.email }",
-							"method": "POST",
-							"headers": map[string]any{
-								"colors":                []any{"red", "green", "blue"},
-								"countries":             []any{"USA", "UK", "India", "Germany"},
-								"fruits":                []any{"apple", "banana", "orange"},
-								"programming_languages": []any{"Go", "Python", "JavaScript"},
-							},
-							"url": ".payload.baseUrl",
-						},
-						map[string]any{
-							"method": "GET",
-							"url":    `(.payload.baseUrl + "/" + .response.body.id)`,
-						},
-						map[string]any{
-							"body":   `{ username: "john_doe_new_username" }`,
-							"method": "PUT",
-							"headers": map[string]any{
-								"colors":                []any{"red", "green", "blue"},
-								"countries":             []any{"USA", "UK", "India", "Germany"},
-								"fruits":                []any{"apple", "banana", "orange"},
-								"programming_languages": []any{"Go", "Python", "JavaScript"},
-							},
-							"url": `(.payload.baseUrl + "/" + .response.body.id)`,
+				headers: map[string][]string{"content-type": {"application/json-patch+json; charset=utf-8"}},
+			},
+		},
+		"InvalidJSONPatch": {
+			args: args{
+				headers: map[string][]string{"Content-Type": {"application/json-patch+json"}},
+				body:    `{"username":"john_doe"}`,
+			},
+			want: want{
+				err: errors.Errorf(errInvalidJSONPatch, contentTypeJSONPatch),
+			},
+		},
+		"InvalidMergePatch": {
+			args: args{
+				body: `[{"op":"remove","path":"/username"}]`,
+			},
+			want: want{
+				err: errors.Errorf(errInvalidMergePatch, contentTypeMergePatch),
+			},
+		},
+		"OtherContentType": {
+			args: args{
+				headers: map[string][]string{"Content-Type": {"text/plain"}},
+				body:    "john_doe",
+			},
+			want: want{
+				headers: map[string][]string{"Content-Type": {"text/plain"}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := patchHeaders(tc.args.headers, tc.args.body)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("patchHeaders(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.headers, got); diff != "" {
+				t.Fatalf("patchHeaders(...): -want headers, +got headers: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_formBody(t *testing.T) {
+	type args struct {
+		body    string
+		headers map[string][]string
+	}
+	type want struct {
+		body    string
+		headers map[string][]string
+		err     error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"EncodeFields": {
+			args: args{
+				body: `{"username":"john doe","roles":["admin","dev"],"age":42}`,
+			},
+			want: want{
+				body:    "age=42&roles=admin&roles=dev&username=john+doe",
+				headers: map[string][]string{"Content-Type": {"application/x-www-form-urlencoded"}},
+			},
+		},
+		"KeepContentType": {
+			args: args{
+				body:    `{"username":"john_doe"}`,
+				headers: map[string][]string{"content-type": {"application/x-www-form-urlencoded; charset=utf-8"}},
+			},
+			want: want{
+				body:    "username=john_doe",
+				headers: map[string][]string{"content-type": {"application/x-www-form-urlencoded; charset=utf-8"}},
+			},
+		},
+		"NotAnObject": {
+			args: args{
+				body: "username=john_doe",
+			},
+			want: want{
+				err: errors.Errorf(errInvalidFormBody, "username=john_doe"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gotBody, gotHeaders, gotErr := formBody(tc.args.body, tc.args.headers)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("formBody(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.body, gotBody); diff != "" {
+				t.Fatalf("formBody(...): -want body, +got body: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.headers, gotHeaders); diff != "" {
+				t.Fatalf("formBody(...): -want headers, +got headers: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_multipartBody(t *testing.T) {
+	type part struct {
+		Name        string
+		FileName    string
+		ContentType string
+		Value       string
+	}
+	type args struct {
+		parts      []v1alpha1.MultipartPart
+		headers    map[string][]string
+		partValues map[string]string
+	}
+	type want struct {
+		parts []part
+		err   error
+	}
+	report := v1alpha1.MultipartPart{
+		Name:     "file",
+		FileName: "report.csv",
+		ValueFrom: &v1alpha1.PartValueSource{
+			ConfigMapKeyRef: &v1alpha1.ConfigMapKeySelector{Name: "reports", Namespace: "default", Key: "report.csv"},
+		},
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Fields": {
+			args: args{
+				parts: []v1alpha1.MultipartPart{
+					{Name: "username", Value: ".payload.body.username"},
+					{Name: "note", Value: `"monthly report"`, ContentType: "text/plain"},
+				},
+				headers: map[string][]string{"content-type": {"application/json"}, "Accept": {"application/json"}},
+			},
+			want: want{
+				parts: []part{
+					{Name: "username", Value: "john_doe"},
+					{Name: "note", ContentType: "text/plain", Value: "monthly report"},
+				},
+			},
+		},
+		"FileWithValue": {
+			args: args{
+				parts:      []v1alpha1.MultipartPart{report},
+				partValues: map[string]string{"file": "month,total\n1,42"},
+			},
+			want: want{
+				parts: []part{
+					{Name: "file", FileName: "report.csv", ContentType: "application/octet-stream", Value: "month,total\n1,42"},
+				},
+			},
+		},
+		"FileMasked": {
+			args: args{
+				parts: []v1alpha1.MultipartPart{report},
+			},
+			want: want{
+				parts: []part{
+					{Name: "file", FileName: "report.csv", ContentType: "application/octet-stream", Value: "***"},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mapping := v1alpha1.Mapping{Method: "POST", BodyType: BodyTypeMultipart, Multipart: tc.args.parts}
+			jqObject := generateRequestObject(testForProvider, v1alpha1.Response{})
+			body, headers, gotErr := multipartBody(mapping, "https://api.example.com/reports", tc.args.headers, jqObject, tc.args.partValues)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("multipartBody(...): -want error, +got error: %s", diff)
+			}
+
+			mediaType, params, err := mime.ParseMediaType(headers[headerContentType][0])
+			if err != nil || mediaType != contentTypeMultipart {
+				t.Fatalf("multipartBody(...): unexpected Content-Type: %v", headers)
+			}
+			for key := range tc.args.headers {
+				if key != "content-type" {
+					if _, ok := headers[key]; !ok {
+						t.Errorf("multipartBody(...): header %s was dropped", key)
+					}
+				}
+			}
+
+			var got []part
+			reader := multipart.NewReader(strings.NewReader(body), params["boundary"])
+			for {
+				p, err := reader.NextPart()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("multipartBody(...): cannot read the body: %s", err)
+				}
+				value, _ := io.ReadAll(p)
+				got = append(got, part{Name: p.FormName(), FileName: p.FileName(), ContentType: p.Header.Get(headerContentType), Value: string(value)})
+			}
+			if diff := cmp.Diff(tc.want.parts, got); diff != "" {
+				t.Errorf("multipartBody(...): -want parts, +got parts: %s", diff)
+			}
+
+			again, _, _ := multipartBody(mapping, "https://api.example.com/reports", tc.args.headers, jqObject, tc.args.partValues)
+			if again != body {
+				t.Errorf("multipartBody(...): the body isn't deterministic")
+			}
+		})
+	}
+}
+
+func Test_xmlHeaders(t *testing.T) {
+	type args struct {
+		headers map[string][]string
+		body    string
+	}
+	type want struct {
+		headers map[string][]string
+		err     error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"DefaultContentType": {
+			args: args{
+				body: `<user><name>john_doe</name></user>`,
+			},
+			want: want{
+				headers: map[string][]string{"Content-Type": {"application/xml"}},
+			},
+		},
+		"KeepContentType": {
+			args: args{
+				headers: map[string][]string{"content-type": {"text/xml; charset=utf-8"}},
+				body:    `<user><name>john_doe</name></user>`,
+			},
+			want: want{
+				headers: map[string][]string{"content-type": {"text/xml; charset=utf-8"}},
+			},
+		},
+		"NotXML": {
+			args: args{
+				body: `{"name":"john_doe"}`,
+			},
+			want: want{
+				err: errors.Errorf(errInvalidXMLBody, `{"name":"john_doe"}`),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gotHeaders, gotErr := xmlHeaders(tc.args.headers, tc.args.body)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("xmlHeaders(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.headers, gotHeaders); diff != "" {
+				t.Fatalf("xmlHeaders(...): -want headers, +got headers: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SelectResponse(t *testing.T) {
+	type args struct {
+		selector string
+		unique   bool
+		response v1alpha1.Response
+	}
+	type want struct {
+		response v1alpha1.Response
+		ok       bool
+		err      error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"SelectElement": {
+			args: args{
+				selector: ".payload.body.username as $name | .response.body.items[] | select(.username == $name)",
+				response: v1alpha1.Response{
+					StatusCode: 200,
+					Body:       `{"items":[{"id":"1","username":"jane_doe"},{"id":"2","username":"john_doe"}]}`,
+				},
+			},
+			want: want{
+				response: v1alpha1.Response{
+					StatusCode: 200,
+					Body:       `{"id":"2","username":"john_doe"}`,
+				},
+				ok: true,
+			},
+		},
+		"NoResult": {
+			args: args{
+				selector: ".payload.body.username as $name | .response.body.items[] | select(.username == $name)",
+				response: v1alpha1.Response{
+					StatusCode: 200,
+					Body:       `{"items":[{"id":"1","username":"jane_doe"}]}`,
+				},
+			},
+			want: want{
+				response: v1alpha1.Response{},
+				ok:       false,
+			},
+		},
+		"SelectFromArray": {
+			args: args{
+				selector: ".payload.body.username as $name | .response.body[] | select(.username == $name)",
+				response: v1alpha1.Response{
+					StatusCode: 200,
+					Body:       `[{"id":"1","username":"jane_doe"},{"id":"2","username":"john_doe"}]`,
+				},
+			},
+			want: want{
+				response: v1alpha1.Response{
+					StatusCode: 200,
+					Body:       `{"id":"2","username":"john_doe"}`,
+				},
+				ok: true,
+			},
+		},
+		"LargeIntegerFromArray": {
+			args: args{
+				selector: ".response.body[] | select(.id == 12345678901234567890)",
+				response: v1alpha1.Response{
+					StatusCode: 200,
+					Body:       `[{"id":12345678901234567889},{"id":12345678901234567890}]`,
+				},
+			},
+			want: want{
+				response: v1alpha1.Response{
+					StatusCode: 200,
+					Body:       `{"id":12345678901234567890}`,
+				},
+				ok: true,
+			},
+		},
+		"EmptyArray": {
+			args: args{
+				selector: ".response.body[]",
+				response: v1alpha1.Response{
+					StatusCode: 200,
+					Body:       `[]`,
+				},
+			},
+			want: want{
+				response: v1alpha1.Response{},
+				ok:       false,
+			},
+		},
+		"UniqueSingleResult": {
+			args: args{
+				selector: ".payload.body.username as $name | .response.body[] | select(.username == $name)",
+				unique:   true,
+				response: v1alpha1.Response{
+					StatusCode: 200,
+					Body:       `[{"id":"1","username":"jane_doe"},{"id":"2","username":"john_doe"}]`,
+				},
+			},
+			want: want{
+				response: v1alpha1.Response{
+					StatusCode: 200,
+					Body:       `{"id":"2","username":"john_doe"}`,
+				},
+				ok: true,
+			},
+		},
+		"UniqueMultipleResults": {
+			args: args{
+				selector: ".payload.body.username as $name | .response.body[] | select(.username == $name)",
+				unique:   true,
+				response: v1alpha1.Response{
+					StatusCode: 200,
+					Body:       `[{"id":"1","username":"john_doe"},{"id":"2","username":"john_doe"}]`,
+				},
+			},
+			want: want{
+				response: v1alpha1.Response{},
+				err:      errors.Errorf(errMultipleSelected, 2),
+			},
+		},
+		"FirstOfMultipleResults": {
+			args: args{
+				selector: ".payload.body.username as $name | .response.body[] | select(.username == $name)",
+				response: v1alpha1.Response{
+					StatusCode: 200,
+					Body:       `[{"id":"1","username":"john_doe"},{"id":"2","username":"john_doe"}]`,
+				},
+			},
+			want: want{
+				response: v1alpha1.Response{
+					StatusCode: 200,
+					Body:       `{"id":"1","username":"john_doe"}`,
+				},
+				ok: true,
+			},
+		},
+		"InvalidSelector": {
+			args: args{
+				selector: ".response.body.items[",
+				response: v1alpha1.Response{
+					StatusCode: 200,
+					Body:       `{"items":[]}`,
+				},
+			},
+			want: want{
+				response: v1alpha1.Response{},
+				err:      errors.Wrap(errors.New(`unexpected token ")"`), errResponseSelector),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotOk, gotErr := SelectResponse(tc.args.selector, tc.args.unique, testForProvider, tc.args.response)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("SelectResponse(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.ok, gotOk); diff != "" {
+				t.Fatalf("SelectResponse(...): -want ok, +got ok: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.response, got); diff != "" {
+				t.Fatalf("SelectResponse(...): -want response, +got response: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_generateRequestObject(t *testing.T) {
+	type args struct {
+		forProvider v1alpha1.RequestParameters
+		response    v1alpha1.Response
+	}
+	type want struct {
+		result map[string]interface{}
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Success": {
+			args: args{
+				forProvider: testForProvider,
+				response: v1alpha1.Response{
+					StatusCode: 200,
+					Body:       `{"id": "123"}`,
+					Headers:    nil,
+				},
+			},
+			want: want{
+				result: map[string]any{
+					"mappings": []any{
+						map[string]any{
+							"body":   "{ username: .payload.body.username, email: .payload.body.email }",
+							"method": "POST",
+							"headers": map[string]any{
+								"colors":                []any{"red", "green", "blue"},
+								"countries":             []any{"USA", "UK", "India", "Germany"},
+								"fruits":                []any{"apple", "banana", "orange"},
+								"programming_languages": []any{"Go", "Python", "JavaScript"},
+							},
+							"url": ".payload.baseUrl",
+						},
+						map[string]any{
+							"method": "GET",
+							"url":    `(.payload.baseUrl + "/" + .response.body.id)`,
+						},
+						map[string]any{
+							"body":   `{ username: "john_doe_new_username" }`,
+							"method": "PUT",
+							"headers": map[string]any{
+								"colors":                []any{"red", "green", "blue"},
+								"countries":             []any{"USA", "UK", "India", "Germany"},
+								"fruits":                []any{"apple", "banana", "orange"},
+								"programming_languages": []any{"Go", "Python", "JavaScript"},
+							},
+							"url": `(.payload.baseUrl + "/" + .response.body.id)`,
 						},
 						map[string]any{
 							"method": "DELETE",
@@ -383,3 +1212,76 @@ func Test_generateRequestObject(t *testing.T) {
 		})
 	}
 }
+
+func Test_NextPageURL(t *testing.T) {
+	type args struct {
+		expression string
+		response   v1alpha1.Response
+	}
+	type want struct {
+		url string
+		err error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NextURL": {
+			args: args{
+				expression: ".response.body.next",
+				response: v1alpha1.Response{
+					StatusCode: 200,
+					Body:       `{"items":[],"next":"http://example/users?page=2"}`,
+				},
+			},
+			want: want{
+				url: "http://example/users?page=2",
+			},
+		},
+		"LastPage": {
+			args: args{
+				expression: ".response.body.next",
+				response: v1alpha1.Response{
+					StatusCode: 200,
+					Body:       `{"items":[],"next":null}`,
+				},
+			},
+		},
+		"ArrayBody": {
+			args: args{
+				expression: `if (.response.body | length) == 2 then "http://example/users?offset=2" else null end`,
+				response: v1alpha1.Response{
+					StatusCode: 200,
+					Body:       `[{"id":"1"},{"id":"2"}]`,
+				},
+			},
+			want: want{
+				url: "http://example/users?offset=2",
+			},
+		},
+		"NotString": {
+			args: args{
+				expression: ".response.body.next",
+				response: v1alpha1.Response{
+					StatusCode: 200,
+					Body:       `{"next":2}`,
+				},
+			},
+			want: want{
+				err: errors.Errorf(errNextPageURLType, 2),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := NextPageURL(tc.args.expression, testForProvider, tc.args.response)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("NextPageURL(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.url, got); diff != "" {
+				t.Fatalf("NextPageURL(...): -want url, +got url: %s", diff)
+			}
+		})
+	}
+}
@@ -2,11 +2,18 @@ package requestprocessing
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 
+	"github.com/pkg/errors"
+
 	"github.com/crossplane-contrib/provider-http/internal/jq"
 )
 
+const (
+	errStringParseFailed = "failed to parse string: %s"
+)
+
 func ConvertStringToJQQuery(input string) string {
 	return strings.Join(strings.Fields(input), " ")
 }
@@ -14,20 +21,24 @@ func ConvertStringToJQQuery(input string) string {
 // ApplyJQOnStr applies a jq query to a Request, returning the result as a string.
 // The function handles complex results by converting them to JSON format.
 func ApplyJQOnStr(jqQuery string, baseMap map[string]interface{}) (string, error) {
-	if result, _ := jq.ParseMapInterface(jqQuery, baseMap); result != nil {
-		transformedData, err := json.Marshal(result)
+	result, err := jq.ParseValue(jqQuery, baseMap)
+	if err != nil {
+		return "", err
+	}
+
+	switch value := result.(type) {
+	case string:
+		return value, nil
+	// An array, such as a JSON Patch body, is marshalled like an object.
+	case map[string]interface{}, []interface{}:
+		transformedData, err := json.Marshal(value)
 		if err != nil {
 			return "", err
 		}
 		return string(transformedData), nil
+	default:
+		return "", errors.Errorf(errStringParseFailed, fmt.Sprint(result))
 	}
-
-	stringResult, err := jq.ParseString(jqQuery, baseMap)
-	if err != nil {
-		return "", err
-	}
-
-	return stringResult, nil
 }
 
 // ApplyJQOnMapStrings applies the provided JQ queries to a map of strings, using the given Request.
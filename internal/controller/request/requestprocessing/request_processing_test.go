@@ -5,6 +5,7 @@ import (
 
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 )
 
 var testHeaders = map[string][]string{
@@ -113,6 +114,34 @@ func Test_ApplyJQOnStr(t *testing.T) {
 				err:    nil,
 			},
 		},
+		"SuccessArray": {
+			args: args{
+				jqQuery:  `[{ op: "replace", path: "/username", value: .payload.body.username }]`,
+				jqObject: testJQObject,
+			},
+			want: want{
+				result: `[{"op":"replace","path":"/username","value":"john_doe"}]`,
+				err:    nil,
+			},
+		},
+		"FailNumber": {
+			args: args{
+				jqQuery:  `.response.statusCode`,
+				jqObject: testJQObject,
+			},
+			want: want{
+				err: errors.Errorf(errStringParseFailed, "200"),
+			},
+		},
+		"FailNull": {
+			args: args{
+				jqQuery:  `.response.missing`,
+				jqObject: testJQObject,
+			},
+			want: want{
+				err: errors.Errorf(errStringParseFailed, "<nil>"),
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -167,6 +196,20 @@ func Test_ApplyJQOnMapStrings(t *testing.T) {
 				err: nil,
 			},
 		},
+		"SuccessWithArray": {
+			args: args{
+				keyToJQQueries: map[string][]string{
+					"tag": {`["a", .payload.body.username]`, `"c"`},
+				},
+				jqObject: testJQObject,
+			},
+			want: want{
+				result: map[string][]string{
+					"tag": {"a", "john_doe", "c"},
+				},
+				err: nil,
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -0,0 +1,49 @@
+package request
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+)
+
+// changedURLs returns the URLs whose cached responses a request changing an object
+// invalidates: the URL of the request and the URL of the object its response points
+// at, if any.
+func changedURLs(url string, details httpClient.HttpDetails) []string {
+	urls := []string{url}
+	if location := createdLocation(details); location != "" && location != url {
+		urls = append(urls, location)
+	}
+	return urls
+}
+
+// sendObserveRequest sends the request of the GET mapping, unless the mapping caches
+// its responses and a fresh one is cached for the Request.
+func (c *external) sendObserveRequest(ctx context.Context, cr *v1alpha1.Request, mapping *v1alpha1.Mapping, requestDetails requestgen.RequestDetails) (httpClient.HttpDetails, error) {
+	if !mapping.CacheResponse || mapping.RoleMethod() != http.MethodGet {
+		return c.sendObservation(ctx, mapping.Method, requestDetails.Url, requestDetails.Body, requestDetails.Headers, insecureSkipTLSVerify(cr, mapping))
+	}
+
+	scope := string(cr.GetUID())
+	if response, ok := httpClient.CachedResponse(scope, mapping.Method, requestDetails.Url, requestDetails.Body, requestDetails.Headers); ok {
+		c.logger.Debug("observing the cached response", "url", requestDetails.Url)
+		return httpClient.HttpDetails{
+			HttpRequest: httpClient.HttpRequest{
+				Method:  mapping.Method,
+				URL:     requestDetails.Url,
+				Body:    requestDetails.Body,
+				Headers: requestDetails.Headers,
+			},
+			HttpResponse: response,
+		}, nil
+	}
+
+	details, err := c.sendObservation(ctx, mapping.Method, requestDetails.Url, requestDetails.Body, requestDetails.Headers, insecureSkipTLSVerify(cr, mapping))
+	if err == nil {
+		httpClient.CacheResponse(scope, mapping.Method, requestDetails.Url, requestDetails.Body, requestDetails.Headers, details.HttpResponse)
+	}
+	return details, err
+}
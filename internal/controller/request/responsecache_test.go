@@ -0,0 +1,110 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+)
+
+func Test_sendObserveRequest(t *testing.T) {
+	cases := map[string]struct {
+		cacheResponse bool
+		// method is the method of the mapping observing the object, GET by default.
+		method string
+		// invalidate invalidates the cached responses after every observation.
+		invalidate func(cr *v1alpha1.Request)
+		want       int
+	}{
+		"NotCached": {
+			want: 2,
+		},
+		"Cached": {
+			cacheResponse: true,
+			want:          1,
+		},
+		"CachedObservationByPost": {
+			cacheResponse: true,
+			method:        http.MethodPost,
+			want:          1,
+		},
+		"InvalidatedByWrite": {
+			cacheResponse: true,
+			invalidate: func(cr *v1alpha1.Request) {
+				httpClient.InvalidateResponses(string(cr.GetUID()))
+			},
+			want: 2,
+		},
+		"InvalidatedByWriteToURL": {
+			cacheResponse: true,
+			invalidate: func(cr *v1alpha1.Request) {
+				httpClient.InvalidateResponses("other", changedURLs("https://api.example.com/users", httpClient.HttpDetails{
+					HttpRequest:  httpClient.HttpRequest{URL: "https://api.example.com/users"},
+					HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusCreated, Headers: map[string][]string{"Location": {"/users/123"}}},
+				})...)
+			},
+			want: 2,
+		},
+		"OtherURLWritten": {
+			cacheResponse: true,
+			invalidate: func(cr *v1alpha1.Request) {
+				httpClient.InvalidateResponses("other", "https://api.example.com/users/456")
+			},
+			want: 1,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			sent := 0
+			e := &external{
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						sent++
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								StatusCode: http.StatusOK,
+								Body:       `{"id":"123"}`,
+								Headers:    map[string][]string{"Cache-Control": {"max-age=300"}},
+							},
+						}, nil
+					},
+				},
+			}
+			cr := httpRequest(func(r *v1alpha1.Request) {
+				r.UID = types.UID(t.Name())
+			})
+			mapping := testGetMapping
+			mapping.CacheResponse = tc.cacheResponse
+			if tc.method != "" {
+				mapping.Method, mapping.Action = tc.method, v1alpha1.ActionObserve
+			}
+			requestDetails := requestgen.RequestDetails{Url: "https://api.example.com/users/123"}
+
+			for i := 0; i < 2; i++ {
+				details, err := e.sendObserveRequest(context.Background(), cr, &mapping, requestDetails)
+				if err != nil {
+					t.Fatalf("sendObserveRequest(...): unexpected error: %s", err)
+				}
+				if diff := cmp.Diff(`{"id":"123"}`, details.HttpResponse.Body); diff != "" {
+					t.Fatalf("sendObserveRequest(...): -want body, +got body: %s", diff)
+				}
+				if tc.invalidate != nil {
+					tc.invalidate(cr)
+				}
+				// The next reconcile connects a new external client.
+				e.forgetObservations()
+			}
+			if diff := cmp.Diff(tc.want, sent); diff != "" {
+				t.Errorf("sendObserveRequest(...): -want requests sent, +got requests sent: %s", diff)
+			}
+		})
+	}
+}
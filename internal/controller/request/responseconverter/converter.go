@@ -13,3 +13,12 @@ func HttpResponseToV1alpha1Response(httpResponse httpClient.HttpResponse) v1alph
 		Headers:    httpResponse.Headers,
 	}
 }
+
+// Convert Response to HttpResponse
+func V1alpha1ResponseToHttpResponse(response v1alpha1.Response) httpClient.HttpResponse {
+	return httpClient.HttpResponse{
+		StatusCode: response.StatusCode,
+		Body:       response.Body,
+		Headers:    response.Headers,
+	}
+}
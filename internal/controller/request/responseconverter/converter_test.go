@@ -53,3 +53,41 @@ func Test_HttpResponseToV1alpha1Response(t *testing.T) {
 	}
 
 }
+
+func Test_V1alpha1ResponseToHttpResponse(t *testing.T) {
+	type args struct {
+		response v1alpha1.Response
+	}
+	type want struct {
+		result httpClient.HttpResponse
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Success": {
+			args: args{
+				response: v1alpha1.Response{
+					Body:       `{"email":"john.doe@example.com","name":"john_doe"}`,
+					Headers:    testHeaders,
+					StatusCode: 200,
+				},
+			},
+			want: want{
+				result: httpClient.HttpResponse{
+					Body:       `{"email":"john.doe@example.com","name":"john_doe"}`,
+					Headers:    testHeaders,
+					StatusCode: 200,
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := V1alpha1ResponseToHttpResponse(tc.args.response)
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("V1alpha1ResponseToHttpResponse(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package request
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errResponseSchemaFrom = "cannot get the response schema of the %s mapping"
+	errResponseSchema     = "response to the %s mapping isn't valid against its response schema"
+)
+
+// validateResponse checks that a successful response to the mapping is valid against
+// its response schema, if it has one.
+func (c *external) validateResponse(ctx context.Context, mapping *v1alpha1.Mapping, response httpClient.HttpResponse) error {
+	if !utils.IsHTTPSuccessFor(response.StatusCode, mapping.ExpectedStatusCodes) {
+		return nil
+	}
+
+	schema := mapping.ResponseSchema
+	if ref := mapping.ResponseSchemaFrom; ref != nil {
+		var err error
+		schema, err = utils.GetConfigMapValue(ctx, c.localKube, ref.Namespace, ref.Name, ref.Key)
+		if err != nil {
+			return errors.Wrapf(err, errResponseSchemaFrom, mapping.Method)
+		}
+	}
+	if schema == "" {
+		return nil
+	}
+
+	return errors.Wrapf(json.ValidateSchema(response.Body, schema), errResponseSchema, mapping.Method)
+}
@@ -0,0 +1,93 @@
+package request
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/json"
+)
+
+func Test_validateResponse(t *testing.T) {
+	const (
+		schema   = `{"type":"object","required":["id"]}`
+		htmlBody = `<html><body>Service Unavailable</body></html>`
+	)
+
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			if o, ok := obj.(*corev1.ConfigMap); ok {
+				o.Data = map[string]string{"user.json": schema}
+			}
+			return nil
+		},
+	}
+
+	type args struct {
+		mapping  *v1alpha1.Mapping
+		response httpClient.HttpResponse
+	}
+	type want struct {
+		err error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoSchema": {
+			args: args{
+				mapping:  &testGetMapping,
+				response: httpClient.HttpResponse{StatusCode: 200, Body: htmlBody},
+			},
+		},
+		"Valid": {
+			args: args{
+				mapping:  &v1alpha1.Mapping{Method: "GET", ResponseSchema: schema},
+				response: httpClient.HttpResponse{StatusCode: 200, Body: `{"id":"123"}`},
+			},
+		},
+		"Invalid": {
+			args: args{
+				mapping:  &v1alpha1.Mapping{Method: "GET", ResponseSchema: schema},
+				response: httpClient.HttpResponse{StatusCode: 200, Body: htmlBody},
+			},
+			want: want{
+				err: errors.Wrapf(json.ValidateSchema(htmlBody, schema), errResponseSchema, "GET"),
+			},
+		},
+		"UnsuccessfulResponse": {
+			args: args{
+				mapping:  &v1alpha1.Mapping{Method: "GET", ResponseSchema: schema},
+				response: httpClient.HttpResponse{StatusCode: 404, Body: htmlBody},
+			},
+		},
+		"SchemaFromConfigMap": {
+			args: args{
+				mapping: &v1alpha1.Mapping{
+					Method:             "GET",
+					ResponseSchemaFrom: &v1alpha1.ConfigMapKeySelector{Name: "schemas", Namespace: "default", Key: "user.json"},
+				},
+				response: httpClient.HttpResponse{StatusCode: 200, Body: `{"name":"john_doe"}`},
+			},
+			want: want{
+				err: errors.Wrapf(json.ValidateSchema(`{"name":"john_doe"}`, schema), errResponseSchema, "GET"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{localKube: kube}
+			gotErr := e.validateResponse(context.Background(), tc.args.mapping, tc.args.response)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Errorf("validateResponse(...): -want error, +got error: %s", diff)
+			}
+		})
+	}
+}
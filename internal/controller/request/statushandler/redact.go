@@ -0,0 +1,39 @@
+package statushandler
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errRedactBody = "cannot redact secret fields"
+)
+
+// redact masks the configured headers and secret fields of the request and response
+// before they're stored in the status.
+func (r *requestStatusHandler) redact() error {
+	headers := r.forProvider.RedactHeaders
+	fields := r.forProvider.SecretFields
+	if len(headers) == 0 && len(fields) == 0 {
+		return nil
+	}
+
+	r.resource.HttpRequest.Headers = utils.RedactHeaders(r.resource.HttpRequest.Headers, headers)
+	r.resource.HttpResponse.Headers = utils.RedactHeaders(r.resource.HttpResponse.Headers, headers)
+
+	body, err := json.RedactJSONString(r.resource.HttpRequest.Body, fields)
+	if err != nil {
+		return errors.Wrap(err, errRedactBody)
+	}
+	r.resource.HttpRequest.Body = body
+
+	body, err = json.RedactJSONString(r.resource.HttpResponse.Body, fields)
+	if err != nil {
+		return errors.Wrap(err, errRedactBody)
+	}
+	r.resource.HttpResponse.Body = body
+
+	return nil
+}
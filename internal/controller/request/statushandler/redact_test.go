@@ -0,0 +1,101 @@
+package statushandler
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+func Test_redact(t *testing.T) {
+	type args struct {
+		forProvider v1alpha1.RequestParameters
+		request     httpClient.HttpRequest
+		response    httpClient.HttpResponse
+	}
+	type want struct {
+		request  httpClient.HttpRequest
+		response httpClient.HttpResponse
+		err      error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NothingToRedact": {
+			args: args{
+				request:  httpClient.HttpRequest{Body: `{"password":"s3cr3t"}`, Headers: map[string][]string{"Authorization": {"Bearer s3cr3t"}}},
+				response: httpClient.HttpResponse{Body: `{"token":"s3cr3t"}`},
+			},
+			want: want{
+				request:  httpClient.HttpRequest{Body: `{"password":"s3cr3t"}`, Headers: map[string][]string{"Authorization": {"Bearer s3cr3t"}}},
+				response: httpClient.HttpResponse{Body: `{"token":"s3cr3t"}`},
+			},
+		},
+		"RedactHeadersAndFields": {
+			args: args{
+				forProvider: v1alpha1.RequestParameters{
+					RedactHeaders: []string{"authorization", "Set-Cookie"},
+					SecretFields:  []string{"$.password", "$.credentials[*].token"},
+				},
+				request: httpClient.HttpRequest{
+					Body:    `{"password":"s3cr3t","username":"john_doe"}`,
+					Headers: map[string][]string{"Authorization": {"Bearer s3cr3t"}, "Accept": {"application/json"}},
+				},
+				response: httpClient.HttpResponse{
+					Body:    `{"credentials":[{"id":"1","token":"s3cr3t"}],"username":"john_doe"}`,
+					Headers: map[string][]string{"Set-Cookie": {"a=1", "b=2"}},
+				},
+			},
+			want: want{
+				request: httpClient.HttpRequest{
+					Body:    `{"password":"***","username":"john_doe"}`,
+					Headers: map[string][]string{"Authorization": {"***"}, "Accept": {"application/json"}},
+				},
+				response: httpClient.HttpResponse{
+					Body:    `{"credentials":[{"id":"1","token":"***"}],"username":"john_doe"}`,
+					Headers: map[string][]string{"Set-Cookie": {"***", "***"}},
+				},
+			},
+		},
+		"InvalidSecretField": {
+			args: args{
+				forProvider: v1alpha1.RequestParameters{
+					SecretFields: []string{"$.items[?(@.secret)]"},
+				},
+				request: httpClient.HttpRequest{Body: `{"items":[]}`},
+			},
+			want: want{
+				request: httpClient.HttpRequest{Body: `{"items":[]}`},
+				err:     errors.Wrap(errors.Errorf("unsupported path %s, only .key, [index], [*] and .* segments are supported", "$.items[?(@.secret)]"), errRedactBody),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &requestStatusHandler{
+				resource: &utils.RequestResource{
+					HttpRequest:  tc.args.request,
+					HttpResponse: tc.args.response,
+				},
+				forProvider: tc.args.forProvider,
+			}
+
+			gotErr := r.redact()
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("redact(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.request, r.resource.HttpRequest); diff != "" {
+				t.Fatalf("redact(...): -want request, +got request: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.response, r.resource.HttpResponse); diff != "" {
+				t.Fatalf("redact(...): -want response, +got response: %s", diff)
+			}
+		})
+	}
+}
@@ -3,7 +3,6 @@ package statushandler
 import (
 	"context"
 	"net/http"
-	"strconv"
 
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
@@ -20,6 +19,9 @@ import (
 type RequestStatusHandler interface {
 	SetRequestStatus() error
 	ResetFailures()
+	SetDiff(diff string)
+	SetDesiredState(desiredState string)
+	SetMapping(mapping *v1alpha1.Mapping)
 }
 
 // requestStatusHandler sets the request status.
@@ -30,6 +32,9 @@ type requestStatusHandler struct {
 	resource      *utils.RequestResource
 	responseError error
 	forProvider   v1alpha1.RequestParameters
+
+	// selected is the mapping that sent the request, when several mappings share its method.
+	selected *v1alpha1.Mapping
 }
 
 // SetRequestStatus updates the current Request's status to reflect the details of the last HTTP request that occurred.
@@ -41,20 +46,48 @@ func (r *requestStatusHandler) SetRequestStatus() error {
 		return r.setErrorAndReturn(r.responseError)
 	}
 
+	if err := r.redact(); err != nil {
+		return r.setErrorAndReturn(err)
+	}
+
 	basicSetters := []utils.SetRequestStatusFunc{
 		r.resource.SetStatusCode(),
 		r.resource.SetHeaders(),
 		r.resource.SetBody(),
 		r.resource.SetRequestDetails(),
+		r.resource.SetTiming(),
 	}
 
 	basicSetters = append(basicSetters, *r.extraSetters...)
 
-	if utils.IsHTTPError(r.resource.HttpResponse.StatusCode) {
+	mapping := r.mapping()
+	success, err := utils.IsResponseSuccess(r.resource.HttpResponse, mapping.SuccessExpression, mapping.ExpectedStatusCodes)
+	if err != nil {
+		return r.setErrorAndReturn(err)
+	}
+
+	// A success expression classifies every response, while without one, a response
+	// whose status code is neither expected nor an error, such as a 3xx, is neither.
+	failed := !success
+	if mapping.SuccessExpression == "" {
+		failed = utils.IsHTTPErrorFor(r.resource.HttpResponse.StatusCode, mapping.ExpectedStatusCodes)
+	}
+
+	// A create answered with the object already existing adopts the object, rather than failing.
+	if r.forProvider.RoleOf(r.resource.HttpRequest.Method) == http.MethodPost {
+		alreadyExists, err := utils.IsAlreadyExists(mapping.AlreadyExistsCheck, r.resource.HttpResponse)
+		if err != nil {
+			return r.setErrorAndReturn(err)
+		}
+		if alreadyExists {
+			success, failed = true, false
+		}
+	}
+	if failed {
 		return r.incrementFailuresAndReturn(basicSetters)
 	}
 
-	if utils.IsHTTPSuccess(r.resource.HttpResponse.StatusCode) {
+	if success {
 		r.appendExtraSetters(r.forProvider, &basicSetters)
 	}
 
@@ -65,8 +98,29 @@ func (r *requestStatusHandler) SetRequestStatus() error {
 	return nil
 }
 
+// mapping returns the mapping that sent the request, which declares how a successful
+// response is recognized: the selected one, or else the first one of the request's
+// method, or an empty mapping if there's none.
+func (r *requestStatusHandler) mapping() v1alpha1.Mapping {
+	if r.selected != nil {
+		return *r.selected
+	}
+	for _, mapping := range r.forProvider.Mappings {
+		if mapping.Method == r.resource.HttpRequest.Method {
+			return mapping
+		}
+	}
+	return v1alpha1.Mapping{}
+}
+
 func (r *requestStatusHandler) setErrorAndReturn(err error) error {
-	if settingError := utils.SetRequestResourceStatus(*r.resource, r.resource.SetError(err)); settingError != nil {
+	setError := r.resource.SetError(err)
+	var rateLimited *utils.RateLimitedError
+	if errors.As(err, &rateLimited) {
+		setError = r.resource.SetRateLimited(rateLimited)
+	}
+
+	if settingError := utils.SetRequestResourceStatus(*r.resource, setError); settingError != nil {
 		return errors.Wrap(settingError, utils.ErrFailedToSetStatus)
 	}
 
@@ -74,23 +128,48 @@ func (r *requestStatusHandler) setErrorAndReturn(err error) error {
 }
 
 func (r *requestStatusHandler) incrementFailuresAndReturn(combinedSetters []utils.SetRequestStatusFunc) error {
+	if utils.IsRateLimited(r.resource.HttpResponse.StatusCode) {
+		// A rate limited request isn't a failure, it's sent again once the API allows it.
+		rateLimited := utils.RateLimitError(r.resource.HttpRequest.Method, r.resource.HttpResponse.Headers)
+		combinedSetters = append(combinedSetters, r.resource.SetRateLimited(rateLimited))
+		if settingError := utils.SetRequestResourceStatus(*r.resource, combinedSetters...); settingError != nil {
+			return errors.Wrap(settingError, utils.ErrFailedToSetStatus)
+		}
+		return rateLimited
+	}
+
 	combinedSetters = append(combinedSetters, r.resource.SetError(nil)) // should increment failures counter
 
 	if settingError := utils.SetRequestResourceStatus(*r.resource, combinedSetters...); settingError != nil {
 		return errors.Wrap(settingError, utils.ErrFailedToSetStatus)
 	}
 
-	return errors.Errorf(utils.ErrStatusCode, r.resource.HttpRequest.Method, strconv.Itoa(r.resource.HttpResponse.StatusCode))
+	// The response body has been redacted by now, so secret fields don't end up in the conditions.
+	return utils.StatusCodeError(r.resource.HttpRequest.Method, r.resource.HttpResponse.StatusCode, r.resource.HttpResponse.Body)
 }
 
 func (r *requestStatusHandler) appendExtraSetters(forProvider v1alpha1.RequestParameters, combinedSetters *[]utils.SetRequestStatusFunc) {
-	if r.resource.HttpRequest.Method != http.MethodGet {
+	role := forProvider.RoleOf(r.resource.HttpRequest.Method)
+	if role != http.MethodGet {
 		*combinedSetters = append(*combinedSetters, r.resource.ResetFailures())
 	}
 
 	if r.shouldSetCache(forProvider) {
 		*combinedSetters = append(*combinedSetters, r.resource.SetCache())
 	}
+
+	if role == http.MethodGet && (forProvider.ConditionalUpdate != nil || forProvider.ConditionalObserve) {
+		*combinedSetters = append(*combinedSetters, r.resource.SetETag(etagHeader(forProvider)))
+	}
+}
+
+// etagHeader returns the name of the response header holding the entity tag, leaving
+// the default to SetETag.
+func etagHeader(forProvider v1alpha1.RequestParameters) string {
+	if forProvider.ConditionalUpdate == nil {
+		return ""
+	}
+	return forProvider.ConditionalUpdate.ETagHeader
 }
 
 // shouldSetCache determines whether the cache should be updated based on the provided mapping, HTTP response,
@@ -116,6 +195,30 @@ func (r *requestStatusHandler) ResetFailures() {
 	*r.extraSetters = append(*r.extraSetters, r.resource.ResetFailures())
 }
 
+// SetDiff stores the differences between the desired and the observed state.
+func (r *requestStatusHandler) SetDiff(diff string) {
+	if r.extraSetters == nil {
+		r.extraSetters = &[]utils.SetRequestStatusFunc{}
+	}
+
+	*r.extraSetters = append(*r.extraSetters, r.resource.SetDiff(diff))
+}
+
+// SetDesiredState stores the desired state the observed state was compared to.
+func (r *requestStatusHandler) SetDesiredState(desiredState string) {
+	if r.extraSetters == nil {
+		r.extraSetters = &[]utils.SetRequestStatusFunc{}
+	}
+
+	*r.extraSetters = append(*r.extraSetters, r.resource.SetDesiredState(desiredState))
+}
+
+// SetMapping sets the mapping that sent the request, selected by its when condition
+// among the mappings sharing its method.
+func (r *requestStatusHandler) SetMapping(mapping *v1alpha1.Mapping) {
+	r.selected = mapping
+}
+
 // NewClient returns a new Request statusHandler
 func NewStatusHandler(ctx context.Context, cr *v1alpha1.Request, requestDetails httpClient.HttpDetails, err error, localKube client.Client, logger logging.Logger) (RequestStatusHandler, error) {
 	// Get the latest version of the resource before updating
@@ -2,8 +2,10 @@ package statushandler
 
 import (
 	"context"
+	"net/http"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -89,11 +91,13 @@ func Test_SetRequestStatus(t *testing.T) {
 		requestDetails httpClient.HttpDetails
 		err            error
 		isSynced       bool
+		mapping        *v1alpha1.Mapping
 	}
 	type want struct {
 		err           error
 		httpRequest   httpClient.HttpRequest
 		failuresIndex int32
+		rateLimited   bool
 	}
 	cases := map[string]struct {
 		args args
@@ -101,7 +105,7 @@ func Test_SetRequestStatus(t *testing.T) {
 	}{
 		"Success": {
 			args: args{
-				cr: testCr,
+				cr: testCr.DeepCopy(),
 				localKube: &test.MockClient{
 					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
 					MockGet:          test.NewMockGetFn(nil),
@@ -124,7 +128,7 @@ func Test_SetRequestStatus(t *testing.T) {
 		},
 		"StatusCodeFailed": {
 			args: args{
-				cr: testCr,
+				cr: testCr.DeepCopy(),
 				localKube: &test.MockClient{
 					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
 					MockGet:          test.NewMockGetFn(nil),
@@ -140,14 +144,142 @@ func Test_SetRequestStatus(t *testing.T) {
 				err: nil,
 			},
 			want: want{
-				err:           errors.Errorf(utils.ErrStatusCode, testMethod, strconv.Itoa(400)),
+				err:           errors.Errorf(utils.ErrStatusCodeBody, testMethod, strconv.Itoa(400), `{"id":"123","username":"john_doe"}`),
 				httpRequest:   testRequest,
 				failuresIndex: 1,
 			},
 		},
+		"SelectedMapping": {
+			args: args{
+				cr: &v1alpha1.Request{
+					Spec: v1alpha1.RequestSpec{
+						ForProvider: func() v1alpha1.RequestParameters {
+							forProvider := testForProvider
+							conditional := testPostMapping
+							conditional.When = "response.statusCode == 404"
+							conditional.ExpectedStatusCodes = []int{400}
+							forProvider.Mappings = []v1alpha1.Mapping{conditional, testPostMapping, testGetMapping}
+							return forProvider
+						}(),
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 400,
+						Body:       `{"id":"123","username":"john_doe"}`,
+						Headers:    testHeaders,
+					},
+					HttpRequest: testRequest,
+				},
+				mapping: &testPostMapping,
+			},
+			want: want{
+				err:           errors.Errorf(utils.ErrStatusCodeBody, testMethod, strconv.Itoa(400), `{"id":"123","username":"john_doe"}`),
+				httpRequest:   testRequest,
+				failuresIndex: 1,
+			},
+		},
+		"StatusCodeFailedRedacted": {
+			args: args{
+				cr: &v1alpha1.Request{
+					Spec: v1alpha1.RequestSpec{
+						ForProvider: func() v1alpha1.RequestParameters {
+							forProvider := testForProvider
+							forProvider.SecretFields = []string{"$.password"}
+							return forProvider
+						}(),
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 400,
+						Body:       `{"error":"weak password","password":"s3cr3t"}`,
+						Headers:    testHeaders,
+					},
+					HttpRequest: testRequest,
+				},
+				err: nil,
+			},
+			want: want{
+				err:           errors.Errorf(utils.ErrStatusCodeBody, testMethod, strconv.Itoa(400), `{"error":"weak password","password":"***"}`),
+				httpRequest:   testRequest,
+				failuresIndex: 1,
+			},
+		},
+		"SuccessExpressionFailed": {
+			args: args{
+				cr: &v1alpha1.Request{
+					Spec: v1alpha1.RequestSpec{
+						ForProvider: func() v1alpha1.RequestParameters {
+							forProvider := testForProvider
+							postMapping := testPostMapping
+							postMapping.SuccessExpression = "response.body.ok"
+							forProvider.Mappings = []v1alpha1.Mapping{postMapping, testGetMapping}
+							return forProvider
+						}(),
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 200,
+						Body:       `{"ok":false,"error":"quota exceeded"}`,
+						Headers:    testHeaders,
+					},
+					HttpRequest: testRequest,
+				},
+				err: nil,
+			},
+			want: want{
+				err:           errors.Errorf(utils.ErrStatusCodeBody, testMethod, strconv.Itoa(200), `{"ok":false,"error":"quota exceeded"}`),
+				httpRequest:   testRequest,
+				failuresIndex: 1,
+			},
+		},
+		"RateLimited": {
+			args: args{
+				cr: &v1alpha1.Request{
+					Spec: v1alpha1.RequestSpec{ForProvider: testForProvider},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: http.StatusTooManyRequests,
+						Headers:    map[string][]string{"Retry-After": {"30"}},
+					},
+					HttpRequest: testRequest,
+				},
+			},
+			want: want{
+				err:           &utils.RateLimitedError{Method: testMethod, RetryAfter: 30 * time.Second},
+				httpRequest:   testRequest,
+				failuresIndex: 0,
+				rateLimited:   true,
+			},
+		},
 		"RequestFailed": {
 			args: args{
-				cr: testCr,
+				cr: func() *v1alpha1.Request {
+					// The request recorded by the previous reconcile is kept when sending fails.
+					cr := testCr.DeepCopy()
+					cr.Status.Failed = 1
+					cr.Status.RequestDetails = v1alpha1.Mapping{Method: testRequest.Method, Body: testRequest.Body, URL: testRequest.URL}
+					return cr
+				}(),
 				localKube: &test.MockClient{
 					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
 					MockGet:          test.NewMockGetFn(nil),
@@ -170,7 +302,7 @@ func Test_SetRequestStatus(t *testing.T) {
 		},
 		"ResetFailures": {
 			args: args{
-				cr: testCr,
+				cr: testCr.DeepCopy(),
 				localKube: &test.MockClient{
 					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
 					MockGet:          test.NewMockGetFn(nil),
@@ -198,6 +330,9 @@ func Test_SetRequestStatus(t *testing.T) {
 			if tc.args.isSynced {
 				r.ResetFailures()
 			}
+			if tc.args.mapping != nil {
+				r.SetMapping(tc.args.mapping)
+			}
 
 			gotErr := r.SetRequestStatus()
 
@@ -209,6 +344,10 @@ func Test_SetRequestStatus(t *testing.T) {
 				t.Fatalf("SetRequestStatus(...): -want Status.Failed, +got Status.Failed: %s", diff)
 			}
 
+			if diff := cmp.Diff(tc.want.rateLimited, tc.args.cr.Status.RateLimitedUntil != nil); diff != "" {
+				t.Fatalf("SetRequestStatus(...): -want Status.RateLimitedUntil set, +got Status.RateLimitedUntil set: %s", diff)
+			}
+
 			if diff := cmp.Diff(tc.want.httpRequest.Body, tc.args.cr.Status.RequestDetails.Body); diff != "" {
 				t.Fatalf("SetRequestStatus(...): -want RequestDetails.Body, +got RequestDetails.Body: %s", diff)
 			}
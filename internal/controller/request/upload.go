@@ -0,0 +1,285 @@
+package request
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/responseconverter"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	"github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errUploadURL          = "cannot find the upload URL in the response to the %s mapping"
+	errUploadContent      = "cannot get the content uploaded by the %s mapping"
+	errUploadContentRef   = "contentFrom of the %s mapping must reference either a secret or a config map key"
+	errUploadChunk        = "cannot upload the bytes %s"
+	errUploadChunkStatus  = "chunk upload failed with status code %d"
+	errUploadIncomplete   = "upload is incomplete after its last chunk, the server answered with status code %d"
+	errEmptyUploadURL     = "upload URL is empty"
+	errUploadResume       = "cannot resume the upload to %s"
+	errUploadOffset       = "server confirmed %d bytes of the %d bytes uploaded"
+	errUploadStalled      = "server accepted none of the bytes %s"
+	errSaveUploadProgress = "cannot save the progress of the upload"
+
+	headerContentRange     = "Content-Range"
+	headerRange            = "Range"
+	contentTypeOctetStream = "application/octet-stream"
+
+	defaultChunkSize        = 8 << 20
+	defaultChunkMaxAttempts = 3
+	chunkRetryDelay         = 500 * time.Millisecond
+)
+
+// uploadChunks uploads the content of the mapping in chunks to the upload session
+// created by its request, sending every chunk up to the configured number of
+// attempts. It returns the response to the last chunk.
+func (c *external) uploadChunks(ctx context.Context, cr *v1alpha1.Request, mapping *v1alpha1.Mapping, headers map[string][]string, initiated httpClient.HttpDetails) (httpClient.HttpDetails, error) {
+	uploadURL, err := chunkUploadURL(mapping.Upload, initiated)
+	if err != nil {
+		return initiated, errors.Wrapf(err, errUploadURL, mapping.Method)
+	}
+	content, err := c.uploadContent(ctx, mapping)
+	if err != nil {
+		return initiated, err
+	}
+
+	progress := &v1alpha1.UploadProgress{Method: mapping.Method, URL: uploadURL, Digest: contentDigest(content)}
+	return c.sendChunks(ctx, cr, mapping, headers, progress, content, initiated)
+}
+
+// resumeUpload resumes the upload session of the mapping saved in the status by an
+// earlier reconcile, from the offset the server confirms, so that an interrupted
+// upload isn't started over. It reports whether the upload was resumed: it isn't
+// when there's no session of the mapping, when the content changed, or when the
+// server forgot the session, and a new one must be created.
+func (c *external) resumeUpload(ctx context.Context, cr *v1alpha1.Request, mapping *v1alpha1.Mapping, headers map[string][]string) (httpClient.HttpDetails, bool, error) {
+	progress := cr.Status.Upload
+	if mapping.Upload == nil || progress == nil || progress.Method != mapping.Method {
+		return httpClient.HttpDetails{}, false, nil
+	}
+
+	content, err := c.uploadContent(ctx, mapping)
+	if err != nil {
+		return httpClient.HttpDetails{}, true, err
+	}
+	if progress.Digest != contentDigest(content) {
+		cr.Status.Upload = nil
+		return httpClient.HttpDetails{}, false, nil
+	}
+
+	// An empty chunk asks the server for the bytes it received.
+	total := int64(len(content))
+	headers = withHeader(headers, "Content-Type", contentTypeOctetStream)
+	contentRange := fmt.Sprintf("bytes */%d", total)
+	details, err := c.uploadChunk(ctx, insecureSkipTLSVerify(cr, mapping), chunkMethod(mapping.Upload), progress.URL, "", withHeader(headers, headerContentRange, contentRange), chunkMaxAttempts(mapping.Upload))
+	if statusCode := details.HttpResponse.StatusCode; statusCode == http.StatusNotFound || statusCode == http.StatusGone {
+		cr.Status.Upload = nil
+		return httpClient.HttpDetails{}, false, nil
+	}
+	if err != nil {
+		return details, true, errors.Wrapf(err, errUploadResume, progress.URL)
+	}
+	if details.HttpResponse.StatusCode != http.StatusPermanentRedirect {
+		cr.Status.Upload = nil
+		return details, true, nil
+	}
+
+	// Without a Range header, the server received none of the bytes.
+	offset, _ := committedOffset(details.HttpResponse.Headers)
+	switch {
+	case offset > total:
+		return details, true, errors.Errorf(errUploadOffset, offset, total)
+	case offset == total && total > 0:
+		return details, true, errors.Errorf(errUploadIncomplete, details.HttpResponse.StatusCode)
+	}
+	resumed := *progress
+	resumed.Offset = offset
+	details, err = c.sendChunks(ctx, cr, mapping, headers, &resumed, content, details)
+	return details, true, err
+}
+
+// sendChunks sends the content from the offset of the progress on, saving the
+// offset the server confirms in the status after every chunk. A 308 response
+// without a Range header accepts the whole chunk.
+func (c *external) sendChunks(ctx context.Context, cr *v1alpha1.Request, mapping *v1alpha1.Mapping, headers map[string][]string, progress *v1alpha1.UploadProgress, content string, details httpClient.HttpDetails) (httpClient.HttpDetails, error) {
+	chunkSize := int64(defaultChunkSize)
+	if mapping.Upload.ChunkSize > 0 {
+		chunkSize = mapping.Upload.ChunkSize
+	}
+	method := chunkMethod(mapping.Upload)
+	maxAttempts := chunkMaxAttempts(mapping.Upload)
+	headers = withHeader(headers, "Content-Type", contentTypeOctetStream)
+	total := int64(len(content))
+
+	for {
+		if err := c.saveUploadProgress(ctx, cr, progress); err != nil {
+			return details, err
+		}
+
+		// Empty content is uploaded as a single empty chunk.
+		start := progress.Offset
+		end := start + chunkSize
+		if end > total {
+			end = total
+		}
+
+		contentRange := chunkContentRange(start, end, total)
+		var err error
+		details, err = c.uploadChunk(ctx, insecureSkipTLSVerify(cr, mapping), method, progress.URL, content[start:end], withHeader(headers, headerContentRange, contentRange), maxAttempts)
+		if err != nil {
+			return details, errors.Wrapf(err, errUploadChunk, contentRange)
+		}
+		if details.HttpResponse.StatusCode != http.StatusPermanentRedirect {
+			cr.Status.Upload = nil
+			return details, nil
+		}
+
+		offset, ok := committedOffset(details.HttpResponse.Headers)
+		if !ok {
+			offset = end
+		}
+		switch {
+		case offset >= total:
+			return details, errors.Errorf(errUploadIncomplete, details.HttpResponse.StatusCode)
+		case offset <= start:
+			return details, errors.Errorf(errUploadStalled, contentRange)
+		}
+		progress.Offset = offset
+	}
+}
+
+// saveUploadProgress records the progress of the upload in the status, so that the
+// upload resumes from there if it's interrupted.
+func (c *external) saveUploadProgress(ctx context.Context, cr *v1alpha1.Request, progress *v1alpha1.UploadProgress) error {
+	saved := *progress
+	cr.Status.Upload = &saved
+	return errors.Wrap(c.localKube.Status().Update(ctx, cr), errSaveUploadProgress)
+}
+
+// uploadContent returns the content uploaded by the mapping.
+func (c *external) uploadContent(ctx context.Context, mapping *v1alpha1.Mapping) (string, error) {
+	source := mapping.Upload.ContentFrom
+	if (source.SecretKeyRef == nil) == (source.ConfigMapKeyRef == nil) {
+		return "", errors.Errorf(errUploadContentRef, mapping.Method)
+	}
+	content, err := partValue(ctx, c.localKube, source)
+	return content, errors.Wrapf(err, errUploadContent, mapping.Method)
+}
+
+// chunkMethod returns the method of the chunk requests of the upload.
+func chunkMethod(upload *v1alpha1.ChunkedUpload) string {
+	if upload.Method != "" {
+		return upload.Method
+	}
+	return http.MethodPut
+}
+
+// chunkMaxAttempts returns the maximum number of attempts to send a chunk of the upload.
+func chunkMaxAttempts(upload *v1alpha1.ChunkedUpload) int {
+	if upload.MaxAttempts > 0 {
+		return upload.MaxAttempts
+	}
+	return defaultChunkMaxAttempts
+}
+
+// contentDigest returns the hex encoded SHA-256 digest of the content.
+func contentDigest(content string) string {
+	digest := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(digest[:])
+}
+
+// committedOffset returns the number of bytes the server received, from the Range
+// header of a 308 Resume Incomplete response, e.g. `bytes=0-42`, and whether the
+// header is set.
+func committedOffset(headers map[string][]string) (int64, bool) {
+	value := http.Header(headers).Get(headerRange)
+	if value == "" {
+		return 0, false
+	}
+
+	_, last, found := strings.Cut(strings.TrimPrefix(value, "bytes="), "-")
+	end, err := strconv.ParseInt(last, 10, 64)
+	if !found || err != nil {
+		return 0, false
+	}
+	return end + 1, true
+}
+
+// uploadChunk sends a chunk until it's accepted with a 2xx or 308 Resume Incomplete
+// status code, retrying failed requests and 5xx responses.
+func (c *external) uploadChunk(ctx context.Context, skipTLSVerify bool, method string, uploadURL string, chunk string, headers map[string][]string, maxAttempts int) (httpClient.HttpDetails, error) {
+	for attempt := 1; ; attempt++ {
+		details, err := c.http.SendRequest(ctx, method, uploadURL, chunk, headers, skipTLSVerify)
+		if err == nil {
+			statusCode := details.HttpResponse.StatusCode
+			if utils.IsHTTPSuccess(statusCode) || statusCode == http.StatusPermanentRedirect {
+				return details, nil
+			}
+
+			err = errors.Errorf(errUploadChunkStatus, statusCode)
+			if statusCode < http.StatusInternalServerError {
+				return details, err
+			}
+		}
+		if attempt >= maxAttempts {
+			return details, err
+		}
+
+		c.logger.Debug("retrying chunk upload", "url", uploadURL, "range", headers[headerContentRange], "attempt", attempt, "error", err.Error())
+		select {
+		case <-ctx.Done():
+			return details, ctx.Err()
+		case <-time.After(chunkRetryDelay):
+		}
+	}
+}
+
+// chunkUploadURL returns the URL of the upload session created by the request,
+// resolved against the URL of the request.
+func chunkUploadURL(upload *v1alpha1.ChunkedUpload, initiated httpClient.HttpDetails) (string, error) {
+	responseMap, err := json.StructToMap(responseconverter.HttpResponseToV1alpha1Response(initiated.HttpResponse))
+	if err != nil {
+		return "", err
+	}
+	json.ConvertJSONStringsToMaps(&responseMap)
+
+	uploadURL, err := jq.ParseString(upload.UploadURL, responseMap)
+	if err != nil {
+		return "", err
+	}
+	if uploadURL == "" {
+		return "", errors.New(errEmptyUploadURL)
+	}
+
+	base, err := url.Parse(initiated.HttpRequest.URL)
+	if err != nil {
+		return "", err
+	}
+	ref, err := url.Parse(uploadURL)
+	if err != nil {
+		return "", err
+	}
+	return base.ResolveReference(ref).String(), nil
+}
+
+// chunkContentRange returns the Content-Range header of the chunk of the bytes from
+// start up to end.
+func chunkContentRange(start, end, total int64) string {
+	if total == 0 {
+		return "bytes */0"
+	}
+	return fmt.Sprintf("bytes %d-%d/%d", start, end-1, total)
+}
@@ -0,0 +1,395 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+// testUploadDigest is the SHA-256 digest of the test artifact, 0123456789.
+const testUploadDigest = "84d89877f0d4041efb6bf91a16f0248f2fd573e6af05c19f96bedb9f882f7882"
+
+func Test_uploadChunks(t *testing.T) {
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			if o, ok := obj.(*corev1.ConfigMap); ok {
+				o.Data = map[string]string{"artifact": "0123456789"}
+			}
+			return nil
+		},
+		MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+	}
+	initiated := httpClient.HttpDetails{
+		HttpRequest:  httpClient.HttpRequest{URL: "https://uploads.example.com/artifacts"},
+		HttpResponse: httpClient.HttpResponse{StatusCode: 201, Headers: map[string][]string{"Location": {"/sessions/1"}}},
+	}
+	upload := func(maxAttempts int) *v1alpha1.ChunkedUpload {
+		return &v1alpha1.ChunkedUpload{
+			UploadURL:   ".headers.Location[0]",
+			ContentFrom: v1alpha1.PartValueSource{ConfigMapKeyRef: &v1alpha1.ConfigMapKeySelector{Name: "artifacts", Namespace: "default", Key: "artifact"}},
+			ChunkSize:   4,
+			MaxAttempts: maxAttempts,
+		}
+	}
+
+	type args struct {
+		upload *v1alpha1.ChunkedUpload
+		// statusCodes are the status codes of the responses to the chunk requests, in order.
+		statusCodes []int
+		// committed are the Range headers of the responses to the chunk requests, if any.
+		committed []string
+	}
+	type want struct {
+		ranges     []string
+		chunks     []string
+		statusCode int
+		// progress is the progress of the upload left in the status.
+		progress *v1alpha1.UploadProgress
+		err      error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Uploaded": {
+			args: args{
+				upload:      upload(0),
+				statusCodes: []int{308, 308, 200},
+			},
+			want: want{
+				ranges:     []string{"bytes 0-3/10", "bytes 4-7/10", "bytes 8-9/10"},
+				chunks:     []string{"0123", "4567", "89"},
+				statusCode: 200,
+			},
+		},
+		"ChunkRetried": {
+			args: args{
+				upload:      upload(2),
+				statusCodes: []int{308, 503, 308, 201},
+			},
+			want: want{
+				ranges:     []string{"bytes 0-3/10", "bytes 4-7/10", "bytes 4-7/10", "bytes 8-9/10"},
+				chunks:     []string{"0123", "4567", "4567", "89"},
+				statusCode: 201,
+			},
+		},
+		"AttemptsExhausted": {
+			args: args{
+				upload:      upload(1),
+				statusCodes: []int{308, 503},
+			},
+			want: want{
+				ranges:     []string{"bytes 0-3/10", "bytes 4-7/10"},
+				chunks:     []string{"0123", "4567"},
+				statusCode: 503,
+				progress:   &v1alpha1.UploadProgress{Method: "POST", URL: "https://uploads.example.com/sessions/1", Digest: testUploadDigest, Offset: 4},
+				err:        errors.Wrapf(errors.Errorf(errUploadChunkStatus, 503), errUploadChunk, "bytes 4-7/10"),
+			},
+		},
+		"ResumedFromCommittedRange": {
+			args: args{
+				upload:      upload(0),
+				statusCodes: []int{308, 308, 200},
+				committed:   []string{"bytes=0-1", "bytes=0-5"},
+			},
+			want: want{
+				ranges:     []string{"bytes 0-3/10", "bytes 2-5/10", "bytes 6-9/10"},
+				chunks:     []string{"0123", "2345", "6789"},
+				statusCode: 200,
+			},
+		},
+		"Stalled": {
+			args: args{
+				upload:      upload(0),
+				statusCodes: []int{308, 308},
+				committed:   []string{"bytes=0-3", "bytes=0-3"},
+			},
+			want: want{
+				ranges:     []string{"bytes 0-3/10", "bytes 4-7/10"},
+				chunks:     []string{"0123", "4567"},
+				statusCode: 308,
+				progress:   &v1alpha1.UploadProgress{Method: "POST", URL: "https://uploads.example.com/sessions/1", Digest: testUploadDigest, Offset: 4},
+				err:        errors.Errorf(errUploadStalled, "bytes 4-7/10"),
+			},
+		},
+		"ClientErrorNotRetried": {
+			args: args{
+				upload:      upload(3),
+				statusCodes: []int{400},
+			},
+			want: want{
+				ranges:     []string{"bytes 0-3/10"},
+				chunks:     []string{"0123"},
+				statusCode: 400,
+				progress:   &v1alpha1.UploadProgress{Method: "POST", URL: "https://uploads.example.com/sessions/1", Digest: testUploadDigest},
+				err:        errors.Wrapf(errors.Errorf(errUploadChunkStatus, 400), errUploadChunk, "bytes 0-3/10"),
+			},
+		},
+		"Incomplete": {
+			args: args{
+				upload:      upload(0),
+				statusCodes: []int{308, 308, 308},
+			},
+			want: want{
+				ranges:     []string{"bytes 0-3/10", "bytes 4-7/10", "bytes 8-9/10"},
+				chunks:     []string{"0123", "4567", "89"},
+				statusCode: 308,
+				progress:   &v1alpha1.UploadProgress{Method: "POST", URL: "https://uploads.example.com/sessions/1", Digest: testUploadDigest, Offset: 8},
+				err:        errors.Errorf(errUploadIncomplete, 308),
+			},
+		},
+		"MissingContentFrom": {
+			args: args{
+				upload: &v1alpha1.ChunkedUpload{UploadURL: ".headers.Location[0]"},
+			},
+			want: want{
+				statusCode: 201,
+				err:        errors.Errorf(errUploadContentRef, "POST"),
+			},
+		},
+		"MissingUploadURL": {
+			args: args{
+				upload: &v1alpha1.ChunkedUpload{UploadURL: `.headers["Upload-Url"][0] // ""`},
+			},
+			want: want{
+				statusCode: 201,
+				err:        errors.Wrapf(errors.New(errEmptyUploadURL), errUploadURL, "POST"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var ranges, chunks []string
+			e := &external{
+				localKube: kube,
+				logger:    logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(_ context.Context, method string, url string, body string, headers map[string][]string, _ bool) (httpClient.HttpDetails, error) {
+						if method != http.MethodPut || url != "https://uploads.example.com/sessions/1" {
+							t.Fatalf("unexpected %s request to %s", method, url)
+						}
+						ranges = append(ranges, headers[headerContentRange]...)
+						chunks = append(chunks, body)
+						response := httpClient.HttpResponse{StatusCode: tc.args.statusCodes[len(chunks)-1]}
+						if len(chunks) <= len(tc.args.committed) {
+							response.Headers = map[string][]string{"Range": {tc.args.committed[len(chunks)-1]}}
+						}
+						return httpClient.HttpDetails{HttpResponse: response}, nil
+					},
+				},
+			}
+			cr := &v1alpha1.Request{}
+			mapping := &v1alpha1.Mapping{Method: "POST", Upload: tc.args.upload}
+
+			got, gotErr := e.uploadChunks(context.Background(), cr, mapping, map[string][]string{"Content-Type": {"application/json"}}, initiated)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("uploadChunks(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.ranges, ranges); diff != "" {
+				t.Errorf("uploadChunks(...): -want ranges, +got ranges: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.chunks, chunks); diff != "" {
+				t.Errorf("uploadChunks(...): -want chunks, +got chunks: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.statusCode, got.HttpResponse.StatusCode); diff != "" {
+				t.Errorf("uploadChunks(...): -want status code, +got status code: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.progress, cr.Status.Upload); diff != "" {
+				t.Errorf("uploadChunks(...): -want progress, +got progress: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_resumeUpload(t *testing.T) {
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			if o, ok := obj.(*corev1.ConfigMap); ok {
+				o.Data = map[string]string{"artifact": "0123456789"}
+			}
+			return nil
+		},
+		MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+	}
+	progress := func(method, digest string) *v1alpha1.UploadProgress {
+		return &v1alpha1.UploadProgress{Method: method, URL: "https://uploads.example.com/sessions/1", Digest: digest, Offset: 4}
+	}
+
+	type args struct {
+		progress *v1alpha1.UploadProgress
+		// responses are the responses to the chunk requests, in order.
+		responses []httpClient.HttpResponse
+	}
+	type want struct {
+		resumed  bool
+		ranges   []string
+		progress *v1alpha1.UploadProgress
+		err      error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoUpload": {
+			want: want{
+				resumed: false,
+			},
+		},
+		"OtherMapping": {
+			args: args{
+				progress: progress("PUT", testUploadDigest),
+			},
+			want: want{
+				resumed:  false,
+				progress: progress("PUT", testUploadDigest),
+			},
+		},
+		"ContentChanged": {
+			args: args{
+				progress: progress("POST", "0000"),
+			},
+			want: want{
+				resumed: false,
+			},
+		},
+		"SessionGone": {
+			args: args{
+				progress:  progress("POST", testUploadDigest),
+				responses: []httpClient.HttpResponse{{StatusCode: 404}},
+			},
+			want: want{
+				resumed: false,
+				ranges:  []string{"bytes */10"},
+			},
+		},
+		"ResumedFromCommittedRange": {
+			args: args{
+				progress: progress("POST", testUploadDigest),
+				responses: []httpClient.HttpResponse{
+					{StatusCode: 308, Headers: map[string][]string{"Range": {"bytes=0-5"}}},
+					{StatusCode: 200},
+				},
+			},
+			want: want{
+				resumed: true,
+				ranges:  []string{"bytes */10", "bytes 6-9/10"},
+			},
+		},
+		"NothingReceived": {
+			args: args{
+				progress: progress("POST", testUploadDigest),
+				responses: []httpClient.HttpResponse{
+					{StatusCode: 308},
+					{StatusCode: 308},
+					{StatusCode: 503},
+				},
+			},
+			want: want{
+				resumed:  true,
+				ranges:   []string{"bytes */10", "bytes 0-3/10", "bytes 4-7/10"},
+				progress: progress("POST", testUploadDigest),
+				err:      errors.Wrapf(errors.Errorf(errUploadChunkStatus, 503), errUploadChunk, "bytes 4-7/10"),
+			},
+		},
+		"AlreadyComplete": {
+			args: args{
+				progress:  progress("POST", testUploadDigest),
+				responses: []httpClient.HttpResponse{{StatusCode: 201}},
+			},
+			want: want{
+				resumed: true,
+				ranges:  []string{"bytes */10"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var ranges []string
+			e := &external{
+				localKube: kube,
+				logger:    logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(_ context.Context, method string, url string, body string, headers map[string][]string, _ bool) (httpClient.HttpDetails, error) {
+						if method != http.MethodPut || url != "https://uploads.example.com/sessions/1" {
+							t.Fatalf("unexpected %s request to %s", method, url)
+						}
+						ranges = append(ranges, headers[headerContentRange]...)
+						return httpClient.HttpDetails{HttpResponse: tc.args.responses[len(ranges)-1]}, nil
+					},
+				},
+			}
+			cr := &v1alpha1.Request{Status: v1alpha1.RequestStatus{Upload: tc.args.progress}}
+			mapping := &v1alpha1.Mapping{Method: "POST", Upload: &v1alpha1.ChunkedUpload{
+				UploadURL:   ".headers.Location[0]",
+				ContentFrom: v1alpha1.PartValueSource{ConfigMapKeyRef: &v1alpha1.ConfigMapKeySelector{Name: "artifacts", Namespace: "default", Key: "artifact"}},
+				ChunkSize:   4,
+				MaxAttempts: 1,
+			}}
+
+			_, resumed, gotErr := e.resumeUpload(context.Background(), cr, mapping, nil)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("resumeUpload(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.resumed, resumed); diff != "" {
+				t.Errorf("resumeUpload(...): -want resumed, +got resumed: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.ranges, ranges); diff != "" {
+				t.Errorf("resumeUpload(...): -want ranges, +got ranges: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.progress, cr.Status.Upload); diff != "" {
+				t.Errorf("resumeUpload(...): -want progress, +got progress: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_committedOffset(t *testing.T) {
+	type want struct {
+		offset int64
+		ok     bool
+	}
+	cases := map[string]struct {
+		headers map[string][]string
+		want    want
+	}{
+		"Committed": {headers: map[string][]string{"Range": {"bytes=0-41"}}, want: want{offset: 42, ok: true}},
+		"Missing":   {want: want{}},
+		"Invalid":   {headers: map[string][]string{"Range": {"bytes=0-"}}, want: want{}},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			offset, ok := committedOffset(tc.headers)
+			if diff := cmp.Diff(tc.want, want{offset: offset, ok: ok}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("committedOffset(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_chunkContentRange(t *testing.T) {
+	cases := map[string]struct {
+		start, end, total int64
+		want              string
+	}{
+		"FirstChunk": {start: 0, end: 4, total: 10, want: "bytes 0-3/10"},
+		"LastChunk":  {start: 8, end: 10, total: 10, want: "bytes 8-9/10"},
+		"Empty":      {start: 0, end: 0, total: 0, want: "bytes */0"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, chunkContentRange(tc.start, tc.end, tc.total)); diff != "" {
+				t.Errorf("chunkContentRange(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
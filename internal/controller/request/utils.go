@@ -1,14 +1,124 @@
 package request
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/responseconverter"
 )
 
 func getMappingByMethod(requestParams *v1alpha1.RequestParameters, method string) (*v1alpha1.Mapping, bool) {
+	return requestParams.MappingByMethod(method)
+}
+
+// getExpectedStatusCodes returns the status codes of a successful response to the
+// method's mapping, if the mapping declares any.
+func getExpectedStatusCodes(requestParams *v1alpha1.RequestParameters, method string) []int {
+	if mapping, ok := getMappingByMethod(requestParams, method); ok {
+		return mapping.ExpectedStatusCodes
+	}
+	return nil
+}
+
+// compareCheck is a comparison of the observed state to the desired state,
+// configured by a mapping.
+type compareCheck struct {
+	name    string
+	mapping v1alpha1.Mapping
+}
+
+// getCompareChecks returns a check for every mapping that declares a comparetype.
+// Without any, the observed state is compared to the desired state by default.
+func getCompareChecks(requestParams *v1alpha1.RequestParameters) []compareCheck {
+	checks := []compareCheck{}
 	for _, mapping := range requestParams.Mappings {
-		if mapping.Method == method {
-			return &mapping, true
+		if mapping.CompareType != "" {
+			checks = append(checks, compareCheck{
+				name:    fmt.Sprintf("%s %s comparison", mapping.Method, mapping.CompareType),
+				mapping: mapping,
+			})
+		}
+	}
+
+	// The default comparison follows the compare options, ignored fields and response
+	// root of the GET mapping.
+	if len(checks) == 0 {
+		check := compareCheck{name: defaultCompareCheck}
+		if get, ok := getMappingByMethod(requestParams, http.MethodGet); ok {
+			check.mapping.CompareOptions = get.CompareOptions
+			check.mapping.IgnoreFields = get.IgnoreFields
+			check.mapping.ResponseRoot = get.ResponseRoot
+		}
+		return []compareCheck{check}
+	}
+	return checks
+}
+
+// requestContext bounds the context of a request sent for the given mapping by
+// the mapping's timeout, if one is set.
+func requestContext(ctx context.Context, mapping *v1alpha1.Mapping) (context.Context, context.CancelFunc) {
+	if mapping.WaitTimeout == nil {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, mapping.WaitTimeout.Duration)
+}
+
+// insecureSkipTLSVerify reports whether the requests sent for the mapping skip the
+// verification of TLS certificates, as the mapping sets, or else the Request.
+func insecureSkipTLSVerify(cr *v1alpha1.Request, mapping *v1alpha1.Mapping) bool {
+	if mapping.InsecureSkipTLSVerify != nil {
+		return *mapping.InsecureSkipTLSVerify
+	}
+	return cr.Spec.ForProvider.InsecureSkipTLSVerify
+}
+
+// skipsAllTLSVerify reports whether the requests sent for every mapping skip the
+// verification of TLS certificates.
+func skipsAllTLSVerify(cr *v1alpha1.Request) bool {
+	for i := range cr.Spec.ForProvider.Mappings {
+		if !insecureSkipTLSVerify(cr, &cr.Spec.ForProvider.Mappings[i]) {
+			return false
+		}
+	}
+	return len(cr.Spec.ForProvider.Mappings) > 0 || cr.Spec.ForProvider.InsecureSkipTLSVerify
+}
+
+// withHeader returns a copy of the headers with the header set to the value,
+// replacing any values of the header regardless of its case.
+func withHeader(headers map[string][]string, header string, value string) map[string][]string {
+	result := make(map[string][]string, len(headers)+1)
+	for key, values := range headers {
+		if http.CanonicalHeaderKey(key) != http.CanonicalHeaderKey(header) {
+			result[key] = values
 		}
 	}
-	return nil, false
+	result[header] = []string{value}
+
+	return result
+}
+
+// withoutHeaders returns a copy of the headers without the given headers, regardless
+// of their case.
+func withoutHeaders(headers map[string][]string, names ...string) map[string][]string {
+	result := make(map[string][]string, len(headers))
+	for key, values := range headers {
+		if !slices.ContainsFunc(names, func(name string) bool {
+			return http.CanonicalHeaderKey(key) == http.CanonicalHeaderKey(name)
+		}) {
+			result[key] = values
+		}
+	}
+
+	return result
+}
+
+// isResponseMatching evaluates a jq condition against the response, whose
+// statusCode, headers and body, parsed when it's JSON, are available to it.
+func isResponseMatching(condition string, response httpClient.HttpResponse) (bool, error) {
+	return isObservedMatching(condition, responseconverter.HttpResponseToV1alpha1Response(response))
 }
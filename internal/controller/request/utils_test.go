@@ -1,10 +1,13 @@
 package request
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
 	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var (
@@ -20,6 +23,12 @@ var (
 		URL:    "(.payload.baseUrl + \"/\" + .response.body.id)",
 	}
 
+	testPatchMapping = v1alpha1.Mapping{
+		Method: "PATCH",
+		Body:   "{ username: \"john_doe_new_username\" }",
+		URL:    "(.payload.baseUrl + \"/\" + .response.body.id)",
+	}
+
 	testGetMapping = v1alpha1.Mapping{
 		Method: "GET",
 		URL:    "(.payload.baseUrl + \"/\" + .response.body.id)",
@@ -85,6 +94,36 @@ func Test_getMappingByMethod(t *testing.T) {
 				ok:      true,
 			},
 		},
+		"Action": {
+			args: args{
+				requestParams: &v1alpha1.RequestParameters{
+					Mappings: []v1alpha1.Mapping{
+						testPostMapping,
+						testGetMapping,
+						{Method: "PURGE", Action: v1alpha1.ActionRemove, URL: ".payload.baseUrl"},
+					},
+				},
+				method: "DELETE",
+			},
+			want: want{
+				mapping: &v1alpha1.Mapping{Method: "PURGE", Action: v1alpha1.ActionRemove, URL: ".payload.baseUrl"},
+				ok:      true,
+			},
+		},
+		"MethodWithOtherAction": {
+			args: args{
+				requestParams: &v1alpha1.RequestParameters{
+					Mappings: []v1alpha1.Mapping{
+						{Method: "POST", Action: v1alpha1.ActionUpdate, URL: ".payload.baseUrl"},
+					},
+				},
+				method: "POST",
+			},
+			want: want{
+				mapping: nil,
+				ok:      false,
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -99,3 +138,108 @@ func Test_getMappingByMethod(t *testing.T) {
 		})
 	}
 }
+
+func Test_requestContext(t *testing.T) {
+	type args struct {
+		mapping *v1alpha1.Mapping
+	}
+	type want struct {
+		hasDeadline bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoMappingTimeout": {
+			args: args{
+				mapping: &testGetMapping,
+			},
+			want: want{
+				hasDeadline: false,
+			},
+		},
+		"MappingTimeout": {
+			args: args{
+				mapping: &v1alpha1.Mapping{
+					Method:      "GET",
+					URL:         ".payload.baseUrl",
+					WaitTimeout: &v1.Duration{Duration: time.Second},
+				},
+			},
+			want: want{
+				hasDeadline: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := requestContext(context.Background(), tc.args.mapping)
+			defer cancel()
+
+			_, got := ctx.Deadline()
+			if diff := cmp.Diff(tc.want.hasDeadline, got); diff != "" {
+				t.Fatalf("requestContext(...): -want deadline, +got deadline: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_insecureSkipTLSVerify(t *testing.T) {
+	skip, verify := true, false
+	type args struct {
+		insecure bool
+		mappings []v1alpha1.Mapping
+	}
+	type want struct {
+		get     bool
+		post    bool
+		skipAll bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ResourceLevel": {
+			args: args{
+				insecure: true,
+				mappings: []v1alpha1.Mapping{{Method: "GET"}, {Method: "POST"}},
+			},
+			want: want{get: true, post: true, skipAll: true},
+		},
+		"MappingVerifies": {
+			args: args{
+				insecure: true,
+				mappings: []v1alpha1.Mapping{{Method: "GET", InsecureSkipTLSVerify: &verify}, {Method: "POST"}},
+			},
+			want: want{get: false, post: true, skipAll: false},
+		},
+		"MappingSkips": {
+			args: args{
+				mappings: []v1alpha1.Mapping{{Method: "GET"}, {Method: "POST", InsecureSkipTLSVerify: &skip}},
+			},
+			want: want{get: false, post: true, skipAll: false},
+		},
+		"EveryMappingSkips": {
+			args: args{
+				mappings: []v1alpha1.Mapping{{Method: "GET", InsecureSkipTLSVerify: &skip}, {Method: "POST", InsecureSkipTLSVerify: &skip}},
+			},
+			want: want{get: true, post: true, skipAll: true},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Request{}
+			cr.Spec.ForProvider.InsecureSkipTLSVerify = tc.args.insecure
+			cr.Spec.ForProvider.Mappings = tc.args.mappings
+
+			got := want{
+				get:     insecureSkipTLSVerify(cr, &cr.Spec.ForProvider.Mappings[0]),
+				post:    insecureSkipTLSVerify(cr, &cr.Spec.ForProvider.Mappings[1]),
+				skipAll: skipsAllTLSVerify(cr),
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("insecureSkipTLSVerify(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package request
+
+import (
+	"net/http"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	"github.com/crossplane-contrib/provider-http/internal/cel"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	"github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/pkg/errors"
+)
+
+const (
+	errWhen = "cannot evaluate the when condition of the %s mapping"
+)
+
+// selectMapping returns the mapping with the role of the method that applies to the
+// observed state: the first one whose CEL when condition matches the GET response of
+// this reconcile, or the last one stored in the status. A mapping without a
+// condition always applies.
+func (c *external) selectMapping(cr *v1alpha1.Request, method string) (*v1alpha1.Mapping, bool, error) {
+	observed := cr.Status.Response
+	if response, ok := c.responses[http.MethodGet]; ok {
+		observed = response
+	}
+
+	for i := range cr.Spec.ForProvider.Mappings {
+		mapping := cr.Spec.ForProvider.Mappings[i]
+		if mapping.RoleMethod() != method {
+			continue
+		}
+		if mapping.When == "" {
+			return &mapping, true, nil
+		}
+
+		matches, err := isWhenMatching(mapping.When, observed)
+		if err != nil {
+			return nil, false, errors.Wrapf(err, errWhen, mapping.Method)
+		}
+		if matches {
+			return &mapping, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// desiredStateMapping returns the mapping whose body describes the desired state. A
+// PUT body holds the full object, so the PUT mapping that applies to the observed
+// state is preferred over the PATCH one, whose body may only hold the changed fields.
+func (c *external) desiredStateMapping(cr *v1alpha1.Request) (*v1alpha1.Mapping, bool, error) {
+	for _, method := range []string{http.MethodPut, http.MethodPatch} {
+		mapping, ok, err := c.selectMapping(cr, method)
+		if err != nil || ok {
+			return mapping, ok, err
+		}
+	}
+	return nil, false, nil
+}
+
+// updateMethod returns the method used to update the resource, preferring PATCH over
+// PUT when a PATCH mapping applies to the observed state.
+func (c *external) updateMethod(cr *v1alpha1.Request) (string, error) {
+	_, ok, err := c.selectMapping(cr, http.MethodPatch)
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		return http.MethodPatch, nil
+	}
+	return http.MethodPut, nil
+}
+
+// isWhenMatching evaluates a CEL when condition against a response stored like in
+// the status, bound to its `response` variable with its statusCode, headers and
+// body, parsed when it's JSON.
+func isWhenMatching(condition string, response v1alpha1.Response) (bool, error) {
+	responseMap, err := json.StructToMap(response)
+	if err != nil {
+		return false, err
+	}
+	json.ConvertJSONStringsToMaps(&responseMap)
+
+	return cel.ParseBool(condition, responseMap, nil)
+}
+
+// isObservedMatching evaluates a jq condition against a response stored like in the
+// status, whose statusCode, headers and body, parsed when it's JSON, are available
+// to it.
+func isObservedMatching(condition string, response v1alpha1.Response) (bool, error) {
+	responseMap, err := json.StructToMap(response)
+	if err != nil {
+		return false, err
+	}
+	json.ConvertJSONStringsToMaps(&responseMap)
+
+	return jq.ParseBool(condition, responseMap)
+}
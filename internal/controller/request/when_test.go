@@ -0,0 +1,189 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+)
+
+func Test_selectMapping(t *testing.T) {
+	enable := v1alpha1.Mapping{Method: "PUT", URL: ".payload.baseUrl", Body: `{enabled: true}`, When: "response.body.enabled == false"}
+	rename := v1alpha1.Mapping{Method: "PUT", URL: ".payload.baseUrl", Body: `{name: "new"}`}
+	disabled := v1alpha1.Response{StatusCode: 200, Body: `{"name":"old","enabled":false}`}
+	enabled := v1alpha1.Response{StatusCode: 200, Body: `{"name":"old","enabled":true}`}
+
+	type args struct {
+		mappings  []v1alpha1.Mapping
+		status    v1alpha1.Response
+		responses map[string]v1alpha1.Response
+		method    string
+	}
+	type want struct {
+		mapping *v1alpha1.Mapping
+		ok      bool
+		err     error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"MatchingCondition": {
+			args: args{
+				mappings: []v1alpha1.Mapping{testPostMapping, testGetMapping, enable, rename},
+				status:   disabled,
+				method:   "PUT",
+			},
+			want: want{mapping: &enable, ok: true},
+		},
+		"Fallback": {
+			args: args{
+				mappings: []v1alpha1.Mapping{testPostMapping, testGetMapping, enable, rename},
+				status:   enabled,
+				method:   "PUT",
+			},
+			want: want{mapping: &rename, ok: true},
+		},
+		"ObservedThisReconcile": {
+			args: args{
+				mappings:  []v1alpha1.Mapping{testPostMapping, testGetMapping, enable, rename},
+				status:    enabled,
+				responses: map[string]v1alpha1.Response{"GET": disabled},
+				method:    "PUT",
+			},
+			want: want{mapping: &enable, ok: true},
+		},
+		"NoMatchingMapping": {
+			args: args{
+				mappings: []v1alpha1.Mapping{testPostMapping, testGetMapping, enable},
+				status:   enabled,
+				method:   "PUT",
+			},
+		},
+		"NotABoolean": {
+			args: args{
+				mappings: []v1alpha1.Mapping{{Method: "PUT", When: "response.body.name"}},
+				status:   enabled,
+				method:   "PUT",
+			},
+			want: want{
+				err: errors.Wrapf(errors.New("CEL expression response.body.name returned old instead of a bool"), errWhen, "PUT"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{responses: tc.args.responses}
+			cr := &v1alpha1.Request{
+				Spec:   v1alpha1.RequestSpec{ForProvider: v1alpha1.RequestParameters{Mappings: tc.args.mappings}},
+				Status: v1alpha1.RequestStatus{Response: tc.args.status},
+			}
+
+			got, ok, gotErr := e.selectMapping(cr, tc.args.method)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("selectMapping(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mapping, got); diff != "" {
+				t.Errorf("selectMapping(...): -want mapping, +got mapping: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.ok, ok); diff != "" {
+				t.Errorf("selectMapping(...): -want ok, +got ok: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_updateMethod(t *testing.T) {
+	enablePatch := v1alpha1.Mapping{Method: "PATCH", URL: ".payload.baseUrl", Body: `{enabled: true}`, When: "response.body.enabled == false"}
+	enabled := v1alpha1.Response{StatusCode: 200, Body: `{"name":"old","enabled":true}`}
+	disabled := v1alpha1.Response{StatusCode: 200, Body: `{"name":"old","enabled":false}`}
+
+	type args struct {
+		mappings []v1alpha1.Mapping
+		status   v1alpha1.Response
+	}
+	type want struct {
+		updateMethod              string
+		desiredStateMappingMethod string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"PutOnly": {
+			args: args{
+				mappings: []v1alpha1.Mapping{testGetMapping, testPutMapping},
+			},
+			want: want{
+				updateMethod:              "PUT",
+				desiredStateMappingMethod: "PUT",
+			},
+		},
+		"PatchOnly": {
+			args: args{
+				mappings: []v1alpha1.Mapping{testGetMapping, testPatchMapping},
+			},
+			want: want{
+				updateMethod:              "PATCH",
+				desiredStateMappingMethod: "PATCH",
+			},
+		},
+		"PatchAndPut": {
+			args: args{
+				mappings: []v1alpha1.Mapping{testGetMapping, testPutMapping, testPatchMapping},
+			},
+			want: want{
+				updateMethod:              "PATCH",
+				desiredStateMappingMethod: "PUT",
+			},
+		},
+		"ConditionalPatchMatching": {
+			args: args{
+				mappings: []v1alpha1.Mapping{testGetMapping, testPutMapping, enablePatch},
+				status:   disabled,
+			},
+			want: want{
+				updateMethod:              "PATCH",
+				desiredStateMappingMethod: "PUT",
+			},
+		},
+		"ConditionalPatchNotMatching": {
+			args: args{
+				mappings: []v1alpha1.Mapping{testGetMapping, testPutMapping, enablePatch},
+				status:   enabled,
+			},
+			want: want{
+				updateMethod:              "PUT",
+				desiredStateMappingMethod: "PUT",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{}
+			cr := &v1alpha1.Request{
+				Spec:   v1alpha1.RequestSpec{ForProvider: v1alpha1.RequestParameters{Mappings: tc.args.mappings}},
+				Status: v1alpha1.RequestStatus{Response: tc.args.status},
+			}
+
+			got, err := e.updateMethod(cr)
+			if err != nil {
+				t.Fatalf("updateMethod(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.updateMethod, got); diff != "" {
+				t.Errorf("updateMethod(...): -want method, +got method: %s", diff)
+			}
+
+			mapping, ok, err := e.desiredStateMapping(cr)
+			if err != nil || !ok {
+				t.Fatalf("desiredStateMapping(...): want a mapping, got %v, %v", ok, err)
+			}
+			if diff := cmp.Diff(tc.want.desiredStateMappingMethod, mapping.Method); diff != "" {
+				t.Errorf("desiredStateMapping(...): -want method, +got method: %s", diff)
+			}
+		})
+	}
+}
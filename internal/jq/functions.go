@@ -0,0 +1,358 @@
+package jq
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	ej "encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/itchyny/gojq"
+	"github.com/pkg/errors"
+)
+
+const (
+	errFunctionInput = "%s expects a string input, got: %s"
+	errBase64Decode  = "b64dec failed to decode: %s"
+	errInputType     = "%s expects %s input, got: %s"
+	errArgumentType  = "%s expects %s argument, got: %s"
+	errDateParse     = "dateparse failed to parse %s with the layout %s"
+)
+
+// functions are the helpers available to every jq query in addition to the
+// jq builtins, e.g. `.payload.body.content | b64enc`.
+var functions = []gojq.CompilerOption{
+	gojq.WithFunction("b64enc", 0, 0, stringFunction("b64enc", func(s string) (any, error) {
+		return base64.StdEncoding.EncodeToString([]byte(s)), nil
+	})),
+	gojq.WithFunction("b64dec", 0, 0, stringFunction("b64dec", func(s string) (any, error) {
+		decoded, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, errors.Errorf(errBase64Decode, s)
+		}
+		return string(decoded), nil
+	})),
+	gojq.WithFunction("sha256", 0, 0, stringFunction("sha256", func(s string) (any, error) {
+		hash := sha256.Sum256([]byte(s))
+		return hex.EncodeToString(hash[:]), nil
+	})),
+
+	// String helpers.
+	gojq.WithFunction("trim", 0, 0, stringFunction("trim", func(s string) (any, error) {
+		return strings.TrimSpace(s), nil
+	})),
+	gojq.WithFunction("upper", 0, 0, stringFunction("upper", func(s string) (any, error) {
+		return strings.ToUpper(s), nil
+	})),
+	gojq.WithFunction("lower", 0, 0, stringFunction("lower", func(s string) (any, error) {
+		return strings.ToLower(s), nil
+	})),
+	gojq.WithFunction("title", 0, 0, stringFunction("title", func(s string) (any, error) {
+		return title(s), nil
+	})),
+	gojq.WithFunction("quote", 0, 0, stringFunction("quote", func(s string) (any, error) {
+		return strconv.Quote(s), nil
+	})),
+	gojq.WithFunction("squote", 0, 0, stringFunction("squote", func(s string) (any, error) {
+		return "'" + s + "'", nil
+	})),
+	gojq.WithFunction("camelcase", 0, 0, stringFunction("camelcase", func(s string) (any, error) {
+		words := splitWords(s)
+		for i, word := range words {
+			if i > 0 {
+				words[i] = title(word)
+			}
+		}
+		return strings.Join(words, ""), nil
+	})),
+	gojq.WithFunction("snakecase", 0, 0, stringFunction("snakecase", func(s string) (any, error) {
+		return strings.Join(splitWords(s), "_"), nil
+	})),
+	gojq.WithFunction("kebabcase", 0, 0, stringFunction("kebabcase", func(s string) (any, error) {
+		return strings.Join(splitWords(s), "-"), nil
+	})),
+	gojq.WithFunction("truncate", 1, 1, func(v any, args []any) any {
+		s, ok := v.(string)
+		if !ok {
+			return errors.Errorf(errFunctionInput, "truncate", fmt.Sprint(v))
+		}
+		n, ok := toInt(args[0])
+		if !ok {
+			return errors.Errorf(errArgumentType, "truncate", "an integer", fmt.Sprint(args[0]))
+		}
+		return truncate(s, n)
+	}),
+	gojq.WithFunction("replace", 2, 2, func(v any, args []any) any {
+		s, ok := v.(string)
+		if !ok {
+			return errors.Errorf(errFunctionInput, "replace", fmt.Sprint(v))
+		}
+		old, okOld := args[0].(string)
+		replacement, okNew := args[1].(string)
+		if !okOld || !okNew {
+			return errors.Errorf(errArgumentType, "replace", "string", fmt.Sprint(args))
+		}
+		return strings.ReplaceAll(s, old, replacement)
+	}),
+	gojq.WithFunction("indent", 1, 1, func(v any, args []any) any {
+		s, ok := v.(string)
+		if !ok {
+			return errors.Errorf(errFunctionInput, "indent", fmt.Sprint(v))
+		}
+		n, ok := toInt(args[0])
+		if !ok || n < 0 {
+			return errors.Errorf(errArgumentType, "indent", "a non-negative integer", fmt.Sprint(args[0]))
+		}
+		padding := strings.Repeat(" ", n)
+		return padding + strings.ReplaceAll(s, "\n", "\n"+padding)
+	}),
+
+	// Defaults.
+	gojq.WithFunction("default", 1, 1, func(v any, args []any) any {
+		if isEmpty(v) {
+			return args[0]
+		}
+		return v
+	}),
+	gojq.WithFunction("coalesce", 0, 0, arrayFunction("coalesce", func(values []any) any {
+		for _, value := range values {
+			if !isEmpty(value) {
+				return value
+			}
+		}
+		return nil
+	})),
+
+	// Dates, with Go layouts such as `2006-01-02`.
+	gojq.WithFunction("dateformat", 1, 1, func(v any, args []any) any {
+		layout, ok := args[0].(string)
+		if !ok {
+			return errors.Errorf(errArgumentType, "dateformat", "a layout string", fmt.Sprint(args[0]))
+		}
+		date, ok := toTime(v)
+		if !ok {
+			return errors.Errorf(errInputType, "dateformat", "a unix time or an RFC 3339 date", fmt.Sprint(v))
+		}
+		return date.UTC().Format(layout)
+	}),
+	gojq.WithFunction("dateparse", 1, 1, func(v any, args []any) any {
+		s, ok := v.(string)
+		if !ok {
+			return errors.Errorf(errFunctionInput, "dateparse", fmt.Sprint(v))
+		}
+		layout, ok := args[0].(string)
+		if !ok {
+			return errors.Errorf(errArgumentType, "dateparse", "a layout string", fmt.Sprint(args[0]))
+		}
+		date, err := time.Parse(layout, s)
+		if err != nil {
+			return errors.Errorf(errDateParse, s, layout)
+		}
+		return int(date.Unix())
+	}),
+
+	// List and object helpers.
+	gojq.WithFunction("compact", 0, 0, arrayFunction("compact", func(values []any) any {
+		compacted := []any{}
+		for _, value := range values {
+			if !isEmpty(value) {
+				compacted = append(compacted, value)
+			}
+		}
+		return compacted
+	})),
+	gojq.WithFunction("uniq", 0, 0, arrayFunction("uniq", func(values []any) any {
+		unique := []any{}
+		seen := map[string]bool{}
+		for _, value := range values {
+			key, err := ej.Marshal(value)
+			if err != nil {
+				return err
+			}
+			if !seen[string(key)] {
+				seen[string(key)] = true
+				unique = append(unique, value)
+			}
+		}
+		return unique
+	})),
+	// Named pickkeys, as jq 1.7 and later gojq versions define pick(pathexps), which
+	// takes paths rather than keys.
+	gojq.WithFunction("pickkeys", 1, 1, keysFunction("pickkeys", func(object map[string]any, keys map[string]bool) any {
+		picked := map[string]any{}
+		for key, value := range object {
+			if keys[key] {
+				picked[key] = value
+			}
+		}
+		return picked
+	})),
+	gojq.WithFunction("omit", 1, 1, keysFunction("omit", func(object map[string]any, keys map[string]bool) any {
+		kept := map[string]any{}
+		for key, value := range object {
+			if !keys[key] {
+				kept[key] = value
+			}
+		}
+		return kept
+	})),
+}
+
+// stringFunction adapts f to a jq function operating on string inputs.
+func stringFunction(name string, f func(string) (any, error)) func(any, []any) any {
+	return func(v any, _ []any) any {
+		s, ok := v.(string)
+		if !ok {
+			return errors.Errorf(errFunctionInput, name, fmt.Sprint(v))
+		}
+
+		result, err := f(s)
+		if err != nil {
+			return err
+		}
+		return result
+	}
+}
+
+// arrayFunction adapts f to a jq function operating on array inputs.
+func arrayFunction(name string, f func([]any) any) func(any, []any) any {
+	return func(v any, _ []any) any {
+		values, ok := v.([]any)
+		if !ok {
+			return errors.Errorf(errInputType, name, "an array", fmt.Sprint(v))
+		}
+		return f(values)
+	}
+}
+
+// keysFunction adapts f to a jq function operating on object inputs, given a key or
+// an array of keys.
+func keysFunction(name string, f func(map[string]any, map[string]bool) any) func(any, []any) any {
+	return func(v any, args []any) any {
+		object, ok := v.(map[string]any)
+		if !ok {
+			return errors.Errorf(errInputType, name, "an object", fmt.Sprint(v))
+		}
+
+		keys := map[string]bool{}
+		switch arg := args[0].(type) {
+		case string:
+			keys[arg] = true
+		case []any:
+			for _, key := range arg {
+				s, ok := key.(string)
+				if !ok {
+					return errors.Errorf(errArgumentType, name, "a key or an array of keys", fmt.Sprint(args[0]))
+				}
+				keys[s] = true
+			}
+		default:
+			return errors.Errorf(errArgumentType, name, "a key or an array of keys", fmt.Sprint(args[0]))
+		}
+		return f(object, keys)
+	}
+}
+
+// isEmpty reports whether the value is null, false, zero, or an empty string, array
+// or object, like the defaults of Sprig.
+func isEmpty(v any) bool {
+	switch value := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !value
+	case int:
+		return value == 0
+	case float64:
+		return value == 0
+	case string:
+		return value == ""
+	case []any:
+		return len(value) == 0
+	case map[string]any:
+		return len(value) == 0
+	default:
+		return false
+	}
+}
+
+// toInt returns the integer value of a jq number.
+func toInt(v any) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case float64:
+		return int(n), n == float64(int(n))
+	default:
+		return 0, false
+	}
+}
+
+// toTime returns the time of a unix time in seconds or an RFC 3339 date.
+func toTime(v any) (time.Time, bool) {
+	switch value := v.(type) {
+	case int:
+		return time.Unix(int64(value), 0), true
+	case float64:
+		seconds := int64(value)
+		return time.Unix(seconds, int64((value-float64(seconds))*float64(time.Second))), true
+	case string:
+		date, err := time.Parse(time.RFC3339, value)
+		return date, err == nil
+	default:
+		return time.Time{}, false
+	}
+}
+
+// truncate keeps the first n runes of s, or the last ones for a negative n.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	switch {
+	case n >= 0 && n < len(runes):
+		return string(runes[:n])
+	case n < 0 && -n < len(runes):
+		return string(runes[len(runes)+n:])
+	default:
+		return s
+	}
+}
+
+// title upper cases the first letter of every word.
+func title(s string) string {
+	runes := []rune(s)
+	for i, r := range runes {
+		if i == 0 || unicode.IsSpace(runes[i-1]) {
+			runes[i] = unicode.ToUpper(r)
+		}
+	}
+	return string(runes)
+}
+
+// splitWords splits s into lower case words, at separators and where a lower case
+// letter or digit is followed by an upper case letter, e.g. `userName-v2` into
+// `user`, `name` and `v2`.
+func splitWords(s string) []string {
+	var words []string
+	var word []rune
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(word) > 0 {
+				words, word = append(words, string(word)), nil
+			}
+			continue
+		}
+		if unicode.IsUpper(r) && len(word) > 0 && !unicode.IsUpper(runes[i-1]) {
+			words, word = append(words, string(word)), nil
+		}
+		word = append(word, unicode.ToLower(r))
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	return words
+}
@@ -25,8 +25,13 @@ func runJQQuery(jqQuery string, obj interface{}) (interface{}, error) {
 		return nil, err
 	}
 
+	code, err := gojq.Compile(query, functions...)
+	if err != nil {
+		return nil, err
+	}
+
 	mutex.Lock()
-	queryRes, ok := query.Run(obj).Next()
+	queryRes, ok := code.Run(obj).Next()
 	mutex.Unlock()
 
 	if !ok {
@@ -41,6 +46,17 @@ func runJQQuery(jqQuery string, obj interface{}) (interface{}, error) {
 	return queryRes, nil
 }
 
+// Validate checks that the query parses and compiles, without running it.
+func Validate(jqQuery string) error {
+	query, err := gojq.Parse(jqQuery)
+	if err != nil {
+		return err
+	}
+
+	_, err = gojq.Compile(query, functions...)
+	return err
+}
+
 func ParseString(jqQuery string, obj interface{}) (string, error) {
 	queryRes, err := runJQQuery(jqQuery, obj)
 	if err != nil {
@@ -69,6 +85,11 @@ func ParseBool(jqQuery string, obj interface{}) (bool, error) {
 	return boolean, nil
 }
 
+// ParseValue returns the first result of the query, which may be any JSON value.
+func ParseValue(jqQuery string, obj interface{}) (interface{}, error) {
+	return runJQQuery(jqQuery, obj)
+}
+
 func ParseMapInterface(jqQuery string, obj interface{}) (map[string]interface{}, error) {
 	queryRes, err := runJQQuery(jqQuery, obj)
 	if err != nil {
@@ -92,13 +113,25 @@ func ParseMapStrings(keyToJQQueries map[string][]string, obj interface{}) (map[s
 	result := make(map[string][]string, len(keyToJQQueries))
 
 	for key, jqQueries := range keyToJQQueries {
-		results := make([]string, len(jqQueries))
+		results := make([]string, 0, len(jqQueries))
 
-		for i, jqQuery := range jqQueries {
+		for _, jqQuery := range jqQueries {
 			queryRes, err := runJQQuery(jqQuery, obj)
 			if err != nil {
 				// Use the original query as a fallback
-				results[i] = jqQuery
+				results = append(results, jqQuery)
+				continue
+			}
+
+			// An array of strings holds several values of the key.
+			if items, ok := queryRes.([]interface{}); ok {
+				for _, item := range items {
+					str, ok := item.(string)
+					if !ok {
+						return nil, errors.Errorf(errResultParseFailed, fmt.Sprint(queryRes))
+					}
+					results = append(results, str)
+				}
 				continue
 			}
 
@@ -108,7 +141,7 @@ func ParseMapStrings(keyToJQQueries map[string][]string, obj interface{}) (map[s
 				return nil, errors.Errorf(errResultParseFailed, fmt.Sprint(queryRes))
 			}
 
-			results[i] = str
+			results = append(results, str)
 		}
 
 		result[key] = results
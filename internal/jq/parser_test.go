@@ -5,6 +5,7 @@ import (
 
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 )
 
 var testJQObject = map[string]any{
@@ -77,6 +78,33 @@ func Test_runJQQuery(t *testing.T) {
 	}
 }
 
+func Test_Validate(t *testing.T) {
+	cases := map[string]struct {
+		query   string
+		wantErr bool
+	}{
+		"Valid": {
+			query: `{ username: .payload.body.username, token: (.payload.body.token | b64enc) }`,
+		},
+		"SyntaxError": {
+			query:   `{ username: .payload.body.username`,
+			wantErr: true,
+		},
+		"UnknownFunction": {
+			query:   `.payload.body.username | b32enc`,
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := Validate(tc.query)
+			if diff := cmp.Diff(tc.wantErr, err != nil); diff != "" {
+				t.Errorf("Validate(...): -want error, +got error: %s: %v", diff, err)
+			}
+		})
+	}
+}
+
 func Test_ParseString(t *testing.T) {
 	type args struct {
 		jqQuery string
@@ -100,6 +128,53 @@ func Test_ParseString(t *testing.T) {
 				err:    nil,
 			},
 		},
+		"SuccessB64Enc": {
+			args: args{
+				jqQuery: `.payload.body.username | b64enc`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: "am9obl9kb2U=",
+			},
+		},
+		"SuccessB64Dec": {
+			args: args{
+				jqQuery: `"am9obl9kb2U=" | b64dec`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: "john_doe",
+			},
+		},
+		"SuccessSHA256": {
+			args: args{
+				jqQuery: `.payload.body.username | sha256`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: "99682b662166cd8e4adc60d43c67b7a8227b3cdd74452dbdf71b6ca42a366363",
+			},
+		},
+		"FailB64DecInvalidInput": {
+			args: args{
+				jqQuery: `"not base64!" | b64dec`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: "",
+				err:    errors.Errorf(errInvalidQuery, `"not base64!" | b64dec`, errors.Errorf(errBase64Decode, "not base64!").Error()),
+			},
+		},
+		"FailSHA256NonStringInput": {
+			args: args{
+				jqQuery: `.response.statusCode | sha256`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: "",
+				err:    errors.Errorf(errInvalidQuery, `.response.statusCode | sha256`, errors.Errorf(errFunctionInput, "sha256", "200").Error()),
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -115,6 +190,232 @@ func Test_ParseString(t *testing.T) {
 	}
 }
 
+func Test_ParseValue(t *testing.T) {
+	type args struct {
+		jqQuery string
+		obj     interface{}
+	}
+	type want struct {
+		result interface{}
+		err    error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"SuccessTrim": {
+			args: args{
+				jqQuery: `"  john_doe " | trim`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: "john_doe",
+			},
+		},
+		"SuccessUpper": {
+			args: args{
+				jqQuery: `.payload.body.username | upper`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: "JOHN_DOE",
+			},
+		},
+		"SuccessTitle": {
+			args: args{
+				jqQuery: `"john doe" | title`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: "John Doe",
+			},
+		},
+		"SuccessCamelCase": {
+			args: args{
+				jqQuery: `.payload.body.username | camelcase`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: "johnDoe",
+			},
+		},
+		"SuccessKebabCase": {
+			args: args{
+				jqQuery: `"userName V2" | kebabcase`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: "user-name-v2",
+			},
+		},
+		"SuccessTruncate": {
+			args: args{
+				jqQuery: `.payload.body.username | truncate(4)`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: "john",
+			},
+		},
+		"SuccessReplace": {
+			args: args{
+				jqQuery: `.payload.body.email | replace("@"; " at ")`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: "john.doe at example.com",
+			},
+		},
+		"SuccessIndent": {
+			args: args{
+				jqQuery: `"a\nb" | indent(2)`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: "  a\n  b",
+			},
+		},
+		"SuccessQuote": {
+			args: args{
+				jqQuery: `.payload.body.username | quote`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: `"john_doe"`,
+			},
+		},
+		"SuccessDefault": {
+			args: args{
+				jqQuery: `.payload.body.nickname | default("anonymous")`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: "anonymous",
+			},
+		},
+		"SuccessDefaultNotEmpty": {
+			args: args{
+				jqQuery: `.payload.body.email | default("none")`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: "john.doe@example.com",
+			},
+		},
+		"SuccessCoalesce": {
+			args: args{
+				jqQuery: `[.payload.body.nickname, "", .payload.body.username] | coalesce`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: "john_doe",
+			},
+		},
+		"SuccessDateFormat": {
+			args: args{
+				jqQuery: `"2023-10-21T07:28:00Z" | dateformat("Jan 2, 2006")`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: "Oct 21, 2023",
+			},
+		},
+		"SuccessDateFormatUnix": {
+			args: args{
+				jqQuery: `0 | dateformat("2006-01-02")`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: "1970-01-01",
+			},
+		},
+		"SuccessDateParse": {
+			args: args{
+				jqQuery: `"2023-10-21" | dateparse("2006-01-02")`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: 1697846400,
+			},
+		},
+		"SuccessCompact": {
+			args: args{
+				jqQuery: `[.payload.body.username, null, "", 0, false] | compact`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: []any{"john_doe"},
+			},
+		},
+		"SuccessUniq": {
+			args: args{
+				jqQuery: `[2, 1, 2, {"a": 1}, {"a": 1}] | uniq`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: []any{2, 1, map[string]any{"a": 1}},
+			},
+		},
+		"SuccessPickKeys": {
+			args: args{
+				jqQuery: `.payload.body | pickkeys(["username"])`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: map[string]any{"username": "john_doe"},
+			},
+		},
+		"SuccessOmit": {
+			args: args{
+				jqQuery: `.payload.body | omit("username")`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: map[string]any{"email": "john.doe@example.com"},
+			},
+		},
+		"FailTruncateNonIntegerArgument": {
+			args: args{
+				jqQuery: `.payload.body.username | truncate("4")`,
+				obj:     testJQObject,
+			},
+			want: want{
+				err: errors.Errorf(errInvalidQuery, `.payload.body.username | truncate("4")`, errors.Errorf(errArgumentType, "truncate", "an integer", "4").Error()),
+			},
+		},
+		"FailDateParse": {
+			args: args{
+				jqQuery: `"21/10/2023" | dateparse("2006-01-02")`,
+				obj:     testJQObject,
+			},
+			want: want{
+				err: errors.Errorf(errInvalidQuery, `"21/10/2023" | dateparse("2006-01-02")`, errors.Errorf(errDateParse, "21/10/2023", "2006-01-02").Error()),
+			},
+		},
+		"FailCompactNonArrayInput": {
+			args: args{
+				jqQuery: `.payload.body | compact`,
+				obj:     testJQObject,
+			},
+			want: want{
+				err: errors.Errorf(errInvalidQuery, `.payload.body | compact`, errors.Errorf(errInputType, "compact", "an array", "map[email:john.doe@example.com username:john_doe]").Error()),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ParseValue(tc.args.jqQuery, tc.args.obj)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("ParseValue(...): -want error, +got error: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("ParseValue(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
 func Test_ParseMapInterface(t *testing.T) {
 	type args struct {
 		jqQuery string
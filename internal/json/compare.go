@@ -0,0 +1,132 @@
+package json
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+)
+
+// CompareOptions relax how JSON values are compared. The zero value compares them
+// exactly.
+type CompareOptions struct {
+	// IgnoreArrayOrder compares arrays regardless of the order of their items.
+	IgnoreArrayOrder bool
+
+	// TreatNullAsAbsent considers fields whose value is null to be missing.
+	TreatNullAsAbsent bool
+
+	// NumericTolerance is the largest difference between numbers that are
+	// considered equal.
+	NumericTolerance float64
+}
+
+// equal reports whether the values are equal according to the options.
+func equal(a, b interface{}, opts CompareOptions) bool {
+	if an, ok := number(a); ok {
+		bn, ok := number(b)
+		return ok && equalNumbers(an, bn, opts.NumericTolerance)
+	}
+
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		return ok && equalObjects(av, bv, opts)
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		if opts.IgnoreArrayOrder {
+			return equalUnordered(av, bv, opts)
+		}
+		for i := range av {
+			if !equal(av[i], bv[i], opts) {
+				return false
+			}
+		}
+		return true
+	default:
+		return deepEqual(a, b)
+	}
+}
+
+// number returns the exact value of a JSON number, whether it was decoded as a
+// json.Number or a float64, or converted to an integer by jq.
+func number(value interface{}) (*big.Rat, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		return new(big.Rat).SetString(v.String())
+	case float64:
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return nil, false
+		}
+		return new(big.Rat).SetFloat64(v), true
+	case int:
+		return new(big.Rat).SetInt64(int64(v)), true
+	case int64:
+		return new(big.Rat).SetInt64(v), true
+	case *big.Int:
+		return new(big.Rat).SetInt(v), true
+	default:
+		return nil, false
+	}
+}
+
+// equalNumbers reports whether the numbers differ by at most the tolerance. They're
+// compared exactly without one.
+func equalNumbers(a, b *big.Rat, tolerance float64) bool {
+	if tolerance == 0 {
+		return a.Cmp(b) == 0
+	}
+	difference, _ := new(big.Rat).Sub(a, b).Float64()
+	return math.Abs(difference) <= tolerance
+}
+
+func equalObjects(a, b map[string]interface{}, opts CompareOptions) bool {
+	if len(withoutNulls(a, opts)) != len(withoutNulls(b, opts)) {
+		return false
+	}
+
+	for key, value := range withoutNulls(a, opts) {
+		other, exists := b[key]
+		if !exists || !equal(value, other, opts) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalUnordered reports whether every item of a is equal to a distinct item of b.
+func equalUnordered(a, b []interface{}, opts CompareOptions) bool {
+	matched := make([]bool, len(b))
+	for _, item := range a {
+		found := false
+		for i, other := range b {
+			if !matched[i] && equal(item, other, opts) {
+				matched[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// withoutNulls returns the fields of the object, leaving out null fields when they're
+// treated as absent.
+func withoutNulls(object map[string]interface{}, opts CompareOptions) map[string]interface{} {
+	if !opts.TreatNullAsAbsent {
+		return object
+	}
+
+	fields := make(map[string]interface{}, len(object))
+	for key, value := range object {
+		if value != nil {
+			fields[key] = value
+		}
+	}
+	return fields
+}
@@ -0,0 +1,88 @@
+package json
+
+import (
+	"maps"
+	"regexp"
+	"strconv"
+)
+
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// A Difference holds the desired and observed values of a field that differ. The
+// observed value is omitted when the field is missing.
+type Difference struct {
+	Desired  interface{} `json:"desired"`
+	Observed interface{} `json:"observed,omitempty"`
+}
+
+// Diff returns the fields of the containee that the container doesn't contain, keyed
+// by their JSONPath. Like Contains, only the top level of the containee may be a subset
+// of the container. Nested objects are narrowed down to their differing fields, unless
+// they only differ by fields missing from the containee. Any other values, including
+// arrays, are compared as a whole, according to the options.
+func Diff(container, containee map[string]interface{}, opts CompareOptions) map[string]Difference {
+	differences := map[string]Difference{}
+	diffObjects("$", container, containee, opts, differences)
+	return differences
+}
+
+func diffObjects(path string, container, containee map[string]interface{}, opts CompareOptions, differences map[string]Difference) {
+	for key, desired := range withoutNulls(containee, opts) {
+		keyPath := childPath(path, key)
+		observed, exists := container[key]
+		if !exists {
+			differences[keyPath] = Difference{Desired: desired}
+			continue
+		}
+
+		if equal(desired, observed, opts) {
+			continue
+		}
+
+		desiredObject, desiredIsObject := desired.(map[string]interface{})
+		observedObject, observedIsObject := observed.(map[string]interface{})
+		if desiredIsObject && observedIsObject {
+			nested := map[string]Difference{}
+			diffObjects(keyPath, observedObject, desiredObject, opts, nested)
+			if len(nested) > 0 {
+				maps.Copy(differences, nested)
+				continue
+			}
+		}
+
+		differences[keyPath] = Difference{Desired: desired, Observed: observed}
+	}
+}
+
+func childPath(path, key string) string {
+	if identifier.MatchString(key) {
+		return path + "." + key
+	}
+	return path + "[" + strconv.Quote(key) + "]"
+}
+
+// ChangedFields returns the fields of the containee that the container doesn't contain,
+// as an object holding only those fields, e.g. for the body of a partial update. Like in
+// Diff, nested objects are narrowed down to their differing fields, unless they only
+// differ by fields missing from the containee.
+func ChangedFields(container, containee map[string]interface{}, opts CompareOptions) map[string]interface{} {
+	changed := map[string]interface{}{}
+	for key, desired := range withoutNulls(containee, opts) {
+		observed, exists := container[key]
+		if exists && equal(desired, observed, opts) {
+			continue
+		}
+
+		desiredObject, desiredIsObject := desired.(map[string]interface{})
+		observedObject, observedIsObject := observed.(map[string]interface{})
+		if desiredIsObject && observedIsObject {
+			if nested := ChangedFields(observedObject, desiredObject, opts); len(nested) > 0 {
+				changed[key] = nested
+				continue
+			}
+		}
+
+		changed[key] = desired
+	}
+	return changed
+}
@@ -0,0 +1,178 @@
+package json
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Diff(t *testing.T) {
+	type args struct {
+		container map[string]interface{}
+		containee map[string]interface{}
+		opts      CompareOptions
+	}
+	type want struct {
+		result map[string]Difference
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Contained": {
+			args: args{
+				container: map[string]interface{}{"username": "john_doe", "id": "123"},
+				containee: map[string]interface{}{"username": "john_doe"},
+			},
+			want: want{
+				result: map[string]Difference{},
+			},
+		},
+		"Changed": {
+			args: args{
+				container: map[string]interface{}{"username": "john_doe", "email": "john@example.com"},
+				containee: map[string]interface{}{"username": "john_doe_new", "email": "john@example.com"},
+			},
+			want: want{
+				result: map[string]Difference{
+					"$.username": {Desired: "john_doe_new", Observed: "john_doe"},
+				},
+			},
+		},
+		"Missing": {
+			args: args{
+				container: map[string]interface{}{"id": "123"},
+				containee: map[string]interface{}{"display-name": "John"},
+			},
+			want: want{
+				result: map[string]Difference{
+					`$["display-name"]`: {Desired: "John"},
+				},
+			},
+		},
+		"NestedChanged": {
+			args: args{
+				container: map[string]interface{}{"settings": map[string]interface{}{"theme": "light", "lang": "en"}},
+				containee: map[string]interface{}{"settings": map[string]interface{}{"theme": "dark", "lang": "en"}},
+			},
+			want: want{
+				result: map[string]Difference{
+					"$.settings.theme": {Desired: "dark", Observed: "light"},
+				},
+			},
+		},
+		"NestedExtraObservedField": {
+			args: args{
+				container: map[string]interface{}{"settings": map[string]interface{}{"theme": "dark", "lang": "en"}},
+				containee: map[string]interface{}{"settings": map[string]interface{}{"theme": "dark"}},
+			},
+			want: want{
+				result: map[string]Difference{
+					"$.settings": {
+						Desired:  map[string]interface{}{"theme": "dark"},
+						Observed: map[string]interface{}{"theme": "dark", "lang": "en"},
+					},
+				},
+			},
+		},
+		"ArrayChanged": {
+			args: args{
+				container: map[string]interface{}{"groups": []interface{}{"admin"}},
+				containee: map[string]interface{}{"groups": []interface{}{"admin", "dev"}},
+			},
+			want: want{
+				result: map[string]Difference{
+					"$.groups": {Desired: []interface{}{"admin", "dev"}, Observed: []interface{}{"admin"}},
+				},
+			},
+		},
+		"CompareOptions": {
+			args: args{
+				container: map[string]interface{}{"groups": []interface{}{"dev", "admin"}, "replicas": 3.0},
+				containee: map[string]interface{}{"groups": []interface{}{"admin", "dev"}, "replicas": 3.0, "owner": nil},
+				opts:      CompareOptions{IgnoreArrayOrder: true, TreatNullAsAbsent: true},
+			},
+			want: want{
+				result: map[string]Difference{},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Diff(tc.args.container, tc.args.containee, tc.args.opts)
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("Diff(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_ChangedFields(t *testing.T) {
+	type args struct {
+		container map[string]interface{}
+		containee map[string]interface{}
+		opts      CompareOptions
+	}
+	type want struct {
+		result map[string]interface{}
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Unchanged": {
+			args: args{
+				container: map[string]interface{}{"username": "john_doe", "id": "123"},
+				containee: map[string]interface{}{"username": "john_doe"},
+			},
+			want: want{
+				result: map[string]interface{}{},
+			},
+		},
+		"ChangedAndMissing": {
+			args: args{
+				container: map[string]interface{}{"username": "john_doe", "email": "john@example.com"},
+				containee: map[string]interface{}{"username": "john_doe", "email": "john.doe@example.com", "role": "admin"},
+			},
+			want: want{
+				result: map[string]interface{}{"email": "john.doe@example.com", "role": "admin"},
+			},
+		},
+		"NestedNarrowed": {
+			args: args{
+				container: map[string]interface{}{"settings": map[string]interface{}{"theme": "light", "language": "en"}},
+				containee: map[string]interface{}{"settings": map[string]interface{}{"theme": "dark", "language": "en"}},
+			},
+			want: want{
+				result: map[string]interface{}{"settings": map[string]interface{}{"theme": "dark"}},
+			},
+		},
+		"NestedOnlyMissingFields": {
+			args: args{
+				container: map[string]interface{}{"settings": map[string]interface{}{"theme": "dark", "beta": true}},
+				containee: map[string]interface{}{"settings": map[string]interface{}{"theme": "dark"}},
+			},
+			want: want{
+				result: map[string]interface{}{"settings": map[string]interface{}{"theme": "dark"}},
+			},
+		},
+		"NullTreatedAsAbsent": {
+			args: args{
+				container: map[string]interface{}{"groups": []interface{}{"dev", "admin"}},
+				containee: map[string]interface{}{"groups": []interface{}{"admin", "dev"}, "owner": nil},
+				opts:      CompareOptions{IgnoreArrayOrder: true, TreatNullAsAbsent: true},
+			},
+			want: want{
+				result: map[string]interface{}{},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ChangedFields(tc.args.container, tc.args.containee, tc.args.opts)
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("ChangedFields(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package json
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+const (
+	errInvalidJSONPath = "invalid JSONPath expression %s"
+	errInvalidStep     = "step must be greater than 0"
+	errInvalidRange    = "starting index %d is greater than ending index %d"
+)
+
+// ErrJSONPathNotFound is returned, wrapped, by the evaluation of a JSONPath expression
+// against an object without the path, e.g. without a field, with fewer items than an
+// index or with an object where an array is expected.
+var ErrJSONPathNotFound = errors.New("the JSONPath expression doesn't match the object")
+
+// normalizeJSONPath accepts both `$.a.b[0]` and `.a.b[0]` notations and wraps
+// the expression in the braces expected by the Kubernetes JSONPath parser.
+func normalizeJSONPath(path string) string {
+	path = strings.TrimSpace(path)
+	if strings.HasPrefix(path, "{") && strings.HasSuffix(path, "}") {
+		return path
+	}
+
+	path = strings.TrimPrefix(path, "$")
+	if !strings.HasPrefix(path, ".") && !strings.HasPrefix(path, "[") {
+		path = "." + path
+	}
+
+	return "{" + path + "}"
+}
+
+// QueryJSONPath returns all the values selected by the JSONPath expression in obj.
+// Array indexing (`[0]`) and wildcard segments (`[*]`, `.*`) are supported.
+// The second return value is false when the path doesn't exist in obj.
+func QueryJSONPath(obj interface{}, path string) ([]interface{}, bool, error) {
+	parser := jsonpath.New(path).AllowMissingKeys(false)
+	if err := parser.Parse(normalizeJSONPath(path)); err != nil {
+		return nil, false, errors.Wrapf(err, errInvalidJSONPath, path)
+	}
+	if err := validateJSONPath(normalizeJSONPath(path)); err != nil {
+		return nil, false, errors.Wrapf(err, errInvalidJSONPath, path)
+	}
+
+	if strings.Contains(path, "?(") {
+		// Filters compare numbers with float literals, and can't compare json.Number.
+		obj = floatNumbers(obj)
+	}
+
+	results, err := findResults(parser, obj)
+	if errors.Is(err, ErrJSONPathNotFound) {
+		return nil, false, nil
+	}
+
+	values := []interface{}{}
+	for _, result := range results {
+		for _, value := range result {
+			values = append(values, value.Interface())
+		}
+	}
+
+	return values, len(values) > 0, nil
+}
+
+// EqualAtJSONPaths compares the values selected by each JSONPath expression in both maps.
+// Paths missing in the containee aren't compared, while paths missing in the container
+// make the result false. Values are compared according to the options.
+func EqualAtJSONPaths(container, containee map[string]interface{}, paths []string, opts CompareOptions) (bool, error) {
+	for _, path := range paths {
+		want, found, err := QueryJSONPath(containee, path)
+		if err != nil {
+			return false, err
+		}
+		if !found {
+			continue
+		}
+
+		got, found, err := QueryJSONPath(container, path)
+		if err != nil || !found {
+			return false, err
+		}
+
+		if !equal(want, got, opts) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// findResults evaluates the parsed JSONPath expression. Once validated, the expression
+// can only fail to evaluate because of the object, with ErrJSONPathNotFound.
+func findResults(parser *jsonpath.JSONPath, obj interface{}) ([][]reflect.Value, error) {
+	results, err := parser.FindResults(obj)
+	if err != nil {
+		return nil, errors.WithMessage(ErrJSONPathNotFound, err.Error())
+	}
+	return results, nil
+}
+
+// validateJSONPath rejects the slices that are invalid whatever the object, which the
+// parser accepts but fail every evaluation, e.g. `[::0]` or `[3:1]`.
+func validateJSONPath(template string) error {
+	parsed, err := jsonpath.Parse("", template)
+	if err != nil {
+		return err
+	}
+	return validateNode(parsed.Root)
+}
+
+func validateNode(node jsonpath.Node) error {
+	switch n := node.(type) {
+	case *jsonpath.ListNode:
+		for _, child := range n.Nodes {
+			if err := validateNode(child); err != nil {
+				return err
+			}
+		}
+	case *jsonpath.UnionNode:
+		for _, child := range n.Nodes {
+			if err := validateNode(child); err != nil {
+				return err
+			}
+		}
+	case *jsonpath.FilterNode:
+		if err := validateNode(n.Left); err != nil {
+			return err
+		}
+		return validateNode(n.Right)
+	case *jsonpath.ArrayNode:
+		start, end, step := n.Params[0], n.Params[1], n.Params[2]
+		if step.Known && step.Value <= 0 {
+			return errors.New(errInvalidStep)
+		}
+		if start.Known && end.Known && start.Value >= 0 && end.Value >= 0 && start.Value > end.Value {
+			return errors.Errorf(errInvalidRange, start.Value, end.Value)
+		}
+	}
+	return nil
+}
+
+// maxExactFloatInt is the largest integer from which on a float64 can't represent
+// every integer.
+const maxExactFloatInt = 1 << 53
+
+// isExactFloat reports whether the number is represented exactly by a float64, or
+// is a decimal number, which a float64 approximates anyway.
+func isExactFloat(n json.Number) bool {
+	if strings.ContainsAny(n.String(), ".eE") {
+		return true
+	}
+	i, err := n.Int64()
+	return err == nil && i <= maxExactFloatInt && i >= -maxExactFloatInt
+}
+
+// floatNumbers returns a copy of the JSON value with its json.Number values
+// converted to float64. Integers a float64 can't represent exactly are kept as
+// json.Number, so that the values selected by a filter keep their precision.
+func floatNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if !isExactFloat(v) {
+			return v
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return v
+		}
+		return f
+	case map[string]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[key] = floatNumbers(item)
+		}
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, len(v))
+		for i, item := range v {
+			converted[i] = floatNumbers(item)
+		}
+		return converted
+	default:
+		return v
+	}
+}
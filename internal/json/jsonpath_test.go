@@ -0,0 +1,240 @@
+package json
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+var testJSONPathObject = map[string]interface{}{
+	"name": "robot",
+	"id":   json.Number("9007199254740993"),
+	"spec": map[string]interface{}{
+		"replicas": float64(3),
+	},
+	"permissions": []interface{}{
+		map[string]interface{}{"kind": "project", "namespace": "library", "level": json.Number("1")},
+		map[string]interface{}{"kind": "project", "namespace": "infra", "level": json.Number("2")},
+	},
+}
+
+func Test_QueryJSONPath(t *testing.T) {
+	type args struct {
+		path string
+	}
+	type want struct {
+		values []interface{}
+		found  bool
+		err    error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"DollarNotation": {
+			args: args{
+				path: "$.spec.replicas",
+			},
+			want: want{
+				values: []interface{}{float64(3)},
+				found:  true,
+			},
+		},
+		"DotNotation": {
+			args: args{
+				path: ".name",
+			},
+			want: want{
+				values: []interface{}{"robot"},
+				found:  true,
+			},
+		},
+		"ArrayIndex": {
+			args: args{
+				path: "$.permissions[1].namespace",
+			},
+			want: want{
+				values: []interface{}{"infra"},
+				found:  true,
+			},
+		},
+		"Wildcard": {
+			args: args{
+				path: "$.permissions[*].namespace",
+			},
+			want: want{
+				values: []interface{}{"library", "infra"},
+				found:  true,
+			},
+		},
+		"LargeInteger": {
+			args: args{
+				path: "$.id",
+			},
+			want: want{
+				values: []interface{}{json.Number("9007199254740993")},
+				found:  true,
+			},
+		},
+		"FilterOnNumber": {
+			args: args{
+				path: "$.permissions[?(@.level>1.5)].namespace",
+			},
+			want: want{
+				values: []interface{}{"infra"},
+				found:  true,
+			},
+		},
+		"MissingKey": {
+			args: args{
+				path: "$.spec.missing",
+			},
+			want: want{
+				found: false,
+			},
+		},
+		"IndexOutOfBounds": {
+			args: args{
+				path: "$.permissions[5].namespace",
+			},
+			want: want{
+				found: false,
+			},
+		},
+		"IndexOfObject": {
+			args: args{
+				path: "$.spec[0]",
+			},
+			want: want{
+				found: false,
+			},
+		},
+		"LastItem": {
+			args: args{
+				path: "$.permissions[-1].namespace",
+			},
+			want: want{
+				values: []interface{}{"infra"},
+				found:  true,
+			},
+		},
+		"InvalidStep": {
+			args: args{
+				path: "$.permissions[::0]",
+			},
+			want: want{
+				err: errors.Wrapf(errors.New(errInvalidStep), errInvalidJSONPath, "$.permissions[::0]"),
+			},
+		},
+		"ReversedRange": {
+			args: args{
+				path: "$.permissions[3:1]",
+			},
+			want: want{
+				err: errors.Wrapf(errors.Errorf(errInvalidRange, 3, 1), errInvalidJSONPath, "$.permissions[3:1]"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, found, err := QueryJSONPath(testJSONPathObject, tc.args.path)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("QueryJSONPath(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.found, found); diff != "" {
+				t.Fatalf("QueryJSONPath(...): -want found, +got found: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.values, got); diff != "" {
+				t.Fatalf("QueryJSONPath(...): -want values, +got values: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_EqualAtJSONPaths(t *testing.T) {
+	type args struct {
+		container map[string]interface{}
+		containee map[string]interface{}
+		paths     []string
+		opts      CompareOptions
+	}
+	type want struct {
+		result bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"EqualIgnoringOtherFields": {
+			args: args{
+				container: map[string]interface{}{"name": "robot", "update_time": "now"},
+				containee: map[string]interface{}{"name": "robot", "update_time": "before"},
+				paths:     []string{"$.name"},
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"NotEqual": {
+			args: args{
+				container: map[string]interface{}{"name": "robot"},
+				containee: map[string]interface{}{"name": "human"},
+				paths:     []string{"$.name"},
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"MissingInContainer": {
+			args: args{
+				container: map[string]interface{}{},
+				containee: map[string]interface{}{"name": "robot"},
+				paths:     []string{"$.name"},
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"WildcardEqual": {
+			args: args{
+				container: testJSONPathObject,
+				containee: map[string]interface{}{"permissions": []interface{}{
+					map[string]interface{}{"namespace": "library"},
+					map[string]interface{}{"namespace": "infra"},
+				}},
+				paths: []string{"$.permissions[*].namespace"},
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"WildcardIgnoreArrayOrder": {
+			args: args{
+				container: testJSONPathObject,
+				containee: map[string]interface{}{"permissions": []interface{}{
+					map[string]interface{}{"namespace": "infra"},
+					map[string]interface{}{"namespace": "library"},
+				}},
+				paths: []string{"$.permissions[*].namespace"},
+				opts:  CompareOptions{IgnoreArrayOrder: true},
+			},
+			want: want{
+				result: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := EqualAtJSONPaths(tc.args.container, tc.args.containee, tc.args.paths, tc.args.opts)
+			if err != nil {
+				t.Fatalf("EqualAtJSONPaths(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("EqualAtJSONPaths(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package json
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errListPathWildcard = "list path %s must select a single list, without wildcards"
+	errNotAList         = "value at %s is not a list"
+	errListItemKey      = "item %d of the list at %s is not an object with a %s field"
+	errDuplicateListKey = "list at %s has several items whose %s is %s"
+)
+
+// ListDifferences are the keys of the items that differ between two lists whose
+// items are matched by a key, encoded as JSON, e.g. `"web"` or `1`.
+type ListDifferences struct {
+	// Missing are the keys of the desired items absent from the observed list.
+	Missing []string
+	// Extra are the keys of the observed items absent from the desired list.
+	Extra []string
+	// Changed are the keys of the observed items that don't contain their desired item.
+	Changed []string
+}
+
+// Empty reports whether the lists are the same.
+func (d ListDifferences) Empty() bool {
+	return len(d.Missing) == 0 && len(d.Extra) == 0 && len(d.Changed) == 0
+}
+
+// CompareKeyedList compares the lists at the path in both maps, pairing their items by
+// the value of their key field rather than by their position. An observed item must
+// contain its desired item according to the options. The fields outside of the list
+// are compared like Contains. A list missing from the containee isn't compared, while
+// one missing from the container has all its desired items missing.
+func CompareKeyedList(container, containee map[string]interface{}, path, key string, opts CompareOptions) (bool, ListDifferences, error) {
+	segments, err := parseSimpleJSONPath(path)
+	if err != nil {
+		return false, ListDifferences{}, err
+	}
+	for _, segment := range segments {
+		if segment.wildcard {
+			return false, ListDifferences{}, errors.Errorf(errListPathWildcard, path)
+		}
+	}
+
+	desired, err := keyedItems(containee, segments, path, key)
+	if err != nil {
+		return false, ListDifferences{}, err
+	}
+	observed, err := keyedItems(container, segments, path, key)
+	if err != nil {
+		return false, ListDifferences{}, err
+	}
+
+	var differences ListDifferences
+	if desired != nil {
+		for _, k := range desired.keys {
+			item, ok := observed.get(k)
+			switch {
+			case !ok:
+				differences.Missing = append(differences.Missing, k)
+			case !Contains(item, desired.items[k], opts):
+				differences.Changed = append(differences.Changed, k)
+			}
+		}
+		for _, k := range observed.all() {
+			if _, ok := desired.items[k]; !ok {
+				differences.Extra = append(differences.Extra, k)
+			}
+		}
+	}
+
+	// The rest of the documents is compared without the lists, on copies of them.
+	rest := func(obj map[string]interface{}) map[string]interface{} {
+		copied, _ := deepCopy(obj).(map[string]interface{})
+		deleteSegments(copied, segments)
+		return copied
+	}
+	return Contains(rest(container), rest(containee), opts) && differences.Empty(), differences, nil
+}
+
+// keyedList is a list whose items are objects indexed by the value of their key.
+type keyedList struct {
+	keys  []string
+	items map[string]map[string]interface{}
+}
+
+func (l *keyedList) get(key string) (map[string]interface{}, bool) {
+	if l == nil {
+		return nil, false
+	}
+	item, ok := l.items[key]
+	return item, ok
+}
+
+func (l *keyedList) all() []string {
+	if l == nil {
+		return nil
+	}
+	return l.keys
+}
+
+// keyedItems returns the items of the list at the segments of obj, or nil if obj
+// has no value there.
+func keyedItems(obj interface{}, segments []pathSegment, path, key string) (*keyedList, error) {
+	value, ok := lookupSegments(obj, segments)
+	if !ok || value == nil {
+		return nil, nil
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, errors.Errorf(errNotAList, path)
+	}
+
+	list := &keyedList{items: make(map[string]map[string]interface{}, len(items))}
+	for i, item := range items {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf(errListItemKey, i, path, key)
+		}
+		value, ok := object[key]
+		if !ok || value == nil {
+			return nil, errors.Errorf(errListItemKey, i, path, key)
+		}
+
+		k, err := listKey(value)
+		if err != nil {
+			return nil, errors.Errorf(errListItemKey, i, path, key)
+		}
+		if _, ok := list.items[k]; ok {
+			return nil, errors.Errorf(errDuplicateListKey, path, key, k)
+		}
+		list.keys = append(list.keys, k)
+		list.items[k] = object
+	}
+	return list, nil
+}
+
+// listKey returns the key of a list item from the value of its key field. Keys are
+// told apart by their JSON encoding, so that 1 and "1" are different keys, while
+// integers are equal whatever their notation, e.g. 1 and 1.0.
+func listKey(value interface{}) (string, error) {
+	if number, ok := value.(json.Number); ok {
+		if integer, ok := new(big.Rat).SetString(number.String()); ok && integer.IsInt() {
+			return integer.Num().String(), nil
+		}
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+func lookupSegments(obj interface{}, segments []pathSegment) (interface{}, bool) {
+	for _, segment := range segments {
+		switch typed := obj.(type) {
+		case map[string]interface{}:
+			if segment.isIndex {
+				return nil, false
+			}
+			value, ok := typed[segment.key]
+			if !ok {
+				return nil, false
+			}
+			obj = value
+		case []interface{}:
+			if !segment.isIndex || segment.index < 0 || segment.index >= len(typed) {
+				return nil, false
+			}
+			obj = typed[segment.index]
+		default:
+			return nil, false
+		}
+	}
+	return obj, true
+}
+
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			copied[key] = deepCopy(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = deepCopy(item)
+		}
+		return copied
+	default:
+		return v
+	}
+}
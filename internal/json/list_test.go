@@ -0,0 +1,182 @@
+package json
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+)
+
+func Test_CompareKeyedList(t *testing.T) {
+	desired := `{"name":"acl","rules":[{"id":1,"action":"allow"},{"id":2,"action":"deny"}]}`
+
+	type args struct {
+		container string
+		containee string
+		path      string
+		key       string
+		opts      CompareOptions
+	}
+	type want struct {
+		synced      bool
+		differences ListDifferences
+		err         error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Reordered": {
+			args: args{
+				container: `{"name":"acl","rules":[{"id":2,"action":"deny"},{"id":1,"action":"allow"}]}`,
+				containee: desired,
+				path:      "$.rules",
+				key:       "id",
+			},
+			want: want{synced: true},
+		},
+		"ServerDefaults": {
+			args: args{
+				container: `{"name":"acl","etag":"1","rules":[{"id":1,"action":"allow","priority":10},{"id":2,"action":"deny","priority":20}]}`,
+				containee: desired,
+				path:      "$.rules",
+				key:       "id",
+			},
+			want: want{synced: true},
+		},
+		"Differences": {
+			args: args{
+				container: `{"name":"acl","rules":[{"id":1,"action":"deny"},{"id":3,"action":"allow"},{"id":4,"action":"allow"}]}`,
+				containee: desired,
+				path:      "$.rules",
+				key:       "id",
+			},
+			want: want{
+				differences: ListDifferences{Missing: []string{"2"}, Extra: []string{"3", "4"}, Changed: []string{"1"}},
+			},
+		},
+		"RestOfDocumentDiffers": {
+			args: args{
+				container: `{"name":"firewall","rules":[{"id":1,"action":"allow"},{"id":2,"action":"deny"}]}`,
+				containee: desired,
+				path:      "$.rules",
+				key:       "id",
+			},
+			want: want{},
+		},
+		"NestedStringKeys": {
+			args: args{
+				container: `{"spec":{"rules":[{"name":"b","port":443},{"name":"a","port":80}]}}`,
+				containee: `{"spec":{"rules":[{"name":"a","port":80},{"name":"b","port":443.0}]}}`,
+				path:      ".spec.rules",
+				key:       "name",
+				opts:      CompareOptions{NumericTolerance: 0.5},
+			},
+			want: want{synced: true},
+		},
+		"NumberAndStringKeys": {
+			// The item whose id is the string "1" isn't the item whose id is 1.
+			args: args{
+				container: `{"name":"acl","rules":[{"id":"1","action":"allow"},{"id":2,"action":"deny"}]}`,
+				containee: desired,
+				path:      "$.rules",
+				key:       "id",
+			},
+			want: want{
+				differences: ListDifferences{Missing: []string{"1"}, Extra: []string{`"1"`}},
+			},
+		},
+		"IntegerNotations": {
+			args: args{
+				container: `{"name":"acl","rules":[{"id":2.0,"action":"deny"},{"id":1e0,"action":"allow"}]}`,
+				containee: desired,
+				path:      "$.rules",
+				key:       "id",
+			},
+			want: want{synced: true},
+		},
+		"StringKeys": {
+			args: args{
+				container: `{"rules":[{"name":"web","port":80}]}`,
+				containee: `{"rules":[{"name":"web","port":443}]}`,
+				path:      "$.rules",
+				key:       "name",
+			},
+			want: want{
+				differences: ListDifferences{Changed: []string{`"web"`}},
+			},
+		},
+		"MissingFromContainer": {
+			args: args{
+				container: `{"name":"acl"}`,
+				containee: desired,
+				path:      "$.rules",
+				key:       "id",
+			},
+			want: want{
+				differences: ListDifferences{Missing: []string{"1", "2"}},
+			},
+		},
+		"MissingFromContainee": {
+			args: args{
+				container: `{"name":"acl","rules":[{"id":1,"action":"allow"}]}`,
+				containee: `{"name":"acl"}`,
+				path:      "$.rules",
+				key:       "id",
+			},
+			want: want{synced: true},
+		},
+		"ItemWithoutKey": {
+			args: args{
+				container: `{"rules":[{"id":1},{"action":"allow"}]}`,
+				containee: desired,
+				path:      "$.rules",
+				key:       "id",
+			},
+			want: want{err: errors.Errorf(errListItemKey, 1, "$.rules", "id")},
+		},
+		"DuplicateKey": {
+			args: args{
+				container: `{"rules":[{"id":1},{"id":1}]}`,
+				containee: desired,
+				path:      "$.rules",
+				key:       "id",
+			},
+			want: want{err: errors.Errorf(errDuplicateListKey, "$.rules", "id", "1")},
+		},
+		"NotAList": {
+			args: args{
+				container: `{"rules":{"id":1}}`,
+				containee: desired,
+				path:      "$.rules",
+				key:       "id",
+			},
+			want: want{err: errors.Errorf(errNotAList, "$.rules")},
+		},
+		"Wildcard": {
+			args: args{
+				container: desired,
+				containee: desired,
+				path:      "$.groups[*].rules",
+				key:       "id",
+			},
+			want: want{err: errors.Errorf(errListPathWildcard, "$.groups[*].rules")},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			synced, differences, err := CompareKeyedList(JsonStringToMap(tc.args.container), JsonStringToMap(tc.args.containee), tc.args.path, tc.args.key, tc.args.opts)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("CompareKeyedList(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.synced, synced); diff != "" {
+				t.Errorf("CompareKeyedList(...): -want synced, +got synced: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.differences, differences, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("CompareKeyedList(...): -want differences, +got differences: %s", diff)
+			}
+		})
+	}
+}
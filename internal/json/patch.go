@@ -0,0 +1,135 @@
+package json
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errParsePatch        = "cannot parse the JSON Patch"
+	errParseDocument     = "cannot parse the JSON document the patch is applied to"
+	errUnsupportedOp     = "unsupported JSON Patch operation %q at %s, only add, replace and remove are supported"
+	errInvalidPointer    = "invalid JSON pointer %q, it must be empty or start with /"
+	errTrailingJSONInput = "unexpected data after the JSON value"
+)
+
+// A PatchOperation is an operation of a JSON Patch document (RFC 6902).
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// ChangingOperation returns the first operation of the JSON Patch that would change
+// the document if it was applied, or nil if applying the patch leaves the document
+// as it is. An add or replace operation changes the document unless the target
+// already holds an equal value according to the options, and a remove operation
+// unless the target is already missing. Adding to an array inserts an item, so it
+// always changes the document.
+func ChangingOperation(document, patch string, opts CompareOptions) (*PatchOperation, error) {
+	var operations []PatchOperation
+	if err := Decode(patch, &operations); err != nil {
+		return nil, errors.Wrap(err, errParsePatch)
+	}
+	var doc interface{}
+	if err := Decode(document, &doc); err != nil {
+		return nil, errors.Wrap(err, errParseDocument)
+	}
+
+	// Operations that don't change the document leave it as it is for the next ones,
+	// so every operation is checked against the document itself.
+	for i := range operations {
+		changed, err := changes(doc, operations[i], opts)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			return &operations[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func changes(doc interface{}, operation PatchOperation, opts CompareOptions) (bool, error) {
+	tokens, err := parsePointer(operation.Path)
+	if err != nil {
+		return false, err
+	}
+
+	switch operation.Op {
+	case "add":
+		if len(tokens) > 0 {
+			if parent, exists := lookup(doc, tokens[:len(tokens)-1]); exists {
+				if _, isArray := parent.([]interface{}); isArray {
+					return true, nil
+				}
+			}
+		}
+		fallthrough
+	case "replace":
+		current, exists := lookup(doc, tokens)
+		return !exists || !equal(operation.Value, current, opts), nil
+	case "remove":
+		_, exists := lookup(doc, tokens)
+		return exists, nil
+	default:
+		return false, errors.Errorf(errUnsupportedOp, operation.Op, operation.Path)
+	}
+}
+
+// parsePointer splits a JSON pointer (RFC 6901) into its unescaped reference tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, errors.Errorf(errInvalidPointer, pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// lookup returns the value referenced by the tokens, and whether it exists.
+func lookup(doc interface{}, tokens []string) (interface{}, bool) {
+	for _, token := range tokens {
+		switch value := doc.(type) {
+		case map[string]interface{}:
+			child, exists := value[token]
+			if !exists {
+				return nil, false
+			}
+			doc = child
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(value) {
+				return nil, false
+			}
+			doc = value[index]
+		default:
+			return nil, false
+		}
+	}
+	return doc, true
+}
+
+// Decode parses a single JSON value, keeping its numbers as json.Number so that
+// integers beyond the precision of a float64 aren't rounded.
+func Decode(data string, v interface{}) error {
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return errors.New(errTrailingJSONInput)
+	}
+	return nil
+}
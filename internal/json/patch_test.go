@@ -0,0 +1,123 @@
+package json
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_ChangingOperation(t *testing.T) {
+	type args struct {
+		document string
+		patch    string
+		opts     CompareOptions
+	}
+	type want struct {
+		operation *PatchOperation
+		err       error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"AlreadyApplied": {
+			args: args{
+				document: `{"name":"a","spec":{"replicas":3,"labels":{"a/b":"c"}},"tags":["x"]}`,
+				patch:    `[{"op":"replace","path":"/name","value":"a"},{"op":"add","path":"/spec/labels/a~1b","value":"c"},{"op":"remove","path":"/spec/paused"},{"op":"replace","path":"/tags/0","value":"x"}]`,
+			},
+		},
+		"ReplaceChanges": {
+			args: args{
+				document: `{"name":"a","spec":{"replicas":3}}`,
+				patch:    `[{"op":"replace","path":"/name","value":"a"},{"op":"replace","path":"/spec/replicas","value":5}]`,
+			},
+			want: want{
+				operation: &PatchOperation{Op: "replace", Path: "/spec/replicas", Value: json.Number("5")},
+			},
+		},
+		"ReplaceMissing": {
+			args: args{
+				document: `{"name":"a"}`,
+				patch:    `[{"op":"replace","path":"/description","value":"b"}]`,
+			},
+			want: want{
+				operation: &PatchOperation{Op: "replace", Path: "/description", Value: "b"},
+			},
+		},
+		"AddMissing": {
+			args: args{
+				document: `{"name":"a"}`,
+				patch:    `[{"op":"add","path":"/spec","value":{"replicas":3}}]`,
+			},
+			want: want{
+				operation: &PatchOperation{Op: "add", Path: "/spec", Value: map[string]interface{}{"replicas": json.Number("3")}},
+			},
+		},
+		"AddToArray": {
+			args: args{
+				document: `{"tags":["x"]}`,
+				patch:    `[{"op":"add","path":"/tags/-","value":"x"}]`,
+			},
+			want: want{
+				operation: &PatchOperation{Op: "add", Path: "/tags/-", Value: "x"},
+			},
+		},
+		"RemoveExisting": {
+			args: args{
+				document: `{"name":"a","paused":true}`,
+				patch:    `[{"op":"remove","path":"/paused"}]`,
+			},
+			want: want{
+				operation: &PatchOperation{Op: "remove", Path: "/paused"},
+			},
+		},
+		"NumericTolerance": {
+			args: args{
+				document: `{"ratio":0.5000001}`,
+				patch:    `[{"op":"replace","path":"/ratio","value":0.5}]`,
+				opts:     CompareOptions{NumericTolerance: 0.001},
+			},
+		},
+		"UnsupportedOperation": {
+			args: args{
+				document: `{"name":"a"}`,
+				patch:    `[{"op":"move","from":"/name","path":"/title"}]`,
+			},
+			want: want{
+				err: errors.Errorf(errUnsupportedOp, "move", "/title"),
+			},
+		},
+		"InvalidPointer": {
+			args: args{
+				document: `{"name":"a"}`,
+				patch:    `[{"op":"remove","path":"name"}]`,
+			},
+			want: want{
+				err: errors.Errorf(errInvalidPointer, "name"),
+			},
+		},
+		"NotAPatch": {
+			args: args{
+				document: `{"name":"a"}`,
+				patch:    `{"name":"a"}`,
+			},
+			want: want{
+				err: errors.Wrap(errors.New("json: cannot unmarshal object into Go value of type []json.PatchOperation"), errParsePatch),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ChangingOperation(tc.args.document, tc.args.patch, tc.args.opts)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("ChangingOperation(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.operation, got); diff != "" {
+				t.Errorf("ChangingOperation(...): -want operation, +got operation: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package json
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// RedactedPlaceholder replaces redacted values, so that redaction doesn't
+	// introduce drift between reconciles.
+	RedactedPlaceholder = "***"
+
+	errUnsupportedPath = "unsupported path %s, only .key, [index], [*] and .* segments are supported"
+)
+
+// pathSegment is a single step of a simple JSONPath expression. An empty key
+// with wildcard set matches every key or index.
+type pathSegment struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// parseSimpleJSONPath splits expressions such as `$.items[*].secret` into segments.
+func parseSimpleJSONPath(path string) ([]pathSegment, error) {
+	rest := strings.TrimSpace(path)
+	rest = strings.TrimSuffix(strings.TrimPrefix(rest, "{"), "}")
+	rest = strings.TrimPrefix(rest, "$")
+
+	var segments []pathSegment
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, errors.Errorf(errUnsupportedPath, path)
+			}
+
+			inner := rest[1:end]
+			rest = rest[end+1:]
+			if inner == "*" {
+				segments = append(segments, pathSegment{wildcard: true})
+				continue
+			}
+
+			index, err := strconv.Atoi(inner)
+			if err != nil {
+				key := strings.Trim(inner, `'"`)
+				if key == inner {
+					return nil, errors.Errorf(errUnsupportedPath, path)
+				}
+				segments = append(segments, pathSegment{key: key})
+				continue
+			}
+			segments = append(segments, pathSegment{index: index, isIndex: true})
+		case strings.HasPrefix(rest, "."):
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+
+			key := rest[:end]
+			rest = rest[end:]
+			if key == "" {
+				return nil, errors.Errorf(errUnsupportedPath, path)
+			}
+			segments = append(segments, pathSegment{key: key, wildcard: key == "*"})
+		default:
+			rest = "." + rest
+		}
+	}
+
+	return segments, nil
+}
+
+// RedactJSONPaths replaces the values selected by each path in obj with RedactedPlaceholder.
+// Paths that don't exist in obj are ignored.
+func RedactJSONPaths(obj interface{}, paths []string) error {
+	for _, path := range paths {
+		segments, err := parseSimpleJSONPath(path)
+		if err != nil {
+			return err
+		}
+		redactSegments(obj, segments)
+	}
+
+	return nil
+}
+
+func redactSegments(obj interface{}, segments []pathSegment) {
+	if len(segments) == 0 {
+		return
+	}
+
+	segment, last := segments[0], len(segments) == 1
+	switch typed := obj.(type) {
+	case map[string]interface{}:
+		for key, value := range typed {
+			if !segment.wildcard && (segment.isIndex || key != segment.key) {
+				continue
+			}
+			if last {
+				typed[key] = RedactedPlaceholder
+				continue
+			}
+			redactSegments(value, segments[1:])
+		}
+	case []interface{}:
+		for i, value := range typed {
+			if !segment.wildcard && (!segment.isIndex || i != segment.index) {
+				continue
+			}
+			if last {
+				typed[i] = RedactedPlaceholder
+				continue
+			}
+			redactSegments(value, segments[1:])
+		}
+	}
+}
+
+// DeleteJSONPaths removes the object fields selected by each path from obj. Paths
+// that don't exist in obj, or select array items, are ignored.
+func DeleteJSONPaths(obj interface{}, paths []string) error {
+	for _, path := range paths {
+		segments, err := parseSimpleJSONPath(path)
+		if err != nil {
+			return err
+		}
+		deleteSegments(obj, segments)
+	}
+
+	return nil
+}
+
+func deleteSegments(obj interface{}, segments []pathSegment) {
+	if len(segments) == 0 {
+		return
+	}
+
+	segment, last := segments[0], len(segments) == 1
+	switch typed := obj.(type) {
+	case map[string]interface{}:
+		for key, value := range typed {
+			if !segment.wildcard && (segment.isIndex || key != segment.key) {
+				continue
+			}
+			if last {
+				delete(typed, key)
+				continue
+			}
+			deleteSegments(value, segments[1:])
+		}
+	case []interface{}:
+		if last {
+			return
+		}
+		for i, value := range typed {
+			if segment.wildcard || (segment.isIndex && i == segment.index) {
+				deleteSegments(value, segments[1:])
+			}
+		}
+	}
+}
+
+// RedactJSONString redacts the values selected by the paths in a JSON document.
+// Documents that aren't JSON objects are returned unchanged.
+func RedactJSONString(jsonStr string, paths []string) (string, error) {
+	if len(paths) == 0 || !IsJSONString(jsonStr) {
+		return jsonStr, nil
+	}
+
+	obj := JsonStringToMap(jsonStr)
+	if err := RedactJSONPaths(obj, paths); err != nil {
+		return "", err
+	}
+
+	redacted, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+
+	return string(redacted), nil
+}
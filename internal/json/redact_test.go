@@ -0,0 +1,201 @@
+package json
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_RedactJSONString(t *testing.T) {
+	type args struct {
+		jsonStr string
+		paths   []string
+	}
+	type want struct {
+		result string
+		err    error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoPaths": {
+			args: args{
+				jsonStr: `{"token": "abc"}`,
+			},
+			want: want{
+				result: `{"token": "abc"}`,
+			},
+		},
+		"NotJSON": {
+			args: args{
+				jsonStr: "token=abc",
+				paths:   []string{"$.token"},
+			},
+			want: want{
+				result: "token=abc",
+			},
+		},
+		"TopLevelKey": {
+			args: args{
+				jsonStr: `{"id":"1","token":"abc"}`,
+				paths:   []string{"$.token"},
+			},
+			want: want{
+				result: `{"id":"1","token":"***"}`,
+			},
+		},
+		"NestedKeyWithoutDollar": {
+			args: args{
+				jsonStr: `{"credentials":{"password":"abc","user":"dan"}}`,
+				paths:   []string{"credentials.password"},
+			},
+			want: want{
+				result: `{"credentials":{"password":"***","user":"dan"}}`,
+			},
+		},
+		"ArrayIndex": {
+			args: args{
+				jsonStr: `{"keys":[{"secret":"a"},{"secret":"b"}]}`,
+				paths:   []string{"$.keys[1].secret"},
+			},
+			want: want{
+				result: `{"keys":[{"secret":"a"},{"secret":"***"}]}`,
+			},
+		},
+		"Wildcard": {
+			args: args{
+				jsonStr: `{"keys":[{"secret":"a"},{"secret":"b"}]}`,
+				paths:   []string{"$.keys[*].secret"},
+			},
+			want: want{
+				result: `{"keys":[{"secret":"***"},{"secret":"***"}]}`,
+			},
+		},
+		"MissingPath": {
+			args: args{
+				jsonStr: `{"id":"1"}`,
+				paths:   []string{"$.token"},
+			},
+			want: want{
+				result: `{"id":"1"}`,
+			},
+		},
+		"UnsupportedPath": {
+			args: args{
+				jsonStr: `{"id":"1"}`,
+				paths:   []string{"$.keys[?(@.id==1)]"},
+			},
+			want: want{
+				err: errors.Errorf(errUnsupportedPath, "$.keys[?(@.id==1)]"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := RedactJSONString(tc.args.jsonStr, tc.args.paths)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("RedactJSONString(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("RedactJSONString(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_DeleteJSONPaths(t *testing.T) {
+	type args struct {
+		jsonStr string
+		paths   []string
+	}
+	type want struct {
+		result map[string]interface{}
+		err    error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoPaths": {
+			args: args{
+				jsonStr: `{"id":"1"}`,
+			},
+			want: want{
+				result: map[string]interface{}{"id": "1"},
+			},
+		},
+		"TopLevelKey": {
+			args: args{
+				jsonStr: `{"id":"1","update_time":"now"}`,
+				paths:   []string{"$.update_time"},
+			},
+			want: want{
+				result: map[string]interface{}{"id": "1"},
+			},
+		},
+		"NestedKeyWithoutDollar": {
+			args: args{
+				jsonStr: `{"metadata":{"etag":"a","name":"dan"}}`,
+				paths:   []string{"metadata.etag"},
+			},
+			want: want{
+				result: map[string]interface{}{"metadata": map[string]interface{}{"name": "dan"}},
+			},
+		},
+		"Wildcard": {
+			args: args{
+				jsonStr: `{"keys":[{"id":"a","secret":"a"},{"id":"b","secret":"b"}]}`,
+				paths:   []string{"$.keys[*].secret"},
+			},
+			want: want{
+				result: map[string]interface{}{"keys": []interface{}{
+					map[string]interface{}{"id": "a"},
+					map[string]interface{}{"id": "b"},
+				}},
+			},
+		},
+		"ArrayItemKept": {
+			args: args{
+				jsonStr: `{"keys":["a","b"]}`,
+				paths:   []string{"$.keys[0]"},
+			},
+			want: want{
+				result: map[string]interface{}{"keys": []interface{}{"a", "b"}},
+			},
+		},
+		"MissingPath": {
+			args: args{
+				jsonStr: `{"id":"1"}`,
+				paths:   []string{"$.metadata.etag"},
+			},
+			want: want{
+				result: map[string]interface{}{"id": "1"},
+			},
+		},
+		"UnsupportedPath": {
+			args: args{
+				jsonStr: `{"id":"1"}`,
+				paths:   []string{"$.keys[?(@.id==1)]"},
+			},
+			want: want{
+				result: map[string]interface{}{"id": "1"},
+				err:    errors.Errorf(errUnsupportedPath, "$.keys[?(@.id==1)]"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := JsonStringToMap(tc.args.jsonStr)
+			err := DeleteJSONPaths(got, tc.args.paths)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("DeleteJSONPaths(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("DeleteJSONPaths(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package json
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+const (
+	errParseSchema    = "cannot parse the JSON Schema"
+	errSchemaDocument = "cannot parse the document validated against the JSON Schema"
+	errSchemaRef      = "cannot load %s, only references within the schema are supported"
+	errSchemaInvalid  = "%s %s"
+
+	// schemaURL is the URL the schemas are compiled from, which their references
+	// within the schema are resolved against.
+	schemaURL = "mem:///schema.json"
+
+	// maxCompiledSchemas bounds the number of compiled schemas kept for reuse.
+	maxCompiledSchemas = 100
+)
+
+// compiledSchemas keeps the schemas compiled, by their source, so that every
+// response to the same mapping isn't validated against a schema compiled again.
+var compiledSchemas = &schemaCache{schemas: map[string]*jsonschema.Schema{}}
+
+type schemaCache struct {
+	mu      sync.Mutex
+	schemas map[string]*jsonschema.Schema
+}
+
+// get returns the compiled schema, compiling it when it isn't cached yet. The
+// cache is emptied once full, as schemas only change when their mappings do.
+func (c *schemaCache) get(schema string) (*jsonschema.Schema, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if compiled, ok := c.schemas[schema]; ok {
+		return compiled, nil
+	}
+
+	compiled, err := CompileSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.schemas) >= maxCompiledSchemas {
+		c.schemas = map[string]*jsonschema.Schema{}
+	}
+	c.schemas[schema] = compiled
+	return compiled, nil
+}
+
+// CompileSchema compiles the JSON Schema, of draft 2020-12 unless its $schema says
+// otherwise. Formats are asserted, and references to other documents, which would
+// be loaded by the provider, are rejected.
+func CompileSchema(schema string) (*jsonschema.Schema, error) {
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+	compiler.AssertFormat = true
+	compiler.LoadURL = func(url string) (io.ReadCloser, error) {
+		return nil, errors.Errorf(errSchemaRef, url)
+	}
+
+	if err := compiler.AddResource(schemaURL, strings.NewReader(schema)); err != nil {
+		return nil, errors.Wrap(err, errParseSchema)
+	}
+	compiled, err := compiler.Compile(schemaURL)
+	if err != nil {
+		return nil, errors.Wrap(err, errParseSchema)
+	}
+	return compiled, nil
+}
+
+// ValidateSchema checks that the JSON document is valid against the JSON Schema,
+// returning the first violation found.
+func ValidateSchema(document, schema string) error {
+	compiled, err := compiledSchemas.get(schema)
+	if err != nil {
+		return err
+	}
+
+	var doc interface{}
+	if err := Decode(document, &doc); err != nil {
+		return errors.Wrap(err, errSchemaDocument)
+	}
+
+	err = compiled.Validate(doc)
+	var validationErr *jsonschema.ValidationError
+	if errors.As(err, &validationErr) {
+		violation := firstViolation(validationErr)
+		return errors.Errorf(errSchemaInvalid, instancePath(violation.InstanceLocation), violation.Message)
+	}
+	return err
+}
+
+// firstViolation returns the innermost cause of the first violation.
+func firstViolation(err *jsonschema.ValidationError) *jsonschema.ValidationError {
+	for len(err.Causes) > 0 {
+		err = err.Causes[0]
+	}
+	return err
+}
+
+// instancePath formats the JSON Pointer of a value, e.g. `/tags/1`, as a JSONPath,
+// e.g. `$.tags[1]`, like the paths reported before the violation was found.
+func instancePath(pointer string) string {
+	path := "$"
+	if pointer == "" {
+		return path
+	}
+
+	for _, segment := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		if _, err := strconv.Atoi(segment); err == nil {
+			path += "[" + segment + "]"
+			continue
+		}
+		path += "." + strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+	}
+	return path
+}
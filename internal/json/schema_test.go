@@ -0,0 +1,199 @@
+package json
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_ValidateSchema(t *testing.T) {
+	const userSchema = `{
+		"type": "object",
+		"required": ["id", "username"],
+		"properties": {
+			"id": {"type": "integer", "minimum": 1},
+			"username": {"type": "string", "minLength": 3, "pattern": "^[a-z_]+$"},
+			"role": {"enum": ["admin", "guest"]},
+			"tags": {"type": "array", "maxItems": 2, "items": {"type": "string"}},
+			"manager": {"type": ["object", "null"]},
+			"email": {"type": "string", "format": "email"},
+			"address": {"$ref": "#/$defs/address"},
+			"contact": {"oneOf": [{"required": ["email"]}, {"required": ["phone"]}]}
+		},
+		"$defs": {
+			"address": {"type": "object", "required": ["city"]}
+		},
+		"additionalProperties": false
+	}`
+
+	type args struct {
+		document string
+		schema   string
+	}
+	type want struct {
+		err error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Valid": {
+			args: args{
+				document: `{"id":123,"username":"john_doe","role":"admin","tags":["a","b"],"manager":null}`,
+				schema:   userSchema,
+			},
+		},
+		"NotJSON": {
+			args: args{
+				document: `<html><body>Service Unavailable</body></html>`,
+				schema:   userSchema,
+			},
+			want: want{
+				err: errors.Wrap(errors.New("invalid character '<' looking for beginning of value"), errSchemaDocument),
+			},
+		},
+		"WrongType": {
+			args: args{
+				document: `["john_doe"]`,
+				schema:   userSchema,
+			},
+			want: want{
+				err: errors.New("$ expected object, but got array"),
+			},
+		},
+		"MissingRequired": {
+			args: args{
+				document: `{"id":123}`,
+				schema:   userSchema,
+			},
+			want: want{
+				err: errors.New("$ missing properties: 'username'"),
+			},
+		},
+		"NotAnInteger": {
+			args: args{
+				document: `{"id":1.5,"username":"john_doe"}`,
+				schema:   userSchema,
+			},
+			want: want{
+				err: errors.New("$.id expected integer, but got number"),
+			},
+		},
+		"BelowMinimum": {
+			args: args{
+				document: `{"id":0,"username":"john_doe"}`,
+				schema:   userSchema,
+			},
+			want: want{
+				err: errors.New("$.id must be >= 1 but found 0"),
+			},
+		},
+		"PatternMismatch": {
+			args: args{
+				document: `{"id":123,"username":"John Doe"}`,
+				schema:   userSchema,
+			},
+			want: want{
+				err: errors.New("$.username does not match pattern '^[a-z_]+$'"),
+			},
+		},
+		"NotInEnum": {
+			args: args{
+				document: `{"id":123,"username":"john_doe","role":"owner"}`,
+				schema:   userSchema,
+			},
+			want: want{
+				err: errors.New(`$.role value must be one of "admin", "guest"`),
+			},
+		},
+		"InvalidItem": {
+			args: args{
+				document: `{"id":123,"username":"john_doe","tags":["a",2]}`,
+				schema:   userSchema,
+			},
+			want: want{
+				err: errors.New("$.tags[1] expected string, but got number"),
+			},
+		},
+		"TooManyItems": {
+			args: args{
+				document: `{"id":123,"username":"john_doe","tags":["a","b","c"]}`,
+				schema:   userSchema,
+			},
+			want: want{
+				err: errors.New("$.tags maximum 2 items required, but found 3 items"),
+			},
+		},
+		"AdditionalProperty": {
+			args: args{
+				document: `{"id":123,"username":"john_doe","password":"s3cr3t"}`,
+				schema:   userSchema,
+			},
+			want: want{
+				err: errors.New("$ additionalProperties 'password' not allowed"),
+			},
+		},
+		"InvalidFormat": {
+			args: args{
+				document: `{"id":123,"username":"john_doe","email":"john.doe"}`,
+				schema:   userSchema,
+			},
+			want: want{
+				err: errors.New("$.email 'john.doe' is not valid 'email'"),
+			},
+		},
+		"InvalidReferencedSchema": {
+			args: args{
+				document: `{"id":123,"username":"john_doe","address":{"street":"Main St"}}`,
+				schema:   userSchema,
+			},
+			want: want{
+				err: errors.New("$.address missing properties: 'city'"),
+			},
+		},
+		"OneOfMatchesBoth": {
+			args: args{
+				document: `{"id":123,"username":"john_doe","contact":{"email":"john.doe@example.com","phone":"555-0100"}}`,
+				schema:   userSchema,
+			},
+			want: want{
+				err: errors.New("$.contact valid against schemas at indexes 0 and 1"),
+			},
+		},
+		"LargeInteger": {
+			args: args{
+				document: `{"id":12345678901234567890,"username":"john_doe"}`,
+				schema:   userSchema,
+			},
+		},
+		"InvalidSchema": {
+			args: args{
+				document: `{"id":123}`,
+				schema:   `{"type":`,
+			},
+			want: want{
+				err: errors.Wrap(errors.New("jsonschema: invalid json mem:///schema.json: unexpected EOF"), errParseSchema),
+			},
+		},
+		"RemoteReference": {
+			args: args{
+				document: `{"id":123}`,
+				schema:   `{"$ref":"https://example.com/user.json"}`,
+			},
+			want: want{
+				err: errors.Wrap(errors.New("jsonschema mem:///schema.json compilation failed: "+
+					"cannot load https://example.com/user.json, only references within the schema are supported"), errParseSchema),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := ValidateSchema(tc.args.document, tc.args.schema)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Errorf("ValidateSchema(...): -want error, +got error: %s", diff)
+			}
+		})
+	}
+}
@@ -3,11 +3,15 @@ package json
 import (
 	"bytes"
 	"encoding/json"
+	"io"
+	"strings"
 )
 
-func Contains(container, containee map[string]interface{}) bool {
-	for key, value := range containee {
-		if containerValue, exists := container[key]; !exists || !deepEqual(value, containerValue) {
+// Contains reports whether the container has every field of the containee, with an
+// equal value according to the options.
+func Contains(container, containee map[string]interface{}, opts CompareOptions) bool {
+	for key, value := range withoutNulls(containee, opts) {
+		if containerValue, exists := container[key]; !exists || !equal(value, containerValue, opts) {
 			return false
 		}
 	}
@@ -19,9 +23,18 @@ func IsJSONString(jsonStr string) bool {
 	return json.Unmarshal([]byte(jsonStr), &js) == nil
 }
 
+// JsonStringToMap parses a JSON object, keeping its numbers as json.Number so that
+// large integers, such as 64-bit IDs, don't lose precision.
 func JsonStringToMap(jsonStr string) map[string]interface{} {
 	var jsonData map[string]interface{}
-	_ = json.Unmarshal([]byte(jsonStr), &jsonData)
+	decoder := json.NewDecoder(strings.NewReader(jsonStr))
+	decoder.UseNumber()
+	if err := decoder.Decode(&jsonData); err != nil {
+		return nil
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil
+	}
 	return jsonData
 }
 
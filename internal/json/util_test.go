@@ -1,6 +1,7 @@
 package json
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
@@ -52,6 +53,7 @@ func Test_Contains(t *testing.T) {
 	type args struct {
 		container map[string]interface{}
 		containee map[string]interface{}
+		opts      CompareOptions
 	}
 	type want struct {
 		result bool
@@ -87,10 +89,115 @@ func Test_Contains(t *testing.T) {
 				result: false,
 			},
 		},
+		"ArrayOrderDiffers": {
+			args: args{
+				container: map[string]any{"groups": []any{"dev", "admin"}},
+				containee: map[string]any{"groups": []any{"admin", "dev"}},
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"IgnoreArrayOrder": {
+			args: args{
+				container: map[string]any{"groups": []any{"dev", map[string]any{"name": "admin"}}},
+				containee: map[string]any{"groups": []any{map[string]any{"name": "admin"}, "dev"}},
+				opts:      CompareOptions{IgnoreArrayOrder: true},
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"IgnoreArrayOrderDuplicates": {
+			args: args{
+				container: map[string]any{"groups": []any{"dev", "admin"}},
+				containee: map[string]any{"groups": []any{"dev", "dev"}},
+				opts:      CompareOptions{IgnoreArrayOrder: true},
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"NullMissing": {
+			args: args{
+				container: map[string]any{"username": "john_doe", "profile": map[string]any{"bio": "hi"}},
+				containee: map[string]any{"username": "john_doe", "email": nil, "profile": map[string]any{"bio": "hi"}},
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"TreatNullAsAbsent": {
+			args: args{
+				container: map[string]any{"username": "john_doe", "profile": map[string]any{"bio": "hi"}},
+				containee: map[string]any{"username": "john_doe", "email": nil, "profile": map[string]any{"bio": "hi", "avatar": nil}},
+				opts:      CompareOptions{TreatNullAsAbsent: true},
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"NumericTolerance": {
+			args: args{
+				container: map[string]any{"price": 9.995, "sizes": []any{1.0, 2.01}},
+				containee: map[string]any{"price": 10.0, "sizes": []any{1.0, 2.0}},
+				opts:      CompareOptions{NumericTolerance: 0.01},
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"NumericToleranceExceeded": {
+			args: args{
+				container: map[string]any{"price": 9.9},
+				containee: map[string]any{"price": 10.0},
+				opts:      CompareOptions{NumericTolerance: 0.01},
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"LargeIntegersDiffer": {
+			args: args{
+				container: JsonStringToMap(`{"id":9007199254740993}`),
+				containee: JsonStringToMap(`{"id":9007199254740992}`),
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"LargeIntegersEqual": {
+			args: args{
+				container: JsonStringToMap(`{"id":9007199254740993,"name":"robot"}`),
+				containee: JsonStringToMap(`{"id":9007199254740993}`),
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"NumberNotations": {
+			args: args{
+				container: JsonStringToMap(`{"replicas":3,"ratio":0.5}`),
+				containee: map[string]any{"replicas": 3.0, "ratio": json.Number("5e-1")},
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"NumberToleranceDecoded": {
+			args: args{
+				container: JsonStringToMap(`{"price":9.995}`),
+				containee: JsonStringToMap(`{"price":10}`),
+				opts:      CompareOptions{NumericTolerance: 0.01},
+			},
+			want: want{
+				result: true,
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			got := Contains(tc.args.container, tc.args.containee)
+			got := Contains(tc.args.container, tc.args.containee, tc.args.opts)
 			if diff := cmp.Diff(tc.want.result, got); diff != "" {
 				t.Fatalf("Contains(...): -want result, +got result: %s", diff)
 			}
@@ -155,6 +262,20 @@ func Test_JsonStringToMap(t *testing.T) {
 				result: map[string]any{"email": "john.doe@example.com", "username": "john_doe"},
 			},
 		},
+		"NumbersKept": {
+			args: args{
+				jsonStr: `{"id":9007199254740993,"price":9.99}`,
+			},
+			want: want{
+				result: map[string]any{"id": json.Number("9007199254740993"), "price": json.Number("9.99")},
+			},
+		},
+		"TrailingData": {
+			args: args{
+				jsonStr: `{"username":"john_doe"} {}`,
+			},
+			want: want{},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	errGetConfigMap      = "cannot get config map %s/%s"
+	errConfigMapKeyEmpty = "key %s not found in config map %s/%s"
+)
+
+// GetConfigMapValue returns the value stored under the key in the data or binary
+// data of the referenced ConfigMap.
+func GetConfigMapValue(ctx context.Context, kube client.Client, namespace, name, key string) (string, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, configMap); err != nil {
+		return "", errors.Wrapf(err, errGetConfigMap, namespace, name)
+	}
+
+	if value, ok := configMap.Data[key]; ok {
+		return value, nil
+	}
+	if value, ok := configMap.BinaryData[key]; ok {
+		return string(value), nil
+	}
+
+	return "", errors.Errorf(errConfigMapKeyEmpty, key, namespace, name)
+}
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Test_GetConfigMapValue(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		kube client.Client
+		key  string
+	}
+	type want struct {
+		value string
+		err   error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Data": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+						obj.(*corev1.ConfigMap).Data = map[string]string{"report.csv": "a,b"}
+						return nil
+					},
+				},
+				key: "report.csv",
+			},
+			want: want{
+				value: "a,b",
+			},
+		},
+		"BinaryData": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+						obj.(*corev1.ConfigMap).BinaryData = map[string][]byte{"logo.png": []byte("\x89PNG")}
+						return nil
+					},
+				},
+				key: "logo.png",
+			},
+			want: want{
+				value: "\x89PNG",
+			},
+		},
+		"ConfigMapNotFound": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+				key: "report.csv",
+			},
+			want: want{
+				err: errors.Wrapf(errBoom, errGetConfigMap, "default", "files"),
+			},
+		},
+		"KeyNotFound": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+				},
+				key: "report.csv",
+			},
+			want: want{
+				err: errors.Errorf(errConfigMapKeyEmpty, "report.csv", "default", "files"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := GetConfigMapValue(context.Background(), tc.args.kube, "default", "files", tc.args.key)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("GetConfigMapValue(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.value, got); diff != "" {
+				t.Fatalf("GetConfigMapValue(...): -want value, +got value: %s", diff)
+			}
+		})
+	}
+}
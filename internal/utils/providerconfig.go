@@ -0,0 +1,327 @@
+package utils
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+const (
+	errParseProxyURL    = "cannot parse proxy URL"
+	errHostAliasIP      = "host alias IP %q is not a valid IP address"
+	errProxyCredentials = "cannot get proxy credentials"
+	errReadSAToken      = "cannot read the service account token"
+	errEmptySAToken     = "service account token %s is empty"
+	errSATokenAudience  = "service account token audience %q must be a single path element"
+	errSATokenPath      = "service account token path %s must be a file under %s"
+	errBasicAuth        = "cannot get basic auth credentials"
+	errOAuth2           = "cannot get OAuth2 client credentials"
+	errKerberos         = "cannot get Kerberos credentials"
+	errTLSCACert        = "cannot get CA bundle"
+	errTLSClientCert    = "cannot get client certificate"
+	proxyUsernameKey    = "username"
+	proxyPasswordKey    = "password"
+	tlsCertKey          = "tls.crt"
+	tlsKeyKey           = "tls.key"
+
+	// defaultSATokenPath is where the token of the pod's ServiceAccount is mounted.
+	defaultSATokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+)
+
+// projectedSATokenDir is where the projected tokens are mounted, by audience. The
+// tokens can only be read from under it, so a ProviderConfig can't make the provider
+// send any other file it can read.
+var projectedSATokenDir = "/var/run/secrets/tokens"
+
+// ProviderConfigOptions returns the Http client options configured by the ProviderConfig,
+// which apply to every resource using it.
+func ProviderConfigOptions(ctx context.Context, kube client.Client, pc *apisv1alpha1.ProviderConfig) ([]httpClient.Option, error) {
+	var opts []httpClient.Option
+
+	if limit := pc.Spec.RateLimit; limit != nil {
+		opts = append(opts, httpClient.WithRateLimit(httpClient.RateLimit{
+			Scope:             pc.Name,
+			RequestsPerSecond: float64(limit.RequestsPerSecond),
+			Burst:             limit.Burst,
+		}))
+	}
+
+	if breaker := pc.Spec.CircuitBreaker; breaker != nil {
+		circuitBreaker := httpClient.CircuitBreaker{
+			Scope:            pc.Name,
+			FailureThreshold: breaker.FailureThreshold,
+		}
+		if breaker.Cooldown != nil {
+			circuitBreaker.Cooldown = breaker.Cooldown.Duration
+		}
+		opts = append(opts, httpClient.WithCircuitBreaker(circuitBreaker))
+	}
+
+	if proxy := pc.Spec.Proxy; proxy != nil {
+		proxyURL, err := proxyURL(ctx, kube, proxy)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, httpClient.WithProxy(httpClient.Proxy{URL: proxyURL, NoProxy: proxy.NoProxy}))
+	}
+
+	if size := pc.Spec.MaxResponseSize; size != nil {
+		opts = append(opts, httpClient.WithMaxResponseSize(size.Value()))
+	}
+
+	if pc.Spec.UserAgent != "" {
+		opts = append(opts, httpClient.WithUserAgent(pc.Spec.UserAgent))
+	}
+
+	if redirects := pc.Spec.Redirects; redirects != nil {
+		opts = append(opts, httpClient.WithRedirectPolicy(httpClient.RedirectPolicy{
+			NoFollow:                 redirects.Follow != nil && !*redirects.Follow,
+			MaxRedirects:             redirects.MaxRedirects,
+			PreserveSensitiveHeaders: redirects.PreserveSensitiveHeaders,
+		}))
+	}
+
+	if pool := pc.Spec.ConnectionPool; pool != nil {
+		connectionPool := httpClient.ConnectionPool{
+			MaxIdleConns:        pool.MaxIdleConns,
+			MaxIdleConnsPerHost: pool.MaxIdleConnsPerHost,
+		}
+		if pool.IdleConnTimeout != nil {
+			connectionPool.IdleConnTimeout = pool.IdleConnTimeout.Duration
+		}
+		opts = append(opts, httpClient.WithConnectionPool(connectionPool))
+	}
+
+	if protocol := pc.Spec.Protocol; protocol != nil {
+		opts = append(opts, httpClient.WithProtocol(httpClient.Protocol{
+			Version: protocol.Version,
+			H2C:     protocol.H2C,
+		}))
+	}
+
+	if len(pc.Spec.HostAliases) > 0 {
+		aliases, err := hostAliases(pc.Spec.HostAliases)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, httpClient.WithHostAliases(aliases))
+	}
+
+	if tls := pc.Spec.TLS; tls != nil {
+		tlsOpts, err := tlsOptions(ctx, kube, tls)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, tlsOpts...)
+	}
+
+	return opts, nil
+}
+
+// ProviderConfigAuthOptions returns the Http client options authorizing the requests
+// as configured by the ProviderConfig, for the resources that don't set their own
+// authentication.
+func ProviderConfigAuthOptions(ctx context.Context, kube client.Client, pc *apisv1alpha1.ProviderConfig) ([]httpClient.Option, error) {
+	var opts []httpClient.Option
+
+	if saToken := pc.Spec.ServiceAccountToken; saToken != nil {
+		token, err := serviceAccountToken(saToken)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, httpClient.WithBearerToken(token))
+	}
+
+	if basicAuth := pc.Spec.BasicAuth; basicAuth != nil {
+		username, err := GetSecretValue(ctx, kube, basicAuth.UsernameSecretRef)
+		if err != nil {
+			return nil, errors.Wrap(err, errBasicAuth)
+		}
+
+		password, err := GetSecretValue(ctx, kube, basicAuth.PasswordSecretRef)
+		if err != nil {
+			return nil, errors.Wrap(err, errBasicAuth)
+		}
+
+		opts = append(opts, httpClient.WithBasicAuth(httpClient.BasicAuth{Username: username, Password: password}))
+	}
+
+	if oauth2 := pc.Spec.OAuth2; oauth2 != nil {
+		clientID, err := GetSecretValue(ctx, kube, oauth2.ClientIDSecretRef)
+		if err != nil {
+			return nil, errors.Wrap(err, errOAuth2)
+		}
+
+		clientSecret, err := GetSecretValue(ctx, kube, oauth2.ClientSecretSecretRef)
+		if err != nil {
+			return nil, errors.Wrap(err, errOAuth2)
+		}
+
+		cfg := httpClient.OAuth2Config{
+			TokenURL:     oauth2.TokenURL,
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			Scopes:       oauth2.Scopes,
+		}
+		if oauth2.DefaultTokenTTL != nil {
+			cfg.DefaultTTL = oauth2.DefaultTokenTTL.Duration
+		}
+
+		opts = append(opts, httpClient.WithOAuth2(cfg))
+	}
+
+	if kerberos := pc.Spec.Kerberos; kerberos != nil {
+		keytab, err := GetSecretValue(ctx, kube, kerberos.KeytabSecretRef)
+		if err != nil {
+			return nil, errors.Wrap(err, errKerberos)
+		}
+
+		config, err := GetSecretValue(ctx, kube, kerberos.ConfigSecretRef)
+		if err != nil {
+			return nil, errors.Wrap(err, errKerberos)
+		}
+
+		opts = append(opts, httpClient.WithKerberos(httpClient.Kerberos{
+			Username: kerberos.Username,
+			Realm:    kerberos.Realm,
+			Keytab:   []byte(keytab),
+			Config:   config,
+			SPN:      kerberos.SPN,
+		}))
+	}
+
+	return opts, nil
+}
+
+// tlsOptions returns the Http client options presenting and trusting the referenced
+// certificates.
+func tlsOptions(ctx context.Context, kube client.Client, tls *apisv1alpha1.TLS) ([]httpClient.Option, error) {
+	var opts []httpClient.Option
+
+	if ref := tls.CACertSecretRef; ref != nil {
+		caBundle, err := GetSecretValue(ctx, kube, *ref)
+		if err != nil {
+			return nil, errors.Wrap(err, errTLSCACert)
+		}
+		opts = append(opts, httpClient.WithCACertificates([]byte(caBundle)))
+	}
+
+	if ref := tls.ClientCertSecretRef; ref != nil {
+		cert, err := GetSecretValue(ctx, kube, xpv1.SecretKeySelector{SecretReference: *ref, Key: tlsCertKey})
+		if err != nil {
+			return nil, errors.Wrap(err, errTLSClientCert)
+		}
+
+		key, err := GetSecretValue(ctx, kube, xpv1.SecretKeySelector{SecretReference: *ref, Key: tlsKeyKey})
+		if err != nil {
+			return nil, errors.Wrap(err, errTLSClientCert)
+		}
+
+		opts = append(opts, httpClient.WithClientCertificate([]byte(cert), []byte(key)))
+	}
+
+	return opts, nil
+}
+
+// TemplateEnvironment returns the values of the environment variables that the
+// ProviderConfig makes available to templates, by name. Unset variables are left out.
+func TemplateEnvironment(pc *apisv1alpha1.ProviderConfig) map[string]string {
+	environment := map[string]string{}
+	for _, name := range pc.Spec.AllowedEnvVars {
+		if value, ok := os.LookupEnv(name); ok {
+			environment[name] = value
+		}
+	}
+	return environment
+}
+
+// hostAliases maps each hostname of the aliases to its IP address.
+func hostAliases(aliases []apisv1alpha1.HostAlias) (map[string]string, error) {
+	result := map[string]string{}
+	for _, alias := range aliases {
+		if net.ParseIP(alias.IP) == nil {
+			return nil, errors.Errorf(errHostAliasIP, alias.IP)
+		}
+		for _, hostname := range alias.Hostnames {
+			result[hostname] = alias.IP
+		}
+	}
+	return result, nil
+}
+
+// proxyURL returns the URL of the proxy, with the credentials of the referenced secret
+// as its user info.
+func proxyURL(ctx context.Context, kube client.Client, proxy *apisv1alpha1.Proxy) (*url.URL, error) {
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		return nil, errors.Wrap(err, errParseProxyURL)
+	}
+
+	ref := proxy.CredentialsSecretRef
+	if ref == nil {
+		return proxyURL, nil
+	}
+
+	username, err := GetSecretValue(ctx, kube, xpv1.SecretKeySelector{SecretReference: *ref, Key: proxyUsernameKey})
+	if err != nil {
+		return nil, errors.Wrap(err, errProxyCredentials)
+	}
+
+	password, err := GetSecretValue(ctx, kube, xpv1.SecretKeySelector{SecretReference: *ref, Key: proxyPasswordKey})
+	if err != nil {
+		return nil, errors.Wrap(err, errProxyCredentials)
+	}
+
+	proxyURL.User = url.UserPassword(username, password)
+	return proxyURL, nil
+}
+
+// serviceAccountToken reads the projected ServiceAccount token. It's read on every
+// call, since the kubelet rotates it.
+func serviceAccountToken(saToken *apisv1alpha1.ServiceAccountToken) (string, error) {
+	path, err := saTokenPath(saToken)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := os.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrap(err, errReadSAToken)
+	}
+
+	trimmed := strings.TrimSpace(string(token))
+	if trimmed == "" {
+		return "", errors.Errorf(errEmptySAToken, path)
+	}
+	return trimmed, nil
+}
+
+// saTokenPath returns the path of the token file, which must be the default token of
+// the pod or a file under the projected token directory.
+func saTokenPath(saToken *apisv1alpha1.ServiceAccountToken) (string, error) {
+	switch {
+	case saToken.Path != "":
+		path := filepath.Clean(saToken.Path)
+		if rel, err := filepath.Rel(projectedSATokenDir, path); err != nil || !filepath.IsAbs(path) || !filepath.IsLocal(rel) {
+			return "", errors.Errorf(errSATokenPath, saToken.Path, projectedSATokenDir)
+		}
+		return path, nil
+	case saToken.Audience != "":
+		if !filepath.IsLocal(saToken.Audience) || filepath.Base(saToken.Audience) != saToken.Audience {
+			return "", errors.Errorf(errSATokenAudience, saToken.Audience)
+		}
+		return filepath.Join(projectedSATokenDir, saToken.Audience, "token"), nil
+	default:
+		return defaultSATokenPath, nil
+	}
+}
@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"net/http"
+
+	"github.com/crossplane-contrib/provider-http/internal/json"
+)
+
+// RedactHeaders returns a copy of the headers with the values of the named headers
+// replaced, regardless of their case.
+func RedactHeaders(headers map[string][]string, names []string) map[string][]string {
+	if len(headers) == 0 || len(names) == 0 {
+		return headers
+	}
+
+	redacted := make(map[string]bool, len(names))
+	for _, name := range names {
+		redacted[http.CanonicalHeaderKey(name)] = true
+	}
+
+	result := make(map[string][]string, len(headers))
+	for key, values := range headers {
+		if !redacted[http.CanonicalHeaderKey(key)] {
+			result[key] = values
+			continue
+		}
+
+		masked := make([]string, len(values))
+		for i := range values {
+			masked[i] = json.RedactedPlaceholder
+		}
+		result[key] = masked
+	}
+
+	return result
+}
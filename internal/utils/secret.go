@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"context"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	errGetSecret      = "cannot get secret %s/%s"
+	errSecretKeyEmpty = "key %s not found in secret %s/%s"
+)
+
+// GetSecretValue returns the value stored under the selector's key in the referenced Secret.
+func GetSecretValue(ctx context.Context, kube client.Client, selector xpv1.SecretKeySelector) (string, error) {
+	secret := &corev1.Secret{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: selector.Name, Namespace: selector.Namespace}, secret); err != nil {
+		return "", errors.Wrapf(err, errGetSecret, selector.Namespace, selector.Name)
+	}
+
+	value, ok := secret.Data[selector.Key]
+	if !ok {
+		return "", errors.Errorf(errSecretKeyEmpty, selector.Key, selector.Namespace, selector.Name)
+	}
+
+	return string(value), nil
+}
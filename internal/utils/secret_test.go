@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var testSecretSelector = xpv1.SecretKeySelector{
+	SecretReference: xpv1.SecretReference{
+		Name:      "creds",
+		Namespace: "crossplane-system",
+	},
+	Key: "token",
+}
+
+func Test_GetSecretValue(t *testing.T) {
+	type args struct {
+		kube     client.Client
+		selector xpv1.SecretKeySelector
+	}
+	type want struct {
+		value string
+		err   error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Success": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+						obj.(*corev1.Secret).Data = map[string][]byte{"token": []byte("s3cr3t")}
+						return nil
+					},
+				},
+				selector: testSecretSelector,
+			},
+			want: want{
+				value: "s3cr3t",
+			},
+		},
+		"SecretNotFound": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+				selector: testSecretSelector,
+			},
+			want: want{
+				err: errors.Wrapf(errBoom, errGetSecret, "crossplane-system", "creds"),
+			},
+		},
+		"KeyNotFound": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+				},
+				selector: testSecretSelector,
+			},
+			want: want{
+				err: errors.Errorf(errSecretKeyEmpty, "token", "crossplane-system", "creds"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := GetSecretValue(context.Background(), tc.args.kube, tc.args.selector)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("GetSecretValue(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.value, got); diff != "" {
+				t.Fatalf("GetSecretValue(...): -want value, +got value: %s", diff)
+			}
+		})
+	}
+}
@@ -2,13 +2,19 @@ package utils
 
 import (
 	"context"
+	"net/http"
+	"time"
 
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
 	ErrFailedToSetStatus = "failed to update status"
+
+	// DefaultETagHeader is the response header holding the entity tag by default.
+	DefaultETagHeader = "ETag"
 )
 
 type SetRequestStatusFunc func()
@@ -77,6 +83,51 @@ func (rr *RequestResource) SetCache() SetRequestStatusFunc {
 	}
 }
 
+// SetETag stores the value of the response header holding the entity tag, if present.
+// The header defaults to ETag.
+func (rr *RequestResource) SetETag(header string) SetRequestStatusFunc {
+	if header == "" {
+		header = DefaultETagHeader
+	}
+
+	return func() {
+		if tagged, ok := rr.Resource.(ETagSetter); ok {
+			if etag := http.Header(rr.HttpResponse.Headers).Get(header); etag != "" {
+				tagged.SetETag(etag)
+			}
+		}
+	}
+}
+
+// SetTiming stores when the request was sent and its response received, if it was.
+func (rr *RequestResource) SetTiming() SetRequestStatusFunc {
+	return func() {
+		if timed, ok := rr.Resource.(TimingSetter); ok {
+			if !rr.HttpResponse.RequestTime.IsZero() {
+				timed.SetTiming(rr.HttpResponse.RequestTime, rr.HttpResponse.ResponseTime)
+			}
+		}
+	}
+}
+
+// SetDiff stores the differences between the desired and the observed state.
+func (rr *RequestResource) SetDiff(diff string) SetRequestStatusFunc {
+	return func() {
+		if differ, ok := rr.Resource.(DiffSetter); ok {
+			differ.SetDiff(diff)
+		}
+	}
+}
+
+// SetDesiredState stores the desired state the observed state was compared to.
+func (rr *RequestResource) SetDesiredState(desiredState string) SetRequestStatusFunc {
+	return func() {
+		if setter, ok := rr.Resource.(DesiredStateSetter); ok {
+			setter.SetDesiredState(desiredState)
+		}
+	}
+}
+
 func (rr *RequestResource) SetError(err error) SetRequestStatusFunc {
 	return func() {
 		if resourceSetErr, ok := rr.Resource.(ErrorSetter); ok {
@@ -85,6 +136,20 @@ func (rr *RequestResource) SetError(err error) SetRequestStatusFunc {
 	}
 }
 
+// SetRateLimited records that the request was rate limited, without counting it as
+// a failure, along with when the API allows the next request, if it said so.
+func (rr *RequestResource) SetRateLimited(err *RateLimitedError) SetRequestStatusFunc {
+	return func() {
+		if setter, ok := rr.Resource.(RateLimitedSetter); ok {
+			var until *metav1.Time
+			if err.RetryAfter > 0 {
+				until = &metav1.Time{Time: time.Now().Add(err.RetryAfter)}
+			}
+			setter.SetRateLimited(err.Error(), until)
+		}
+	}
+}
+
 func (rr *RequestResource) ResetFailures() SetRequestStatusFunc {
 	return func() {
 		if resetter, ok := rr.Resource.(ResetFailures); ok {
@@ -107,10 +172,30 @@ type SyncedSetter interface {
 	SetSynced(synced bool)
 }
 
+type ETagSetter interface {
+	SetETag(etag string)
+}
+
+type DiffSetter interface {
+	SetDiff(diff string)
+}
+
+type DesiredStateSetter interface {
+	SetDesiredState(desiredState string)
+}
+
+type TimingSetter interface {
+	SetTiming(requestTime, responseTime time.Time)
+}
+
 type ErrorSetter interface {
 	SetError(err error)
 }
 
+type RateLimitedSetter interface {
+	SetRateLimited(message string, until *metav1.Time)
+}
+
 type ResetFailures interface {
 	ResetFailures()
 }
@@ -3,11 +3,13 @@ package utils
 import (
 	"context"
 	"testing"
+	"time"
 
 	v1alpha1_disposable "github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha1"
 	v1alpha1_request "github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
@@ -202,6 +204,53 @@ func Test_SetRequestResourceStatus(t *testing.T) {
 	}
 }
 
+func Test_SetTiming(t *testing.T) {
+	requestTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	type want struct {
+		lastRequestTime  *metav1.Time
+		lastResponseTime *metav1.Time
+		latencyMillis    int64
+	}
+	cases := map[string]struct {
+		response httpClient.HttpResponse
+		want     want
+	}{
+		"Sent": {
+			response: httpClient.HttpResponse{
+				StatusCode:   200,
+				RequestTime:  requestTime,
+				ResponseTime: requestTime.Add(1500 * time.Millisecond),
+			},
+			want: want{
+				lastRequestTime:  &metav1.Time{Time: requestTime},
+				lastResponseTime: &metav1.Time{Time: requestTime.Add(1500 * time.Millisecond)},
+				latencyMillis:    1500,
+			},
+		},
+		"NotSent": {
+			response: httpClient.HttpResponse{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1_request.Request{}
+			rr := RequestResource{Resource: cr, HttpResponse: tc.response}
+			rr.SetTiming()()
+
+			if diff := cmp.Diff(tc.want.lastRequestTime, cr.Status.LastRequestTime); diff != "" {
+				t.Errorf("SetTiming(...): -want last request time, +got last request time: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.lastResponseTime, cr.Status.LastResponseTime); diff != "" {
+				t.Errorf("SetTiming(...): -want last response time, +got last response time: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.latencyMillis, cr.Status.LatencyMillis); diff != "" {
+				t.Errorf("SetTiming(...): -want latency, +got latency: %s", diff)
+			}
+		})
+	}
+}
+
 func Test_DisposableRequest_SetRequestResourceStatus(t *testing.T) {
 	type args struct {
 		rr          RequestResource
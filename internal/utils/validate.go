@@ -1,17 +1,90 @@
 package utils
 
 import (
+	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	"github.com/crossplane-contrib/provider-http/internal/cel"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	"github.com/crossplane-contrib/provider-http/internal/json"
 )
 
 const (
-	errEmptyMethod = "no method is specified"
-	ErrInvalidURL  = "invalid url %s"
-	ErrStatusCode  = "HTTP %s request failed with status code: %s"
+	errEmptyMethod    = "no method is specified"
+	errSuccessExpr    = "cannot evaluate the success expression"
+	errAlreadyExists  = "cannot evaluate the already exists condition"
+	ErrInvalidURL     = "invalid url %s"
+	ErrStatusCode     = "HTTP %s request failed with status code: %s"
+	ErrStatusCodeBody = "HTTP %s request failed with status code: %s, response body: %s"
+	ErrRateLimited    = "HTTP %s request rate limited"
+	ErrRateLimitedFor = "HTTP %s request rate limited, retrying after %s"
+
+	// MaxErrorBodyLength is the length in bytes up to which the response body is
+	// included in the error of a failed request.
+	MaxErrorBodyLength = 512
 )
 
+// StatusCodeError returns the error of a request that failed with the status code.
+// The response body, truncated to MaxErrorBodyLength, is included so that the reason
+// for the failure shows up on the resource conditions.
+func StatusCodeError(method string, statusCode int, body string) error {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return errors.Errorf(ErrStatusCode, method, strconv.Itoa(statusCode))
+	}
+
+	return errors.Errorf(ErrStatusCodeBody, method, strconv.Itoa(statusCode), truncate(body, MaxErrorBodyLength))
+}
+
+// RateLimitedError means that the API answered a request with 429 Too Many Requests.
+// It's not a failure of the request, which is sent again once the API allows it.
+type RateLimitedError struct {
+	Method string
+	// RetryAfter is how long the API asked to wait before the next request, if
+	// it did.
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	if e.RetryAfter == 0 {
+		return errors.Errorf(ErrRateLimited, e.Method).Error()
+	}
+	return errors.Errorf(ErrRateLimitedFor, e.Method, e.RetryAfter).Error()
+}
+
+// IsRateLimited reports whether the status code means that the request was rate limited.
+func IsRateLimited(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests
+}
+
+// RateLimitError returns the error of a request that was rate limited, honoring the
+// Retry-After header of the response.
+func RateLimitError(method string, headers map[string][]string) *RateLimitedError {
+	retryAfter, _ := httpClient.RetryAfter(headers)
+	return &RateLimitedError{Method: method, RetryAfter: retryAfter.Round(time.Second)}
+}
+
+// truncate shortens the string to at most size bytes, without splitting a character.
+func truncate(s string, size int) string {
+	if len(s) <= size {
+		return s
+	}
+
+	end := size
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	return s[:end] + "..."
+}
+
 func IsRequestValid(method string, url string) error {
 	if method == "" {
 		return errors.New(errEmptyMethod)
@@ -34,6 +107,90 @@ func IsHTTPError(statusCode int) bool {
 	return statusCode >= 400 && statusCode < 600
 }
 
+// IsHTTPSuccessFor checks if an HTTP status code indicates success, given the status codes
+// expected on success. Any 2xx status code indicates success when none are expected.
+func IsHTTPSuccessFor(statusCode int, expectedStatusCodes []int) bool {
+	if len(expectedStatusCodes) == 0 {
+		return IsHTTPSuccess(statusCode)
+	}
+
+	for _, expected := range expectedStatusCodes {
+		if statusCode == expected {
+			return true
+		}
+	}
+	return false
+}
+
+// IsHTTPErrorFor checks if an HTTP status code indicates an error, given the status codes
+// expected on success. Any other status code indicates an error when some are expected.
+func IsHTTPErrorFor(statusCode int, expectedStatusCodes []int) bool {
+	if len(expectedStatusCodes) == 0 {
+		return IsHTTPError(statusCode)
+	}
+
+	return !IsHTTPSuccessFor(statusCode, expectedStatusCodes)
+}
+
+// IsResponseSuccess checks if a response indicates success. A success expression, when
+// given, decides in place of the status codes: the CEL expression is evaluated with
+// the response, whose body is parsed if it's JSON, bound to its `response` variable,
+// e.g. `response.statusCode == 200 && response.body.ok`.
+func IsResponseSuccess(response httpClient.HttpResponse, successExpression string, expectedStatusCodes []int) (bool, error) {
+	if successExpression == "" {
+		return IsHTTPSuccessFor(response.StatusCode, expectedStatusCodes), nil
+	}
+
+	responseMap, err := json.StructToMap(v1alpha1.Response{
+		StatusCode: response.StatusCode,
+		Body:       response.Body,
+		Headers:    response.Headers,
+	})
+	if err != nil {
+		return false, errors.Wrap(err, errSuccessExpr)
+	}
+	json.ConvertJSONStringsToMaps(&responseMap)
+
+	success, err := cel.ParseBool(successExpression, responseMap, nil)
+	return success, errors.Wrap(err, errSuccessExpr)
+}
+
+// IsAlreadyExists checks if a response to a create request means that the object
+// already exists, when it matches either the status codes of the check, by default
+// 409, or its condition. Without a check, no response does.
+func IsAlreadyExists(check *v1alpha1.AlreadyExistsCheck, response httpClient.HttpResponse) (bool, error) {
+	if check == nil {
+		return false, nil
+	}
+
+	statusCodes := check.StatusCodes
+	if len(statusCodes) == 0 {
+		statusCodes = []int{http.StatusConflict}
+	}
+	for _, code := range statusCodes {
+		if code == response.StatusCode {
+			return true, nil
+		}
+	}
+
+	if check.Condition == "" {
+		return false, nil
+	}
+
+	responseMap, err := json.StructToMap(v1alpha1.Response{
+		StatusCode: response.StatusCode,
+		Body:       response.Body,
+		Headers:    response.Headers,
+	})
+	if err != nil {
+		return false, errors.Wrap(err, errAlreadyExists)
+	}
+	json.ConvertJSONStringsToMaps(&responseMap)
+
+	exists, err := jq.ParseBool(check.Condition, responseMap)
+	return exists, errors.Wrap(err, errAlreadyExists)
+}
+
 func IsUrlValid(input string) bool {
 	u, err := url.ParseRequestURI(input)
 	return err == nil && u.Scheme != "" && u.Host != ""
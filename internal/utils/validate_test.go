@@ -2,11 +2,15 @@ package utils
 
 import (
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 )
 
 func Test_IsRequestValid(t *testing.T) {
@@ -126,6 +130,296 @@ func Test_IsHTTPError(t *testing.T) {
 	}
 }
 
+func Test_IsHTTPSuccessFor(t *testing.T) {
+	type args struct {
+		statusCode          int
+		expectedStatusCodes []int
+	}
+	type want struct {
+		result bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"DefaultResultTrue": {
+			args: args{
+				statusCode: 201,
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"ExpectedResultTrue": {
+			args: args{
+				statusCode:          304,
+				expectedStatusCodes: []int{200, 304},
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"UnexpectedResultFalse": {
+			args: args{
+				statusCode:          201,
+				expectedStatusCodes: []int{200},
+			},
+			want: want{
+				result: false,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsHTTPSuccessFor(tc.args.statusCode, tc.args.expectedStatusCodes)
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("IsHTTPSuccessFor(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_IsResponseSuccess(t *testing.T) {
+	type args struct {
+		response            httpClient.HttpResponse
+		successExpression   string
+		expectedStatusCodes []int
+	}
+	type want struct {
+		result bool
+		err    error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"StatusCodes": {
+			args: args{
+				response:            httpClient.HttpResponse{StatusCode: 304, Body: `{"ok":false}`},
+				expectedStatusCodes: []int{200, 304},
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"BodyLevelError": {
+			args: args{
+				response:          httpClient.HttpResponse{StatusCode: 200, Body: `{"ok":false}`},
+				successExpression: "response.body.ok",
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"BodyLevelSuccess": {
+			args: args{
+				response:            httpClient.HttpResponse{StatusCode: 500, Body: `{"ok":true}`},
+				successExpression:   "response.statusCode < 600 && response.body.ok",
+				expectedStatusCodes: []int{200},
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"NotBoolean": {
+			args: args{
+				response:          httpClient.HttpResponse{StatusCode: 200, Body: `{"ok":"yes"}`},
+				successExpression: "response.body.ok",
+			},
+			want: want{
+				err: errors.Wrap(errors.New(`CEL expression response.body.ok returned yes instead of a bool`), errSuccessExpr),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := IsResponseSuccess(tc.args.response, tc.args.successExpression, tc.args.expectedStatusCodes)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("IsResponseSuccess(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("IsResponseSuccess(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_IsAlreadyExists(t *testing.T) {
+	type args struct {
+		check    *v1alpha1.AlreadyExistsCheck
+		response httpClient.HttpResponse
+	}
+	type want struct {
+		result bool
+		err    error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoCheck": {
+			args: args{
+				response: httpClient.HttpResponse{StatusCode: 409},
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"DefaultStatusCode": {
+			args: args{
+				check:    &v1alpha1.AlreadyExistsCheck{},
+				response: httpClient.HttpResponse{StatusCode: 409},
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"StatusCodes": {
+			args: args{
+				check:    &v1alpha1.AlreadyExistsCheck{StatusCodes: []int{422}},
+				response: httpClient.HttpResponse{StatusCode: 409},
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"Condition": {
+			args: args{
+				check:    &v1alpha1.AlreadyExistsCheck{StatusCodes: []int{409}, Condition: `.body.error.code == "DUPLICATE_NAME"`},
+				response: httpClient.HttpResponse{StatusCode: 400, Body: `{"error":{"code":"DUPLICATE_NAME"}}`},
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"NotBoolean": {
+			args: args{
+				check:    &v1alpha1.AlreadyExistsCheck{Condition: ".body.error"},
+				response: httpClient.HttpResponse{StatusCode: 400, Body: `{"error":"duplicate"}`},
+			},
+			want: want{
+				err: errors.Wrap(errors.New("failed to parse string: duplicate"), errAlreadyExists),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := IsAlreadyExists(tc.args.check, tc.args.response)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("IsAlreadyExists(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("IsAlreadyExists(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_IsHTTPErrorFor(t *testing.T) {
+	type args struct {
+		statusCode          int
+		expectedStatusCodes []int
+	}
+	type want struct {
+		result bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"DefaultResultFalse": {
+			args: args{
+				statusCode: 304,
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"ExpectedResultFalse": {
+			args: args{
+				statusCode:          409,
+				expectedStatusCodes: []int{201, 409},
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"UnexpectedResultTrue": {
+			args: args{
+				statusCode:          200,
+				expectedStatusCodes: []int{201},
+			},
+			want: want{
+				result: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsHTTPErrorFor(tc.args.statusCode, tc.args.expectedStatusCodes)
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("IsHTTPErrorFor(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_StatusCodeError(t *testing.T) {
+	long := strings.Repeat("a", MaxErrorBodyLength-1) + "é"
+	cases := map[string]struct {
+		body string
+		want error
+	}{
+		"NoBody": {
+			body: "",
+			want: errors.Errorf(ErrStatusCode, "POST", "400"),
+		},
+		"Body": {
+			body: "{\"error\":\"invalid name\"}\n",
+			want: errors.Errorf(ErrStatusCodeBody, "POST", "400", `{"error":"invalid name"}`),
+		},
+		"TruncatedBody": {
+			body: long,
+			want: errors.Errorf(ErrStatusCodeBody, "POST", "400", strings.Repeat("a", MaxErrorBodyLength-1)+"..."),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := StatusCodeError("POST", 400, tc.body)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("StatusCodeError(...): -want error, +got error: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_RateLimitError(t *testing.T) {
+	cases := map[string]struct {
+		headers map[string][]string
+		want    string
+	}{
+		"NoRetryAfter": {
+			want: "HTTP GET request rate limited",
+		},
+		"RetryAfterSeconds": {
+			headers: map[string][]string{"Retry-After": {"90"}},
+			want:    "HTTP GET request rate limited, retrying after 1m30s",
+		},
+		"InvalidRetryAfter": {
+			headers: map[string][]string{"Retry-After": {"soon"}},
+			want:    "HTTP GET request rate limited",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := RateLimitError("GET", tc.headers)
+			if diff := cmp.Diff(tc.want, got.Error()); diff != "" {
+				t.Errorf("RateLimitError(...): -want error, +got error: %s", diff)
+			}
+		})
+	}
+}
+
 func Test_IsUrlValid(t *testing.T) {
 	type args struct {
 		url string
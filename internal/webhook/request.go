@@ -0,0 +1,331 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+	"github.com/crossplane-contrib/provider-http/internal/cel"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestprocessing"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+)
+
+const (
+	errNotRequest = "managed resource is not a Request custom resource"
+
+	msgMappingRequired     = "a %s mapping is required to %s the object"
+	msgInvalidJQ           = "must be a valid jq expression: %s"
+	msgInvalidCEL          = "must be a valid CEL expression: %s"
+	msgCompareExpression   = "is required when comparetype is %s"
+	msgComparePaths        = "is required when comparetype is jsonpath"
+	msgComparePatch        = "is required when comparetype is jsonpatch"
+	msgCompareList         = "is required when comparetype is keyedlist"
+	msgNumericTolerance    = "must be a non-negative number"
+	msgSecretFields        = "can't be combined with secretFields, as the stored response is redacted"
+	msgInvalidJSON         = "must be a JSON document"
+	msgInvalidSchema       = "must be a valid JSON Schema: %s"
+	msgBodyAndBodyFrom     = "can't be set along with body"
+	msgSchemaAndSchemaFrom = "can't be set along with responseSchema"
+	msgPagination          = "requires a responseSelector to find the object in the pages"
+	msgWhenObserve         = "can't be set on the GET mapping, which observes the state it's evaluated against"
+	msgUploadObserve       = "can't be set on the GET mapping"
+	msgContentFrom         = "must reference either a secret or a config map key"
+	msgGetMappingOnly      = "can only be set on the GET mapping"
+	msgPostMappingOnly     = "can only be set on the POST mapping"
+	msgPatchMappingOnly    = "can only be set on the PATCH mapping"
+	msgAdoptedResponse     = "can't reference the response, which describes the conflict rather than the adopted object, unless the alreadyExistsCheck of the POST mapping sets an identity"
+)
+
+// responseReference matches the jq expressions referencing the response of the last request.
+var responseReference = regexp.MustCompile(`(^|[^\w\])])\.response\b`)
+
+// compareTypes are the values of comparetype known to the Request controller.
+var compareTypes = []string{"gitlab-file", "harbor-robot", "jsonpath", "jq", "cel", "jsonpatch", "keyedlist"}
+
+// SetupRequest registers the validating webhook of Requests with the manager.
+func SetupRequest(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.Request{}).
+		WithValidator(&requestValidator{}).
+		Complete()
+}
+
+// requestValidator rejects Requests whose mappings can't be reconciled, so that
+// misconfigurations surface when the Request is applied rather than when it's
+// reconciled.
+type requestValidator struct{}
+
+func (v *requestValidator) ValidateCreate(_ context.Context, obj runtime.Object) error {
+	return validate(obj)
+}
+
+func (v *requestValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) error {
+	return validate(newObj)
+}
+
+func (v *requestValidator) ValidateDelete(context.Context, runtime.Object) error {
+	return nil
+}
+
+func validate(obj runtime.Object) error {
+	cr, ok := obj.(*v1alpha1.Request)
+	if !ok {
+		return errors.New(errNotRequest)
+	}
+
+	if errs := ValidateRequest(cr); len(errs) > 0 {
+		return apierrors.NewInvalid(v1alpha1.RequestGroupVersionKind.GroupKind(), cr.Name, errs)
+	}
+	return nil
+}
+
+// ValidateRequest returns the problems of the Request's mappings: the mappings
+// needed to manage the object are missing, a comparetype is unknown or lacks its
+// settings, or a template doesn't parse.
+func ValidateRequest(cr *v1alpha1.Request) field.ErrorList {
+	forProvider := &cr.Spec.ForProvider
+	path := field.NewPath("spec", "forProvider", "mappings")
+
+	var errs field.ErrorList
+	if _, ok := forProvider.MappingByMethod(http.MethodGet); !ok {
+		errs = append(errs, field.Required(path, fmt.Sprintf(msgMappingRequired, http.MethodGet, "observe")))
+	}
+	if _, ok := forProvider.MappingByMethod(http.MethodPost); !ok && cr.Spec.ManagementPolicy != xpv1.ManagementObserveOnly {
+		errs = append(errs, field.Required(path, fmt.Sprintf(msgMappingRequired, http.MethodPost, "create")))
+	}
+
+	// Mappings are told apart by their role, and the requests recorded in the status
+	// by their method. Mappings with a when condition may share a role, and its
+	// method, with the mappings that follow them.
+	methods := map[string]string{}
+	unconditional := map[string]bool{}
+	for i, mapping := range forProvider.Mappings {
+		mappingPath := path.Index(i)
+		role := mapping.RoleMethod()
+		if methodRole, ok := methods[mapping.Method]; ok && (methodRole != role || unconditional[role]) {
+			errs = append(errs, field.Duplicate(mappingPath.Child("method"), mapping.Method))
+		} else if unconditional[role] {
+			errs = append(errs, field.Duplicate(mappingPath.Child("action"), mapping.Action))
+		}
+		methods[mapping.Method] = role
+		if mapping.When == "" {
+			unconditional[role] = true
+		}
+
+		errs = append(errs, validateMapping(mapping, mappingPath)...)
+	}
+
+	if _, ok := forProvider.MappingByMethod(http.MethodDelete); !ok && len(forProvider.ImmutableFields) > 0 && cr.Spec.ManagementPolicy != xpv1.ManagementObserveOnly {
+		errs = append(errs, field.Required(path, fmt.Sprintf(msgMappingRequired, http.MethodDelete, "recreate")))
+	}
+
+	errs = append(errs, validateAdoptedResponseReferences(forProvider, path)...)
+
+	if forProvider.ConditionalObserve && len(forProvider.SecretFields) > 0 {
+		errs = append(errs, field.Forbidden(field.NewPath("spec", "forProvider", "conditionalObserve"), msgSecretFields))
+	}
+
+	return errs
+}
+
+// validateAdoptedResponseReferences rejects the mappings referencing the response
+// when a create may adopt an existing object without finding its identity, as the
+// response kept in the status is then the conflict response.
+func validateAdoptedResponseReferences(forProvider *v1alpha1.RequestParameters, path *field.Path) field.ErrorList {
+	adopting := false
+	for _, mapping := range forProvider.Mappings {
+		if check := mapping.AlreadyExistsCheck; check != nil && check.Identity == "" && mapping.RoleMethod() == http.MethodPost {
+			adopting = true
+		}
+	}
+	if !adopting {
+		return nil
+	}
+
+	var errs field.ErrorList
+	for i, mapping := range forProvider.Mappings {
+		if mapping.RoleMethod() == http.MethodPost {
+			continue
+		}
+
+		mappingPath := path.Index(i)
+		if responseReference.MatchString(mapping.URL) {
+			errs = append(errs, field.Invalid(mappingPath.Child("url"), mapping.URL, msgAdoptedResponse))
+		}
+		if responseReference.MatchString(mapping.Body) {
+			errs = append(errs, field.Invalid(mappingPath.Child("body"), mapping.Body, msgAdoptedResponse))
+		}
+		for _, params := range []struct {
+			name   string
+			values map[string][]string
+		}{{"headers", mapping.Headers}, {"queryParams", mapping.QueryParams}} {
+			for _, name := range slices.Sorted(maps.Keys(params.values)) {
+				for _, value := range params.values[name] {
+					if responseReference.MatchString(value) {
+						errs = append(errs, field.Invalid(mappingPath.Child(params.name).Key(name), value, msgAdoptedResponse))
+					}
+				}
+			}
+		}
+	}
+	return errs
+}
+
+func validateMapping(mapping v1alpha1.Mapping, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	errs = append(errs, validateJQ(mapping.URL, path.Child("url"))...)
+	if mapping.Body != "" {
+		errs = append(errs, validateJQ(requestprocessing.ConvertStringToJQQuery(mapping.Body), path.Child("body"))...)
+		if mapping.BodyFrom != nil {
+			errs = append(errs, field.Forbidden(path.Child("bodyFrom"), msgBodyAndBodyFrom))
+		}
+	}
+	if mapping.ResponseSelector != "" {
+		errs = append(errs, validateJQ(mapping.ResponseSelector, path.Child("responseSelector"))...)
+	}
+	if pagination := mapping.Pagination; pagination != nil {
+		if mapping.ResponseSelector == "" {
+			errs = append(errs, field.Required(path.Child("responseSelector"), msgPagination))
+		}
+		if pagination.NextURL != "" {
+			errs = append(errs, validateJQ(pagination.NextURL, path.Child("pagination", "nextUrl"))...)
+		}
+	}
+	if check := mapping.ReadinessCheck; check != nil && check.Condition != "" {
+		errs = append(errs, validateJQ(check.Condition, path.Child("readinessCheck", "condition"))...)
+	}
+	if mapping.ResponseAggregation != "" {
+		errs = append(errs, validateJQ(mapping.ResponseAggregation, path.Child("responseAggregation"))...)
+	}
+	if mapping.When != "" {
+		if mapping.RoleMethod() == http.MethodGet {
+			errs = append(errs, field.Forbidden(path.Child("when"), msgWhenObserve))
+		}
+		if err := cel.Validate(mapping.When); err != nil {
+			errs = append(errs, field.Invalid(path.Child("when"), mapping.When, fmt.Sprintf(msgInvalidCEL, err)))
+		}
+	}
+	if upload := mapping.Upload; upload != nil {
+		uploadPath := path.Child("upload")
+		if mapping.RoleMethod() == http.MethodGet {
+			errs = append(errs, field.Forbidden(uploadPath, msgUploadObserve))
+		}
+		errs = append(errs, validateJQ(upload.UploadURL, uploadPath.Child("uploadURL"))...)
+		if (upload.ContentFrom.SecretKeyRef == nil) == (upload.ContentFrom.ConfigMapKeyRef == nil) {
+			errs = append(errs, field.Invalid(uploadPath.Child("contentFrom"), upload.ContentFrom, msgContentFrom))
+		}
+	}
+	if mapping.CompareStatusOnly && mapping.RoleMethod() != http.MethodGet {
+		errs = append(errs, field.Forbidden(path.Child("compareStatusOnly"), msgGetMappingOnly))
+	}
+	if check := mapping.AlreadyExistsCheck; check != nil {
+		if mapping.RoleMethod() != http.MethodPost {
+			errs = append(errs, field.Forbidden(path.Child("alreadyExistsCheck"), msgPostMappingOnly))
+		}
+		if check.Condition != "" {
+			errs = append(errs, validateJQ(check.Condition, path.Child("alreadyExistsCheck", "condition"))...)
+		}
+		if check.Identity != "" {
+			errs = append(errs, validateJQ(check.Identity, path.Child("alreadyExistsCheck", "identity"))...)
+		}
+	}
+	if mapping.CacheResponse && mapping.RoleMethod() != http.MethodGet {
+		errs = append(errs, field.Forbidden(path.Child("cacheResponse"), msgGetMappingOnly))
+	}
+	if mapping.MinimalUpdate && mapping.RoleMethod() != http.MethodPatch {
+		errs = append(errs, field.Forbidden(path.Child("minimalUpdate"), msgPatchMappingOnly))
+	}
+	if mapping.SuccessExpression != "" {
+		if err := cel.Validate(mapping.SuccessExpression); err != nil {
+			errs = append(errs, field.Invalid(path.Child("successExpression"), mapping.SuccessExpression, fmt.Sprintf(msgInvalidCEL, err)))
+		}
+	}
+	if mapping.ExpectedResponse != "" && !json.Valid([]byte(mapping.ExpectedResponse)) {
+		errs = append(errs, field.Invalid(path.Child("expectedResponse"), mapping.ExpectedResponse, msgInvalidJSON))
+	}
+	if mapping.ResponseSchema != "" {
+		if _, err := json_util.CompileSchema(mapping.ResponseSchema); err != nil {
+			errs = append(errs, field.Invalid(path.Child("responseSchema"), mapping.ResponseSchema, fmt.Sprintf(msgInvalidSchema, err)))
+		}
+		if mapping.ResponseSchemaFrom != nil {
+			errs = append(errs, field.Forbidden(path.Child("responseSchemaFrom"), msgSchemaAndSchemaFrom))
+		}
+	}
+	if requestgen.BodyType(mapping) == requestgen.BodyTypeMultipart {
+		for i, part := range mapping.Multipart {
+			if part.Value != "" {
+				errs = append(errs, validateJQ(part.Value, path.Child("multipart").Index(i).Child("value"))...)
+			}
+		}
+	}
+
+	if mapping.CompareType != "" && !slices.Contains(compareTypes, mapping.CompareType) {
+		errs = append(errs, field.NotSupported(path.Child("comparetype"), mapping.CompareType, compareTypes))
+	}
+
+	switch mapping.CompareType {
+	case "jq":
+		if mapping.CompareExpression == "" {
+			errs = append(errs, field.Required(path.Child("compareExpression"), fmt.Sprintf(msgCompareExpression, mapping.CompareType)))
+		} else {
+			errs = append(errs, validateJQ(mapping.CompareExpression, path.Child("compareExpression"))...)
+		}
+	case "cel":
+		if mapping.CompareExpression == "" {
+			errs = append(errs, field.Required(path.Child("compareExpression"), fmt.Sprintf(msgCompareExpression, mapping.CompareType)))
+		} else if err := cel.Validate(mapping.CompareExpression); err != nil {
+			errs = append(errs, field.Invalid(path.Child("compareExpression"), mapping.CompareExpression, fmt.Sprintf(msgInvalidCEL, err)))
+		}
+	case "jsonpath":
+		if len(mapping.ComparePaths) == 0 {
+			errs = append(errs, field.Required(path.Child("comparePaths"), msgComparePaths))
+		}
+	case "jsonpatch":
+		if mapping.Body == "" && mapping.BodyFrom == nil {
+			errs = append(errs, field.Required(path.Child("body"), msgComparePatch))
+		}
+	case "keyedlist":
+		if mapping.CompareList == nil {
+			errs = append(errs, field.Required(path.Child("compareList"), msgCompareList))
+			break
+		}
+		if mapping.CompareList.Path == "" {
+			errs = append(errs, field.Required(path.Child("compareList", "path"), msgCompareList))
+		}
+		if mapping.CompareList.Key == "" {
+			errs = append(errs, field.Required(path.Child("compareList", "key"), msgCompareList))
+		}
+	}
+
+	if mapping.CompareOptions != nil && mapping.CompareOptions.NumericTolerance != "" {
+		tolerance := mapping.CompareOptions.NumericTolerance
+		if value, err := strconv.ParseFloat(tolerance, 64); err != nil || value < 0 {
+			errs = append(errs, field.Invalid(path.Child("compareOptions", "numericTolerance"), tolerance, msgNumericTolerance))
+		}
+	}
+
+	return errs
+}
+
+func validateJQ(query string, path *field.Path) field.ErrorList {
+	if err := jq.Validate(query); err != nil {
+		return field.ErrorList{field.Invalid(path, query, fmt.Sprintf(msgInvalidJQ, err))}
+	}
+	return nil
+}
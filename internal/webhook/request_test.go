@@ -0,0 +1,381 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/google/go-cmp/cmp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha1"
+)
+
+var (
+	testPostMapping = v1alpha1.Mapping{
+		Method: "POST",
+		Body:   "{ username: .payload.body.username }",
+		URL:    ".payload.baseUrl",
+	}
+
+	testGetMapping = v1alpha1.Mapping{
+		Method: "GET",
+		URL:    "(.payload.baseUrl + \"/\" + .response.body.id)",
+	}
+)
+
+func request(mappings ...v1alpha1.Mapping) *v1alpha1.Request {
+	return &v1alpha1.Request{
+		Spec: v1alpha1.RequestSpec{
+			ForProvider: v1alpha1.RequestParameters{Mappings: mappings},
+		},
+	}
+}
+
+func Test_ValidateRequest(t *testing.T) {
+	mappingsPath := field.NewPath("spec", "forProvider", "mappings")
+
+	type want struct {
+		// fields are the fields of the errors, in order.
+		fields []string
+		types  []field.ErrorType
+	}
+	cases := map[string]struct {
+		cr   *v1alpha1.Request
+		want want
+	}{
+		"Valid": {
+			cr: request(testPostMapping, testGetMapping, v1alpha1.Mapping{
+				Method:            "PUT",
+				Body:              "{ username: .payload.body.username }",
+				URL:               "(.payload.baseUrl + \"/\" + .response.body.id)",
+				CompareType:       "jq",
+				CompareExpression: ".response.username == .desired.username",
+			}),
+		},
+		"MissingMappings": {
+			cr: request(),
+			want: want{
+				fields: []string{mappingsPath.String(), mappingsPath.String()},
+				types:  []field.ErrorType{field.ErrorTypeRequired, field.ErrorTypeRequired},
+			},
+		},
+		"ObserveOnlyWithoutPost": {
+			cr: func() *v1alpha1.Request {
+				cr := request(testGetMapping)
+				cr.Spec.ManagementPolicy = xpv1.ManagementObserveOnly
+				return cr
+			}(),
+		},
+		"ImmutableFieldsWithoutDelete": {
+			cr: func() *v1alpha1.Request {
+				cr := request(testPostMapping, testGetMapping)
+				cr.Spec.ForProvider.ImmutableFields = []string{"$.region"}
+				return cr
+			}(),
+			want: want{
+				fields: []string{mappingsPath.String()},
+				types:  []field.ErrorType{field.ErrorTypeRequired},
+			},
+		},
+		"ImmutableFieldsWithDelete": {
+			cr: func() *v1alpha1.Request {
+				cr := request(testPostMapping, testGetMapping, v1alpha1.Mapping{Method: "DELETE", URL: "(.payload.baseUrl + \"/\" + .response.body.id)"})
+				cr.Spec.ForProvider.ImmutableFields = []string{"$.region"}
+				return cr
+			}(),
+		},
+		"DuplicateMethod": {
+			cr: request(testPostMapping, testGetMapping, testGetMapping),
+			want: want{
+				fields: []string{"spec.forProvider.mappings[2].method"},
+				types:  []field.ErrorType{field.ErrorTypeDuplicate},
+			},
+		},
+		"CustomMethod": {
+			cr: request(testPostMapping, testGetMapping, v1alpha1.Mapping{Method: "PURGE", Action: v1alpha1.ActionRemove, URL: ".payload.baseUrl"}),
+		},
+		"DuplicateAction": {
+			cr: request(testPostMapping, testGetMapping, v1alpha1.Mapping{Method: "PROPFIND", Action: v1alpha1.ActionObserve, URL: ".payload.baseUrl"}),
+			want: want{
+				fields: []string{"spec.forProvider.mappings[2].action"},
+				types:  []field.ErrorType{field.ErrorTypeDuplicate},
+			},
+		},
+		"ConditionalMappings": {
+			cr: request(testPostMapping, testGetMapping,
+				v1alpha1.Mapping{Method: "PUT", URL: ".payload.baseUrl", Body: `{enabled: true}`, When: "response.body.enabled == false"},
+				v1alpha1.Mapping{Method: "PUT", URL: ".payload.baseUrl", Body: `{name: .payload.body.name}`},
+			),
+		},
+		"ConditionalMappingAfterUnconditional": {
+			cr: request(testPostMapping, testGetMapping,
+				v1alpha1.Mapping{Method: "PUT", URL: ".payload.baseUrl", Body: `{name: .payload.body.name}`},
+				v1alpha1.Mapping{Method: "PUT", URL: ".payload.baseUrl", Body: `{enabled: true}`, When: "response.body.enabled == false"},
+			),
+			want: want{
+				fields: []string{"spec.forProvider.mappings[3].method"},
+				types:  []field.ErrorType{field.ErrorTypeDuplicate},
+			},
+		},
+		"InvalidWhen": {
+			cr: request(testPostMapping, v1alpha1.Mapping{Method: "GET", URL: ".payload.baseUrl", When: "response.body.enabled =="}),
+			want: want{
+				fields: []string{"spec.forProvider.mappings[1].when", "spec.forProvider.mappings[1].when"},
+				types:  []field.ErrorType{field.ErrorTypeForbidden, field.ErrorTypeInvalid},
+			},
+		},
+		"InvalidUpload": {
+			cr: request(testPostMapping, testGetMapping, v1alpha1.Mapping{
+				Method: "PUT",
+				URL:    ".payload.baseUrl",
+				Upload: &v1alpha1.ChunkedUpload{UploadURL: ".headers.Location[0"},
+			}),
+			want: want{
+				fields: []string{"spec.forProvider.mappings[2].upload.uploadURL", "spec.forProvider.mappings[2].upload.contentFrom"},
+				types:  []field.ErrorType{field.ErrorTypeInvalid, field.ErrorTypeInvalid},
+			},
+		},
+		"InvalidBody": {
+			cr: request(v1alpha1.Mapping{Method: "POST", Body: "{ username: .payload.body.username", URL: ".payload.baseUrl"}, testGetMapping),
+			want: want{
+				fields: []string{"spec.forProvider.mappings[0].body"},
+				types:  []field.ErrorType{field.ErrorTypeInvalid},
+			},
+		},
+		"InvalidSuccessExpression": {
+			cr: request(testPostMapping, v1alpha1.Mapping{Method: "GET", URL: ".payload.baseUrl", SuccessExpression: "response.body.ok &&"}),
+			want: want{
+				fields: []string{"spec.forProvider.mappings[1].successExpression"},
+				types:  []field.ErrorType{field.ErrorTypeInvalid},
+			},
+		},
+		"InvalidResponseSchema": {
+			cr: request(testPostMapping, v1alpha1.Mapping{
+				Method:             "GET",
+				URL:                ".payload.baseUrl",
+				ResponseSchema:     `{"type":`,
+				ResponseSchemaFrom: &v1alpha1.ConfigMapKeySelector{Name: "schemas", Namespace: "default", Key: "user.json"},
+			}),
+			want: want{
+				fields: []string{"spec.forProvider.mappings[1].responseSchema", "spec.forProvider.mappings[1].responseSchemaFrom"},
+				types:  []field.ErrorType{field.ErrorTypeInvalid, field.ErrorTypeForbidden},
+			},
+		},
+		"ResponseSchemaRemoteReference": {
+			cr: request(testPostMapping, v1alpha1.Mapping{
+				Method:         "GET",
+				URL:            ".payload.baseUrl",
+				ResponseSchema: `{"$ref":"https://example.com/user.json"}`,
+			}),
+			want: want{
+				fields: []string{"spec.forProvider.mappings[1].responseSchema"},
+				types:  []field.ErrorType{field.ErrorTypeInvalid},
+			},
+		},
+		"InvalidURL": {
+			cr: request(testPostMapping, v1alpha1.Mapping{Method: "GET", URL: "(.payload.baseUrl + "}),
+			want: want{
+				fields: []string{"spec.forProvider.mappings[1].url"},
+				types:  []field.ErrorType{field.ErrorTypeInvalid},
+			},
+		},
+		"UnknownCompareType": {
+			cr: request(testPostMapping, v1alpha1.Mapping{Method: "GET", URL: ".payload.baseUrl", CompareType: "deep"}),
+			want: want{
+				fields: []string{"spec.forProvider.mappings[1].comparetype"},
+				types:  []field.ErrorType{field.ErrorTypeNotSupported},
+			},
+		},
+		"CompareSettingsMissing": {
+			cr: request(testPostMapping, v1alpha1.Mapping{Method: "GET", URL: ".payload.baseUrl", CompareType: "jq"}, v1alpha1.Mapping{Method: "PUT", URL: ".payload.baseUrl", CompareType: "jsonpath"}, v1alpha1.Mapping{Method: "PATCH", URL: ".payload.baseUrl", CompareType: "jsonpatch"}),
+			want: want{
+				fields: []string{"spec.forProvider.mappings[1].compareExpression", "spec.forProvider.mappings[2].comparePaths", "spec.forProvider.mappings[3].body"},
+				types:  []field.ErrorType{field.ErrorTypeRequired, field.ErrorTypeRequired, field.ErrorTypeRequired},
+			},
+		},
+		"CELCompareExpression": {
+			cr: request(testPostMapping, v1alpha1.Mapping{Method: "PUT", URL: ".payload.baseUrl", CompareType: "cel", CompareExpression: `omit(response, ["updated_at"]) == desired`}, v1alpha1.Mapping{Method: "GET", URL: ".payload.baseUrl", CompareType: "cel", CompareExpression: `response.id ==`}),
+			want: want{
+				fields: []string{"spec.forProvider.mappings[2].compareExpression"},
+				types:  []field.ErrorType{field.ErrorTypeInvalid},
+			},
+		},
+		"CompareListMissing": {
+			cr: request(testPostMapping, v1alpha1.Mapping{Method: "GET", URL: ".payload.baseUrl", CompareType: "keyedlist"}, v1alpha1.Mapping{Method: "PUT", URL: ".payload.baseUrl", CompareType: "keyedlist", CompareList: &v1alpha1.CompareList{Path: "$.rules"}}),
+			want: want{
+				fields: []string{"spec.forProvider.mappings[1].compareList", "spec.forProvider.mappings[2].compareList.key"},
+				types:  []field.ErrorType{field.ErrorTypeRequired, field.ErrorTypeRequired},
+			},
+		},
+		"CompareStatusOnlyNotObserving": {
+			cr: request(v1alpha1.Mapping{Method: "POST", URL: ".payload.baseUrl", CompareStatusOnly: true}, v1alpha1.Mapping{Method: "GET", URL: ".payload.baseUrl", CompareStatusOnly: true}),
+			want: want{
+				fields: []string{"spec.forProvider.mappings[0].compareStatusOnly"},
+				types:  []field.ErrorType{field.ErrorTypeForbidden},
+			},
+		},
+		"InvalidAlreadyExistsCheck": {
+			cr: request(testPostMapping, v1alpha1.Mapping{Method: "GET", URL: ".payload.baseUrl", AlreadyExistsCheck: &v1alpha1.AlreadyExistsCheck{Condition: ".body.error =="}}),
+			want: want{
+				fields: []string{"spec.forProvider.mappings[1].alreadyExistsCheck", "spec.forProvider.mappings[1].alreadyExistsCheck.condition"},
+				types:  []field.ErrorType{field.ErrorTypeForbidden, field.ErrorTypeInvalid},
+			},
+		},
+		"AdoptedResponseReferenced": {
+			cr: request(
+				v1alpha1.Mapping{Method: "POST", URL: ".payload.baseUrl", AlreadyExistsCheck: &v1alpha1.AlreadyExistsCheck{}},
+				v1alpha1.Mapping{Method: "GET", URL: `(.payload.baseUrl + "/" + .response.body.id)`, Headers: map[string][]string{"If-None-Match": {".response.headers.ETag[0]"}}},
+				v1alpha1.Mapping{Method: "PUT", URL: `(.payload.baseUrl + "/" + .payload.body.name)`},
+			),
+			want: want{
+				fields: []string{"spec.forProvider.mappings[1].url", "spec.forProvider.mappings[1].headers[If-None-Match]"},
+				types:  []field.ErrorType{field.ErrorTypeInvalid, field.ErrorTypeInvalid},
+			},
+		},
+		"AdoptedResponseWithIdentity": {
+			cr: request(
+				v1alpha1.Mapping{Method: "POST", URL: ".payload.baseUrl", AlreadyExistsCheck: &v1alpha1.AlreadyExistsCheck{Identity: "{id: .body.existingId}"}},
+				v1alpha1.Mapping{Method: "GET", URL: `(.payload.baseUrl + "/" + .response.body.id)`},
+			),
+		},
+		"InvalidAlreadyExistsIdentity": {
+			cr: request(
+				v1alpha1.Mapping{Method: "POST", URL: ".payload.baseUrl", AlreadyExistsCheck: &v1alpha1.AlreadyExistsCheck{Identity: "{id: "}},
+				testGetMapping,
+			),
+			want: want{
+				fields: []string{"spec.forProvider.mappings[0].alreadyExistsCheck.identity"},
+				types:  []field.ErrorType{field.ErrorTypeInvalid},
+			},
+		},
+		"CacheResponseNotObserving": {
+			cr: request(v1alpha1.Mapping{Method: "POST", URL: ".payload.baseUrl", CacheResponse: true}, v1alpha1.Mapping{Method: "GET", URL: ".payload.baseUrl", CacheResponse: true}),
+			want: want{
+				fields: []string{"spec.forProvider.mappings[0].cacheResponse"},
+				types:  []field.ErrorType{field.ErrorTypeForbidden},
+			},
+		},
+		"MinimalUpdateNotPatching": {
+			cr: request(testPostMapping, v1alpha1.Mapping{Method: "PUT", URL: ".payload.baseUrl", MinimalUpdate: true}, v1alpha1.Mapping{Method: "PATCH", URL: ".payload.baseUrl", MinimalUpdate: true}, testGetMapping),
+			want: want{
+				fields: []string{"spec.forProvider.mappings[1].minimalUpdate"},
+				types:  []field.ErrorType{field.ErrorTypeForbidden},
+			},
+		},
+		"InvalidMultipartValue": {
+			cr: request(v1alpha1.Mapping{
+				Method:    "POST",
+				URL:       ".payload.baseUrl",
+				BodyType:  "multipart",
+				Multipart: []v1alpha1.MultipartPart{{Name: "description", Value: ".payload.body | b32enc"}},
+			}, testGetMapping),
+			want: want{
+				fields: []string{"spec.forProvider.mappings[0].multipart[0].value"},
+				types:  []field.ErrorType{field.ErrorTypeInvalid},
+			},
+		},
+		"InvalidReadinessCondition": {
+			cr: request(testPostMapping, v1alpha1.Mapping{
+				Method:         "GET",
+				URL:            ".payload.baseUrl",
+				ReadinessCheck: &v1alpha1.ReadinessCheck{Condition: ".body.status =="},
+			}),
+			want: want{
+				fields: []string{"spec.forProvider.mappings[1].readinessCheck.condition"},
+				types:  []field.ErrorType{field.ErrorTypeInvalid},
+			},
+		},
+		"PaginationWithoutSelector": {
+			cr: request(testPostMapping, v1alpha1.Mapping{
+				Method:     "GET",
+				URL:        ".payload.baseUrl",
+				Pagination: &v1alpha1.Pagination{NextURL: ".response.body.next |"},
+			}),
+			want: want{
+				fields: []string{"spec.forProvider.mappings[1].responseSelector", "spec.forProvider.mappings[1].pagination.nextUrl"},
+				types:  []field.ErrorType{field.ErrorTypeRequired, field.ErrorTypeInvalid},
+			},
+		},
+		"ConditionalObserveWithSecretFields": {
+			cr: func() *v1alpha1.Request {
+				cr := request(testPostMapping, testGetMapping)
+				cr.Spec.ForProvider.ConditionalObserve = true
+				cr.Spec.ForProvider.SecretFields = []string{"$.password"}
+				return cr
+			}(),
+			want: want{
+				fields: []string{"spec.forProvider.conditionalObserve"},
+				types:  []field.ErrorType{field.ErrorTypeForbidden},
+			},
+		},
+		"BodyAndBodyFrom": {
+			cr: request(v1alpha1.Mapping{
+				Method:   "POST",
+				Body:     "{ username: .payload.body.username }",
+				BodyFrom: &v1alpha1.BodySource{ConfigMapKeyRef: &v1alpha1.ConfigMapKeySelector{Name: "policies", Namespace: "default", Key: "policy"}},
+				URL:      ".payload.baseUrl",
+			}, testGetMapping),
+			want: want{
+				fields: []string{"spec.forProvider.mappings[0].bodyFrom"},
+				types:  []field.ErrorType{field.ErrorTypeForbidden},
+			},
+		},
+		"InvalidExpectedResponse": {
+			cr: request(testPostMapping, v1alpha1.Mapping{
+				Method:           "GET",
+				URL:              ".payload.baseUrl",
+				ExpectedResponse: `{"status": active}`,
+			}),
+			want: want{
+				fields: []string{"spec.forProvider.mappings[1].expectedResponse"},
+				types:  []field.ErrorType{field.ErrorTypeInvalid},
+			},
+		},
+		"InvalidNumericTolerance": {
+			cr: request(testPostMapping, v1alpha1.Mapping{
+				Method:         "GET",
+				URL:            ".payload.baseUrl",
+				CompareOptions: &v1alpha1.CompareOptions{NumericTolerance: "a little"},
+			}),
+			want: want{
+				fields: []string{"spec.forProvider.mappings[1].compareOptions.numericTolerance"},
+				types:  []field.ErrorType{field.ErrorTypeInvalid},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var fields []string
+			var types []field.ErrorType
+			for _, err := range ValidateRequest(tc.cr) {
+				fields = append(fields, err.Field)
+				types = append(types, err.Type)
+			}
+			if diff := cmp.Diff(tc.want.fields, fields); diff != "" {
+				t.Errorf("ValidateRequest(...): -want fields, +got fields: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.types, types); diff != "" {
+				t.Errorf("ValidateRequest(...): -want types, +got types: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_requestValidator(t *testing.T) {
+	v := &requestValidator{}
+
+	if err := v.ValidateCreate(context.Background(), request(testPostMapping, testGetMapping)); err != nil {
+		t.Errorf("ValidateCreate(...): unexpected error: %s", err)
+	}
+
+	err := v.ValidateUpdate(context.Background(), request(testPostMapping, testGetMapping), request(testPostMapping))
+	if !apierrors.IsInvalid(err) {
+		t.Errorf("ValidateUpdate(...): want an invalid error, got: %v", err)
+	}
+
+	if err := v.ValidateDelete(context.Background(), request()); err != nil {
+		t.Errorf("ValidateDelete(...): unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,139 @@
+package xml
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errParseDocument = "cannot parse XML document"
+	errNoRootElement = "XML document has no root element"
+
+	namespaceXMLNS = "xmlns"
+)
+
+// element is a parsed XML element. Names are qualified by their namespace URI,
+// so that documents using different prefixes for the same namespace compare equal.
+type element struct {
+	name     xml.Name
+	attrs    map[xml.Name]string
+	text     string
+	children []*element
+}
+
+// IsXMLString reports whether the string is a well-formed XML document.
+func IsXMLString(document string) bool {
+	_, err := parse(document)
+	return err == nil
+}
+
+// Contains reports whether the container document contains the containee document.
+// The root elements must have the same name, and every attribute, text and child
+// element of the containee must be found in the container. Child elements are
+// matched regardless of their order, whitespace around text is ignored, and
+// comments and processing instructions are skipped.
+func Contains(container, containee string) (bool, error) {
+	containerRoot, err := parse(container)
+	if err != nil {
+		return false, err
+	}
+
+	containeeRoot, err := parse(containee)
+	if err != nil {
+		return false, err
+	}
+
+	return containerRoot.contains(containeeRoot), nil
+}
+
+func (e *element) contains(other *element) bool {
+	if e.name != other.name {
+		return false
+	}
+
+	for name, value := range other.attrs {
+		if containerValue, ok := e.attrs[name]; !ok || containerValue != value {
+			return false
+		}
+	}
+
+	if other.text != "" && e.text != other.text {
+		return false
+	}
+
+	for _, otherChild := range other.children {
+		if !e.containsChild(otherChild) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (e *element) containsChild(other *element) bool {
+	for _, child := range e.children {
+		if child.contains(other) {
+			return true
+		}
+	}
+	return false
+}
+
+// parse parses the document into its root element.
+func parse(document string) (*element, error) {
+	decoder := xml.NewDecoder(strings.NewReader(document))
+
+	var root *element
+	var stack []*element
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, errParseDocument)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			e := &element{name: t.Name, attrs: attributes(t.Attr)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, e)
+			} else if root == nil {
+				root = e
+			}
+			stack = append(stack, e)
+		case xml.EndElement:
+			e := stack[len(stack)-1]
+			e.text = strings.TrimSpace(e.text)
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text += string(t)
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New(errNoRootElement)
+	}
+
+	return root, nil
+}
+
+// attributes returns the attributes by name, leaving out namespace declarations.
+func attributes(attrs []xml.Attr) map[xml.Name]string {
+	result := make(map[xml.Name]string, len(attrs))
+	for _, attr := range attrs {
+		if attr.Name.Space == namespaceXMLNS || (attr.Name.Space == "" && attr.Name.Local == namespaceXMLNS) {
+			continue
+		}
+		result[attr.Name] = attr.Value
+	}
+	return result
+}
@@ -0,0 +1,104 @@
+package xml
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_Contains(t *testing.T) {
+	type args struct {
+		container string
+		containee string
+	}
+	type want struct {
+		result bool
+		err    error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ContainsIgnoringWhitespaceAndOrder": {
+			args: args{
+				container: `<?xml version="1.0"?>
+<user id="123">
+  <email>john.doe@example.com</email>
+  <name> john_doe </name>
+  <created>2024-01-01</created>
+</user>`,
+				containee: `<user><name>john_doe</name><email>john.doe@example.com</email></user>`,
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"ContainsWithDifferentPrefixes": {
+			args: args{
+				container: `<a:user xmlns:a="urn:users" a:role="admin"><a:name>john_doe</a:name></a:user>`,
+				containee: `<user xmlns="urn:users" xmlns:b="urn:users" b:role="admin"><name>john_doe</name></user>`,
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"DifferentNamespace": {
+			args: args{
+				container: `<user xmlns="urn:users"><name>john_doe</name></user>`,
+				containee: `<user xmlns="urn:accounts"><name>john_doe</name></user>`,
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"DifferentText": {
+			args: args{
+				container: `<user><name>john_doe</name></user>`,
+				containee: `<user><name>jane_doe</name></user>`,
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"MissingAttribute": {
+			args: args{
+				container: `<user><name>john_doe</name></user>`,
+				containee: `<user role="admin"><name>john_doe</name></user>`,
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"InvalidDocument": {
+			args: args{
+				container: `<user><name>john_doe</user>`,
+				containee: `<user/>`,
+			},
+			want: want{
+				err: errors.Wrap(errors.New("XML syntax error on line 1: element <name> closed by </user>"), errParseDocument),
+			},
+		},
+		"NoRootElement": {
+			args: args{
+				container: `john_doe`,
+				containee: `<user/>`,
+			},
+			want: want{
+				err: errors.New(errNoRootElement),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := Contains(tc.args.container, tc.args.containee)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("Contains(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("Contains(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}